@@ -10,6 +10,7 @@ import (
 	"runtime/debug"
 	"runtime/pprof"
 	"syscall"
+	"time"
 
 	"github.com/spf13/pflag"
 
@@ -110,7 +111,7 @@ func main() {
 // Logs only error level message to stderr.
 func initLogTemp() *log.Logger {
 	l := log.NewLogger()
-	l.Init("", true, "Error")
+	l.Init("", true, "Error", log.RotateOptions{})
 	logger.Logger = l
 
 	return l
@@ -118,7 +119,11 @@ func initLogTemp() *log.Logger {
 
 func initLog(cfg *config.Config) *log.Logger {
 	l := log.NewLogger()
-	l.Init(cfg.GetLogFile(), cfg.GetLogOut(), cfg.GetLogLevel())
+	l.Init(cfg.GetLogFile(), cfg.GetLogOut(), cfg.GetLogLevel(), log.RotateOptions{
+		MaxSize:    int64(cfg.GetLogMaxSize()) * 1024 * 1024,
+		MaxAge:     time.Duration(cfg.GetLogMaxAge()) * 24 * time.Hour,
+		MaxBackups: cfg.GetLogMaxBackups(),
+	})
 	logger.Logger = l
 
 	return l
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"net"
 	"net/http"
@@ -8,10 +9,12 @@ import (
 	"path"
 	"strings"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/session"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 const (
@@ -29,6 +32,42 @@ func allowUnauthenticated(r *http.Request) bool {
 	return strings.HasPrefix(r.URL.Path, loginEndpoint) || r.URL.Path == logoutEndpoint || r.URL.Path == "/css" || strings.HasPrefix(r.URL.Path, "/assets")
 }
 
+// allowPublicAPI returns true if the request is a GraphQL request and public
+// API fields have been configured. Such requests are passed through to the
+// GraphQL server, which restricts the operation using publicAPIMiddleware.
+func allowPublicAPI(c *config.Config, r *http.Request) bool {
+	return r.URL.Path == gqlEndpoint && len(c.GetPublicAPIFields()) > 0
+}
+
+// publicAPIMiddleware rejects GraphQL operations from unauthenticated users
+// that select any top-level field not present in the public API field list.
+func publicAPIMiddleware(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	c := config.GetInstance()
+	if !c.HasCredentials() {
+		return next(ctx)
+	}
+
+	// requests without a current user are internal, such as those from plugins
+	userID := session.GetCurrentUserID(ctx)
+	if userID == nil || *userID != "" {
+		return next(ctx)
+	}
+
+	allowed := c.GetPublicAPIFields()
+	oc := graphql.GetOperationContext(ctx)
+	for _, f := range graphql.CollectFields(oc, oc.Operation.SelectionSet, nil) {
+		if f.Name == "__typename" || sliceutil.Contains(allowed, f.Name) {
+			continue
+		}
+
+		return func(ctx context.Context) *graphql.Response {
+			return graphql.ErrorResponse(ctx, "%s requires authentication", f.Name)
+		}
+	}
+
+	return next(ctx)
+}
+
 func authenticateHandler() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -75,7 +114,7 @@ func authenticateHandler() func(http.Handler) http.Handler {
 
 			if c.HasCredentials() {
 				// authentication is required
-				if userID == "" && !allowUnauthenticated(r) {
+				if userID == "" && !allowUnauthenticated(r) && !allowPublicAPI(c, r) {
 					// if graphql or a non-webpage was requested, we just return a forbidden error
 					ext := path.Ext(r.URL.Path)
 					if r.URL.Path == gqlEndpoint || (ext != "" && ext != ".html") {
@@ -103,6 +142,7 @@ func authenticateHandler() func(http.Handler) http.Handler {
 			}
 
 			ctx = session.SetCurrentUserID(ctx, userID)
+			ctx = session.SetRequestSource(ctx, session.GetRequestSourceFromRequest(r))
 
 			r = r.WithContext(ctx)
 
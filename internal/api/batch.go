@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxBatchSize is the maximum number of operations accepted in a single
+// batched GraphQL request.
+const maxBatchSize = 50
+
+// batchResponseWriter buffers the response of a single operation within a
+// batched request.
+type batchResponseWriter struct {
+	header http.Header
+	buf    bytes.Buffer
+}
+
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchResponseWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+func (w *batchResponseWriter) WriteHeader(statusCode int) {}
+
+func isJSONRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && r.Body != nil && strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+}
+
+func isBatchRequest(body []byte) bool {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// batchHandler wraps a GraphQL handler to support batched requests. A batched
+// request is a POST request with a JSON array body, where each element is a
+// regular GraphQL request. The operations are executed in order and the
+// results are returned as a JSON array in the same order.
+//
+// Only JSON request bodies are read, up to maxBodySize bytes. Other requests,
+// such as multipart uploads, are passed through unread.
+func batchHandler(next http.Handler, maxBodySize int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isJSONRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !isBatchRequest(body) {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var operations []json.RawMessage
+		if err := json.Unmarshal(body, &operations); err != nil {
+			http.Error(w, fmt.Sprintf("invalid batch request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if len(operations) > maxBatchSize {
+			http.Error(w, fmt.Sprintf("batch request exceeds maximum of %d operations", maxBatchSize), http.StatusBadRequest)
+			return
+		}
+
+		results := make([]json.RawMessage, len(operations))
+		for i, op := range operations {
+			opReq := r.Clone(r.Context())
+			opReq.Body = io.NopCloser(bytes.NewReader(op))
+			opReq.ContentLength = int64(len(op))
+
+			opWriter := &batchResponseWriter{header: make(http.Header)}
+			next.ServeHTTP(opWriter, opReq)
+
+			result := opWriter.buf.Bytes()
+			if !json.Valid(result) {
+				// non-GraphQL error responses are plain text
+				result, _ = json.Marshal(map[string]interface{}{
+					"errors": []map[string]string{{"message": strings.TrimSpace(string(result))}},
+				})
+			}
+			results[i] = result
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(results); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
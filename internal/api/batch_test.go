@@ -0,0 +1,113 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testMaxBodySize = 1 << 20
+
+func echoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+}
+
+func TestBatchHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			"single",
+			`{"query":"a"}`,
+			`{"query":"a"}`,
+		},
+		{
+			"batch",
+			`[{"query":"a"}, {"query":"b"}]`,
+			`[{"query":"a"},{"query":"b"}]` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, gqlEndpoint, strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			batchHandler(echoHandler(), testMaxBodySize).ServeHTTP(w, r)
+
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("batchHandler() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchHandlerTooLarge(t *testing.T) {
+	body := "[" + strings.Repeat(`{"query":"a"},`, maxBatchSize) + `{"query":"a"}]`
+	r := httptest.NewRequest(http.MethodPost, gqlEndpoint, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	batchHandler(echoHandler(), testMaxBodySize).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("batchHandler() status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestBatchHandlerBodyTooLarge(t *testing.T) {
+	body := `{"query":"` + strings.Repeat("a", testMaxBodySize) + `"}`
+	r := httptest.NewRequest(http.MethodPost, gqlEndpoint, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	batchHandler(echoHandler(), testMaxBodySize).ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("batchHandler() status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestBatchHandlerNonJSON(t *testing.T) {
+	// multipart uploads must reach the next handler unread, regardless of
+	// size
+	body := &countingReader{r: strings.NewReader(strings.Repeat("a", testMaxBodySize*2))}
+	r := httptest.NewRequest(http.MethodPost, gqlEndpoint, body)
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	w := httptest.NewRecorder()
+
+	var readBeforeNext int
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readBeforeNext = body.n
+		_, _ = io.Copy(io.Discard, r.Body)
+	})
+
+	batchHandler(next, testMaxBodySize).ServeHTTP(w, r)
+
+	if readBeforeNext != 0 {
+		t.Errorf("batchHandler() read %d bytes of a non-JSON body", readBeforeNext)
+	}
+	if body.n != testMaxBodySize*2 {
+		t.Errorf("next handler read %d bytes, want %d", body.n, testMaxBodySize*2)
+	}
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
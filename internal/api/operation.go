@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stashapp/stash/pkg/sqlite"
+)
+
+// operationNameMiddleware adds the name of the GraphQL operation to the
+// context, so that it is included in slow query logs. Anonymous operations
+// are named after their top-level fields.
+func operationNameMiddleware(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+
+	name := oc.OperationName
+	if name == "" && oc.Operation != nil {
+		var fields []string
+		for _, f := range graphql.CollectFields(oc, oc.Operation.SelectionSet, nil) {
+			fields = append(fields, f.Name)
+		}
+		name = strings.Join(fields, ",")
+	}
+
+	if name != "" {
+		ctx = sqlite.WithOperation(ctx, name)
+	}
+
+	return next(ctx)
+}
@@ -1,6 +1,10 @@
 package api
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
 
 func (r *galleryFileResolver) Fingerprint(ctx context.Context, obj *GalleryFile, type_ string) (*string, error) {
 	fp := obj.BaseFile.Fingerprints.For(type_)
@@ -28,3 +32,25 @@ func (r *videoFileResolver) Fingerprint(ctx context.Context, obj *VideoFile, typ
 	}
 	return nil, nil
 }
+
+func (r *videoFileResolver) ProbeData(ctx context.Context, obj *VideoFile) (map[string]interface{}, error) {
+	var data []byte
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		data, err = r.repository.File.GetProbeData(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	var ret map[string]interface{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("unmarshalling probe data for file %d: %w", obj.ID, err)
+	}
+
+	return ret, nil
+}
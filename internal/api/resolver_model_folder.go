@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *folderResolver) ParentFolder(ctx context.Context, obj *models.Folder) (*models.Folder, error) {
+	if obj.ParentFolderID == nil {
+		return nil, nil
+	}
+
+	var ret *models.Folder
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Folder.Find(ctx, *obj.ParentFolderID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -3,6 +3,8 @@ package api
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/stashapp/stash/internal/api/loaders"
@@ -112,8 +114,10 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 	streamPath := builder.GetStreamURL(config.GetAPIKey()).String()
 	webpPath := builder.GetStreamPreviewImageURL()
 	objHash := obj.GetHash(config.GetVideoFileNamingAlgorithm())
-	vttPath := builder.GetSpriteVTTURL(objHash)
-	spritePath := builder.GetSpriteURL(objHash)
+	// the sprite image and VTT file are generated together, so share a version
+	spriteVersion := fileVersion(manager.GetInstance().Paths.Scene.GetSpriteImageFilePath(objHash))
+	vttPath := builder.GetSpriteVTTURL(objHash, spriteVersion)
+	spritePath := builder.GetSpriteURL(objHash, spriteVersion)
 	funscriptPath := builder.GetFunscriptURL()
 	captionBasePath := builder.GetCaptionURL()
 	interactiveHeatmap := builder.GetInteractiveHeatmapURL()
@@ -131,6 +135,16 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 	}, nil
 }
 
+// fileVersion returns the modification time of the file at path, for use
+// in cache-busting URLs. Returns an empty string if the file does not exist.
+func fileVersion(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatInt(info.ModTime().Unix(), 10)
+}
+
 func (r *sceneResolver) SceneMarkers(ctx context.Context, obj *models.Scene) (ret []*models.SceneMarker, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.SceneMarker.FindBySceneID(ctx, obj.ID)
@@ -12,6 +12,7 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -81,10 +82,52 @@ func (r *mutationResolver) setConfigFloat(key string, value *float64) {
 	}
 }
 
+// setConfigFFMpegArgs validates and sets the extra ffmpeg arguments for key,
+// if value is not nil.
+func (r *mutationResolver) setConfigFFMpegArgs(key string, value []string) error {
+	if value == nil {
+		return nil
+	}
+
+	if err := ffmpeg.ValidateExtraArgs(value); err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	logger.Infof("Setting %s to %q", key, value)
+	config.GetInstance().SetInterface(key, value)
+	return nil
+}
+
 func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGeneralInput) (*ConfigGeneralResult, error) {
 	c := config.GetInstance()
 
+	refreshLibraryWatcher := false
 	existingPaths := c.GetStashPaths()
+
+	// trashed files would be scanned into the library again if the trash
+	// overlapped a library path
+	if input.Stashes != nil || input.TrashPath != nil {
+		trashPath := c.GetTrashPath()
+		if input.TrashPath != nil {
+			trashPath = *input.TrashPath
+		}
+
+		var libraryPaths []string
+		if input.Stashes != nil {
+			for _, s := range input.Stashes {
+				libraryPaths = append(libraryPaths, s.Path)
+			}
+		} else {
+			for _, s := range existingPaths {
+				libraryPaths = append(libraryPaths, s.Path)
+			}
+		}
+
+		if err := file.ValidateTrashPath(trashPath, libraryPaths); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+	}
+
 	if input.Stashes != nil {
 		for _, s := range input.Stashes {
 			// Only validate existence of new paths
@@ -103,8 +146,11 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 			}
 		}
 		c.SetInterface(config.Stash, input.Stashes)
+		refreshLibraryWatcher = true
 	}
 
+	r.setConfigBool(config.AutoTagPerformerAliases, input.AutoTagPerformerAliases)
+
 	checkConfigOverride := func(key string) error {
 		if c.HasOverride(key) {
 			return fmt.Errorf("%w: %s", ErrOverriddenConfig, key)
@@ -149,6 +195,21 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.BackupDirectoryPath, *input.BackupDirectoryPath)
 	}
 
+	r.setConfigInt(config.BackupRetention, input.BackupRetention)
+
+	existingTrashPath := c.GetTrashPath()
+	if input.TrashPath != nil && existingTrashPath != *input.TrashPath {
+		if err := validateDir(config.TrashPath, *input.TrashPath, true); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+
+		c.SetString(config.TrashPath, *input.TrashPath)
+	}
+
+	r.setConfigInt(config.TrashRetention, input.TrashRetention)
+	r.setConfigInt(config.DeletedObjectRetention, input.DeletedObjectRetention)
+	r.setConfigBool(config.AuditLog, input.AuditLog)
+
 	existingGeneratedPath := c.GetGeneratedPath()
 	if input.GeneratedPath != nil && existingGeneratedPath != *input.GeneratedPath {
 		if err := validateDir(config.Generated, *input.GeneratedPath, false); err != nil {
@@ -269,24 +330,75 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 
 	r.setConfigBool(config.CalculateMD5, input.CalculateMd5)
 	r.setConfigInt(config.ParallelTasks, input.ParallelTasks)
+	r.setConfigInt(config.ScanParallelTasks, input.ScanParallelTasks)
+	if input.FfmpegGenerateNiceness != nil {
+		if n := *input.FfmpegGenerateNiceness; n < -20 || n > 19 {
+			return makeConfigGeneralResult(), errors.New("ffmpeg generate niceness must be between -20 and 19")
+		}
+	}
+
+	r.setConfigInt(config.GenerateFFMpegThreads, input.FfmpegGenerateThreads)
+	r.setConfigInt(config.GenerateFFMpegNiceness, input.FfmpegGenerateNiceness)
+	r.setConfigInt(config.GenerateMaxFFMpegProcesses, input.FfmpegGenerateMaxProcesses)
+	r.setConfigInt(config.MaxFFMpegProcesses, input.FfmpegMaxProcesses)
+
+	if input.WatchLibrary != nil || input.WatchLibraryDebounce != nil {
+		r.setConfigBool(config.WatchLibrary, input.WatchLibrary)
+		r.setConfigInt(config.WatchLibraryDebounce, input.WatchLibraryDebounce)
+		refreshLibraryWatcher = true
+	}
+
 	r.setConfigBool(config.PreviewAudio, input.PreviewAudio)
 	r.setConfigInt(config.PreviewSegments, input.PreviewSegments)
 	r.setConfigFloat(config.PreviewSegmentDuration, input.PreviewSegmentDuration)
 	r.setConfigString(config.PreviewExcludeStart, input.PreviewExcludeStart)
 	r.setConfigString(config.PreviewExcludeEnd, input.PreviewExcludeEnd)
+	r.setConfigInt(config.PreviewWidth, input.PreviewWidth)
 	if input.PreviewPreset != nil {
 		c.SetString(config.PreviewPreset, input.PreviewPreset.String())
 	}
 
 	r.setConfigBool(config.TranscodeHardwareAcceleration, input.TranscodeHardwareAcceleration)
+	r.setConfigBool(config.TranscodeToneMapping, input.TranscodeToneMapping)
+	if input.TranscodeHardwareEncoder != nil {
+		c.SetString(config.TranscodeHardwareEncoder, input.TranscodeHardwareEncoder.String())
+	}
 	if input.MaxTranscodeSize != nil {
 		c.SetString(config.MaxTranscodeSize, input.MaxTranscodeSize.String())
 	}
+	if input.GeneratedTranscodeCodec != nil {
+		c.SetString(config.GeneratedTranscodeCodec, input.GeneratedTranscodeCodec.String())
+	}
+	if input.GeneratedTranscodeCrf != nil {
+		if crf := *input.GeneratedTranscodeCrf; crf < 0 || crf > 51 {
+			return makeConfigGeneralResult(), errors.New("generated transcode crf must be between 0 and 51")
+		}
+	}
+	r.setConfigInt(config.GeneratedTranscodeCRF, input.GeneratedTranscodeCrf)
 
 	if input.MaxStreamingTranscodeSize != nil {
 		c.SetString(config.MaxStreamingTranscodeSize, input.MaxStreamingTranscodeSize.String())
 	}
+
+	if input.LiveTranscodePreset != nil {
+		c.SetString(config.LiveTranscodePreset, input.LiveTranscodePreset.String())
+	}
+	if input.LiveTranscodeCrf != nil {
+		if crf := *input.LiveTranscodeCrf; crf < 0 || crf > 51 {
+			return makeConfigGeneralResult(), errors.New("live transcode crf must be between 0 and 51")
+		}
+	}
+	if input.LiveTranscodeMaxBitrate != nil && *input.LiveTranscodeMaxBitrate < 0 {
+		return makeConfigGeneralResult(), errors.New("live transcode max bitrate must not be negative")
+	}
+	r.setConfigInt(config.LiveTranscodeCRF, input.LiveTranscodeCrf)
+	r.setConfigInt(config.LiveTranscodeMaxBitrate, input.LiveTranscodeMaxBitrate)
+	if input.LiveTranscodeCacheSize != nil && *input.LiveTranscodeCacheSize < 0 {
+		return makeConfigGeneralResult(), errors.New("live transcode cache size must not be negative")
+	}
+	r.setConfigInt(config.LiveTranscodeCacheSize, input.LiveTranscodeCacheSize)
 	r.setConfigBool(config.WriteImageThumbnails, input.WriteImageThumbnails)
+	r.setConfigInt(config.ImageThumbnailSize, input.ImageThumbnailSize)
 	r.setConfigBool(config.CreateImageClipsFromVideos, input.CreateImageClipsFromVideos)
 
 	if input.GalleryCoverRegex != nil {
@@ -326,6 +438,9 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	r.setConfigString(config.LogFile, input.LogFile)
 	r.setConfigBool(config.LogOut, input.LogOut)
 	r.setConfigBool(config.LogAccess, input.LogAccess)
+	r.setConfigInt(config.LogMaxSize, input.LogMaxSize)
+	r.setConfigInt(config.LogMaxAge, input.LogMaxAge)
+	r.setConfigInt(config.LogMaxBackups, input.LogMaxBackups)
 
 	if input.LogLevel != nil && *input.LogLevel != c.GetLogLevel() {
 		c.SetString(config.LogLevel, *input.LogLevel)
@@ -383,17 +498,27 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		r.setConfigString(config.PythonPath, input.PythonPath)
 	}
 
-	if input.TranscodeInputArgs != nil {
-		c.SetInterface(config.TranscodeInputArgs, input.TranscodeInputArgs)
-	}
-	if input.TranscodeOutputArgs != nil {
-		c.SetInterface(config.TranscodeOutputArgs, input.TranscodeOutputArgs)
+	for key, value := range map[string][]string{
+		config.TranscodeInputArgs:           input.TranscodeInputArgs,
+		config.TranscodeOutputArgs:          input.TranscodeOutputArgs,
+		config.PreviewInputArgs:             input.PreviewInputArgs,
+		config.PreviewOutputArgs:            input.PreviewOutputArgs,
+		config.ScreenshotInputArgs:          input.ScreenshotInputArgs,
+		config.ScreenshotOutputArgs:         input.ScreenshotOutputArgs,
+		config.GeneratedTranscodeInputArgs:  input.GeneratedTranscodeInputArgs,
+		config.GeneratedTranscodeOutputArgs: input.GeneratedTranscodeOutputArgs,
+		config.LiveTranscodeInputArgs:       input.LiveTranscodeInputArgs,
+		config.LiveTranscodeOutputArgs:      input.LiveTranscodeOutputArgs,
+	} {
+		if err := r.setConfigFFMpegArgs(key, value); err != nil {
+			return makeConfigGeneralResult(), err
+		}
 	}
-	if input.LiveTranscodeInputArgs != nil {
-		c.SetInterface(config.LiveTranscodeInputArgs, input.LiveTranscodeInputArgs)
+	if input.LiveTranscodeH264Encoders != nil {
+		c.SetInterface(config.LiveTranscodeH264Encoders, input.LiveTranscodeH264Encoders)
 	}
-	if input.LiveTranscodeOutputArgs != nil {
-		c.SetInterface(config.LiveTranscodeOutputArgs, input.LiveTranscodeOutputArgs)
+	if input.LiveTranscodeVp9Encoders != nil {
+		c.SetInterface(config.LiveTranscodeVP9Encoders, input.LiveTranscodeVp9Encoders)
 	}
 
 	r.setConfigBool(config.DrawFunscriptHeatmapRange, input.DrawFunscriptHeatmapRange)
@@ -437,6 +562,9 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	if refreshPluginSource {
 		manager.GetInstance().RefreshPluginSourceManager()
 	}
+	if refreshLibraryWatcher {
+		manager.GetInstance().RefreshLibraryWatcher()
+	}
 
 	return makeConfigGeneralResult(), nil
 }
@@ -458,6 +586,7 @@ func (r *mutationResolver) ConfigureInterface(ctx context.Context, input ConfigI
 	r.setConfigBool(config.ShowScrubber, input.ShowScrubber)
 
 	r.setConfigString(config.WallPlayback, input.WallPlayback)
+	r.setConfigString(config.CardPlayback, input.CardPlayback)
 	r.setConfigInt(config.MaximumLoopDuration, input.MaximumLoopDuration)
 	r.setConfigBool(config.AutostartVideo, input.AutostartVideo)
 	r.setConfigBool(config.ShowStudioAsText, input.ShowStudioAsText)
@@ -616,6 +745,46 @@ func (r *mutationResolver) ConfigureDefaults(ctx context.Context, input ConfigDe
 	return makeConfigDefaultsResult(), nil
 }
 
+func (r *mutationResolver) ConfigureScheduledTasks(ctx context.Context, input []*config.ScheduledTask) ([]*ScheduledTask, error) {
+	if err := manager.ValidateScheduledTasks(input); err != nil {
+		return nil, err
+	}
+
+	c := config.GetInstance()
+	c.SetInterface(config.ScheduledTasks, input)
+
+	if err := c.Write(); err != nil {
+		return nil, err
+	}
+
+	manager.GetInstance().RefreshScheduler()
+
+	return makeScheduledTasksResult(), nil
+}
+
+func (r *mutationResolver) ConfigureTaskNotifications(ctx context.Context, input []*config.TaskNotification) ([]*config.TaskNotification, error) {
+	if err := manager.ValidateTaskNotifications(input); err != nil {
+		return nil, err
+	}
+
+	c := config.GetInstance()
+	c.SetInterface(config.TaskNotifications, input)
+
+	if err := c.Write(); err != nil {
+		return nil, err
+	}
+
+	return makeTaskNotificationsResult(), nil
+}
+
+func (r *mutationResolver) TestTaskNotification(ctx context.Context, input config.TaskNotification) (bool, error) {
+	if err := manager.GetInstance().TestTaskNotification(ctx, input); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) GenerateAPIKey(ctx context.Context, input GenerateAPIKeyInput) (string, error) {
 	c := config.GetInstance()
 
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) RestoreDeletedObjects(ctx context.Context, input RestoreDeletedObjectsInput) (bool, error) {
+	ids, err := stringslice.StringSliceToIntSlice(input.Ids)
+	if err != nil {
+		return false, fmt.Errorf("converting ids: %w", err)
+	}
+
+	if err := manager.GetInstance().RestoreDeletedObjects(ctx, ids); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) PurgeDeletedObjects(ctx context.Context, input manager.PurgeDeletedObjectsInput) (string, error) {
+	jobID, err := manager.GetInstance().PurgeDeletedObjects(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
@@ -3,10 +3,12 @@ package api
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/file/video"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
@@ -96,9 +98,17 @@ func (r *mutationResolver) MoveFiles(ctx context.Context, input MoveFilesInput)
 				}
 			}
 
+			oldPath := f[0].Base().Path
 			if err := mover.Move(ctx, f[0], folder, basename); err != nil {
 				return err
 			}
+
+			if vf, ok := f[0].(*models.VideoFile); ok {
+				newPath := filepath.Join(folder.Path, vf.Basename)
+				if err := r.moveVideoSidecarFiles(ctx, mover, vf, oldPath, newPath); err != nil {
+					return err
+				}
+			}
 		}
 
 		return nil
@@ -109,6 +119,43 @@ func (r *mutationResolver) MoveFiles(ctx context.Context, input MoveFilesInput)
 	return true, nil
 }
 
+// moveVideoSidecarFiles moves the funscript and caption files associated
+// with a moved video file, so that they remain associated with it.
+func (r *mutationResolver) moveVideoSidecarFiles(ctx context.Context, mover *file.Mover, f *models.VideoFile, oldPath, newPath string) error {
+	if oldPath == newPath {
+		return nil
+	}
+
+	if err := mover.MoveSidecarFile(video.GetFunscriptPath(oldPath), video.GetFunscriptPath(newPath)); err != nil {
+		return fmt.Errorf("moving funscript for %s: %w", oldPath, err)
+	}
+
+	fileStore := r.repository.File
+	captions, err := fileStore.GetCaptions(ctx, f.ID)
+	if err != nil {
+		return fmt.Errorf("getting captions for %s: %w", oldPath, err)
+	}
+
+	if len(captions) == 0 {
+		return nil
+	}
+
+	for _, c := range captions {
+		captionPath := video.GetCaptionPath(newPath, c.LanguageCode, c.CaptionType)
+		if err := mover.MoveSidecarFile(c.Path(oldPath), captionPath); err != nil {
+			return fmt.Errorf("moving caption for %s: %w", oldPath, err)
+		}
+
+		c.Filename = filepath.Base(captionPath)
+	}
+
+	if err := fileStore.UpdateCaptions(ctx, f.ID, captions); err != nil {
+		return fmt.Errorf("updating captions for %s: %w", newPath, err)
+	}
+
+	return nil
+}
+
 func (r *mutationResolver) validateFolderPath(folderPath string) error {
 	paths := manager.GetInstance().Config.GetStashPaths()
 	if l := paths.GetStashFromDirPath(folderPath); l == nil {
@@ -143,13 +190,21 @@ func (r *mutationResolver) validateFileExtensionList(exts []string, oldBasename,
 	return nil
 }
 
+// newFileDeleter returns a file deleter that moves deleted library files to
+// the configured trash directory, if set.
+func newFileDeleter() *file.Deleter {
+	ret := file.NewDeleter()
+	ret.TrashPath = manager.GetInstance().Config.GetTrashPath()
+	return ret
+}
+
 func (r *mutationResolver) DeleteFiles(ctx context.Context, ids []string) (ret bool, err error) {
 	fileIDs, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
-	fileDeleter := file.NewDeleter()
+	fileDeleter := newFileDeleter()
 	destroyer := &file.ZipDestroyer{
 		FileDestroyer:   r.repository.File,
 		FolderDestroyer: r.repository.Folder,
@@ -272,3 +327,20 @@ func (r *mutationResolver) FileSetFingerprints(ctx context.Context, input FileSe
 
 	return true, nil
 }
+
+func (r *mutationResolver) RestoreTrashedFiles(ctx context.Context, input RestoreTrashedFilesInput) (bool, error) {
+	if err := manager.GetInstance().RestoreTrashedFiles(input.Names); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) EmptyTrash(ctx context.Context, input manager.EmptyTrashInput) (string, error) {
+	jobID, err := manager.GetInstance().EmptyTrash(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
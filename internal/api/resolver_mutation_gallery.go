@@ -8,7 +8,6 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
@@ -336,8 +335,9 @@ func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.Gall
 	var galleries []*models.Gallery
 	var imgsDestroyed []*models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
-		Paths:   manager.GetInstance().Paths,
+		Deleter:       newFileDeleter(),
+		Paths:         manager.GetInstance().Paths,
+		ThumbnailSize: manager.GetInstance().Config.GetImageThumbnailSize(),
 	}
 
 	deleteGenerated := utils.IsTrue(input.DeleteGenerated)
@@ -362,6 +362,10 @@ func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.Gall
 
 			galleries = append(galleries, gallery)
 
+			if err := manager.KeepDeletedGallery(ctx, r.repository, gallery); err != nil {
+				return err
+			}
+
 			imgsDestroyed, err = r.galleryService.Destroy(ctx, gallery, fileDeleter, deleteGenerated, deleteFile)
 			if err != nil {
 				return err
@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -310,8 +309,9 @@ func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageD
 
 	var i *models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
-		Paths:   manager.GetInstance().Paths,
+		Deleter:       newFileDeleter(),
+		Paths:         manager.GetInstance().Paths,
+		ThumbnailSize: manager.GetInstance().Config.GetImageThumbnailSize(),
 	}
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		i, err = r.repository.Image.Find(ctx, imageID)
@@ -350,8 +350,9 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 
 	var images []*models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
-		Paths:   manager.GetInstance().Paths,
+		Deleter:       newFileDeleter(),
+		Paths:         manager.GetInstance().Paths,
+		ThumbnailSize: manager.GetInstance().Config.GetImageThumbnailSize(),
 	}
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Image
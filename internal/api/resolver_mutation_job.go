@@ -22,3 +22,39 @@ func (r *mutationResolver) StopAllJobs(ctx context.Context) (bool, error) {
 	manager.GetInstance().JobManager.CancelAll()
 	return true, nil
 }
+
+func (r *mutationResolver) PrioritiseJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	return manager.GetInstance().JobManager.PrioritiseJob(id), nil
+}
+
+func (r *mutationResolver) PauseJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().PauseJob(id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResumeJob(ctx context.Context, id string) (string, error) {
+	pausedID, err := strconv.Atoi(id)
+	if err != nil {
+		return "", fmt.Errorf("converting id: %w", err)
+	}
+
+	jobID, err := manager.GetInstance().ResumeJob(ctx, pausedID)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
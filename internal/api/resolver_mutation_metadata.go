@@ -12,6 +12,9 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
 func (r *mutationResolver) MetadataScan(ctx context.Context, input manager.ScanMetadataInput) (string, error) {
@@ -87,10 +90,99 @@ func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input manager.Au
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) AutoTagApply(ctx context.Context, input []*AutoTagSceneMatchInput) (bool, error) {
+	var updated []int
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		for _, m := range input {
+			sceneID, err := strconv.Atoi(m.SceneID)
+			if err != nil {
+				return fmt.Errorf("converting scene id: %w", err)
+			}
+
+			s, err := qb.Find(ctx, sceneID)
+			if err != nil {
+				return err
+			}
+			if s == nil {
+				return fmt.Errorf("scene with id %d not found", sceneID)
+			}
+
+			partial := models.NewScenePartial()
+
+			if len(m.PerformerIds) > 0 {
+				ids, err := stringslice.StringSliceToIntSlice(m.PerformerIds)
+				if err != nil {
+					return fmt.Errorf("converting performer ids: %w", err)
+				}
+				partial.PerformerIDs = &models.UpdateIDs{
+					IDs:  ids,
+					Mode: models.RelationshipUpdateModeAdd,
+				}
+			}
+
+			if m.StudioID != nil && s.StudioID == nil {
+				studioID, err := strconv.Atoi(*m.StudioID)
+				if err != nil {
+					return fmt.Errorf("converting studio id: %w", err)
+				}
+				partial.StudioID = models.NewOptionalInt(studioID)
+			}
+
+			if len(m.TagIds) > 0 {
+				ids, err := stringslice.StringSliceToIntSlice(m.TagIds)
+				if err != nil {
+					return fmt.Errorf("converting tag ids: %w", err)
+				}
+				partial.TagIDs = &models.UpdateIDs{
+					IDs:  ids,
+					Mode: models.RelationshipUpdateModeAdd,
+				}
+			}
+
+			if partial.PerformerIDs == nil && !partial.StudioID.Set && partial.TagIDs == nil {
+				continue
+			}
+
+			if _, err := qb.UpdatePartial(ctx, sceneID, partial); err != nil {
+				return err
+			}
+
+			updated = append(updated, sceneID)
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	// execute post hooks outside of txn
+	for _, id := range updated {
+		r.hookExecutor.ExecutePostHooks(ctx, id, hook.SceneUpdatePost, input, nil)
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.Options) (string, error) {
+	// fill in anything not provided from the saved identify settings
+	input = input.WithDefaults(config.GetInstance().GetDefaultIdentifySettings())
+
 	t := manager.CreateIdentifyJob(input)
-	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying...", t)
+	mgr := manager.GetInstance()
+	jobID := mgr.AddJob(ctx, "Identifying...", t, config.NotificationTaskTypeIdentify)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataImportNfo(ctx context.Context, input manager.ImportNFOInput) (string, error) {
+	jobID := manager.GetInstance().ImportNFO(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
 
+func (r *mutationResolver) MetadataExportNfo(ctx context.Context, input manager.ExportNFOInput) (string, error) {
+	jobID := manager.GetInstance().ExportNFO(ctx, input)
 	return strconv.Itoa(jobID), nil
 }
 
@@ -99,6 +191,16 @@ func (r *mutationResolver) MetadataClean(ctx context.Context, input manager.Clea
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataFindDuplicates(ctx context.Context, input manager.FindDuplicatesMetadataInput) (string, error) {
+	jobID := manager.GetInstance().FindDuplicates(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataCheckIntegrity(ctx context.Context, input manager.IntegrityCheckInput) (string, error) {
+	jobID := manager.GetInstance().CheckIntegrity(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MetadataCleanGenerated(ctx context.Context, input task.CleanGeneratedOptions) (string, error) {
 	mgr := manager.GetInstance()
 	t := &task.CleanGeneratedJob{
@@ -148,6 +250,20 @@ func (r *mutationResolver) BackupDatabase(ctx context.Context, input BackupDatab
 	return nil, nil
 }
 
+func (r *mutationResolver) RestoreDatabase(ctx context.Context, input RestoreDatabaseInput) (bool, error) {
+	backupPath := ""
+	if input.BackupPath != nil {
+		backupPath = *input.BackupPath
+	}
+
+	if err := manager.GetInstance().RestoreDatabase(backupPath); err != nil {
+		logger.Errorf("Error restoring database: %v", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) AnonymiseDatabase(ctx context.Context, input AnonymiseDatabaseInput) (*string, error) {
 	// if download is true, then save to temporary file and return a link
 	download := input.Download != nil && *input.Download
@@ -177,7 +293,11 @@ func (r *mutationResolver) AnonymiseDatabase(ctx context.Context, input Anonymis
 	return nil, nil
 }
 
-func (r *mutationResolver) OptimiseDatabase(ctx context.Context) (string, error) {
-	jobID := manager.GetInstance().OptimiseDatabase(ctx)
+func (r *mutationResolver) OptimiseDatabase(ctx context.Context, input *manager.OptimiseDatabaseInput) (string, error) {
+	if input == nil {
+		input = &manager.OptimiseDatabaseInput{}
+	}
+
+	jobID := manager.GetInstance().OptimiseDatabase(ctx, *input)
 	return strconv.Itoa(jobID), nil
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
@@ -51,3 +52,37 @@ func (r *mutationResolver) Migrate(ctx context.Context, input manager.MigrateInp
 
 	return strconv.Itoa(jobID), nil
 }
+
+func (r *mutationResolver) MigrateDown(ctx context.Context, input MigrateDownInput) (string, error) {
+	if input.SchemaVersion < 0 {
+		return "", fmt.Errorf("invalid schema version %d", input.SchemaVersion)
+	}
+
+	mgr := manager.GetInstance()
+	t := &task.MigrateDownJob{
+		SchemaVersion: uint(input.SchemaVersion),
+		Config:        mgr.Config,
+		Database:      mgr.Database,
+	}
+	if input.BackupPath != nil {
+		t.BackupPath = *input.BackupPath
+	}
+
+	jobID := mgr.JobManager.Add(ctx, "Reverting database migrations...", t)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MigrateGeneratedFiles(ctx context.Context, input manager.MigrateGeneratedInput) (string, error) {
+	jobID := manager.GetInstance().MigrateGenerated(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) RepairSchema(ctx context.Context) (*SchemaStatus, error) {
+	status, err := manager.GetInstance().Database.RepairSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaStatusResult(status), nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/performer"
 	"github.com/stashapp/stash/pkg/plugin/hook"
@@ -49,6 +50,8 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 	newPerformer.PenisLength = input.PenisLength
 	newPerformer.Circumcised = input.Circumcised
 	newPerformer.CareerLength = translator.string(input.CareerLength)
+	newPerformer.CareerStart = input.CareerStart
+	newPerformer.CareerEnd = input.CareerEnd
 	newPerformer.Tattoos = translator.string(input.Tattoos)
 	newPerformer.Piercings = translator.string(input.Piercings)
 	newPerformer.Favorite = translator.bool(input.Favorite)
@@ -243,6 +246,8 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 	updatedPerformer.PenisLength = translator.optionalFloat64(input.PenisLength, "penis_length")
 	updatedPerformer.Circumcised = translator.optionalString((*string)(input.Circumcised), "circumcised")
 	updatedPerformer.CareerLength = translator.optionalString(input.CareerLength, "career_length")
+	updatedPerformer.CareerStart = translator.optionalInt(input.CareerStart, "career_start")
+	updatedPerformer.CareerEnd = translator.optionalInt(input.CareerEnd, "career_end")
 	updatedPerformer.Tattoos = translator.optionalString(input.Tattoos, "tattoos")
 	updatedPerformer.Piercings = translator.optionalString(input.Piercings, "piercings")
 	updatedPerformer.Favorite = translator.optionalBool(input.Favorite, "favorite")
@@ -358,6 +363,8 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 	updatedPerformer.PenisLength = translator.optionalFloat64(input.PenisLength, "penis_length")
 	updatedPerformer.Circumcised = translator.optionalString((*string)(input.Circumcised), "circumcised")
 	updatedPerformer.CareerLength = translator.optionalString(input.CareerLength, "career_length")
+	updatedPerformer.CareerStart = translator.optionalInt(input.CareerStart, "career_start")
+	updatedPerformer.CareerEnd = translator.optionalInt(input.CareerEnd, "career_end")
 	updatedPerformer.Tattoos = translator.optionalString(input.Tattoos, "tattoos")
 	updatedPerformer.Piercings = translator.optionalString(input.Piercings, "piercings")
 
@@ -458,7 +465,7 @@ func (r *mutationResolver) PerformerDestroy(ctx context.Context, input Performer
 	}
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		return r.repository.Performer.Destroy(ctx, id)
+		return r.destroyPerformer(ctx, id)
 	}); err != nil {
 		return false, err
 	}
@@ -468,6 +475,26 @@ func (r *mutationResolver) PerformerDestroy(ctx context.Context, input Performer
 	return true, nil
 }
 
+// destroyPerformer destroys the performer, keeping it as a deleted object so
+// that it can be restored.
+func (r *mutationResolver) destroyPerformer(ctx context.Context, id int) error {
+	qb := r.repository.Performer
+	p, err := qb.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if p == nil {
+		return fmt.Errorf("performer with id %d not found", id)
+	}
+
+	if err := manager.KeepDeletedPerformer(ctx, r.repository, p); err != nil {
+		return err
+	}
+
+	return qb.Destroy(ctx, id)
+}
+
 func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs []string) (bool, error) {
 	ids, err := stringslice.StringSliceToIntSlice(performerIDs)
 	if err != nil {
@@ -475,9 +502,8 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 	}
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Performer
 		for _, id := range ids {
-			if err := qb.Destroy(ctx, id); err != nil {
+			if err := r.destroyPerformer(ctx, id); err != nil {
 				return err
 			}
 		}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/stashapp/stash/internal/manager/config"
@@ -30,12 +31,16 @@ func (r *mutationResolver) SaveFilter(ctx context.Context, input SaveFilterInput
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.SavedFilter
 
+		// created_at is ignored when updating
+		now := time.Now()
 		f := models.SavedFilter{
 			Mode:         input.Mode,
 			Name:         input.Name,
 			FindFilter:   input.FindFilter,
 			ObjectFilter: input.ObjectFilter,
 			UIOptions:    input.UIOptions,
+			CreatedAt:    now,
+			UpdatedAt:    now,
 		}
 
 		if id == nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/file/video"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -431,7 +432,7 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 
 	var s *models.Scene
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        newFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -454,6 +455,10 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 		// kill any running encoders
 		manager.KillRunningStreams(s, fileNamingAlgo)
 
+		if err := manager.KeepDeletedScene(ctx, r.repository, s); err != nil {
+			return err
+		}
+
 		return r.sceneService.Destroy(ctx, s, fileDeleter, deleteGenerated, deleteFile)
 	}); err != nil {
 		fileDeleter.Rollback()
@@ -484,7 +489,7 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        newFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -509,6 +514,10 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 			// kill any running encoders
 			manager.KillRunningStreams(scene, fileNamingAlgo)
 
+			if err := manager.KeepDeletedScene(ctx, r.repository, scene); err != nil {
+				return err
+			}
+
 			if err := r.sceneService.Destroy(ctx, scene, fileDeleter, deleteGenerated, deleteFile); err != nil {
 				return err
 			}
@@ -547,12 +556,119 @@ func (r *mutationResolver) SceneAssignFile(ctx context.Context, input AssignScen
 		return false, fmt.Errorf("converting file id: %w", err)
 	}
 
+	// scenes whose primary file changes
+	var changed []int
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		return r.Resolver.sceneService.AssignFile(ctx, sceneID, models.FileID(fileID))
+		qb := r.repository.Scene
+
+		before, err := qb.FindByPrimaryFileID(ctx, models.FileID(fileID))
+		if err != nil {
+			return err
+		}
+
+		if err := r.Resolver.sceneService.AssignFile(ctx, sceneID, models.FileID(fileID)); err != nil {
+			return err
+		}
+
+		after, err := qb.FindByPrimaryFileID(ctx, models.FileID(fileID))
+		if err != nil {
+			return err
+		}
+
+		for _, s := range append(before, after...) {
+			changed = append(changed, s.ID)
+		}
+
+		return nil
 	}); err != nil {
 		return false, fmt.Errorf("assigning file to scene: %w", err)
 	}
 
+	if utils.IsTrue(input.Generate) {
+		manager.GetInstance().GenerateScenes(ctx, changed)
+	}
+
+	return true, nil
+}
+
+// scenePrimaryVideoFile returns the primary file of the scene with the provided id.
+// Assumes it is being called within a transaction.
+func (r *mutationResolver) scenePrimaryVideoFile(ctx context.Context, sceneID int) (*models.VideoFile, error) {
+	s, err := r.repository.Scene.Find(ctx, sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == nil {
+		return nil, fmt.Errorf("scene with id %d not found", sceneID)
+	}
+
+	if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+		return nil, err
+	}
+
+	f := s.Files.Primary()
+	if f == nil {
+		return nil, fmt.Errorf("scene with id %d has no files", sceneID)
+	}
+
+	return f, nil
+}
+
+func (r *mutationResolver) SceneAddCaption(ctx context.Context, input SceneAddCaptionInput) (*models.VideoCaption, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	lang := ""
+	if input.LanguageCode != nil {
+		lang = *input.LanguageCode
+	}
+
+	var ret *models.VideoCaption
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		f, err := r.scenePrimaryVideoFile(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		ret, err = video.AddCaption(ctx, f, lang, input.CaptionType, []byte(input.Data), r.repository.File)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("adding caption to scene: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneRemoveCaption(ctx context.Context, input SceneRemoveCaptionInput) (bool, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return false, fmt.Errorf("converting scene id: %w", err)
+	}
+
+	fileDeleter := newFileDeleter()
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		f, err := r.scenePrimaryVideoFile(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		captionPath, err := video.RemoveCaption(ctx, f, input.LanguageCode, input.CaptionType, r.repository.File)
+		if err != nil {
+			return err
+		}
+
+		return fileDeleter.MediaFiles([]string{captionPath})
+	}); err != nil {
+		fileDeleter.Rollback()
+		return false, fmt.Errorf("removing caption from scene: %w", err)
+	}
+
+	fileDeleter.Commit()
+
 	return true, nil
 }
 
@@ -601,6 +717,27 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 
 	var ret *models.Scene
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		// generated files of the source scenes are deleted when the
+		// transaction is committed, and restored if it is rolled back
+		fileDeleter.RegisterHooks(ctx)
+
+		// keep the source scenes before their files are moved to the
+		// destination
+		sources, err := r.repository.Scene.FindMany(ctx, sliceutil.AppendUniques(nil, srcIDs))
+		if err != nil {
+			return err
+		}
+
+		for _, src := range sources {
+			if src.ID == destID {
+				continue
+			}
+
+			if err := manager.KeepDeletedScene(ctx, r.repository, src); err != nil {
+				return err
+			}
+		}
+
 		if err := r.Resolver.sceneService.Merge(ctx, srcIDs, destID, fileDeleter, scene.MergeOptions{
 			ScenePartial:       *values,
 			IncludePlayHistory: utils.IsTrue(input.PlayHistory),
@@ -676,6 +813,8 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 		return nil, err
 	}
 
+	manager.GetInstance().GenerateMarkerPreviews(ctx, newMarker.ID)
+
 	r.hookExecutor.ExecutePostHooks(ctx, newMarker.ID, hook.SceneMarkerCreatePost, input, nil)
 	return r.getSceneMarker(ctx, newMarker.ID)
 }
@@ -721,6 +860,8 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 		Paths:          mgr.Paths,
 	}
 
+	regenerate := false
+
 	// Start the transaction and save the scene marker
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.SceneMarker
@@ -754,6 +895,7 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 			if err := fileDeleter.MarkMarkerFiles(existingScene, seconds); err != nil {
 				return err
 			}
+			regenerate = true
 		}
 
 		if tagIdsIncluded {
@@ -774,6 +916,10 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 	// perform the post-commit actions
 	fileDeleter.Commit()
 
+	if regenerate {
+		mgr.GenerateMarkerPreviews(ctx, markerID)
+	}
+
 	r.hookExecutor.ExecutePostHooks(ctx, markerID, hook.SceneMarkerUpdatePost, input, translator.getFields())
 	return r.getSceneMarker(ctx, markerID)
 }
@@ -1065,11 +1211,52 @@ func (r *mutationResolver) SceneDeleteO(ctx context.Context, id string, t []*tim
 }
 
 func (r *mutationResolver) SceneGenerateScreenshot(ctx context.Context, id string, at *float64) (string, error) {
+	if at != nil && *at < 0 {
+		return "", fmt.Errorf("screenshot time must not be negative")
+	}
+
+	var jobID int
 	if at != nil {
-		manager.GetInstance().GenerateScreenshot(ctx, id, *at)
+		jobID = manager.GetInstance().GenerateScreenshot(ctx, id, *at)
 	} else {
-		manager.GetInstance().GenerateDefaultScreenshot(ctx, id)
+		jobID = manager.GetInstance().GenerateDefaultScreenshot(ctx, id)
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) SceneExtractClip(ctx context.Context, input SceneExtractClipInput) (string, error) {
+	sceneID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return "", fmt.Errorf("converting id: %w", err)
+	}
+
+	var s *models.Scene
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		s, err = r.repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		return s.LoadPrimaryFile(ctx, r.repository.File)
+	}); err != nil {
+		return "", err
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+
+	jobID, err := manager.GetInstance().ExtractClip(ctx, s, manager.ExtractClipOptions{
+		Start:       input.Start,
+		End:         input.End,
+		Reencode:    utils.IsTrue(input.Reencode),
+		CreateScene: utils.IsTrue(input.CreateScene),
+	}, baseURL)
+	if err != nil {
+		return "", err
 	}
 
-	return "todo", nil
+	return strconv.Itoa(jobID), nil
 }
@@ -33,7 +33,6 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 	newStudio := models.NewStudio()
 
 	newStudio.Name = input.Name
-	newStudio.URL = translator.string(input.URL)
 	newStudio.Rating = input.Rating100
 	newStudio.Favorite = translator.bool(input.Favorite)
 	newStudio.Details = translator.string(input.Details)
@@ -41,6 +40,12 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 	newStudio.Aliases = models.NewRelatedStrings(input.Aliases)
 	newStudio.StashIDs = models.NewRelatedStashIDs(input.StashIds)
 
+	if input.Urls != nil {
+		newStudio.URLs = models.NewRelatedStrings(input.Urls)
+	} else if input.URL != nil {
+		newStudio.URLs = models.NewRelatedStrings([]string{*input.URL})
+	}
+
 	var err error
 
 	newStudio.ParentID, err = translator.intPtrFromString(input.ParentID)
@@ -106,7 +111,7 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 
 	updatedStudio.ID = studioID
 	updatedStudio.Name = translator.optionalString(input.Name, "name")
-	updatedStudio.URL = translator.optionalString(input.URL, "url")
+	updatedStudio.URLs = translator.optionalURLs(input.Urls, input.URL)
 	updatedStudio.Details = translator.optionalString(input.Details, "details")
 	updatedStudio.Rating = translator.optionalInt(input.Rating100, "rating100")
 	updatedStudio.Favorite = translator.optionalBool(input.Favorite, "favorite")
@@ -163,6 +168,77 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 	return r.getStudio(ctx, studioID)
 }
 
+func (r *mutationResolver) BulkStudioUpdate(ctx context.Context, input BulkStudioUpdateInput) ([]*models.Studio, error) {
+	ids, err := stringslice.StringSliceToIntSlice(input.Ids)
+	if err != nil {
+		return nil, fmt.Errorf("converting ids: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	// Populate studio from the input
+	partial := models.NewStudioPartial()
+
+	partial.URLs = translator.updateStringsBulk(input.Urls, "urls")
+	partial.Details = translator.optionalString(input.Details, "details")
+	partial.Rating = translator.optionalInt(input.Rating100, "rating100")
+	partial.Favorite = translator.optionalBool(input.Favorite, "favorite")
+	partial.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
+
+	partial.ParentID, err = translator.optionalIntFromString(input.ParentID, "parent_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting parent id: %w", err)
+	}
+
+	partial.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting tag ids: %w", err)
+	}
+
+	ret := []*models.Studio{}
+
+	// Start the transaction and save the studios
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Studio
+
+		for _, id := range ids {
+			partial.ID = id
+
+			if err := studio.ValidateModify(ctx, partial, qb); err != nil {
+				return err
+			}
+
+			s, err := qb.UpdatePartial(ctx, partial)
+			if err != nil {
+				return err
+			}
+
+			ret = append(ret, s)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// execute post hooks outside of txn
+	var newRet []*models.Studio
+	for _, s := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, s.ID, hook.StudioUpdatePost, input, translator.getFields())
+
+		s, err = r.getStudio(ctx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		newRet = append(newRet, s)
+	}
+
+	return newRet, nil
+}
+
 func (r *mutationResolver) StudioDestroy(ctx context.Context, input StudioDestroyInput) (bool, error) {
 	id, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -205,3 +281,42 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 
 	return true, nil
 }
+
+func (r *mutationResolver) StudioMerge(ctx context.Context, input StudioMergeInput) (*models.Studio, error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	if len(source) == 0 {
+		return nil, nil
+	}
+
+	var s *models.Studio
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Studio
+
+		var err error
+		s, err = qb.Find(ctx, destination)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("studio with id %d not found", destination)
+		}
+
+		return qb.Merge(ctx, source, destination)
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, s.ID, hook.StudioMergePost, input, nil)
+
+	return r.getStudio(ctx, s.ID)
+}
@@ -24,6 +24,13 @@ func (r *mutationResolver) getTag(ctx context.Context, id int) (ret *models.Tag,
 	return ret, nil
 }
 
+// executeTagPostHooks clears the cached tag suggestions, which may include
+// the changed tag, and executes the post hooks for the tag.
+func (r *mutationResolver) executeTagPostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
+	r.tagSuggester.Invalidate()
+	r.hookExecutor.ExecutePostHooks(ctx, id, hookType, input, inputFields)
+}
+
 func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput) (*models.Tag, error) {
 	translator := changesetTranslator{
 		inputMap: getUpdateInputMap(ctx),
@@ -84,7 +91,7 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 		return nil, err
 	}
 
-	r.hookExecutor.ExecutePostHooks(ctx, newTag.ID, hook.TagCreatePost, input, nil)
+	r.executeTagPostHooks(ctx, newTag.ID, hook.TagCreatePost, input, nil)
 	return r.getTag(ctx, newTag.ID)
 }
 
@@ -153,7 +160,7 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 		return nil, err
 	}
 
-	r.hookExecutor.ExecutePostHooks(ctx, t.ID, hook.TagUpdatePost, input, translator.getFields())
+	r.executeTagPostHooks(ctx, t.ID, hook.TagUpdatePost, input, translator.getFields())
 	return r.getTag(ctx, t.ID)
 }
 
@@ -213,7 +220,7 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 	// execute post hooks outside of txn
 	var newRet []*models.Tag
 	for _, tag := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, tag.ID, hook.TagUpdatePost, input, translator.getFields())
+		r.executeTagPostHooks(ctx, tag.ID, hook.TagUpdatePost, input, translator.getFields())
 
 		tag, err = r.getTag(ctx, tag.ID)
 		if err != nil {
@@ -238,7 +245,7 @@ func (r *mutationResolver) TagDestroy(ctx context.Context, input TagDestroyInput
 		return false, err
 	}
 
-	r.hookExecutor.ExecutePostHooks(ctx, tagID, hook.TagDestroyPost, input, nil)
+	r.executeTagPostHooks(ctx, tagID, hook.TagDestroyPost, input, nil)
 
 	return true, nil
 }
@@ -263,7 +270,7 @@ func (r *mutationResolver) TagsDestroy(ctx context.Context, tagIDs []string) (bo
 	}
 
 	for _, id := range ids {
-		r.hookExecutor.ExecutePostHooks(ctx, id, hook.TagDestroyPost, tagIDs, nil)
+		r.executeTagPostHooks(ctx, id, hook.TagDestroyPost, tagIDs, nil)
 	}
 
 	return true, nil
@@ -327,7 +334,7 @@ func (r *mutationResolver) TagsMerge(ctx context.Context, input TagsMergeInput)
 		return nil, err
 	}
 
-	r.hookExecutor.ExecutePostHooks(ctx, t.ID, hook.TagMergePost, input, nil)
+	r.executeTagPostHooks(ctx, t.ID, hook.TagMergePost, input, nil)
 
 	return t, nil
 }
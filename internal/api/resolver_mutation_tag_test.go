@@ -3,11 +3,13 @@ package api
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/mocks"
 	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/tag"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,6 +20,7 @@ func newResolver(db *mocks.Database) *Resolver {
 	return &Resolver{
 		repository:   db.Repository(),
 		hookExecutor: &mockHookExecutor{},
+		tagSuggester: tag.NewSuggester(),
 	}
 }
 
@@ -119,3 +122,61 @@ func TestTagCreate(t *testing.T) {
 	assert.NotNil(t, tag)
 	db.AssertExpectations(t)
 }
+
+func TestTagDestroyInvalidatesSuggestions(t *testing.T) {
+	db := mocks.NewDatabase()
+	r := newResolver(db)
+
+	suggestions := []*models.TagCoOccurrence{{TagID: newTagID, Count: 1}}
+	db.Tag.On("FindCoOccurring", testCtx, []int{existingTagID}, 10).Return(suggestions, nil).Twice()
+	db.Tag.On("Destroy", mock.Anything, newTagID).Return(nil).Once()
+
+	suggest := func() {
+		got, err := r.tagSuggester.Suggest(testCtx, db.Tag, []int{existingTagID}, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, suggestions, got)
+	}
+
+	// the second call is cached
+	suggest()
+	suggest()
+
+	_, err := r.Mutation().TagDestroy(testCtx, TagDestroyInput{
+		ID: strconv.Itoa(newTagID),
+	})
+	assert.Nil(t, err)
+
+	// the suggestions are found again after the tag is destroyed
+	suggest()
+
+	db.AssertExpectations(t)
+}
+
+func TestExecuteTagPostHooksInvalidatesSuggestions(t *testing.T) {
+	hookTypes := []hook.TriggerEnum{
+		hook.TagCreatePost,
+		hook.TagUpdatePost,
+		hook.TagMergePost,
+		hook.TagDestroyPost,
+	}
+
+	for _, hookType := range hookTypes {
+		t.Run(hookType.String(), func(t *testing.T) {
+			db := mocks.NewDatabase()
+			r := newResolver(db)
+			m := &mutationResolver{r}
+
+			db.Tag.On("FindCoOccurring", testCtx, []int{existingTagID}, 10).Return(nil, nil).Twice()
+
+			_, err := r.tagSuggester.Suggest(testCtx, db.Tag, []int{existingTagID}, 10)
+			assert.Nil(t, err)
+
+			m.executeTagPostHooks(testCtx, newTagID, hookType, nil, nil)
+
+			_, err = r.tagSuggester.Suggest(testCtx, db.Tag, []int{existingTagID}, 10)
+			assert.Nil(t, err)
+
+			db.AssertExpectations(t)
+		})
+	}
+}
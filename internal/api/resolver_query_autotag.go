@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/autotag"
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *queryResolver) AutoTagPreview(ctx context.Context, input AutoTagPreviewInput, filter *models.FindFilterType) (*AutoTagPreviewResult, error) {
+	options := autotag.PreviewOptions{
+		Performers: input.Performers == nil || *input.Performers,
+		Studios:    input.Studios == nil || *input.Studios,
+		Tags:       input.Tags == nil || *input.Tags,
+	}
+
+	ret := &AutoTagPreviewResult{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		scenes, err := r.autoTagPreviewScenes(ctx, input, filter, ret)
+		if err != nil {
+			return err
+		}
+
+		cache := manager.GetInstance().NewAutoTagCache()
+		for _, s := range scenes {
+			m, err := autotag.PreviewScene(ctx, s, r.repository.Scene, r.repository.Performer, r.repository.Studio, r.repository.Tag, cache, options)
+			if err != nil {
+				return fmt.Errorf("previewing auto-tag for %s: %w", s.DisplayName(), err)
+			}
+
+			if m.IsEmpty() {
+				continue
+			}
+
+			ret.Scenes = append(ret.Scenes, &AutoTagSceneMatch{
+				Scene:      m.Scene,
+				Performers: m.Performers,
+				Studio:     m.Studio,
+				Tags:       m.Tags,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// autoTagPreviewScenes returns the scenes to preview, setting the total count
+// on ret.
+func (r *queryResolver) autoTagPreviewScenes(ctx context.Context, input AutoTagPreviewInput, filter *models.FindFilterType, ret *AutoTagPreviewResult) ([]*models.Scene, error) {
+	qb := r.repository.Scene
+
+	if len(input.SceneIDs) > 0 {
+		ids, err := stringslice.StringSliceToIntSlice(input.SceneIDs)
+		if err != nil {
+			return nil, fmt.Errorf("converting scene ids: %w", err)
+		}
+
+		scenes, err := qb.FindMany(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.Count = len(scenes)
+		return scenes, nil
+	}
+
+	sceneFilter := scene.FilterFromPaths(input.Paths)
+	organized := false
+	sceneFilter.Organized = &organized
+
+	scenes, count, err := scene.QueryWithCount(ctx, qb, sceneFilter, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.Count = count
+	return scenes, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) CleanDryRunReport(ctx context.Context) (*CleanReport, error) {
+	report := manager.GetInstance().CleanDryRunReport()
+	if report == nil {
+		return nil, nil
+	}
+
+	ret := &CleanReport{
+		Time:      report.Time,
+		Paths:     report.Paths,
+		Files:     cleanReportItems(report.Files),
+		Folders:   cleanReportItems(report.Folders),
+		Scenes:    []*models.Scene{},
+		Images:    []*models.Image{},
+		Galleries: []*models.Gallery{},
+	}
+
+	// objects may have been removed since the dry run, so skip any
+	// that are no longer present
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, id := range report.SceneIDs {
+			s, err := r.repository.Scene.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if s != nil {
+				ret.Scenes = append(ret.Scenes, s)
+			}
+		}
+
+		for _, id := range report.ImageIDs {
+			i, err := r.repository.Image.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if i != nil {
+				ret.Images = append(ret.Images, i)
+			}
+		}
+
+		for _, id := range report.GalleryIDs {
+			g, err := r.repository.Gallery.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if g != nil {
+				ret.Galleries = append(ret.Galleries, g)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func cleanReportItems(items []manager.CleanReportItem) []*CleanReportItem {
+	ret := make([]*CleanReportItem, len(items))
+	for i, item := range items {
+		ret[i] = &CleanReportItem{
+			Path:   item.Path,
+			Reason: CleanReason(item.Reason),
+		}
+	}
+
+	return ret
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/models"
@@ -83,6 +84,11 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		Stashes:                       config.GetStashPaths(),
 		DatabasePath:                  config.GetDatabasePath(),
 		BackupDirectoryPath:           config.GetBackupDirectoryPath(),
+		BackupRetention:               config.GetBackupRetention(),
+		TrashPath:                     config.GetTrashPath(),
+		TrashRetention:                config.GetTrashRetention(),
+		DeletedObjectRetention:        config.GetDeletedObjectRetention(),
+		AuditLog:                      config.GetAuditLog(),
 		GeneratedPath:                 config.GetGeneratedPath(),
 		MetadataPath:                  config.GetMetadataPath(),
 		ConfigFilePath:                config.GetConfigFile(),
@@ -96,16 +102,34 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		CalculateMd5:                  config.IsCalculateMD5(),
 		VideoFileNamingAlgorithm:      config.GetVideoFileNamingAlgorithm(),
 		ParallelTasks:                 config.GetParallelTasks(),
+		ScanParallelTasks:             config.GetScanParallelTasks(),
+		FfmpegGenerateThreads:         config.GetGenerateFFMpegThreads(),
+		FfmpegGenerateNiceness:        config.GetGenerateFFMpegNiceness(),
+		FfmpegGenerateMaxProcesses:    config.GetGenerateMaxFFMpegProcesses(),
+		FfmpegMaxProcesses:            config.GetMaxFFMpegProcesses(),
+		WatchLibrary:                  config.GetWatchLibrary(),
+		WatchLibraryDebounce:          config.GetWatchLibraryDebounce(),
+		AutoTagPerformerAliases:       config.GetAutoTagPerformerAliases(),
 		PreviewAudio:                  config.GetPreviewAudio(),
 		PreviewSegments:               config.GetPreviewSegments(),
 		PreviewSegmentDuration:        config.GetPreviewSegmentDuration(),
 		PreviewExcludeStart:           config.GetPreviewExcludeStart(),
 		PreviewExcludeEnd:             config.GetPreviewExcludeEnd(),
 		PreviewPreset:                 config.GetPreviewPreset(),
+		PreviewWidth:                  config.GetPreviewWidth(),
 		TranscodeHardwareAcceleration: config.GetTranscodeHardwareAcceleration(),
+		TranscodeToneMapping:          config.GetTranscodeToneMapping(),
+		TranscodeHardwareEncoder:      config.GetTranscodeHardwareEncoder(),
 		MaxTranscodeSize:              &maxTranscodeSize,
+		GeneratedTranscodeCodec:       config.GetGeneratedTranscodeCodec(),
+		GeneratedTranscodeCrf:         config.GetGeneratedTranscodeCRF(),
 		MaxStreamingTranscodeSize:     &maxStreamingTranscodeSize,
+		LiveTranscodePreset:           config.GetLiveTranscodePreset(),
+		LiveTranscodeCrf:              config.GetLiveTranscodeCRF(),
+		LiveTranscodeMaxBitrate:       config.GetLiveTranscodeMaxBitrate(),
+		LiveTranscodeCacheSize:        config.GetLiveTranscodeCacheSize(),
 		WriteImageThumbnails:          config.IsWriteImageThumbnails(),
+		ImageThumbnailSize:            config.GetImageThumbnailSize(),
 		CreateImageClipsFromVideos:    config.IsCreateImageClipsFromVideos(),
 		GalleryCoverRegex:             config.GetGalleryCoverRegex(),
 		APIKey:                        config.GetAPIKey(),
@@ -116,6 +140,9 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		LogOut:                        config.GetLogOut(),
 		LogLevel:                      config.GetLogLevel(),
 		LogAccess:                     config.GetLogAccess(),
+		LogMaxSize:                    config.GetLogMaxSize(),
+		LogMaxAge:                     config.GetLogMaxAge(),
+		LogMaxBackups:                 config.GetLogMaxBackups(),
 		VideoExtensions:               config.GetVideoExtensions(),
 		ImageExtensions:               config.GetImageExtensions(),
 		GalleryExtensions:             config.GetGalleryExtensions(),
@@ -127,8 +154,16 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		PythonPath:                    config.GetPythonPath(),
 		TranscodeInputArgs:            config.GetTranscodeInputArgs(),
 		TranscodeOutputArgs:           config.GetTranscodeOutputArgs(),
+		PreviewInputArgs:              config.GetPreviewInputArgs(),
+		PreviewOutputArgs:             config.GetPreviewOutputArgs(),
+		ScreenshotInputArgs:           config.GetScreenshotInputArgs(),
+		ScreenshotOutputArgs:          config.GetScreenshotOutputArgs(),
+		GeneratedTranscodeInputArgs:   config.GetGeneratedTranscodeInputArgs(),
+		GeneratedTranscodeOutputArgs:  config.GetGeneratedTranscodeOutputArgs(),
 		LiveTranscodeInputArgs:        config.GetLiveTranscodeInputArgs(),
 		LiveTranscodeOutputArgs:       config.GetLiveTranscodeOutputArgs(),
+		LiveTranscodeH264Encoders:     config.GetLiveTranscodeH264Encoders(),
+		LiveTranscodeVp9Encoders:      config.GetLiveTranscodeVP9Encoders(),
 		DrawFunscriptHeatmapRange:     config.GetDrawFunscriptHeatmapRange(),
 		ScraperPackageSources:         config.GetScraperPackageSources(),
 		PluginPackageSources:          config.GetPluginPackageSources(),
@@ -142,6 +177,7 @@ func makeConfigInterfaceResult() *ConfigInterfaceResult {
 	wallShowTitle := config.GetWallShowTitle()
 	showScrubber := config.GetShowScrubber()
 	wallPlayback := config.GetWallPlayback()
+	cardPlayback := config.GetCardPlayback()
 	noBrowser := config.GetNoBrowser()
 	notificationsEnabled := config.GetNotificationsEnabled()
 	maximumLoopDuration := config.GetMaximumLoopDuration()
@@ -167,6 +203,7 @@ func makeConfigInterfaceResult() *ConfigInterfaceResult {
 		SoundOnPreview:               &soundOnPreview,
 		WallShowTitle:                &wallShowTitle,
 		WallPlayback:                 &wallPlayback,
+		CardPlayback:                 &cardPlayback,
 		ShowScrubber:                 &showScrubber,
 		MaximumLoopDuration:          &maximumLoopDuration,
 		NoBrowser:                    &noBrowser,
@@ -235,6 +272,25 @@ func makeConfigDefaultsResult() *ConfigDefaultSettingsResult {
 	}
 }
 
+func (r *queryResolver) ScheduledTasks(ctx context.Context) ([]*ScheduledTask, error) {
+	return makeScheduledTasksResult(), nil
+}
+
+func makeScheduledTasksResult() []*ScheduledTask {
+	ret := []*ScheduledTask{}
+	for _, t := range manager.GetInstance().ScheduledTasks() {
+		ret = append(ret, &ScheduledTask{
+			Type:     t.Task.Type,
+			Schedule: t.Task.Schedule,
+			Enabled:  t.Task.Enabled,
+			LastRun:  t.LastRun,
+			NextRun:  t.NextRun,
+		})
+	}
+
+	return ret
+}
+
 func makeConfigUIResult() map[string]interface{} {
 	return config.GetInstance().GetUIConfiguration()
 }
@@ -275,3 +331,16 @@ func (r *queryResolver) ValidateStashBoxCredentials(ctx context.Context, input c
 
 	return &result, nil
 }
+
+func (r *queryResolver) TaskNotifications(ctx context.Context) ([]*config.TaskNotification, error) {
+	return makeTaskNotificationsResult(), nil
+}
+
+func makeTaskNotificationsResult() []*config.TaskNotification {
+	ret := config.GetInstance().GetTaskNotifications()
+	if ret == nil {
+		ret = []*config.TaskNotification{}
+	}
+
+	return ret
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) DeletedObjects(ctx context.Context, objectType *models.DeletedObjectType) (ret []*models.DeletedObject, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.DeletedObject.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if objectType != nil {
+		var filtered []*models.DeletedObject
+		for _, o := range ret {
+			if o.ObjectType == *objectType {
+				filtered = append(filtered, o)
+			}
+		}
+		ret = filtered
+	}
+
+	return ret, nil
+}
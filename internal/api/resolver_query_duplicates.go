@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) DuplicateReport(ctx context.Context) (*DuplicateReport, error) {
+	report := manager.GetInstance().DuplicateReport()
+	if report == nil {
+		return nil, nil
+	}
+
+	ret := &DuplicateReport{
+		Time:         report.Time,
+		Distance:     report.Distance,
+		DurationDiff: report.DurationDiff,
+		Groups:       []*DuplicateGroup{},
+	}
+
+	// scenes may have been removed since the report was created, so skip any
+	// that are no longer present, along with groups that are no longer
+	// duplicates
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, g := range report.Groups {
+			var scenes []*models.Scene
+			for _, id := range g.SceneIDs {
+				s, err := r.repository.Scene.Find(ctx, id)
+				if err != nil {
+					return err
+				}
+				if s != nil {
+					scenes = append(scenes, s)
+				}
+			}
+
+			if len(scenes) > 1 {
+				ret.Groups = append(ret.Groups, &DuplicateGroup{
+					Confidence: DuplicateConfidence(g.Confidence),
+					Scenes:     scenes,
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
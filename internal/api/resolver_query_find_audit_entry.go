@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindAuditEntries(ctx context.Context, filter *models.AuditEntryFilterType, findFilter *models.FindFilterType) (ret *FindAuditEntriesResultType, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		entries, total, err := r.repository.AuditEntry.Query(ctx, filter, findFilter)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindAuditEntriesResultType{
+			Count:        total,
+			AuditEntries: entries,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) BrowseFolder(ctx context.Context, id *string, path *string) (*BrowseFolderResult, error) {
+	ret := &BrowseFolderResult{}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		folder, err := r.findBrowseFolder(ctx, id, path)
+		if err != nil {
+			return err
+		}
+
+		if folder == nil {
+			// browse the library root
+			ret.Folders, err = r.findLibraryFolders(ctx)
+			return err
+		}
+
+		ret.Folder = folder
+
+		ret.Folders, err = r.repository.Folder.FindByParentFolderID(ctx, folder.ID)
+		if err != nil {
+			return err
+		}
+
+		ret.Scenes, err = r.repository.Scene.FindByParentFolderID(ctx, folder.ID)
+		if err != nil {
+			return err
+		}
+
+		ret.Galleries, err = r.repository.Gallery.FindByParentFolderID(ctx, folder.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// findBrowseFolder returns the folder identified by id or path.
+// Returns nil if neither is provided, or an error if the folder is not found.
+func (r *queryResolver) findBrowseFolder(ctx context.Context, id *string, path *string) (*models.Folder, error) {
+	var ret *models.Folder
+
+	switch {
+	case id != nil:
+		idInt, err := strconv.Atoi(*id)
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err = r.repository.Folder.Find(ctx, models.FolderID(idInt))
+		if err != nil {
+			return nil, err
+		}
+
+		if ret == nil {
+			return nil, fmt.Errorf("folder %s not found", *id)
+		}
+	case path != nil:
+		var err error
+		ret, err = r.repository.Folder.FindByPath(ctx, *path)
+		if err != nil {
+			return nil, err
+		}
+
+		if ret == nil {
+			return nil, fmt.Errorf("folder %s not found", *path)
+		}
+	}
+
+	return ret, nil
+}
+
+// findLibraryFolders returns the folders for the configured library paths.
+// Library paths that have not been scanned are omitted.
+func (r *queryResolver) findLibraryFolders(ctx context.Context) ([]*models.Folder, error) {
+	var ret []*models.Folder
+
+	for _, s := range config.GetInstance().GetStashPaths() {
+		f, err := r.repository.Folder.FindByPath(ctx, s.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if f != nil {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret, nil
+}
@@ -2,9 +2,11 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
@@ -69,3 +71,65 @@ func (r *queryResolver) AllTags(ctx context.Context) (ret []*models.Tag, err err
 
 	return ret, nil
 }
+
+const defaultTagSuggestionLimit = 10
+
+func (r *queryResolver) SuggestTags(ctx context.Context, input SuggestTagsInput) (ret []*TagSuggestion, err error) {
+	tagIDs, err := stringslice.StringSliceToIntSlice(input.TagIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting tag ids: %w", err)
+	}
+
+	limit := defaultTagSuggestionLimit
+	if input.Limit != nil {
+		limit = *input.Limit
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Tag
+
+		if input.SceneID != nil {
+			sceneID, err := strconv.Atoi(*input.SceneID)
+			if err != nil {
+				return fmt.Errorf("converting scene id: %w", err)
+			}
+
+			sceneTags, err := qb.FindBySceneID(ctx, sceneID)
+			if err != nil {
+				return err
+			}
+
+			for _, t := range sceneTags {
+				tagIDs = sliceutil.AppendUnique(tagIDs, t.ID)
+			}
+		}
+
+		suggestions, err := r.tagSuggester.Suggest(ctx, qb, tagIDs, limit)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int, len(suggestions))
+		for i, s := range suggestions {
+			ids[i] = s.TagID
+		}
+
+		tags, err := qb.FindMany(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for i, s := range suggestions {
+			ret = append(ret, &TagSuggestion{
+				Tag:   tags[i],
+				Count: s.Count,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
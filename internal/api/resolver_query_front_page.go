@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+const defaultFrontPageSectionLimit = 25
+
+var defaultFrontPageSections = []*FrontPageSectionInput{
+	{Type: FrontPageSectionTypeRecentlyAdded},
+	{Type: FrontPageSectionTypeRecentlyReleased},
+	{Type: FrontPageSectionTypeContinueWatching},
+	{Type: FrontPageSectionTypeMostPlayed},
+}
+
+func (r *queryResolver) FrontPage(ctx context.Context, sections []*FrontPageSectionInput) (ret []*FrontPageSection, err error) {
+	if sections == nil {
+		sections = defaultFrontPageSections
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, section := range sections {
+			scenes, err := r.frontPageSectionScenes(ctx, section)
+			if err != nil {
+				return fmt.Errorf("querying %s section: %w", section.Type, err)
+			}
+
+			ret = append(ret, &FrontPageSection{
+				Type:          section.Type,
+				SavedFilterID: section.SavedFilterID,
+				Scenes:        scenes,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) frontPageSectionScenes(ctx context.Context, section *FrontPageSectionInput) ([]*models.Scene, error) {
+	limit := defaultFrontPageSectionLimit
+	if section.Limit != nil {
+		limit = *section.Limit
+	}
+
+	desc := models.SortDirectionEnumDesc
+	findFilter := &models.FindFilterType{
+		PerPage:   &limit,
+		Direction: &desc,
+	}
+
+	setSort := func(sort string) {
+		findFilter.Sort = &sort
+	}
+
+	var sceneFilter *models.SceneFilterType
+
+	switch section.Type {
+	case FrontPageSectionTypeRecentlyAdded:
+		setSort("created_at")
+	case FrontPageSectionTypeRecentlyReleased:
+		setSort("date")
+		sceneFilter = &models.SceneFilterType{
+			Date: &models.DateCriterionInput{
+				Modifier: models.CriterionModifierNotNull,
+			},
+		}
+	case FrontPageSectionTypeContinueWatching:
+		setSort("last_played_at")
+		sceneFilter = &models.SceneFilterType{
+			ResumeTime: &models.IntCriterionInput{
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}
+	case FrontPageSectionTypeMostPlayed:
+		setSort("play_count")
+		sceneFilter = &models.SceneFilterType{
+			PlayCount: &models.IntCriterionInput{
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}
+	case FrontPageSectionTypeSavedFilter:
+		if section.SavedFilterID == nil {
+			return nil, fmt.Errorf("saved_filter_id is required")
+		}
+
+		filterID, err := strconv.Atoi(*section.SavedFilterID)
+		if err != nil {
+			return nil, fmt.Errorf("converting saved filter id: %w", err)
+		}
+
+		savedFilter, err := r.repository.SavedFilter.Find(ctx, filterID)
+		if err != nil {
+			return nil, err
+		}
+
+		if savedFilter == nil {
+			return nil, fmt.Errorf("saved filter with id %d not found", filterID)
+		}
+
+		sceneFilter, err = savedFilter.SceneFilter()
+		if err != nil {
+			return nil, err
+		}
+
+		if savedFilter.FindFilter != nil {
+			findFilter.Q = savedFilter.FindFilter.Q
+		}
+
+		setSort("random_" + strconv.FormatUint(rand.Uint64(), 10))
+	default:
+		return nil, fmt.Errorf("unsupported front page section type: %s", section.Type)
+	}
+
+	return scene.Query(ctx, r.repository.Scene, sceneFilter, findFilter)
+}
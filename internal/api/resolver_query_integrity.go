@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) IntegrityReport(ctx context.Context) (*IntegrityReport, error) {
+	report := manager.GetInstance().IntegrityReport()
+	if report == nil {
+		return nil, nil
+	}
+
+	ret := &IntegrityReport{
+		Time:             report.Time,
+		Paths:            report.Paths,
+		FilesChecked:     report.FilesChecked,
+		GeneratedChecked: report.GeneratedChecked,
+		Items:            make([]*IntegrityReportItem, len(report.Items)),
+	}
+
+	for i, item := range report.Items {
+		ret.Items[i] = &IntegrityReportItem{
+			Path:    item.Path,
+			Problem: IntegrityProblem(item.Problem),
+		}
+
+		if item.FileID != 0 {
+			fileID := item.FileID.String()
+			ret.Items[i].FileID = &fileID
+		}
+
+		if item.Detail != "" {
+			detail := item.Detail
+			ret.Items[i].Detail = &detail
+		}
+	}
+
+	return ret, nil
+}
@@ -32,6 +32,29 @@ func (r *queryResolver) FindJob(ctx context.Context, input FindJobInput) (*Job,
 	return jobToJobModel(*j), nil
 }
 
+func (r *queryResolver) JobLog(ctx context.Context, input FindJobInput) ([]*LogEntry, error) {
+	jobID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := manager.GetInstance().JobLog(jobID)
+	if err != nil || items == nil {
+		return nil, err
+	}
+
+	ret := make([]*LogEntry, len(items))
+	for i, entry := range items {
+		ret[i] = &LogEntry{
+			Time:    entry.Time,
+			Level:   getLogLevel(entry.Type),
+			Message: entry.Message,
+		}
+	}
+
+	return ret, nil
+}
+
 func jobToJobModel(j job.Job) *Job {
 	ret := &Job{
 		ID:          strconv.Itoa(j.ID),
@@ -42,11 +65,61 @@ func jobToJobModel(j job.Job) *Job {
 		EndTime:     j.EndTime,
 		AddTime:     j.AddTime,
 		Error:       j.Error,
+		Result:      j.Result,
+		Pausable:    manager.GetInstance().IsJobPausable(j),
 	}
 
 	if j.Progress != -1 {
 		ret.Progress = &j.Progress
 	}
 
+	for _, p := range j.DetailsProgress {
+		if p == job.ProgressIndefinite {
+			ret.SubTaskProgress = append(ret.SubTaskProgress, nil)
+			continue
+		}
+
+		percent := p
+		ret.SubTaskProgress = append(ret.SubTaskProgress, &percent)
+	}
+
+	if j.Status == job.StatusRunning {
+		processed := j.Processed
+		ret.Processed = &processed
+	}
+
+	if j.Total > 0 {
+		total := j.Total
+		ret.Total = &total
+	}
+
+	for _, p := range j.Phases {
+		ret.Phases = append(ret.Phases, &JobPhase{
+			Name:      p.Name,
+			Processed: p.Processed,
+		})
+	}
+
+	if remaining := j.TimeRemaining(); remaining != nil {
+		eta := remaining.Seconds()
+		ret.Eta = &eta
+	}
+
 	return ret
 }
+
+func (r *queryResolver) PausedJobs(ctx context.Context) ([]*PausedJob, error) {
+	paused := manager.GetInstance().PausedJobs()
+
+	ret := make([]*PausedJob, len(paused))
+	for i, p := range paused {
+		ret[i] = &PausedJob{
+			ID:          strconv.Itoa(p.ID),
+			Type:        PausedJobType(p.Type),
+			Description: p.Description,
+			PausedAt:    p.PausedAt,
+		}
+	}
+
+	return ret, nil
+}
@@ -4,8 +4,38 @@ import (
 	"context"
 
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/sqlite"
 )
 
 func (r *queryResolver) SystemStatus(ctx context.Context) (*manager.SystemStatus, error) {
 	return manager.GetInstance().GetSystemStatus(), nil
 }
+
+func (r *queryResolver) MigrationStatus(ctx context.Context) (*MigrationStatus, error) {
+	m, err := sqlite.NewMigrator(manager.GetInstance().Database)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	pending, err := m.PendingMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &MigrationStatus{
+		CurrentSchemaVersion:  int(m.CurrentSchemaVersion()),
+		RequiredSchemaVersion: int(m.RequiredSchemaVersion()),
+		PendingMigrations:     make([]*SchemaMigration, len(pending)),
+	}
+
+	for i, p := range pending {
+		ret.PendingMigrations[i] = &SchemaMigration{
+			Version:    int(p.Version),
+			Name:       p.Name,
+			Reversible: p.Reversible,
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) OptimiseDatabaseReport(ctx context.Context) (*OptimiseDatabaseReport, error) {
+	report := manager.GetInstance().OptimiseDatabaseReport()
+	if report == nil {
+		return nil, nil
+	}
+
+	ret := &OptimiseDatabaseReport{
+		Time:             report.Time,
+		SizeBefore:       report.SizeBefore,
+		SizeAfter:        report.SizeAfter,
+		Analyzed:         report.Analyzed,
+		Vacuumed:         report.Vacuumed,
+		IntegrityChecked: report.IntegrityChecked,
+		IntegrityErrors:  report.IntegrityErrors,
+	}
+
+	if ret.IntegrityErrors == nil {
+		ret.IntegrityErrors = []string{}
+	}
+
+	return ret, nil
+}
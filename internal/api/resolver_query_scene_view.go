@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) SceneViewHistory(ctx context.Context, start *time.Time, end *time.Time) (ret []*models.SceneView, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.GetViews(ctx, start, end)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) SceneViewsByDay(ctx context.Context, start *time.Time, end *time.Time) (ret []*models.SceneViewDay, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.CountViewsByDay(ctx, start, end)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) SceneViewsOnThisDay(ctx context.Context, date *string) (ret []*models.SceneView, err error) {
+	day := time.Now()
+	if date != nil {
+		day, err = time.ParseInLocation("2006-01-02", *date, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", *date, err)
+		}
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.GetViewsOnThisDay(ctx, day)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/sqlite"
+)
+
+func schemaStatusResult(s *sqlite.SchemaStatus) *SchemaStatus {
+	ret := &SchemaStatus{
+		SchemaVersion: int(s.SchemaVersion),
+		Schema:        s.Dump(),
+		Differences:   make([]*SchemaDifference, len(s.Differences)),
+	}
+
+	for i, d := range s.Differences {
+		ret.Differences[i] = &SchemaDifference{
+			Type:       d.Type,
+			Name:       d.Name,
+			Kind:       SchemaDifferenceKind(d.Kind),
+			Repairable: d.Repairable(),
+		}
+
+		if d.Expected != "" {
+			expected := d.Expected
+			ret.Differences[i].Expected = &expected
+		}
+
+		if d.Actual != "" {
+			actual := d.Actual
+			ret.Differences[i].Actual = &actual
+		}
+	}
+
+	return ret
+}
+
+func (r *queryResolver) SchemaStatus(ctx context.Context) (*SchemaStatus, error) {
+	status, err := manager.GetInstance().Database.CheckSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaStatusResult(status), nil
+}
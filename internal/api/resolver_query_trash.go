@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) TrashedFiles(ctx context.Context) ([]*TrashedFile, error) {
+	files, err := manager.GetInstance().TrashedFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*TrashedFile, len(files))
+	for i, f := range files {
+		ret[i] = &TrashedFile{
+			Name:         f.Name,
+			OriginalPath: f.OriginalPath,
+			DeletedAt:    f.DeletedAt,
+		}
+	}
+
+	return ret, nil
+}
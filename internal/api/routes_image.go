@@ -48,7 +48,8 @@ func (rs imageRoutes) Routes() chi.Router {
 func (rs imageRoutes) Thumbnail(w http.ResponseWriter, r *http.Request) {
 	mgr := manager.GetInstance()
 	img := r.Context().Value(imageKey).(*models.Image)
-	filepath := mgr.Paths.Generated.GetThumbnailPath(img.Checksum, models.DefaultGthumbWidth)
+	thumbSize := mgr.Config.GetImageThumbnailSize()
+	filepath := mgr.Paths.Generated.GetThumbnailPath(img.Checksum, thumbSize)
 
 	// if the thumbnail doesn't exist, encode on the fly
 	exists, _ := fsutil.FileExists(filepath)
@@ -75,7 +76,7 @@ func (rs imageRoutes) Thumbnail(w http.ResponseWriter, r *http.Request) {
 		}
 
 		encoder := image.NewThumbnailEncoder(manager.GetInstance().FFMpeg, manager.GetInstance().FFProbe, clipPreviewOptions)
-		data, err := encoder.GetThumbnail(f, models.DefaultGthumbWidth)
+		data, err := encoder.GetThumbnail(f, thumbSize)
 		if err != nil {
 			// don't log for unsupported image format
 			// don't log for file not found - can optionally be logged in serveImage
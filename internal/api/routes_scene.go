@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -61,14 +62,22 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/stream", rs.StreamDirect)
 		r.Get("/stream.mp4", rs.StreamMp4)
 		r.Get("/stream.webm", rs.StreamWebM)
+		r.Get("/stream_av1.webm", rs.StreamWebMAV1)
 		r.Get("/stream.mkv", rs.StreamMKV)
 		r.Get("/stream.m3u8", rs.StreamHLS)
 		r.Get("/stream.m3u8/{segment}.ts", rs.StreamHLSSegment)
+		r.Get("/stream_fmp4.m3u8", rs.StreamHLSFMP4)
+		r.Get("/stream_fmp4.m3u8/{segment}.m4s", rs.StreamHLSFMP4Segment)
+		r.Get("/stream_master.m3u8", rs.StreamHLSMaster)
+		r.Get("/stream_fmp4_master.m3u8", rs.StreamHLSFMP4Master)
 		r.Get("/stream.mpd", rs.StreamDASH)
 		r.Get("/stream.mpd/{segment}_v.webm", rs.StreamDASHVideoSegment)
 		r.Get("/stream.mpd/{segment}_a.webm", rs.StreamDASHAudioSegment)
+		r.Get("/stream.mpd/{segment}_v.m4s", rs.StreamDASHMP4VideoSegment)
+		r.Get("/stream.mpd/{segment}_a.m4s", rs.StreamDASHMP4AudioSegment)
 
 		r.Get("/screenshot", rs.Screenshot)
+		r.Get("/frame", rs.Frame)
 		r.Get("/preview", rs.Preview)
 		r.Get("/webp", rs.Webp)
 		r.Get("/vtt/chapter", rs.VttChapter)
@@ -99,13 +108,47 @@ func (rs sceneRoutes) StreamDirect(w http.ResponseWriter, r *http.Request) {
 }
 
 func (rs sceneRoutes) StreamMp4(w http.ResponseWriter, r *http.Request) {
+	// serve the generated transcode if present, to avoid transcoding live
+	if rs.canServeTranscode(r) {
+		rs.StreamDirect(w, r)
+		return
+	}
+
 	rs.streamTranscode(w, r, ffmpeg.StreamTypeMP4)
 }
 
+// canServeTranscode returns true if the request may be served from the
+// generated mp4 transcode of the scene. This is only the case when the
+// transcode exists and the original resolution is requested from the start,
+// without subtitles burned in.
+func (rs sceneRoutes) canServeTranscode(r *http.Request) bool {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	if !manager.HasTranscode(scene, config.GetInstance().GetVideoFileNamingAlgorithm()) {
+		return false
+	}
+
+	query := r.URL.Query()
+	if start, _ := strconv.ParseFloat(query.Get("start"), 64); start != 0 {
+		return false
+	}
+
+	if query.Get("subtitle") != "" {
+		return false
+	}
+
+	resolution := query.Get("resolution")
+	return resolution == "" || resolution == models.StreamingResolutionEnumOriginal.String()
+}
+
 func (rs sceneRoutes) StreamWebM(w http.ResponseWriter, r *http.Request) {
 	rs.streamTranscode(w, r, ffmpeg.StreamTypeWEBM)
 }
 
+func (rs sceneRoutes) StreamWebMAV1(w http.ResponseWriter, r *http.Request) {
+	rs.streamTranscode(w, r, ffmpeg.StreamTypeWEBMAV1)
+}
+
 func (rs sceneRoutes) StreamMKV(w http.ResponseWriter, r *http.Request) {
 	// only allow mkv streaming if the scene container is an mkv already
 	scene := r.Context().Value(sceneKey).(*models.Scene)
@@ -153,11 +196,18 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 	ss, _ := strconv.ParseFloat(startTime, 64)
 	resolution := r.Form.Get("resolution")
 
+	subtitle, err := rs.getSubtitle(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	options := ffmpeg.TranscodeOptions{
 		StreamType: streamType,
 		VideoFile:  f,
 		Resolution: resolution,
 		StartTime:  ss,
+		Subtitle:   subtitle,
 	}
 
 	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, streamType.MimeType)
@@ -168,7 +218,50 @@ func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 	rs.streamManifest(w, r, ffmpeg.StreamTypeHLS, "HLS")
 }
 
+func (rs sceneRoutes) StreamHLSFMP4(w http.ResponseWriter, r *http.Request) {
+	rs.streamManifest(w, r, ffmpeg.StreamTypeHLSFMP4, "HLS fMP4")
+}
+
+// StreamDASH serves the DASH manifest. WebM segments are used by default.
+// Fragmented MP4 segments are used if the format parameter is mp4.
+func (rs sceneRoutes) StreamHLSMaster(w http.ResponseWriter, r *http.Request) {
+	rs.streamHLSMaster(w, r, "stream.m3u8")
+}
+
+func (rs sceneRoutes) StreamHLSFMP4Master(w http.ResponseWriter, r *http.Request) {
+	rs.streamHLSMaster(w, r, "stream_fmp4.m3u8")
+}
+
+func (rs sceneRoutes) streamHLSMaster(w http.ResponseWriter, r *http.Request, manifestName string) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	streamManager := manager.GetInstance().StreamManager
+	if streamManager == nil {
+		http.Error(w, "Live transcoding disabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	f := scene.Files.Primary()
+	if f == nil {
+		return
+	}
+
+	subtitle, err := rs.getSubtitle(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logger.Debugf("[transcode] returning HLS master playlist for scene %d", scene.ID)
+	streamManager.ServeHLSMasterPlaylist(w, r, f, manifestName, subtitle)
+}
+
 func (rs sceneRoutes) StreamDASH(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("format") == "mp4" {
+		rs.streamManifest(w, r, ffmpeg.StreamTypeDASHMP4Video, "DASH MP4")
+		return
+	}
+
 	rs.streamManifest(w, r, ffmpeg.StreamTypeDASHVideo, "DASH")
 }
 
@@ -192,14 +285,24 @@ func (rs sceneRoutes) streamManifest(w http.ResponseWriter, r *http.Request, str
 
 	resolution := r.Form.Get("resolution")
 
+	subtitle, err := rs.getSubtitle(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	logger.Debugf("[transcode] returning %s manifest for scene %d", logName, scene.ID)
-	streamManager.ServeManifest(w, r, streamType, f, resolution)
+	streamManager.ServeManifest(w, r, streamType, f, resolution, subtitle)
 }
 
 func (rs sceneRoutes) StreamHLSSegment(w http.ResponseWriter, r *http.Request) {
 	rs.streamSegment(w, r, ffmpeg.StreamTypeHLS)
 }
 
+func (rs sceneRoutes) StreamHLSFMP4Segment(w http.ResponseWriter, r *http.Request) {
+	rs.streamSegment(w, r, ffmpeg.StreamTypeHLSFMP4)
+}
+
 func (rs sceneRoutes) StreamDASHVideoSegment(w http.ResponseWriter, r *http.Request) {
 	rs.streamSegment(w, r, ffmpeg.StreamTypeDASHVideo)
 }
@@ -208,6 +311,14 @@ func (rs sceneRoutes) StreamDASHAudioSegment(w http.ResponseWriter, r *http.Requ
 	rs.streamSegment(w, r, ffmpeg.StreamTypeDASHAudio)
 }
 
+func (rs sceneRoutes) StreamDASHMP4VideoSegment(w http.ResponseWriter, r *http.Request) {
+	rs.streamSegment(w, r, ffmpeg.StreamTypeDASHMP4Video)
+}
+
+func (rs sceneRoutes) StreamDASHMP4AudioSegment(w http.ResponseWriter, r *http.Request) {
+	rs.streamSegment(w, r, ffmpeg.StreamTypeDASHMP4Audio)
+}
+
 func (rs sceneRoutes) streamSegment(w http.ResponseWriter, r *http.Request, streamType *ffmpeg.StreamType) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
@@ -231,17 +342,71 @@ func (rs sceneRoutes) streamSegment(w http.ResponseWriter, r *http.Request, stre
 	segment := chi.URLParam(r, "segment")
 	resolution := r.Form.Get("resolution")
 
+	subtitle, err := rs.getSubtitle(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	options := ffmpeg.StreamOptions{
 		StreamType: streamType,
 		VideoFile:  f,
 		Resolution: resolution,
 		Hash:       sceneHash,
 		Segment:    segment,
+		Subtitle:   subtitle,
 	}
 
 	streamManager.ServeSegment(w, r, options)
 }
 
+// getSubtitle returns the subtitle track selected by the subtitle parameter
+// to burn into a live transcode, or nil if none is selected. Embedded
+// subtitle streams are selected by their index among the subtitle streams of
+// the file, and caption files by their language code and type, for example
+// en.srt. The request form must have been parsed.
+func (rs sceneRoutes) getSubtitle(r *http.Request, f *models.VideoFile) (*ffmpeg.Subtitle, error) {
+	id := r.Form.Get("subtitle")
+	if id == "" {
+		return nil, nil
+	}
+
+	if idx, err := strconv.Atoi(id); err == nil {
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid subtitle stream %d", idx)
+		}
+
+		return &ffmpeg.Subtitle{
+			ID:          id,
+			Path:        f.Path,
+			StreamIndex: idx,
+		}, nil
+	}
+
+	lang, ext, _ := strings.Cut(id, ".")
+
+	var captions []*models.VideoCaption
+	if err := rs.withReadTxn(r, func(ctx context.Context) error {
+		var err error
+		captions, err = rs.captionFinder.GetCaptions(ctx, f.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, caption := range captions {
+		if lang == caption.LanguageCode && video.CaptionTypeMatches(ext, caption.CaptionType) {
+			return &ffmpeg.Subtitle{
+				ID:          id,
+				Path:        caption.Path(f.Path),
+				StreamIndex: -1,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("subtitle %q not found", id)
+}
+
 func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
@@ -252,6 +417,16 @@ func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
 	ss.ServeScreenshot(scene, w, r)
 }
 
+func (rs sceneRoutes) Frame(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	ss := manager.SceneServer{
+		TxnManager:       rs.txnManager,
+		SceneCoverGetter: rs.sceneFinder,
+	}
+	ss.ServeFrame(scene, w, r)
+}
+
 func (rs sceneRoutes) Preview(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
@@ -366,6 +541,7 @@ func (rs sceneRoutes) VttSprite(w http.ResponseWriter, r *http.Request) {
 	}
 	filepath := manager.GetInstance().Paths.Scene.GetSpriteImageFilePath(sceneHash)
 
+	w.Header().Set("Content-Type", "image/jpeg")
 	utils.ServeStaticFile(w, r, filepath)
 }
 
@@ -423,7 +599,7 @@ func (rs sceneRoutes) Caption(w http.ResponseWriter, r *http.Request, lang strin
 	}
 
 	for _, caption := range captions {
-		if lang != caption.LanguageCode || ext != caption.CaptionType {
+		if lang != caption.LanguageCode || !video.CaptionTypeMatches(ext, caption.CaptionType) {
 			continue
 		}
 
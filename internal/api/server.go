@@ -35,6 +35,7 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/utils"
 	"github.com/stashapp/stash/ui"
 )
@@ -163,6 +164,7 @@ func Initialize() (*Server, error) {
 		imageService:   imageService,
 		galleryService: galleryService,
 		hookExecutor:   pluginCache,
+		tagSuggester:   tag.NewSuggester(),
 	}
 
 	gqlSrv := gqlHandler.New(NewExecutableSchema(Config{Resolvers: resolver}))
@@ -184,12 +186,19 @@ func Initialize() (*Server, error) {
 
 	gqlSrv.SetQueryCache(gqlLru.New(1000))
 	gqlSrv.Use(gqlExtension.Introspection{})
+	gqlSrv.Use(gqlExtension.AutomaticPersistedQuery{
+		Cache: gqlLru.New(1000),
+	})
 
 	gqlSrv.SetErrorPresenter(gqlErrorHandler)
+	gqlSrv.AroundOperations(publicAPIMiddleware)
+	gqlSrv.AroundOperations(operationNameMiddleware)
+	gqlSrv.AroundResponses(resolver.sharedTxnMiddleware)
 
+	gqlBatchSrv := batchHandler(gqlSrv, cfg.GetMaxUploadSize())
 	gqlHandlerFunc := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", "no-store")
-		gqlSrv.ServeHTTP(w, r)
+		gqlBatchSrv.ServeHTTP(w, r)
 	}
 
 	// register GQL handler with plugin cache
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// sharedTxnMutations are the mutations that may be run in a shared
+// transaction. These mutations only make database changes, or defer their
+// side effects using transaction hooks: plugin hooks and file deletions run
+// after the commit, and blob files written for new images are removed on
+// rollback. Mutations that delete library files or start jobs are excluded,
+// since their side effects cannot be rolled back.
+var sharedTxnMutations = map[string]bool{
+	"sceneCreate":          true,
+	"sceneUpdate":          true,
+	"bulkSceneUpdate":      true,
+	"scenesUpdate":         true,
+	"sceneMerge":           true,
+	"imageUpdate":          true,
+	"bulkImageUpdate":      true,
+	"imagesUpdate":         true,
+	"galleryCreate":        true,
+	"galleryUpdate":        true,
+	"bulkGalleryUpdate":    true,
+	"galleriesUpdate":      true,
+	"addGalleryImages":     true,
+	"removeGalleryImages":  true,
+	"galleryChapterCreate": true,
+	"galleryChapterUpdate": true,
+	"performerCreate":      true,
+	"performerUpdate":      true,
+	"bulkPerformerUpdate":  true,
+	"studioCreate":         true,
+	"studioUpdate":         true,
+	"bulkStudioUpdate":     true,
+	"studioMerge":          true,
+	"movieCreate":          true,
+	"movieUpdate":          true,
+	"bulkMovieUpdate":      true,
+	"groupCreate":          true,
+	"groupUpdate":          true,
+	"bulkGroupUpdate":      true,
+	"tagCreate":            true,
+	"tagUpdate":            true,
+	"tagsMerge":            true,
+	"bulkTagUpdate":        true,
+}
+
+// useSharedTxn returns true if the fields of the operation should be
+// executed in a single transaction. This is the case for mutations with
+// more than one top-level field, where all fields support shared
+// transactions.
+func useSharedTxn(oc *graphql.OperationContext) bool {
+	if oc.Operation == nil || oc.Operation.Operation != ast.Mutation {
+		return false
+	}
+
+	fields := graphql.CollectFields(oc, oc.Operation.SelectionSet, nil)
+	if len(fields) < 2 {
+		return false
+	}
+
+	for _, f := range fields {
+		if f.Name != "__typename" && !sharedTxnMutations[f.Name] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sharedTxnMiddleware executes mutations with multiple top-level fields in a
+// single transaction, so that either all of the changes are made or none of
+// them are. If any field returns an error, the transaction is rolled back and
+// no data is returned.
+//
+// The selected fields of the results are resolved within the transaction, so
+// that they include the uncommitted changes. The write lock is therefore held
+// until the whole response is resolved.
+func (r *Resolver) sharedTxnMiddleware(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !useSharedTxn(graphql.GetOperationContext(ctx)) {
+		return next(ctx)
+	}
+
+	var resp *graphql.Response
+	if err := txn.WithSharedTxn(ctx, r.repository.TxnManager, func(ctx context.Context) error {
+		resp = next(ctx)
+		if resp != nil && len(resp.Errors) > 0 {
+			return resp.Errors
+		}
+		return nil
+	}); err != nil {
+		if resp == nil || len(resp.Errors) == 0 {
+			return graphql.ErrorResponse(ctx, "%v", err)
+		}
+
+		// changes made by the successful fields were rolled back
+		resp.Data = nil
+		resp.Errors = append(resp.Errors, gqlerror.Errorf("all changes were rolled back"))
+	}
+
+	return resp
+}
@@ -45,12 +45,23 @@ func (b SceneURLBuilder) GetStreamPreviewImageURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/webp"
 }
 
-func (b SceneURLBuilder) GetSpriteVTTURL(checksum string) string {
-	return b.BaseURL + "/scene/" + checksum + "_thumbs.vtt"
+// GetSpriteVTTURL returns the URL of the sprite VTT file. If version is not
+// empty, it is appended to the URL so that the file may be cached by the client.
+func (b SceneURLBuilder) GetSpriteVTTURL(checksum string, version string) string {
+	return withVersion(b.BaseURL+"/scene/"+checksum+"_thumbs.vtt", version)
 }
 
-func (b SceneURLBuilder) GetSpriteURL(checksum string) string {
-	return b.BaseURL + "/scene/" + checksum + "_sprite.jpg"
+// GetSpriteURL returns the URL of the sprite image. If version is not empty,
+// it is appended to the URL so that the file may be cached by the client.
+func (b SceneURLBuilder) GetSpriteURL(checksum string, version string) string {
+	return withVersion(b.BaseURL+"/scene/"+checksum+"_sprite.jpg", version)
+}
+
+func withVersion(u string, version string) string {
+	if version == "" {
+		return u
+	}
+	return u + "?t=" + version
 }
 
 func (b SceneURLBuilder) GetScreenshotURL() string {
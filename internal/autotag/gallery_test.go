@@ -55,7 +55,7 @@ func TestGalleryPerformers(t *testing.T) {
 		db := mocks.NewDatabase()
 
 		db.Performer.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
-		db.Performer.On("QueryForAutoTag", testCtx, mock.Anything).Return([]*models.Performer{&performer, &reversedPerformer}, nil).Once()
+		db.Performer.On("QueryForAutoTag", testCtx, mock.Anything, false).Return([]*models.Performer{&performer, &reversedPerformer}, nil).Once()
 
 		if test.Matches {
 			matchPartial := mock.MatchedBy(func(got models.GalleryPartial) bool {
@@ -38,15 +38,16 @@ func getPerformerTaggers(p *models.Performer, cache *match.Cache) []tagger {
 		cache: cache,
 	}}
 
-	// TODO - disabled until we can have finer control over alias matching
-	// for _, a := range p.Aliases.List() {
-	// 	ret = append(ret, tagger{
-	// 		ID:    p.ID,
-	// 		Type:  "performer",
-	// 		Name:  a,
-	// 		cache: cache,
-	// 	})
-	// }
+	if cache != nil && cache.PerformerAliases {
+		for _, a := range p.Aliases.List() {
+			ret = append(ret, tagger{
+				ID:    p.ID,
+				Type:  "performer",
+				Name:  a,
+				cache: cache,
+			})
+		}
+	}
 
 	return ret
 }
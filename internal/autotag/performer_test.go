@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/mocks"
 	"github.com/stashapp/stash/pkg/scene"
@@ -304,3 +305,25 @@ func testPerformerGalleries(t *testing.T, performerName, expectedRegex string) {
 	assert.Nil(err)
 	db.AssertExpectations(t)
 }
+
+func TestGetPerformerTaggersAliases(t *testing.T) {
+	t.Parallel()
+
+	performer := &models.Performer{
+		ID:      1,
+		Name:    "performer name",
+		Aliases: models.NewRelatedStrings([]string{"alias one", "alias two"}),
+	}
+
+	assert := assert.New(t)
+
+	assert.Len(getPerformerTaggers(performer, nil), 1)
+	assert.Len(getPerformerTaggers(performer, &match.Cache{}), 1)
+
+	taggers := getPerformerTaggers(performer, &match.Cache{PerformerAliases: true})
+	if assert.Len(taggers, 3) {
+		assert.Equal("performer name", taggers[0].Name)
+		assert.Equal("alias one", taggers[1].Name)
+		assert.Equal("alias two", taggers[2].Name)
+	}
+}
@@ -0,0 +1,98 @@
+package autotag
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+type ScenePreviewReader interface {
+	models.PerformerIDLoader
+	models.TagIDLoader
+}
+
+// PreviewOptions sets which types of objects are matched by PreviewScene.
+type PreviewOptions struct {
+	Performers bool
+	Studios    bool
+	Tags       bool
+}
+
+// SceneMatches contains the performers, studio and tags that auto-tag would
+// add to a scene.
+type SceneMatches struct {
+	Scene      *models.Scene
+	Performers []*models.Performer
+	Studio     *models.Studio
+	Tags       []*models.Tag
+}
+
+// IsEmpty returns true if auto-tag would not change the scene.
+func (m SceneMatches) IsEmpty() bool {
+	return len(m.Performers) == 0 && m.Studio == nil && len(m.Tags) == 0
+}
+
+// PreviewScene returns the performers, studio and tags that auto-tag would add
+// to the provided scene, without modifying the scene. Performers and tags
+// already set on the scene are not returned, and no studio is returned if the
+// scene already has a studio.
+func PreviewScene(ctx context.Context, s *models.Scene, r ScenePreviewReader, performerReader models.PerformerAutoTagQueryer, studioReader models.StudioAutoTagQueryer, tagReader models.TagAutoTagQueryer, cache *match.Cache, options PreviewOptions) (*SceneMatches, error) {
+	ret := &SceneMatches{
+		Scene: s,
+	}
+
+	if s.Path == "" {
+		return ret, nil
+	}
+
+	t := getSceneFileTagger(s, cache)
+
+	if options.Performers {
+		performers, err := match.PathToPerformers(ctx, t.Path, performerReader, t.cache, t.trimExt)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.LoadPerformerIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		existing := s.PerformerIDs.List()
+
+		for _, p := range performers {
+			if !sliceutil.Contains(existing, p.ID) {
+				ret.Performers = append(ret.Performers, p)
+			}
+		}
+	}
+
+	if options.Studios && s.StudioID == nil {
+		studio, err := match.PathToStudio(ctx, t.Path, studioReader, t.cache, t.trimExt)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.Studio = studio
+	}
+
+	if options.Tags {
+		tags, err := match.PathToTags(ctx, t.Path, tagReader, t.cache, t.trimExt)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.LoadTagIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		existing := s.TagIDs.List()
+
+		for _, tag := range tags {
+			if !sliceutil.Contains(existing, tag.ID) {
+				ret.Tags = append(ret.Tags, tag)
+			}
+		}
+	}
+
+	return ret, nil
+}
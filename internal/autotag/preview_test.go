@@ -0,0 +1,124 @@
+package autotag
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPreviewScene(t *testing.T) {
+	t.Parallel()
+
+	const (
+		sceneID             = 1
+		performerID         = 2
+		existingPerformerID = 3
+		studioID            = 4
+		tagID               = 5
+		unmatchedTagID      = 6
+		scenePath           = "performer name.existing name.studio name.tag name.mp4"
+	)
+
+	performers := []*models.Performer{
+		{ID: performerID, Name: "performer name", Aliases: models.NewRelatedStrings([]string{})},
+		{ID: existingPerformerID, Name: "existing name", Aliases: models.NewRelatedStrings([]string{})},
+	}
+	studio := &models.Studio{ID: studioID, Name: "studio name"}
+	tags := []*models.Tag{
+		{ID: tagID, Name: "tag name"},
+		{ID: unmatchedTagID, Name: "other"},
+	}
+
+	newDB := func() *mocks.Database {
+		db := mocks.NewDatabase()
+		db.Performer.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
+		db.Studio.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
+		db.Tag.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
+		db.Performer.On("QueryForAutoTag", testCtx, mock.Anything, false).Return(performers, nil)
+		db.Studio.On("QueryForAutoTag", testCtx, mock.Anything).Return([]*models.Studio{studio}, nil)
+		db.Studio.On("GetAliases", testCtx, mock.Anything).Return([]string{}, nil)
+		db.Tag.On("QueryForAutoTag", testCtx, mock.Anything).Return(tags, nil)
+		db.Tag.On("GetAliases", testCtx, mock.Anything).Return([]string{}, nil)
+		return db
+	}
+
+	all := PreviewOptions{Performers: true, Studios: true, Tags: true}
+
+	t.Run("new matches", func(t *testing.T) {
+		db := newDB()
+		s := &models.Scene{
+			ID:           sceneID,
+			Path:         scenePath,
+			PerformerIDs: models.NewRelatedIDs([]int{existingPerformerID}),
+			TagIDs:       models.NewRelatedIDs([]int{}),
+		}
+
+		got, err := PreviewScene(testCtx, s, db.Scene, db.Performer, db.Studio, db.Tag, nil, all)
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		assert.Equal(t, []*models.Performer{performers[0]}, got.Performers)
+		assert.Equal(t, studio, got.Studio)
+		assert.Equal(t, []*models.Tag{tags[0]}, got.Tags)
+		assert.False(t, got.IsEmpty())
+
+		// no updates are expected
+		db.Scene.AssertNotCalled(t, "UpdatePartial", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("existing studio", func(t *testing.T) {
+		db := newDB()
+		existingStudio := studioID
+		s := &models.Scene{
+			ID:       sceneID,
+			Path:     scenePath,
+			StudioID: &existingStudio,
+		}
+
+		got, err := PreviewScene(testCtx, s, db.Scene, db.Performer, db.Studio, db.Tag, nil, PreviewOptions{Studios: true})
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		assert.True(t, got.IsEmpty())
+	})
+
+	t.Run("alias match", func(t *testing.T) {
+		aliasPerformer := &models.Performer{
+			ID:      performerID,
+			Name:    "other name",
+			Aliases: models.NewRelatedStrings([]string{"performer name"}),
+		}
+
+		db := mocks.NewDatabase()
+		db.Performer.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
+		db.Performer.On("QueryForAutoTag", testCtx, mock.Anything, false).Return([]*models.Performer{aliasPerformer}, nil).Once()
+		db.Performer.On("QueryForAutoTag", testCtx, mock.Anything, true).Return([]*models.Performer{aliasPerformer}, nil).Once()
+
+		s := &models.Scene{
+			ID:           sceneID,
+			Path:         scenePath,
+			PerformerIDs: models.NewRelatedIDs([]int{}),
+		}
+
+		options := PreviewOptions{Performers: true}
+
+		// aliases are only matched when enabled in the cache
+		got, err := PreviewScene(testCtx, s, db.Scene, db.Performer, db.Studio, db.Tag, &match.Cache{}, options)
+		if assert.Nil(t, err) {
+			assert.Empty(t, got.Performers)
+		}
+
+		got, err = PreviewScene(testCtx, s, db.Scene, db.Performer, db.Studio, db.Tag, &match.Cache{PerformerAliases: true}, options)
+		if assert.Nil(t, err) {
+			assert.Equal(t, []*models.Performer{aliasPerformer}, got.Performers)
+		}
+
+		db.AssertExpectations(t)
+	})
+}
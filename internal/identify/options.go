@@ -43,6 +43,74 @@ type MetadataOptions struct {
 	SkipSingleNamePerformerTag *string `json:"skipSingleNamePerformerTag"`
 }
 
+// WithDefaults returns a copy of the options with values that are not set
+// taken from defaults. The default sources are used if no sources are set.
+// Returns o unchanged if defaults is nil.
+func (o Options) WithDefaults(defaults *Options) Options {
+	if defaults == nil {
+		return o
+	}
+
+	if len(o.Sources) == 0 {
+		o.Sources = defaults.Sources
+	}
+
+	o.Options = o.Options.withDefaults(defaults.Options)
+
+	return o
+}
+
+func (o *MetadataOptions) withDefaults(defaults *MetadataOptions) *MetadataOptions {
+	if o == nil {
+		return defaults
+	}
+	if defaults == nil {
+		return o
+	}
+
+	ret := *o
+
+	// field options set here replace the default options for the same field
+	ret.FieldOptions = append([]*FieldOptions(nil), o.FieldOptions...)
+	for _, df := range defaults.FieldOptions {
+		found := false
+		for _, f := range o.FieldOptions {
+			if f.Field == df.Field {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			ret.FieldOptions = append(ret.FieldOptions, df)
+		}
+	}
+
+	if ret.SetCoverImage == nil {
+		ret.SetCoverImage = defaults.SetCoverImage
+	}
+	if ret.SetOrganized == nil {
+		ret.SetOrganized = defaults.SetOrganized
+	}
+	if ret.IncludeMalePerformers == nil {
+		ret.IncludeMalePerformers = defaults.IncludeMalePerformers
+	}
+	if ret.SkipMultipleMatches == nil {
+		ret.SkipMultipleMatches = defaults.SkipMultipleMatches
+	}
+	if ret.SkipMultipleMatchTag == nil {
+		ret.SkipMultipleMatchTag = defaults.SkipMultipleMatchTag
+	}
+	if ret.SkipSingleNamePerformers == nil {
+		ret.SkipSingleNamePerformers = defaults.SkipSingleNamePerformers
+	}
+	if ret.SkipSingleNamePerformerTag == nil {
+		ret.SkipSingleNamePerformerTag = defaults.SkipSingleNamePerformerTag
+	}
+
+	return &ret
+}
+
 type FieldOptions struct {
 	Field    string        `json:"field"`
 	Strategy FieldStrategy `json:"strategy"`
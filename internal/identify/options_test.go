@@ -0,0 +1,88 @@
+package identify
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_WithDefaults(t *testing.T) {
+	var (
+		trueVal  = true
+		falseVal = false
+
+		stashBox  = "stash-box"
+		scraperID = "scraper"
+
+		inputSource = &Source{
+			Source: &scraper.Source{ScraperID: &scraperID},
+		}
+		defaultSource = &Source{
+			Source: &scraper.Source{StashBoxEndpoint: &stashBox},
+		}
+
+		titleOverwrite = &FieldOptions{Field: "title", Strategy: FieldStrategyOverwrite}
+		titleIgnore    = &FieldOptions{Field: "title", Strategy: FieldStrategyIgnore}
+		tagsMerge      = &FieldOptions{Field: "tags", Strategy: FieldStrategyMerge, CreateMissing: &trueVal}
+	)
+
+	defaults := &Options{
+		Sources: []*Source{defaultSource},
+		Options: &MetadataOptions{
+			FieldOptions:  []*FieldOptions{titleIgnore, tagsMerge},
+			SetCoverImage: &falseVal,
+			SetOrganized:  &trueVal,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    Options
+		defaults *Options
+		want     Options
+	}{
+		{
+			"nil defaults",
+			Options{SceneIDs: []string{"1"}},
+			nil,
+			Options{SceneIDs: []string{"1"}},
+		},
+		{
+			"empty input",
+			Options{SceneIDs: []string{"1"}},
+			defaults,
+			Options{
+				Sources:  defaults.Sources,
+				Options:  defaults.Options,
+				SceneIDs: []string{"1"},
+			},
+		},
+		{
+			"input overrides defaults",
+			Options{
+				Sources: []*Source{inputSource},
+				Options: &MetadataOptions{
+					FieldOptions:  []*FieldOptions{titleOverwrite},
+					SetCoverImage: &trueVal,
+				},
+			},
+			defaults,
+			Options{
+				Sources: []*Source{inputSource},
+				Options: &MetadataOptions{
+					FieldOptions:  []*FieldOptions{titleOverwrite, tagsMerge},
+					SetCoverImage: &trueVal,
+					SetOrganized:  &trueVal,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.input.WithDefaults(tt.defaults)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// captureHook writes log entries to a set of writers as JSON-encoded
+// LogItems.
+type captureHook struct {
+	mutex   sync.Mutex
+	writers map[int]io.Writer
+	lastID  int
+}
+
+func (hook *captureHook) Fire(entry *logrus.Entry) error {
+	hook.mutex.Lock()
+	defer hook.mutex.Unlock()
+
+	if len(hook.writers) == 0 {
+		return nil
+	}
+
+	line, err := json.Marshal(LogItem{
+		Time:    entry.Time,
+		Type:    logItemType(entry.Level),
+		Message: entry.Message,
+	})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	for _, w := range hook.writers {
+		// errors writing to a capture shouldn't affect the main log
+		_, _ = w.Write(line)
+	}
+
+	return nil
+}
+
+func (hook *captureHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func logItemType(level logrus.Level) string {
+	switch level {
+	case logrus.TraceLevel:
+		return "trace"
+	case logrus.DebugLevel:
+		return "debug"
+	case logrus.WarnLevel:
+		return "warn"
+	case logrus.InfoLevel:
+		return "info"
+	default:
+		return "error"
+	}
+}
+
+// Capture writes log entries at or above the current log level to w, until
+// the returned function is called. Entries are written as JSON-encoded
+// LogItems, one per line, and can be read using ReadLogItems.
+func (log *Logger) Capture(w io.Writer) (stop func()) {
+	hook := log.capture
+
+	hook.mutex.Lock()
+	defer hook.mutex.Unlock()
+
+	hook.lastID++
+	id := hook.lastID
+	hook.writers[id] = w
+
+	return func() {
+		hook.mutex.Lock()
+		defer hook.mutex.Unlock()
+
+		delete(hook.writers, id)
+	}
+}
+
+// ReadLogItems reads the log items written by Capture. Lines that cannot be
+// read are skipped.
+func ReadLogItems(r io.Reader) ([]LogItem, error) {
+	var ret []LogItem
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var item LogItem
+		if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+			continue
+		}
+
+		ret = append(ret, item)
+	}
+
+	return ret, scanner.Err()
+}
@@ -2,6 +2,7 @@ package log
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -25,6 +26,7 @@ type Logger struct {
 	waiting        bool
 	lastBroadcast  time.Time
 	logBuffer      []LogItem
+	capture        *captureHook
 }
 
 func NewLogger() *Logger {
@@ -32,16 +34,21 @@ func NewLogger() *Logger {
 		logger:         logrus.New(),
 		progressLogger: logrus.New(),
 		lastBroadcast:  time.Now(),
+		capture: &captureHook{
+			writers: make(map[int]io.Writer),
+		},
 	}
 
 	ret.progressLogger.SetFormatter(new(ProgressFormatter))
+	ret.logger.AddHook(ret.capture)
 
 	return ret
 }
 
-// Init initialises the logger based on a logging configuration
-func (log *Logger) Init(logFile string, logOut bool, logLevel string) {
-	var file *os.File
+// Init initialises the logger based on a logging configuration. The log file
+// is rotated based on the provided rotate options.
+func (log *Logger) Init(logFile string, logOut bool, logLevel string, rotate RotateOptions) {
+	var file io.Writer
 	customFormatter := new(logrus.TextFormatter)
 	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
 	customFormatter.ForceColors = true
@@ -58,9 +65,14 @@ func (log *Logger) Init(logFile string, logOut bool, logLevel string) {
 
 	if logFile != "" {
 		var err error
-		file, err = os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if rotate.enabled() {
+			file, err = openRotatingFile(logFile, rotate)
+		} else {
+			file, err = os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		}
 
 		if err != nil {
+			file = nil
 			fmt.Printf("Could not open '%s' for log output due to error: %s\n", logFile, err.Error())
 		}
 	}
@@ -0,0 +1,182 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const rotatedTimeFormat = "2006-01-02T15-04-05"
+
+// RotateOptions sets when the log file is rotated. A rotated log file is
+// renamed to include the time it was rotated, and a new log file is started.
+type RotateOptions struct {
+	// MaxSize is the size in bytes after which the log file is rotated.
+	// Zero disables size-based rotation.
+	MaxSize int64
+	// MaxAge is the age after which the log file is rotated. The age of an
+	// existing log file is taken from its modification time when it is
+	// opened. Zero disables time-based rotation.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated log files to keep. Zero keeps all
+	// rotated log files.
+	MaxBackups int
+}
+
+func (o RotateOptions) enabled() bool {
+	return o.MaxSize > 0 || o.MaxAge > 0
+}
+
+// rotatingFile is a log file that is rotated based on its size and age.
+type rotatingFile struct {
+	path    string
+	options RotateOptions
+
+	mutex    sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+func openRotatingFile(path string, options RotateOptions) (*rotatingFile, error) {
+	ret := &rotatingFile{
+		path:    path,
+		options: options,
+	}
+
+	if err := ret.open(); err != nil {
+		return nil, err
+	}
+
+	// rotate existing log files that are already too old or too large
+	if ret.shouldRotate(0) {
+		if err := ret.rotate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+	if f.size > 0 {
+		f.openedAt = info.ModTime()
+	}
+
+	return nil
+}
+
+func (f *rotatingFile) shouldRotate(n int) bool {
+	if f.size == 0 {
+		return false
+	}
+
+	if f.options.MaxSize > 0 && f.size+int64(n) > f.options.MaxSize {
+		return true
+	}
+
+	return f.options.MaxAge > 0 && time.Since(f.openedAt) > f.options.MaxAge
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			// keep writing to the current file
+			fmt.Fprintf(os.Stderr, "Could not rotate log file '%s': %v\n", f.path, err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-" + t.Format(rotatedTimeFormat) + ext
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	renameErr := os.Rename(f.path, f.backupName(time.Now()))
+
+	// reopen the log file even if the rename failed
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if renameErr != nil {
+		return renameErr
+	}
+
+	f.removeOldBackups()
+	return nil
+}
+
+// backups returns the rotated log files, oldest first.
+func (f *rotatingFile) backups() []string {
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(filepath.Base(f.path), ext) + "-"
+
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return nil
+	}
+
+	var ret []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+
+		ts := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		if _, err := time.Parse(rotatedTimeFormat, ts); err != nil {
+			continue
+		}
+
+		ret = append(ret, filepath.Join(filepath.Dir(f.path), name))
+	}
+
+	// timestamp format sorts chronologically
+	sort.Strings(ret)
+	return ret
+}
+
+func (f *rotatingFile) removeOldBackups() {
+	if f.options.MaxBackups <= 0 {
+		return
+	}
+
+	backups := f.backups()
+	for len(backups) > f.options.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not remove old log file '%s': %v\n", backups[0], err)
+		}
+		backups = backups[1:]
+	}
+}
@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/common"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// auditSource returns the source of a change made with the provided context,
+// and the id of the plugin that made it, if known.
+func auditSource(ctx context.Context) (models.AuditSource, string) {
+	// changes made by plugin hooks are made in requests with the visited
+	// hooks set
+	if visited := session.GetVisitedPluginHooks(ctx); len(visited) > 0 {
+		return models.AuditSourcePlugin, visited[len(visited)-1].PluginID
+	}
+
+	// jobs keep the values of the request that started them
+	if _, isJob := job.GetJobID(ctx); isJob {
+		return models.AuditSourceTask, ""
+	}
+
+	switch session.GetRequestSource(ctx) {
+	case session.RequestSourcePlugin:
+		return models.AuditSourcePlugin, ""
+	case session.RequestSourceAPIKey:
+		return models.AuditSourceAPIKey, ""
+	case session.RequestSourceUI:
+		return models.AuditSourceUI, ""
+	}
+
+	return models.AuditSourceTask, ""
+}
+
+// recordAuditEntry records a change to an object in the audit log, if
+// enabled. It is called for each post hook, after the change is committed.
+func (s *Manager) recordAuditEntry(ctx context.Context, hookContext common.HookContext) {
+	if !s.Config.GetAuditLog() {
+		return
+	}
+
+	// hook types are of the form <object type>.<action>.Post
+	parts := strings.Split(hookContext.Type, ".")
+	if len(parts) != 3 {
+		return
+	}
+
+	objectType := parts[0]
+	// movie hooks are triggered along with the group hooks
+	if objectType == "Movie" {
+		return
+	}
+
+	entry := models.AuditEntry{
+		CreatedAt:  time.Now(),
+		ObjectType: objectType,
+		ObjectID:   hookContext.ID,
+		Action:     parts[1],
+		Fields:     hookContext.InputFields,
+	}
+
+	entry.Source, entry.PluginID = auditSource(ctx)
+
+	if userID := session.GetCurrentUserID(ctx); userID != nil {
+		entry.UserID = *userID
+	}
+
+	if hookContext.Input != nil {
+		input, err := json.Marshal(hookContext.Input)
+		if err != nil {
+			logger.Warnf("error encoding audit entry input: %v", err)
+		} else {
+			entry.Input = string(input)
+		}
+	}
+
+	r := s.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.AuditEntry.Create(ctx, &entry)
+	}); err != nil {
+		logger.Errorf("error recording audit entry for %s %d: %v", objectType, hookContext.ID, err)
+	}
+}
@@ -0,0 +1,61 @@
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveBackupPath(t *testing.T) {
+	backupDir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		backupPath string
+		want       string
+		wantErr    bool
+	}{
+		{
+			"filename",
+			"stash-go.sqlite.10.20230101_120000",
+			filepath.Join(backupDir, "stash-go.sqlite.10.20230101_120000"),
+			false,
+		},
+		{
+			"path in backup directory",
+			filepath.Join(backupDir, "stash-go.sqlite.10.20230101_120000"),
+			filepath.Join(backupDir, "stash-go.sqlite.10.20230101_120000"),
+			false,
+		},
+		{
+			"path outside backup directory",
+			filepath.Join(filepath.Dir(backupDir), "stash-go.sqlite"),
+			"",
+			true,
+		},
+		{
+			"relative path escaping backup directory",
+			filepath.Join(backupDir, "..", "stash-go.sqlite"),
+			"",
+			true,
+		},
+		{
+			"backup directory",
+			backupDir,
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveBackupPath(backupDir, tt.backupPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveBackupPath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -33,6 +33,20 @@ const (
 	Stash               = "stash"
 	Cache               = "cache"
 	BackupDirectoryPath = "backup_directory_path"
+	BackupRetention     = "backup_retention"
+	TrashPath           = "trash_path"
+	TrashRetention      = "trash_retention"
+
+	// DeletedObjectRetention is the number of days to keep deleted scenes,
+	// galleries and performers
+	DeletedObjectRetention = "deleted_object_retention"
+
+	// AuditLog enables recording changes to objects in the audit log
+	AuditLog = "audit_log"
+
+	// MigrationBackupPath is the path of the backup made before the most
+	// recent database migration
+	MigrationBackupPath = "migration_backup_path"
 	Generated           = "generated"
 	Metadata            = "metadata"
 	BlobsPath           = "blobs_path"
@@ -51,6 +65,27 @@ const (
 
 	Database = "database"
 
+	// DatabaseBusyTimeout is the time in milliseconds to wait for a locked
+	// database before failing
+	DatabaseBusyTimeout        = "database_busy_timeout"
+	databaseBusyTimeoutDefault = 50
+
+	// DatabaseCacheSize is the page cache size of each database connection,
+	// in KiB. Zero uses the SQLite default.
+	DatabaseCacheSize = "database_cache_size"
+
+	// DatabaseSynchronous is the value of the SQLite synchronous pragma
+	DatabaseSynchronous        = "database_synchronous"
+	databaseSynchronousDefault = "NORMAL"
+
+	// DatabaseSlowQueryThreshold is the time in milliseconds above which SQL
+	// statements are logged. Zero disables slow query logging.
+	DatabaseSlowQueryThreshold = "database_slow_query_threshold"
+
+	// DatabaseEncryptionKey is the passphrase used to encrypt the database
+	// with SQLCipher. Empty disables encryption.
+	DatabaseEncryptionKey = "database_encryption_key"
+
 	Exclude      = "exclude"
 	ImageExclude = "image_exclude"
 
@@ -76,15 +111,62 @@ const (
 	LiveTranscodeInputArgs  = "ffmpeg.live_transcode.input_args"
 	LiveTranscodeOutputArgs = "ffmpeg.live_transcode.output_args"
 
+	// ffmpeg extra args for each generate operation, applied after the
+	// transcode args
+	PreviewInputArgs             = "ffmpeg.generate.preview.input_args"
+	PreviewOutputArgs            = "ffmpeg.generate.preview.output_args"
+	ScreenshotInputArgs          = "ffmpeg.generate.screenshot.input_args"
+	ScreenshotOutputArgs         = "ffmpeg.generate.screenshot.output_args"
+	GeneratedTranscodeInputArgs  = "ffmpeg.generate.transcode.input_args"
+	GeneratedTranscodeOutputArgs = "ffmpeg.generate.transcode.output_args"
+
+	// generated transcode quality options
+	GeneratedTranscodeCodec = "ffmpeg.generate.transcode.codec"
+	GeneratedTranscodeCRF   = "ffmpeg.generate.transcode.crf"
+
+	// live transcode quality options
+	LiveTranscodePreset     = "ffmpeg.live_transcode.preset"
+	LiveTranscodeCRF        = "ffmpeg.live_transcode.crf"
+	LiveTranscodeMaxBitrate = "ffmpeg.live_transcode.max_bitrate"
+
+	LiveTranscodeH264Encoders = "ffmpeg.live_transcode.h264_encoders"
+	LiveTranscodeVP9Encoders  = "ffmpeg.live_transcode.vp9_encoders"
+
+	LiveTranscodeCacheSize        = "ffmpeg.live_transcode.cache_size"
+	liveTranscodeCacheSizeDefault = 1024
+
+	// ffmpeg generation throttling options
+	GenerateFFMpegThreads      = "ffmpeg.generate.threads"
+	GenerateFFMpegNiceness     = "ffmpeg.generate.niceness"
+	GenerateMaxFFMpegProcesses = "ffmpeg.generate.max_processes"
+
+	MaxFFMpegProcesses = "ffmpeg.max_processes"
+
 	ParallelTasks        = "parallel_tasks"
 	parallelTasksDefault = 1
 
+	ScanParallelTasks = "scan_parallel_tasks"
+
+	// ScanBatchSize is the number of new files created in each transaction
+	// during a scan
+	ScanBatchSize        = "scan_batch_size"
+	scanBatchSizeDefault = 50
+
 	PreviewPreset                 = "preview_preset"
 	TranscodeHardwareAcceleration = "ffmpeg.hardware_acceleration"
+	TranscodeHardwareEncoder      = "ffmpeg.hardware_encoder"
+	TranscodeToneMapping          = "ffmpeg.tone_mapping"
 
 	SequentialScanning        = "sequential_scanning"
 	SequentialScanningDefault = false
 
+	WatchLibrary = "watch_library"
+
+	AutoTagPerformerAliases = "auto_tag_performer_aliases"
+
+	WatchLibraryDebounce        = "watch_library_debounce"
+	watchLibraryDebounceDefault = 30
+
 	PreviewAudio        = "preview_audio"
 	previewAudioDefault = true
 
@@ -100,9 +182,15 @@ const (
 	PreviewExcludeEnd        = "preview_exclude_end"
 	previewExcludeEndDefault = "0"
 
+	PreviewWidth        = "preview_width"
+	previewWidthDefault = 640
+
 	WriteImageThumbnails        = "write_image_thumbnails"
 	writeImageThumbnailsDefault = true
 
+	ImageThumbnailSize        = "image_thumbnail_size"
+	imageThumbnailSizeDefault = 640
+
 	CreateImageClipsFromVideos        = "create_image_clip_from_videos"
 	createImageClipsFromVideosDefault = false
 
@@ -197,6 +285,9 @@ const (
 	WallPlayback        = "wall_playback"
 	defaultWallPlayback = "video"
 
+	CardPlayback        = "card_playback"
+	defaultCardPlayback = "video"
+
 	// Image lightbox options
 	legacyImageLightboxSlideshowDelay       = "slideshow_delay"
 	ImageLightboxSlideshowDelay             = "image_lightbox.slideshow_delay"
@@ -232,6 +323,10 @@ const (
 	SecurityTripwireAccessedFromPublicInternet        = "security_tripwire_accessed_from_public_internet"
 	securityTripwireAccessedFromPublicInternetDefault = ""
 
+	// PublicAPIFields is the list of top-level GraphQL fields which may be
+	// queried without authentication when credentials are configured.
+	PublicAPIFields = "public_api_fields"
+
 	sslCertPath = "ssl_cert_path"
 	sslKeyPath  = "ssl_key_path"
 
@@ -256,12 +351,24 @@ const (
 	LogAccess        = "logaccess"
 	defaultLogAccess = true
 
+	// LogMaxSize is the size in megabytes after which the log file is rotated
+	LogMaxSize = "log_max_size"
+	// LogMaxAge is the age in days after which the log file is rotated
+	LogMaxAge            = "log_max_age"
+	LogMaxBackups        = "log_max_backups"
+	defaultLogMaxBackups = 5
+
 	// Default settings
 	DefaultScanSettings     = "defaults.scan_task"
 	DefaultIdentifySettings = "defaults.identify_task"
 	DefaultAutoTagSettings  = "defaults.auto_tag_task"
 	DefaultGenerateSettings = "defaults.generate_task"
 
+	ScheduledTasks = "scheduled_tasks"
+
+	TaskNotifications = "task_notifications"
+	SMTP              = "smtp"
+
 	DeleteFileDefault             = "defaults.delete_file"
 	DeleteGeneratedDefault        = "defaults.delete_generated"
 	deleteGeneratedDefaultDefault = true
@@ -694,6 +801,55 @@ func (i *Config) GetDatabasePath() string {
 	return i.getString(Database)
 }
 
+// GetDatabaseBusyTimeout returns the number of milliseconds to wait for a
+// locked database before failing.
+func (i *Config) GetDatabaseBusyTimeout() int {
+	ret := i.getInt(DatabaseBusyTimeout)
+	if ret <= 0 {
+		return databaseBusyTimeoutDefault
+	}
+	return ret
+}
+
+// GetDatabaseCacheSize returns the page cache size of each database
+// connection in KiB. Returns zero to use the SQLite default.
+func (i *Config) GetDatabaseCacheSize() int {
+	ret := i.getInt(DatabaseCacheSize)
+	if ret < 0 {
+		return 0
+	}
+	return ret
+}
+
+// GetDatabaseSynchronous returns the value of the SQLite synchronous pragma.
+// Invalid values return the default of NORMAL.
+func (i *Config) GetDatabaseSynchronous() string {
+	ret := strings.ToUpper(i.getString(DatabaseSynchronous))
+	switch ret {
+	case "OFF", "NORMAL", "FULL", "EXTRA":
+		return ret
+	default:
+		return databaseSynchronousDefault
+	}
+}
+
+// GetDatabaseSlowQueryThreshold returns the number of milliseconds above
+// which SQL statements are logged. Returns zero if slow query logging is
+// disabled.
+func (i *Config) GetDatabaseSlowQueryThreshold() int {
+	ret := i.getInt(DatabaseSlowQueryThreshold)
+	if ret < 0 {
+		return 0
+	}
+	return ret
+}
+
+// GetDatabaseEncryptionKey returns the passphrase used to encrypt the
+// database. Returns an empty string if encryption is disabled.
+func (i *Config) GetDatabaseEncryptionKey() string {
+	return i.getString(DatabaseEncryptionKey)
+}
+
 func (i *Config) GetBackupDirectoryPath() string {
 	return i.getString(BackupDirectoryPath)
 }
@@ -707,6 +863,53 @@ func (i *Config) GetBackupDirectoryPathOrDefault() string {
 	return ret
 }
 
+// GetBackupRetention returns the number of database backups to keep in the
+// backup directory. Older backups are deleted when a new backup is made.
+// If zero or less, all backups are kept.
+func (i *Config) GetBackupRetention() int {
+	return i.getInt(BackupRetention)
+}
+
+// GetMigrationBackupPath returns the path of the database backup made before
+// the most recent schema migration.
+func (i *Config) GetMigrationBackupPath() string {
+	return i.getString(MigrationBackupPath)
+}
+
+// SetMigrationBackupPath records the path of the database backup made before
+// a schema migration and writes the configuration.
+func (i *Config) SetMigrationBackupPath(path string) error {
+	i.SetString(MigrationBackupPath, path)
+	return i.Write()
+}
+
+// GetTrashPath returns the directory that files deleted from the library are
+// moved to. If empty, deleted files are removed immediately.
+func (i *Config) GetTrashPath() string {
+	return i.getString(TrashPath)
+}
+
+// GetTrashRetention returns the number of days that files are kept in the
+// trash directory before being deleted by the empty trash task. If zero or
+// less, trashed files are only deleted when the trash is emptied manually.
+func (i *Config) GetTrashRetention() int {
+	return i.getInt(TrashRetention)
+}
+
+// GetDeletedObjectRetention returns the number of days that deleted scenes,
+// galleries and performers are kept before being removed by the purge
+// deleted objects task. If zero or less, they are only removed when purged
+// manually.
+func (i *Config) GetDeletedObjectRetention() int {
+	return i.getInt(DeletedObjectRetention)
+}
+
+// GetAuditLog returns true if changes made to objects should be recorded in
+// the audit log.
+func (i *Config) GetAuditLog() bool {
+	return i.getBool(AuditLog)
+}
+
 // GetFFMpegPath returns the path to the FFMpeg executable.
 // If empty, stash will attempt to resolve it from the path.
 func (i *Config) GetFFMpegPath() string {
@@ -804,6 +1007,28 @@ func (i *Config) GetSequentialScanning() bool {
 	return i.getBool(SequentialScanning)
 }
 
+// GetAutoTagPerformerAliases returns true if the auto-tag task should match
+// performer aliases as well as performer names.
+func (i *Config) GetAutoTagPerformerAliases() bool {
+	return i.getBool(AutoTagPerformerAliases)
+}
+
+// GetWatchLibrary returns true if the stash library paths should be watched
+// for changes and scanned automatically.
+func (i *Config) GetWatchLibrary() bool {
+	return i.getBool(WatchLibrary)
+}
+
+// GetWatchLibraryDebounce returns the number of seconds to wait after the
+// last detected change before scanning changed library paths.
+func (i *Config) GetWatchLibraryDebounce() int {
+	ret := i.getInt(WatchLibraryDebounce)
+	if ret <= 0 {
+		ret = watchLibraryDebounceDefault
+	}
+	return ret
+}
+
 func (i *Config) GetGalleryCoverRegex() string {
 	var regexString = i.getString(GalleryCoverRegex)
 
@@ -954,6 +1179,30 @@ func (i *Config) GetParallelTasksWithAutoDetection() int {
 	return parallelTasks
 }
 
+// GetScanParallelTasks returns the number of files that should be processed
+// in parallel by the scan task. If 0, the general parallel tasks setting is
+// used.
+func (i *Config) GetScanParallelTasks() int {
+	return i.getInt(ScanParallelTasks)
+}
+
+func (i *Config) GetScanParallelTasksWithAutoDetection() int {
+	if scanParallelTasks := i.getInt(ScanParallelTasks); scanParallelTasks > 0 {
+		return scanParallelTasks
+	}
+	return i.GetParallelTasksWithAutoDetection()
+}
+
+// GetScanBatchSize returns the number of new files to create in each
+// transaction during a scan. Returns 1 if batching is disabled.
+func (i *Config) GetScanBatchSize() int {
+	ret := i.getInt(ScanBatchSize)
+	if ret < 1 {
+		return 1
+	}
+	return ret
+}
+
 func (i *Config) GetPreviewAudio() bool {
 	return i.getBool(PreviewAudio)
 }
@@ -982,6 +1231,17 @@ func (i *Config) GetPreviewExcludeEnd() string {
 	return i.getString(PreviewExcludeEnd)
 }
 
+// GetPreviewWidth returns the width in pixels of generated scene previews.
+// The height is scaled to maintain the aspect ratio.
+func (i *Config) GetPreviewWidth() int {
+	ret := i.getInt(PreviewWidth)
+	if ret <= 0 {
+		return previewWidthDefault
+	}
+
+	return ret
+}
+
 // GetPreviewPreset returns the preset when generating previews. Defaults to
 // Slow.
 func (i *Config) GetPreviewPreset() models.PreviewPreset {
@@ -999,6 +1259,23 @@ func (i *Config) GetTranscodeHardwareAcceleration() bool {
 	return i.getBool(TranscodeHardwareAcceleration)
 }
 
+// GetTranscodeToneMapping returns true if HDR video should be tone mapped to
+// SDR when transcoding.
+func (i *Config) GetTranscodeToneMapping() bool {
+	return i.getBool(TranscodeToneMapping)
+}
+
+// GetTranscodeHardwareEncoder returns the hardware encoder used when hardware
+// acceleration is enabled. Defaults to the first supported encoder.
+func (i *Config) GetTranscodeHardwareEncoder() models.HardwareEncoder {
+	ret := models.HardwareEncoder(i.getString(TranscodeHardwareEncoder))
+	if !ret.IsValid() {
+		return models.HardwareEncoderAuto
+	}
+
+	return ret
+}
+
 func (i *Config) GetMaxTranscodeSize() models.StreamingResolutionEnum {
 	ret := i.getString(MaxTranscodeSize)
 
@@ -1029,6 +1306,48 @@ func (i *Config) GetTranscodeOutputArgs() []string {
 	return i.getStringSlice(TranscodeOutputArgs)
 }
 
+func (i *Config) GetPreviewInputArgs() []string {
+	return i.getStringSlice(PreviewInputArgs)
+}
+
+func (i *Config) GetPreviewOutputArgs() []string {
+	return i.getStringSlice(PreviewOutputArgs)
+}
+
+func (i *Config) GetScreenshotInputArgs() []string {
+	return i.getStringSlice(ScreenshotInputArgs)
+}
+
+func (i *Config) GetScreenshotOutputArgs() []string {
+	return i.getStringSlice(ScreenshotOutputArgs)
+}
+
+func (i *Config) GetGeneratedTranscodeInputArgs() []string {
+	return i.getStringSlice(GeneratedTranscodeInputArgs)
+}
+
+func (i *Config) GetGeneratedTranscodeOutputArgs() []string {
+	return i.getStringSlice(GeneratedTranscodeOutputArgs)
+}
+
+// GetGeneratedTranscodeCodec returns the video codec used when generating
+// transcodes. Defaults to H264.
+func (i *Config) GetGeneratedTranscodeCodec() models.TranscodeCodec {
+	ret := models.TranscodeCodec(i.getString(GeneratedTranscodeCodec))
+
+	if !ret.IsValid() {
+		return models.TranscodeCodecH264
+	}
+
+	return ret
+}
+
+// GetGeneratedTranscodeCRF returns the CRF used when generating transcodes.
+// Zero uses the default for the codec.
+func (i *Config) GetGeneratedTranscodeCRF() int {
+	return i.getInt(GeneratedTranscodeCRF)
+}
+
 func (i *Config) GetLiveTranscodeInputArgs() []string {
 	return i.getStringSlice(LiveTranscodeInputArgs)
 }
@@ -1037,6 +1356,74 @@ func (i *Config) GetLiveTranscodeOutputArgs() []string {
 	return i.getStringSlice(LiveTranscodeOutputArgs)
 }
 
+// GetLiveTranscodePreset returns the libx264 preset used when live
+// transcoding. Defaults to Veryfast.
+func (i *Config) GetLiveTranscodePreset() models.PreviewPreset {
+	ret := models.PreviewPreset(i.getString(LiveTranscodePreset))
+
+	// default to veryfast
+	if !ret.IsValid() {
+		return models.PreviewPresetVeryfast
+	}
+
+	return ret
+}
+
+// GetLiveTranscodeCRF returns the CRF used when live transcoding with
+// software encoders. Zero uses the default for the codec.
+func (i *Config) GetLiveTranscodeCRF() int {
+	return i.getInt(LiveTranscodeCRF)
+}
+
+// GetLiveTranscodeMaxBitrate returns the maximum video bitrate of live
+// transcodes in kbit/s. Zero is unlimited.
+func (i *Config) GetLiveTranscodeMaxBitrate() int {
+	return i.getInt(LiveTranscodeMaxBitrate)
+}
+
+// GetLiveTranscodeH264Encoders returns the ordered list of ffmpeg encoders to
+// try when live transcoding to H.264, such as h264_nvenc or libx264.
+func (i *Config) GetLiveTranscodeH264Encoders() []string {
+	return i.getStringSlice(LiveTranscodeH264Encoders)
+}
+
+// GetLiveTranscodeVP9Encoders returns the ordered list of ffmpeg encoders to
+// try when live transcoding to VP9, such as vp9_qsv or libvpx-vp9.
+func (i *Config) GetLiveTranscodeVP9Encoders() []string {
+	return i.getStringSlice(LiveTranscodeVP9Encoders)
+}
+
+// GetLiveTranscodeCacheSize returns the maximum size in MB of HLS/DASH
+// segments kept in the cache directory after a stream stops, so they can be
+// reused without transcoding again. Zero disables caching.
+func (i *Config) GetLiveTranscodeCacheSize() int {
+	return i.getInt(LiveTranscodeCacheSize)
+}
+
+// GetGenerateFFMpegThreads returns the number of threads passed to ffmpeg
+// when generating. Zero uses the ffmpeg default.
+func (i *Config) GetGenerateFFMpegThreads() int {
+	return i.getInt(GenerateFFMpegThreads)
+}
+
+// GetGenerateFFMpegNiceness returns the niceness of ffmpeg processes run
+// when generating. Zero leaves the priority unchanged.
+func (i *Config) GetGenerateFFMpegNiceness() int {
+	return i.getInt(GenerateFFMpegNiceness)
+}
+
+// GetGenerateMaxFFMpegProcesses returns the maximum number of ffmpeg
+// processes run at once when generating. Zero is unlimited.
+func (i *Config) GetGenerateMaxFFMpegProcesses() int {
+	return i.getInt(GenerateMaxFFMpegProcesses)
+}
+
+// GetMaxFFMpegProcesses returns the maximum number of ffmpeg processes run
+// at once, including live transcodes. Zero is unlimited.
+func (i *Config) GetMaxFFMpegProcesses() int {
+	return i.getInt(MaxFFMpegProcesses)
+}
+
 func (i *Config) GetDrawFunscriptHeatmapRange() bool {
 	return i.getBoolDefault(DrawFunscriptHeatmapRange, drawFunscriptHeatmapRangeDefault)
 }
@@ -1047,6 +1434,17 @@ func (i *Config) IsWriteImageThumbnails() bool {
 	return i.getBool(WriteImageThumbnails)
 }
 
+// GetImageThumbnailSize returns the maximum width or height in pixels of
+// generated image thumbnails.
+func (i *Config) GetImageThumbnailSize() int {
+	ret := i.getInt(ImageThumbnailSize)
+	if ret <= 0 {
+		return imageThumbnailSizeDefault
+	}
+
+	return ret
+}
+
 func (i *Config) IsCreateImageClipsFromVideos() bool {
 	return i.getBool(CreateImageClipsFromVideos)
 }
@@ -1203,6 +1601,21 @@ func (i *Config) GetWallPlayback() string {
 	return ret
 }
 
+// GetCardPlayback returns the type of preview shown when hovering over scene
+// cards. Either video or animation.
+func (i *Config) GetCardPlayback() string {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := defaultCardPlayback
+	v := i.forKey(CardPlayback)
+	if v.Exists(CardPlayback) {
+		ret = v.String(CardPlayback)
+	}
+
+	return ret
+}
+
 func (i *Config) GetShowScrubber() bool {
 	return i.getBoolDefault(ShowScrubber, showScrubberDefault)
 }
@@ -1527,6 +1940,55 @@ func (i *Config) GetDefaultGenerateSettings() *models.GenerateMetadataOptions {
 	return nil
 }
 
+// GetScheduledTasks returns the configured scheduled tasks.
+// Returns nil if the tasks could not be unmarshalled, or if none are set.
+func (i *Config) GetScheduledTasks() []*ScheduledTask {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(ScheduledTasks)
+
+	var ret []*ScheduledTask
+	if v.Exists(ScheduledTasks) {
+		if err := v.Unmarshal(ScheduledTasks, &ret); err != nil {
+			return nil
+		}
+	}
+
+	return ret
+}
+
+// GetTaskNotifications returns the configured task notifications.
+// Returns nil if the notifications could not be unmarshalled, or if none are
+// set.
+func (i *Config) GetTaskNotifications() []*TaskNotification {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(TaskNotifications)
+
+	var ret []*TaskNotification
+	if v.Exists(TaskNotifications) {
+		if err := v.Unmarshal(TaskNotifications, &ret); err != nil {
+			return nil
+		}
+	}
+
+	return ret
+}
+
+// GetSMTPSettings returns the settings used to send email notifications.
+func (i *Config) GetSMTPSettings() SMTPSettings {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(SMTP)
+
+	var ret SMTPSettings
+	if v.Exists(SMTP) {
+		_ = v.Unmarshal(SMTP, &ret)
+	}
+
+	return ret
+}
+
 // GetDangerousAllowPublicWithoutAuth determines if the security feature is enabled.
 // See https://docs.stashapp.cc/networking/authentication-required-when-accessing-stash-from-the-internet
 func (i *Config) GetDangerousAllowPublicWithoutAuth() bool {
@@ -1540,6 +2002,13 @@ func (i *Config) GetSecurityTripwireAccessedFromPublicInternet() string {
 	return i.getString(SecurityTripwireAccessedFromPublicInternet)
 }
 
+// GetPublicAPIFields returns the top-level GraphQL fields that
+// unauthenticated clients are permitted to query. If empty, all GraphQL
+// requests require authentication when credentials are set.
+func (i *Config) GetPublicAPIFields() []string {
+	return i.getStringSlice(PublicAPIFields)
+}
+
 // GetDLNAServerName returns the visible name of the DLNA server. If empty,
 // "stash" will be used.
 func (i *Config) GetDLNAServerName() string {
@@ -1613,6 +2082,33 @@ func (i *Config) GetLogLevel() string {
 	return value
 }
 
+// GetLogMaxSize returns the size in megabytes after which the log file is
+// rotated. Returns 0 if the log file should not be rotated based on size.
+func (i *Config) GetLogMaxSize() int {
+	return i.getInt(LogMaxSize)
+}
+
+// GetLogMaxAge returns the age in days after which the log file is rotated.
+// Returns 0 if the log file should not be rotated based on age.
+func (i *Config) GetLogMaxAge() int {
+	return i.getInt(LogMaxAge)
+}
+
+// GetLogMaxBackups returns the number of rotated log files to keep. Returns
+// 0 if all rotated log files should be kept.
+func (i *Config) GetLogMaxBackups() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := defaultLogMaxBackups
+	v := i.forKey(LogMaxBackups)
+	if v.Exists(LogMaxBackups) {
+		ret = v.Int(LogMaxBackups)
+	}
+
+	return ret
+}
+
 // GetLogAccess returns true if http requests should be logged to the terminal.
 // HTTP requests are not logged to the log file. Defaults to true.
 func (i *Config) GetLogAccess() bool {
@@ -1762,17 +2258,22 @@ func (i *Config) setDefaultValues() {
 	i.setDefault(Port, portDefault)
 
 	i.setDefault(ParallelTasks, parallelTasksDefault)
+	i.setDefault(ScanBatchSize, scanBatchSizeDefault)
 	i.setDefault(SequentialScanning, SequentialScanningDefault)
+	i.setDefault(WatchLibraryDebounce, watchLibraryDebounceDefault)
+	i.setDefault(LiveTranscodeCacheSize, liveTranscodeCacheSizeDefault)
 	i.setDefault(PreviewSegmentDuration, previewSegmentDurationDefault)
 	i.setDefault(PreviewSegments, previewSegmentsDefault)
 	i.setDefault(PreviewExcludeStart, previewExcludeStartDefault)
 	i.setDefault(PreviewExcludeEnd, previewExcludeEndDefault)
+	i.setDefault(PreviewWidth, previewWidthDefault)
 	i.setDefault(PreviewAudio, previewAudioDefault)
 	i.setDefault(SoundOnPreview, false)
 
 	i.setDefault(ThemeColor, DefaultThemeColor)
 
 	i.setDefault(WriteImageThumbnails, writeImageThumbnailsDefault)
+	i.setDefault(ImageThumbnailSize, imageThumbnailSizeDefault)
 	i.setDefault(CreateImageClipsFromVideos, createImageClipsFromVideosDefault)
 
 	i.setDefault(Database, defaultDatabaseFilePath)
@@ -32,3 +32,35 @@ func TestConfig_GetAllPluginConfiguration(t *testing.T) {
 		"plugin2": {"key3": "value3"},
 	}, i.GetAllPluginConfiguration())
 }
+
+func TestConfig_GetTaskNotifications(t *testing.T) {
+	i := InitializeEmpty()
+
+	assert.Nil(t, i.GetTaskNotifications())
+
+	notifications := []*TaskNotification{
+		{
+			Type:         NotificationTypeNtfy,
+			URL:          "https://ntfy.sh/topic",
+			Tasks:        []NotificationTaskType{NotificationTaskTypeScan},
+			FailuresOnly: true,
+			Enabled:      true,
+		},
+	}
+	i.SetInterface(TaskNotifications, notifications)
+
+	got := i.GetTaskNotifications()
+	assert.Equal(t, notifications, got)
+
+	n := got[0]
+	assert.True(t, n.Matches(NotificationTaskTypeScan, true))
+	assert.False(t, n.Matches(NotificationTaskTypeScan, false))
+	assert.False(t, n.Matches(NotificationTaskTypeGenerate, true))
+	assert.False(t, n.Matches("", true))
+
+	n.Tasks = nil
+	assert.True(t, n.Matches("", true))
+
+	n.Enabled = false
+	assert.False(t, n.Matches("", true))
+}
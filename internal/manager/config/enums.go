@@ -48,3 +48,155 @@ func (e *BlobsStorageType) UnmarshalGQL(v interface{}) error {
 func (e BlobsStorageType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type ScheduledTaskType string
+
+const (
+	ScheduledTaskTypeScan                ScheduledTaskType = "SCAN"
+	ScheduledTaskTypeGenerate            ScheduledTaskType = "GENERATE"
+	ScheduledTaskTypeClean               ScheduledTaskType = "CLEAN"
+	ScheduledTaskTypeBackup              ScheduledTaskType = "BACKUP"
+	ScheduledTaskTypeIdentify            ScheduledTaskType = "IDENTIFY"
+	ScheduledTaskTypeEmptyTrash          ScheduledTaskType = "EMPTY_TRASH"
+	ScheduledTaskTypePurgeDeletedObjects ScheduledTaskType = "PURGE_DELETED_OBJECTS"
+)
+
+var AllScheduledTaskType = []ScheduledTaskType{
+	ScheduledTaskTypeScan,
+	ScheduledTaskTypeGenerate,
+	ScheduledTaskTypeClean,
+	ScheduledTaskTypeBackup,
+	ScheduledTaskTypeIdentify,
+	ScheduledTaskTypeEmptyTrash,
+	ScheduledTaskTypePurgeDeletedObjects,
+}
+
+func (e ScheduledTaskType) IsValid() bool {
+	switch e {
+	case ScheduledTaskTypeScan, ScheduledTaskTypeGenerate, ScheduledTaskTypeClean, ScheduledTaskTypeBackup, ScheduledTaskTypeIdentify, ScheduledTaskTypeEmptyTrash, ScheduledTaskTypePurgeDeletedObjects:
+		return true
+	}
+	return false
+}
+
+func (e ScheduledTaskType) String() string {
+	return string(e)
+}
+
+func (e *ScheduledTaskType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScheduledTaskType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScheduledTaskType", str)
+	}
+	return nil
+}
+
+func (e ScheduledTaskType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type NotificationType string
+
+const (
+	NotificationTypeWebhook NotificationType = "WEBHOOK"
+	NotificationTypeGotify  NotificationType = "GOTIFY"
+	NotificationTypeNtfy    NotificationType = "NTFY"
+	NotificationTypeEmail   NotificationType = "EMAIL"
+)
+
+var AllNotificationType = []NotificationType{
+	NotificationTypeWebhook,
+	NotificationTypeGotify,
+	NotificationTypeNtfy,
+	NotificationTypeEmail,
+}
+
+func (e NotificationType) IsValid() bool {
+	switch e {
+	case NotificationTypeWebhook, NotificationTypeGotify, NotificationTypeNtfy, NotificationTypeEmail:
+		return true
+	}
+	return false
+}
+
+func (e NotificationType) String() string {
+	return string(e)
+}
+
+func (e *NotificationType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationType", str)
+	}
+	return nil
+}
+
+func (e NotificationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type NotificationTaskType string
+
+const (
+	NotificationTaskTypeScan     NotificationTaskType = "SCAN"
+	NotificationTaskTypeGenerate NotificationTaskType = "GENERATE"
+	NotificationTaskTypeAutoTag  NotificationTaskType = "AUTO_TAG"
+	NotificationTaskTypeClean    NotificationTaskType = "CLEAN"
+	NotificationTaskTypeIdentify NotificationTaskType = "IDENTIFY"
+	NotificationTaskTypeImport   NotificationTaskType = "IMPORT"
+	NotificationTaskTypeExport   NotificationTaskType = "EXPORT"
+	NotificationTaskTypeBackup   NotificationTaskType = "BACKUP"
+	NotificationTaskTypePlugin   NotificationTaskType = "PLUGIN"
+)
+
+var AllNotificationTaskType = []NotificationTaskType{
+	NotificationTaskTypeScan,
+	NotificationTaskTypeGenerate,
+	NotificationTaskTypeAutoTag,
+	NotificationTaskTypeClean,
+	NotificationTaskTypeIdentify,
+	NotificationTaskTypeImport,
+	NotificationTaskTypeExport,
+	NotificationTaskTypeBackup,
+	NotificationTaskTypePlugin,
+}
+
+func (e NotificationTaskType) IsValid() bool {
+	switch e {
+	case NotificationTaskTypeScan, NotificationTaskTypeGenerate, NotificationTaskTypeAutoTag, NotificationTaskTypeClean, NotificationTaskTypeIdentify,
+		NotificationTaskTypeImport, NotificationTaskTypeExport, NotificationTaskTypeBackup, NotificationTaskTypePlugin:
+		return true
+	}
+	return false
+}
+
+func (e NotificationTaskType) String() string {
+	return string(e)
+}
+
+func (e *NotificationTaskType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationTaskType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationTaskType", str)
+	}
+	return nil
+}
+
+func (e NotificationTaskType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -42,6 +42,8 @@ var (
 		"cache":         Cache,
 		"stash":         Stash,
 		"ui":            UILocation,
+
+		"database_encryption_key": DatabaseEncryptionKey,
 	}
 )
 
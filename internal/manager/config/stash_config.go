@@ -11,12 +11,20 @@ type StashConfigInput struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// Regular expressions of video paths to exclude, in addition to the global exclusions
+	Excludes []string `json:"excludes"`
+	// Regular expressions of image and gallery paths to exclude, in addition to the global exclusions
+	ImageExcludes []string `json:"imageExcludes"`
 }
 
 type StashConfig struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// Regular expressions of video paths to exclude, in addition to the global exclusions
+	Excludes []string `json:"excludes"`
+	// Regular expressions of image and gallery paths to exclude, in addition to the global exclusions
+	ImageExcludes []string `json:"imageExcludes"`
 }
 
 type StashConfigs []*StashConfig
@@ -27,3 +27,58 @@ type AutoTagMetadataOptions struct {
 	// IDs of tags to tag files with, or "*" for all
 	Tags []string `json:"tags"`
 }
+
+type ScheduledTask struct {
+	// Task to run
+	Type ScheduledTaskType `json:"type"`
+	// Cron expression determining when the task is run
+	Schedule string `json:"schedule"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// TaskNotification sends a notification to an external service when tasks
+// finish or fail.
+type TaskNotification struct {
+	Type NotificationType `json:"type"`
+	// URL of the webhook, Gotify server or ntfy topic
+	URL string `json:"url"`
+	// Gotify application token or ntfy access token
+	Token string `json:"token"`
+	// Email recipients
+	To []string `json:"to"`
+	// Types of task to send notifications for. If empty, notifications are
+	// sent for all tasks.
+	Tasks []NotificationTaskType `json:"tasks"`
+	// Only send notifications when tasks fail
+	FailuresOnly bool `json:"failuresOnly"`
+	Enabled      bool `json:"enabled"`
+}
+
+// Matches returns true if the notification should be sent for a task of the
+// provided type. taskType is empty for tasks without a type.
+func (n TaskNotification) Matches(taskType NotificationTaskType, failed bool) bool {
+	if !n.Enabled || (n.FailuresOnly && !failed) {
+		return false
+	}
+
+	if len(n.Tasks) == 0 {
+		return true
+	}
+
+	for _, t := range n.Tasks {
+		if t == taskType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SMTPSettings are the settings used to send email notifications.
+type SMTPSettings struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+}
@@ -0,0 +1,692 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/tag"
+)
+
+// deletedRelations are the ids of the studio, performers and tags of a
+// deleted object. The export refers to these by name, so the ids are kept to
+// relink the restored object to the same objects, even if they have been
+// renamed since.
+type deletedRelations struct {
+	StudioID     *int  `json:"studio_id,omitempty"`
+	PerformerIDs []int `json:"performer_ids,omitempty"`
+	TagIDs       []int `json:"tag_ids,omitempty"`
+}
+
+// deletedScene is the data kept for a deleted scene.
+type deletedScene struct {
+	jsonschema.Scene
+	Relations *deletedRelations `json:"relations,omitempty"`
+}
+
+// deletedGallery is the data kept for a deleted gallery. The gallery's
+// images and scenes are not part of the gallery export, so their ids are kept
+// to re-add them to the gallery when it is restored.
+type deletedGallery struct {
+	jsonschema.Gallery
+	Relations *deletedRelations `json:"relations,omitempty"`
+	ImageIDs  []int             `json:"image_ids,omitempty"`
+	SceneIDs  []int             `json:"scene_ids,omitempty"`
+}
+
+// deletedPerformer is the data kept for a deleted performer. The objects that
+// the performer was in are not part of the performer export, so their ids are
+// kept to add the performer back to them when it is restored.
+type deletedPerformer struct {
+	jsonschema.Performer
+	Relations  *deletedRelations `json:"relations,omitempty"`
+	SceneIDs   []int             `json:"scene_ids,omitempty"`
+	ImageIDs   []int             `json:"image_ids,omitempty"`
+	GalleryIDs []int             `json:"gallery_ids,omitempty"`
+}
+
+// existing returns the relations that still exist.
+func (rel *deletedRelations) existing(ctx context.Context, r models.Repository) (*deletedRelations, error) {
+	ret := &deletedRelations{}
+
+	if rel.StudioID != nil {
+		s, err := r.Studio.Find(ctx, *rel.StudioID)
+		if err != nil {
+			return nil, err
+		}
+
+		if s != nil {
+			ret.StudioID = rel.StudioID
+		}
+	}
+
+	for _, id := range rel.PerformerIDs {
+		p, err := r.Performer.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if p != nil {
+			ret.PerformerIDs = append(ret.PerformerIDs, id)
+		}
+	}
+
+	for _, id := range rel.TagIDs {
+		t, err := r.Tag.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if t != nil {
+			ret.TagIDs = append(ret.TagIDs, id)
+		}
+	}
+
+	return ret, nil
+}
+
+func (rel *deletedRelations) studioID() models.OptionalInt {
+	if rel.StudioID == nil {
+		return models.OptionalInt{}
+	}
+	return models.NewOptionalInt(*rel.StudioID)
+}
+
+func setIDs(ids []int) *models.UpdateIDs {
+	return &models.UpdateIDs{
+		IDs:  ids,
+		Mode: models.RelationshipUpdateModeSet,
+	}
+}
+
+func createDeletedObject(ctx context.Context, r models.Repository, objectType models.DeletedObjectType, id int, name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding %s json: %w", strings.ToLower(objectType.String()), err)
+	}
+
+	return r.DeletedObject.Create(ctx, &models.DeletedObject{
+		ObjectType: objectType,
+		ObjectID:   id,
+		Name:       name,
+		Data:       data,
+		DeletedAt:  time.Now(),
+	})
+}
+
+// KeepDeletedScene stores the scene as a deleted object so that it can be
+// restored. It must be called in the same transaction that destroys the
+// scene, before the scene is destroyed.
+func KeepDeletedScene(ctx context.Context, r models.Repository, s *models.Scene) error {
+	if err := s.LoadRelationships(ctx, r.Scene); err != nil {
+		return fmt.Errorf("loading scene relationships: %w", err)
+	}
+
+	sceneJSON, err := scene.ToBasicJSON(ctx, r.Scene, s)
+	if err != nil {
+		return fmt.Errorf("getting scene JSON: %w", err)
+	}
+
+	sceneJSON.Studio, err = scene.GetStudioName(ctx, r.Studio, s)
+	if err != nil {
+		return fmt.Errorf("getting scene studio name: %w", err)
+	}
+
+	galleries, err := r.Gallery.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return fmt.Errorf("getting scene galleries: %w", err)
+	}
+
+	for _, g := range galleries {
+		if err := g.LoadFiles(ctx, r.Gallery); err != nil {
+			return fmt.Errorf("getting scene gallery files: %w", err)
+		}
+	}
+
+	sceneJSON.Galleries = gallery.GetRefs(galleries)
+	sceneJSON.ResumeTime = s.ResumeTime
+	sceneJSON.PlayDuration = s.PlayDuration
+
+	performers, err := r.Performer.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return fmt.Errorf("getting scene performers: %w", err)
+	}
+
+	sceneJSON.Performers = performer.GetNames(performers)
+
+	sceneJSON.Tags, err = scene.GetTagNames(ctx, r.Tag, s)
+	if err != nil {
+		return fmt.Errorf("getting scene tag names: %w", err)
+	}
+
+	sceneJSON.Markers, err = scene.GetSceneMarkersJSON(ctx, r.SceneMarker, r.Tag, s)
+	if err != nil {
+		return fmt.Errorf("getting scene markers JSON: %w", err)
+	}
+
+	sceneJSON.Groups, err = scene.GetSceneGroupsJSON(ctx, r.Group, s)
+	if err != nil {
+		return fmt.Errorf("getting scene groups JSON: %w", err)
+	}
+
+	data := deletedScene{
+		Scene: *sceneJSON,
+		Relations: &deletedRelations{
+			StudioID:     s.StudioID,
+			PerformerIDs: s.PerformerIDs.List(),
+			TagIDs:       s.TagIDs.List(),
+		},
+	}
+
+	return createDeletedObject(ctx, r, models.DeletedObjectTypeScene, s.ID, s.DisplayName(), data)
+}
+
+// KeepDeletedGallery stores the gallery as a deleted object so that it can
+// be restored. It must be called in the same transaction that destroys the
+// gallery, before the gallery is destroyed.
+func KeepDeletedGallery(ctx context.Context, r models.Repository, g *models.Gallery) error {
+	if err := g.LoadFiles(ctx, r.Gallery); err != nil {
+		return fmt.Errorf("getting gallery files: %w", err)
+	}
+
+	if err := g.LoadURLs(ctx, r.Gallery); err != nil {
+		return fmt.Errorf("getting gallery urls: %w", err)
+	}
+
+	galleryJSON, err := gallery.ToBasicJSON(g)
+	if err != nil {
+		return fmt.Errorf("getting gallery JSON: %w", err)
+	}
+
+	galleryJSON.Studio, err = gallery.GetStudioName(ctx, r.Studio, g)
+	if err != nil {
+		return fmt.Errorf("getting gallery studio name: %w", err)
+	}
+
+	performers, err := r.Performer.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return fmt.Errorf("getting gallery performers: %w", err)
+	}
+
+	galleryJSON.Performers = performer.GetNames(performers)
+
+	tags, err := r.Tag.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return fmt.Errorf("getting gallery tags: %w", err)
+	}
+
+	galleryJSON.Tags = tag.GetNames(tags)
+
+	relations := &deletedRelations{
+		StudioID: g.StudioID,
+	}
+	for _, p := range performers {
+		relations.PerformerIDs = append(relations.PerformerIDs, p.ID)
+	}
+	for _, t := range tags {
+		relations.TagIDs = append(relations.TagIDs, t.ID)
+	}
+
+	galleryJSON.Chapters, err = gallery.GetGalleryChaptersJSON(ctx, r.GalleryChapter, g)
+	if err != nil {
+		return fmt.Errorf("getting gallery chapters JSON: %w", err)
+	}
+
+	images, err := r.Image.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return fmt.Errorf("getting gallery images: %w", err)
+	}
+
+	scenes, err := r.Scene.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return fmt.Errorf("getting gallery scenes: %w", err)
+	}
+
+	data := deletedGallery{
+		Gallery:   *galleryJSON,
+		Relations: relations,
+	}
+
+	for _, i := range images {
+		data.ImageIDs = append(data.ImageIDs, i.ID)
+	}
+
+	for _, s := range scenes {
+		data.SceneIDs = append(data.SceneIDs, s.ID)
+	}
+
+	return createDeletedObject(ctx, r, models.DeletedObjectTypeGallery, g.ID, g.DisplayName(), data)
+}
+
+// KeepDeletedPerformer stores the performer as a deleted object so that it
+// can be restored. It must be called in the same transaction that destroys
+// the performer, before the performer is destroyed.
+func KeepDeletedPerformer(ctx context.Context, r models.Repository, p *models.Performer) error {
+	performerJSON, err := performer.ToJSON(ctx, r.Performer, p)
+	if err != nil {
+		return fmt.Errorf("getting performer JSON: %w", err)
+	}
+
+	tags, err := r.Tag.FindByPerformerID(ctx, p.ID)
+	if err != nil {
+		return fmt.Errorf("getting performer tags: %w", err)
+	}
+
+	performerJSON.Tags = tag.GetNames(tags)
+
+	data := deletedPerformer{
+		Performer: *performerJSON,
+		Relations: &deletedRelations{},
+	}
+
+	for _, t := range tags {
+		data.Relations.TagIDs = append(data.Relations.TagIDs, t.ID)
+	}
+
+	scenes, err := r.Scene.FindByPerformerID(ctx, p.ID)
+	if err != nil {
+		return fmt.Errorf("getting performer scenes: %w", err)
+	}
+
+	for _, s := range scenes {
+		data.SceneIDs = append(data.SceneIDs, s.ID)
+	}
+
+	performerCriterion := &models.MultiCriterionInput{
+		Value:    []string{strconv.Itoa(p.ID)},
+		Modifier: models.CriterionModifierIncludes,
+	}
+	perPage := models.PerPageAll
+	findFilter := &models.FindFilterType{
+		PerPage: &perPage,
+	}
+
+	images, err := image.Query(ctx, r.Image, &models.ImageFilterType{Performers: performerCriterion}, findFilter)
+	if err != nil {
+		return fmt.Errorf("getting performer images: %w", err)
+	}
+
+	for _, i := range images {
+		data.ImageIDs = append(data.ImageIDs, i.ID)
+	}
+
+	galleries, _, err := r.Gallery.Query(ctx, &models.GalleryFilterType{Performers: performerCriterion}, findFilter)
+	if err != nil {
+		return fmt.Errorf("getting performer galleries: %w", err)
+	}
+
+	for _, g := range galleries {
+		data.GalleryIDs = append(data.GalleryIDs, g.ID)
+	}
+
+	return createDeletedObject(ctx, r, models.DeletedObjectTypePerformer, p.ID, p.Name, data)
+}
+
+// RestoreDeletedObjects recreates the deleted objects with the provided ids,
+// using the same process as the metadata import. Restored objects keep their
+// original ids, and are relinked to their studio, performers and tags by id.
+// References to objects that no longer exist are ignored. Restoring fails if
+// the files of a scene or gallery no longer exist, or if they belong to
+// another scene or gallery.
+func (s *Manager) RestoreDeletedObjects(ctx context.Context, ids []int) error {
+	r := s.Repository
+	fileNamingAlgorithm := s.Config.GetVideoFileNamingAlgorithm()
+
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		objects, err := r.DeletedObject.FindMany(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range objects {
+			var err error
+			switch o.ObjectType {
+			case models.DeletedObjectTypeScene:
+				err = restoreScene(ctx, r, o, fileNamingAlgorithm)
+			case models.DeletedObjectTypeGallery:
+				err = restoreGallery(ctx, r, o)
+			case models.DeletedObjectTypePerformer:
+				err = restorePerformer(ctx, r, o)
+			default:
+				err = fmt.Errorf("unsupported object type %q", o.ObjectType)
+			}
+
+			if err != nil {
+				return fmt.Errorf("restoring %s %q: %w", strings.ToLower(o.ObjectType.String()), o.Name, err)
+			}
+
+			if err := r.DeletedObject.Destroy(ctx, o.ID); err != nil {
+				return err
+			}
+
+			logger.Infof("Restored deleted %s %q", strings.ToLower(o.ObjectType.String()), o.Name)
+		}
+
+		return nil
+	})
+}
+
+func restoreScene(ctx context.Context, r models.Repository, o *models.DeletedObject, fileNamingAlgorithm models.HashAlgorithm) error {
+	var data deletedScene
+	if err := json.Unmarshal(o.Data, &data); err != nil {
+		return fmt.Errorf("decoding scene json: %w", err)
+	}
+
+	sceneJSON := data.Scene
+	if data.Relations != nil {
+		// relinked by id after importing
+		sceneJSON.Studio = ""
+		sceneJSON.Performers = nil
+		sceneJSON.Tags = nil
+	}
+
+	sceneImporter := &scene.Importer{
+		ReaderWriter: r.Scene,
+		Input:        sceneJSON,
+		FileFinder:   r.File,
+		RestoreID:    o.ObjectID,
+
+		FileNamingAlgorithm: fileNamingAlgorithm,
+		MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+
+		GalleryFinder:   r.Gallery,
+		GroupWriter:     r.Group,
+		PerformerWriter: r.Performer,
+		StudioWriter:    r.Studio,
+		TagWriter:       r.Tag,
+	}
+
+	if err := performImport(ctx, sceneImporter, ImportDuplicateEnumFail); err != nil {
+		return err
+	}
+
+	for _, m := range sceneJSON.Markers {
+		markerImporter := &scene.MarkerImporter{
+			SceneID:             sceneImporter.ID,
+			Input:               m,
+			MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+			ReaderWriter:        r.SceneMarker,
+			TagWriter:           r.Tag,
+		}
+
+		if err := performImport(ctx, markerImporter, ImportDuplicateEnumFail); err != nil {
+			return err
+		}
+	}
+
+	if data.Relations != nil {
+		rel, err := data.Relations.existing(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		// UpdatedAt is not set, to keep the restored value
+		partial := models.ScenePartial{
+			StudioID:     rel.studioID(),
+			PerformerIDs: setIDs(rel.PerformerIDs),
+			TagIDs:       setIDs(rel.TagIDs),
+		}
+		if _, err := r.Scene.UpdatePartial(ctx, sceneImporter.ID, partial); err != nil {
+			return fmt.Errorf("relinking scene: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func restoreGallery(ctx context.Context, r models.Repository, o *models.DeletedObject) error {
+	var data deletedGallery
+	if err := json.Unmarshal(o.Data, &data); err != nil {
+		return fmt.Errorf("decoding gallery json: %w", err)
+	}
+
+	galleryJSON := data.Gallery
+	if data.Relations != nil {
+		// relinked by id after importing
+		galleryJSON.Studio = ""
+		galleryJSON.Performers = nil
+		galleryJSON.Tags = nil
+	}
+
+	galleryImporter := &gallery.Importer{
+		ReaderWriter:        r.Gallery,
+		FolderFinder:        r.Folder,
+		FileFinder:          r.File,
+		PerformerWriter:     r.Performer,
+		StudioWriter:        r.Studio,
+		TagWriter:           r.Tag,
+		Input:               galleryJSON,
+		MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+		RestoreID:           o.ObjectID,
+	}
+
+	if err := performImport(ctx, galleryImporter, ImportDuplicateEnumFail); err != nil {
+		return err
+	}
+
+	for _, m := range data.Chapters {
+		chapterImporter := &gallery.ChapterImporter{
+			GalleryID:           galleryImporter.ID,
+			Input:               m,
+			MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+			ReaderWriter:        r.GalleryChapter,
+		}
+
+		if err := performImport(ctx, chapterImporter, ImportDuplicateEnumFail); err != nil {
+			return err
+		}
+	}
+
+	// images may have been deleted along with the gallery
+	var imageIDs []int
+	for _, id := range data.ImageIDs {
+		i, err := r.Image.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if i != nil {
+			imageIDs = append(imageIDs, id)
+		}
+	}
+
+	if len(imageIDs) > 0 {
+		if err := r.Gallery.AddImages(ctx, galleryImporter.ID, imageIDs...); err != nil {
+			return fmt.Errorf("adding images to gallery: %w", err)
+		}
+	}
+
+	// UpdatedAt is not set, to keep the restored value
+	var partial models.GalleryPartial
+
+	// scenes may have been deleted since
+	var sceneIDs []int
+	for _, id := range data.SceneIDs {
+		s, err := r.Scene.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if s != nil {
+			sceneIDs = append(sceneIDs, id)
+		}
+	}
+
+	if len(sceneIDs) > 0 {
+		partial.SceneIDs = &models.UpdateIDs{
+			IDs:  sceneIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+	}
+
+	if data.Relations != nil {
+		rel, err := data.Relations.existing(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		partial.StudioID = rel.studioID()
+		partial.PerformerIDs = setIDs(rel.PerformerIDs)
+		partial.TagIDs = setIDs(rel.TagIDs)
+	}
+
+	if _, err := r.Gallery.UpdatePartial(ctx, galleryImporter.ID, partial); err != nil {
+		return fmt.Errorf("relinking gallery: %w", err)
+	}
+
+	return nil
+}
+
+func restorePerformer(ctx context.Context, r models.Repository, o *models.DeletedObject) error {
+	var data deletedPerformer
+	if err := json.Unmarshal(o.Data, &data); err != nil {
+		return fmt.Errorf("decoding performer json: %w", err)
+	}
+
+	performerJSON := data.Performer
+	if data.Relations != nil {
+		// relinked by id after importing
+		performerJSON.Tags = nil
+	}
+
+	importer := &performer.Importer{
+		ReaderWriter:        r.Performer,
+		TagWriter:           r.Tag,
+		Input:               performerJSON,
+		MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+		RestoreID:           o.ObjectID,
+	}
+
+	if err := performImport(ctx, importer, ImportDuplicateEnumFail); err != nil {
+		return err
+	}
+
+	if data.Relations != nil {
+		rel, err := data.Relations.existing(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		// UpdatedAt is not set, to keep the restored value
+		partial := models.PerformerPartial{
+			TagIDs: setIDs(rel.TagIDs),
+		}
+		if _, err := r.Performer.UpdatePartial(ctx, importer.ID, partial); err != nil {
+			return fmt.Errorf("relinking performer: %w", err)
+		}
+	}
+
+	addPerformer := &models.UpdateIDs{
+		IDs:  []int{importer.ID},
+		Mode: models.RelationshipUpdateModeAdd,
+	}
+
+	// skip objects that have been deleted since
+	for _, id := range data.SceneIDs {
+		s, err := r.Scene.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if s != nil {
+			partial := models.NewScenePartial()
+			partial.PerformerIDs = addPerformer
+			if _, err := r.Scene.UpdatePartial(ctx, id, partial); err != nil {
+				return fmt.Errorf("adding performer to scene %d: %w", id, err)
+			}
+		}
+	}
+
+	for _, id := range data.ImageIDs {
+		i, err := r.Image.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if i != nil {
+			partial := models.NewImagePartial()
+			partial.PerformerIDs = addPerformer
+			if _, err := r.Image.UpdatePartial(ctx, id, partial); err != nil {
+				return fmt.Errorf("adding performer to image %d: %w", id, err)
+			}
+		}
+	}
+
+	for _, id := range data.GalleryIDs {
+		g, err := r.Gallery.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if g != nil {
+			partial := models.NewGalleryPartial()
+			partial.PerformerIDs = addPerformer
+			if _, err := r.Gallery.UpdatePartial(ctx, id, partial); err != nil {
+				return fmt.Errorf("adding performer to gallery %d: %w", id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+type PurgeDeletedObjectsInput struct {
+	// Purge all deleted objects, regardless of the deleted object retention
+	// setting. Defaults to false.
+	All *bool `json:"all"`
+}
+
+type purgeDeletedObjectsJob struct {
+	repository models.Repository
+	retention  int
+	all        bool
+}
+
+func (j *purgeDeletedObjectsJob) Execute(ctx context.Context, progress *job.Progress) error {
+	before := time.Now()
+	if !j.all {
+		if j.retention <= 0 {
+			logger.Info("Deleted object retention is not set. Not purging any deleted objects.")
+			return nil
+		}
+
+		before = before.AddDate(0, 0, -j.retention)
+	}
+
+	var purged int
+	r := j.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		var err error
+		purged, err = r.DeletedObject.DestroyBefore(ctx, before)
+		return err
+	}); err != nil {
+		return fmt.Errorf("purging deleted objects: %w", err)
+	}
+
+	logger.Infof("Purged %d deleted objects", purged)
+	return nil
+}
+
+// PurgeDeletedObjects queues a job to permanently remove deleted scenes,
+// galleries and performers. Unless all is set, only objects deleted longer
+// ago than the deleted object retention setting are removed.
+func (s *Manager) PurgeDeletedObjects(ctx context.Context, input PurgeDeletedObjectsInput) (int, error) {
+	j := &purgeDeletedObjectsJob{
+		repository: s.Repository,
+		retention:  s.Config.GetDeletedObjectRetention(),
+		all:        input.All != nil && *input.All,
+	}
+
+	return s.JobManager.Add(ctx, "Purging deleted objects...", j), nil
+}
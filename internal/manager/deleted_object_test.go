@@ -0,0 +1,79 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestKeepDeletedGallery(t *testing.T) {
+	const (
+		galleryID = 1
+		title     = "title"
+		url       = "url"
+		tagName   = "tag"
+		tagID     = 4
+		perfName  = "performer"
+		perfID    = 5
+		studioID  = 6
+		sceneID   = 7
+	)
+
+	imageIDs := []int{2, 3}
+
+	ctx := context.Background()
+	db := mocks.NewDatabase()
+
+	g := &models.Gallery{
+		ID:    galleryID,
+		Title: title,
+		Files: models.NewRelatedFiles([]models.File{}),
+		URLs:  models.NewRelatedStrings([]string{url}),
+	}
+
+	sID := studioID
+	g.StudioID = &sID
+
+	db.Studio.On("Find", ctx, studioID).Return(&models.Studio{ID: studioID}, nil).Once()
+	db.Performer.On("FindByGalleryID", ctx, galleryID).Return([]*models.Performer{{ID: perfID, Name: perfName}}, nil).Once()
+	db.Tag.On("FindByGalleryID", ctx, galleryID).Return([]*models.Tag{{ID: tagID, Name: tagName}}, nil).Once()
+	db.Scene.On("FindByGalleryID", ctx, galleryID).Return([]*models.Scene{{ID: sceneID}}, nil).Once()
+	db.GalleryChapter.On("FindByGalleryID", ctx, galleryID).Return(nil, nil).Once()
+	db.Image.On("FindByGalleryID", ctx, galleryID).Return([]*models.Image{{ID: imageIDs[0]}, {ID: imageIDs[1]}}, nil).Once()
+
+	var created *models.DeletedObject
+	db.DeletedObject.On("Create", ctx, mock.AnythingOfType("*models.DeletedObject")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.DeletedObject)
+	}).Return(nil).Once()
+
+	err := KeepDeletedGallery(ctx, db.Repository(), g)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	db.AssertExpectations(t)
+
+	assert.Equal(t, models.DeletedObjectTypeGallery, created.ObjectType)
+	assert.Equal(t, galleryID, created.ObjectID)
+	assert.Equal(t, title, created.Name)
+
+	var data deletedGallery
+	if assert.Nil(t, json.Unmarshal(created.Data, &data)) {
+		assert.Equal(t, title, data.Title)
+		assert.Equal(t, []string{url}, data.URLs)
+		assert.Equal(t, []string{perfName}, data.Performers)
+		assert.Equal(t, []string{tagName}, data.Tags)
+		assert.Equal(t, imageIDs, data.ImageIDs)
+		assert.Equal(t, []int{sceneID}, data.SceneIDs)
+		assert.Equal(t, &deletedRelations{
+			StudioID:     &sID,
+			PerformerIDs: []int{perfID},
+			TagIDs:       []int{tagID},
+		}, data.Relations)
+	}
+}
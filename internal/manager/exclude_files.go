@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
 )
 
@@ -87,3 +88,24 @@ func matchFileSimple(file string, regExps []*regexp.Regexp) bool {
 	}
 	return false
 }
+
+// stashExcludeRegexps contains the compiled exclusion patterns of a stash
+// library path.
+type stashExcludeRegexps struct {
+	video []*regexp.Regexp
+	image []*regexp.Regexp
+}
+
+// generateStashExcludeRegexps returns the compiled exclusion patterns of
+// each stash library path, keyed by path.
+func generateStashExcludeRegexps(stashes config.StashConfigs) map[string]stashExcludeRegexps {
+	ret := make(map[string]stashExcludeRegexps)
+	for _, s := range stashes {
+		ret[s.Path] = stashExcludeRegexps{
+			video: generateRegexps(s.Excludes),
+			image: generateRegexps(s.ImageExcludes),
+		}
+	}
+
+	return ret
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
 )
 
@@ -96,3 +97,55 @@ func runMatch(filenames []string, patterns []string, expCount int) error {
 
 	return nil
 }
+
+func TestScanFilterStashExcludes(t *testing.T) {
+	stashes := config.StashConfigs{
+		{
+			Path:          "/stash/videos",
+			Excludes:      []string{"/extras/"},
+			ImageExcludes: []string{"\\$RECYCLE\\.BIN"},
+		},
+		{
+			Path: "/stash/other",
+		},
+	}
+
+	f := &scanFilter{
+		videoExcludeRegex: generateRegexps([]string{"sample\\.mp4$"}),
+		stashExcludeRegex: generateStashExcludeRegexps(stashes),
+	}
+
+	videoTests := []struct {
+		path     string
+		stash    *config.StashConfig
+		expected bool
+	}{
+		{"/stash/videos/extras/scene.mp4", stashes[0], true},
+		{"/stash/videos/scene sample.mp4", stashes[0], true},
+		{"/stash/videos/scene.mp4", stashes[0], false},
+		{"/stash/other/extras/scene.mp4", stashes[1], false},
+		{"/stash/other/scene sample.mp4", stashes[1], true},
+	}
+
+	for _, tt := range videoTests {
+		if got := f.matchVideoExclude(tt.path, tt.stash); got != tt.expected {
+			t.Errorf("matchVideoExclude(%q) = %v, want %v", tt.path, got, tt.expected)
+		}
+	}
+
+	imageTests := []struct {
+		path     string
+		stash    *config.StashConfig
+		expected bool
+	}{
+		{"/stash/videos/$RECYCLE.BIN/image.jpg", stashes[0], true},
+		{"/stash/videos/extras/image.jpg", stashes[0], false},
+		{"/stash/other/$RECYCLE.BIN/image.jpg", stashes[1], false},
+	}
+
+	for _, tt := range imageTests {
+		if got := f.matchImageExclude(tt.path, tt.stash); got != tt.expected {
+			t.Errorf("matchImageExclude(%q) = %v, want %v", tt.path, got, tt.expected)
+		}
+	}
+}
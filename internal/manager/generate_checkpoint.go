@@ -0,0 +1,103 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/paths"
+)
+
+const generatedArtifactBatchSize = 1000
+
+type sceneGeneratedArtifact struct {
+	sceneID  int
+	artifact models.GeneratedArtifact
+}
+
+// generatedArtifactExists returns true if the generated file of type t
+// exists for the provided scene hash.
+func generatedArtifactExists(gp *paths.Paths, t models.GeneratedArtifactType, hash string) bool {
+	if hash == "" {
+		return false
+	}
+
+	p := gp.Scene
+	var paths []string
+	switch t {
+	case models.GeneratedArtifactTypeSprite:
+		paths = []string{p.GetSpriteImageFilePath(hash), p.GetSpriteVttFilePath(hash)}
+	case models.GeneratedArtifactTypePreview:
+		paths = []string{p.GetVideoPreviewPath(hash)}
+	case models.GeneratedArtifactTypeImagePreview:
+		paths = []string{p.GetWebpPreviewPath(hash)}
+	case models.GeneratedArtifactTypeTranscode:
+		paths = []string{p.GetTranscodePath(hash)}
+	case models.GeneratedArtifactTypeInteractiveHeatmap:
+		paths = []string{p.GetInteractiveHeatmapPath(hash)}
+	default:
+		return false
+	}
+
+	for _, path := range paths {
+		if exists, _ := fsutil.FileExists(path); !exists {
+			return false
+		}
+	}
+
+	return true
+}
+
+// artifactGenerated returns true if the artifact of type t is recorded as
+// generated and its files exist. Generated files may be removed without
+// the record being cleared, in which case the artifact must be generated
+// again.
+func artifactGenerated(gp *paths.Paths, generated []models.GeneratedArtifact, t models.GeneratedArtifactType, hash string) bool {
+	return models.FindGeneratedArtifact(generated, t, hash) != nil && generatedArtifactExists(gp, t, hash)
+}
+
+// recordGeneratedArtifact records that the artifact of type t was generated
+// for the scene, so that later generate tasks can skip it. Errors are logged
+// and otherwise ignored.
+func recordGeneratedArtifact(ctx context.Context, r models.Repository, sceneID int, t models.GeneratedArtifactType, hash string) {
+	if hash == "" || r.TxnManager == nil {
+		return
+	}
+
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.Scene.SetGeneratedArtifact(ctx, sceneID, models.GeneratedArtifact{
+			Type:        t,
+			Hash:        hash,
+			GeneratedAt: time.Now(),
+		})
+	}); err != nil && ctx.Err() == nil {
+		logger.Warnf("error recording generated %s for scene %d: %v", t, sceneID, err)
+	}
+}
+
+// recordGeneratedArtifacts records generated artifacts in batches.
+func recordGeneratedArtifacts(ctx context.Context, r models.Repository, artifacts []sceneGeneratedArtifact) {
+	for len(artifacts) > 0 {
+		batch := artifacts
+		if len(batch) > generatedArtifactBatchSize {
+			batch = batch[:generatedArtifactBatchSize]
+		}
+		artifacts = artifacts[len(batch):]
+
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			for _, a := range batch {
+				if err := r.Scene.SetGeneratedArtifact(ctx, a.sceneID, a.artifact); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			if ctx.Err() == nil {
+				logger.Warnf("error recording existing generated files: %v", err)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactGenerated(t *testing.T) {
+	const hash = "hash"
+
+	p := paths.NewPaths(t.TempDir(), "")
+	previewPath := p.Scene.GetVideoPreviewPath(hash)
+
+	recorded := []models.GeneratedArtifact{
+		{Type: models.GeneratedArtifactTypePreview, Hash: hash},
+	}
+
+	// recorded, but the file was deleted
+	assert.False(t, artifactGenerated(&p, recorded, models.GeneratedArtifactTypePreview, hash))
+
+	if err := os.MkdirAll(filepath.Dir(previewPath), 0755); err != nil {
+		t.Fatalf("creating directory: %v", err)
+	}
+	if err := os.WriteFile(previewPath, []byte("preview"), 0644); err != nil {
+		t.Fatalf("writing preview: %v", err)
+	}
+
+	assert.True(t, artifactGenerated(&p, recorded, models.GeneratedArtifactTypePreview, hash))
+
+	// file exists, but was not recorded for this hash or type
+	assert.False(t, artifactGenerated(&p, nil, models.GeneratedArtifactTypePreview, hash))
+	assert.False(t, artifactGenerated(&p, recorded, models.GeneratedArtifactTypePreview, "other"))
+	assert.False(t, artifactGenerated(&p, recorded, models.GeneratedArtifactTypeSprite, hash))
+}
@@ -26,6 +26,9 @@ type SpriteGenerator struct {
 	SlowSeek        bool // use alternate seek function, very slow!
 
 	Overwrite bool
+	// Progress is called with the fraction of the sprite images generated.
+	// May be nil.
+	Progress func(percent float64)
 
 	g *generate.Generator
 }
@@ -79,6 +82,7 @@ func NewSpriteGenerator(videoFile ffmpeg.VideoFile, videoChecksum string, imageO
 			FFMpegConfig: instance.Config,
 			LockManager:  instance.ReadLockManager,
 			ScenePaths:   instance.Paths.Scene,
+			Throttle:     instance.GenerateThrottle,
 		},
 	}, nil
 }
@@ -93,6 +97,12 @@ func (g *SpriteGenerator) Generate() error {
 	return nil
 }
 
+func (g *SpriteGenerator) reportProgress(generated int) {
+	if g.Progress != nil {
+		g.Progress(float64(generated) / float64(g.Info.ChunkCount))
+	}
+}
+
 func (g *SpriteGenerator) generateSpriteImage() error {
 	if !g.Overwrite && g.imageExists() {
 		return nil
@@ -113,6 +123,7 @@ func (g *SpriteGenerator) generateSpriteImage() error {
 				return err
 			}
 			images = append(images, img)
+			g.reportProgress(i + 1)
 		}
 	} else {
 		logger.Infof("[generator] generating sprite image for %s (%d frames)", g.Info.VideoFile.Path, g.Info.VideoFile.FrameCount)
@@ -131,6 +142,7 @@ func (g *SpriteGenerator) generateSpriteImage() error {
 				return err
 			}
 			images = append(images, img)
+			g.reportProgress(i + 1)
 		}
 
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scene/generate"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
@@ -85,6 +86,9 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 		JobManager:      initJobManager(cfg),
 		ReadLockManager: fsutil.NewReadLockManager(),
+		GenerateThrottle: &generate.Throttle{
+			Config: cfg,
+		},
 
 		DownloadStore: NewDownloadStore(),
 
@@ -103,6 +107,9 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		scanSubs: &subscriptionManager{},
 	}
 
+	mgr.startTaskNotifications(context.Background())
+	mgr.startJobLogs()
+
 	if !cfg.IsNewSystem() {
 		logger.Infof("using config file: %s", cfg.GetConfigFile())
 
@@ -185,6 +192,7 @@ func (s *Manager) postInit(ctx context.Context) error {
 
 	s.SessionStore = session.NewStore(s.Config)
 	s.PluginCache.RegisterSessionStore(s.SessionStore)
+	s.PluginCache.RegisterPostHookListener(s.recordAuditEntry)
 
 	s.RefreshPluginCache()
 	s.RefreshPluginSourceManager()
@@ -221,6 +229,7 @@ func (s *Manager) postInit(ctx context.Context) error {
 		})
 	}
 
+	s.SetDatabaseOptions()
 	if err := s.Database.Open(s.Config.GetDatabasePath()); err != nil {
 		var migrationNeededErr *sqlite.MigrationNeededError
 		if errors.As(err, &migrationNeededErr) {
@@ -240,6 +249,8 @@ func (s *Manager) postInit(ctx context.Context) error {
 
 	s.RefreshFFMpeg(ctx)
 	s.RefreshStreamManager()
+	s.RefreshLibraryWatcher()
+	s.RefreshScheduler()
 
 	return nil
 }
@@ -304,6 +315,7 @@ func (s *Manager) RefreshFFMpeg(ctx context.Context) {
 		logger.Debugf("using ffmpeg: %s", ffmpegPath)
 		logger.Debugf("using ffprobe: %s", ffprobePath)
 
+		ffmpeg.SetProcessConfig(s.Config)
 		s.FFMpeg = ffmpeg.NewEncoder(ffmpegPath)
 		s.FFProbe = ffmpeg.FFProbe(ffprobePath)
 
@@ -0,0 +1,78 @@
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/job"
+)
+
+// liveTranscodeMonitor starts a job for each live transcode, so that the
+// progress of live transcodes is reported with other jobs. Cancelling the job
+// stops the transcode.
+type liveTranscodeMonitor struct {
+	jobManager *job.Manager
+}
+
+func (m liveTranscodeMonitor) TranscodeStarted(description string, cancel func()) ffmpeg.TranscodeProgress {
+	j := &liveTranscodeJob{
+		cancel:  cancel,
+		percent: job.ProgressIndefinite,
+		updated: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	m.jobManager.Start(context.Background(), description, j)
+
+	return j
+}
+
+type liveTranscodeJob struct {
+	cancel func()
+
+	mutex   sync.Mutex
+	percent float64
+	updated chan struct{}
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func (j *liveTranscodeJob) SetPercent(percent float64) {
+	j.mutex.Lock()
+	j.percent = percent
+	j.mutex.Unlock()
+
+	// don't block if an update is already pending
+	select {
+	case j.updated <- struct{}{}:
+	default:
+	}
+}
+
+func (j *liveTranscodeJob) Done() {
+	j.doneOnce.Do(func() {
+		close(j.done)
+	})
+}
+
+func (j *liveTranscodeJob) Execute(ctx context.Context, progress *job.Progress) error {
+	for {
+		select {
+		case <-j.updated:
+			j.mutex.Lock()
+			percent := j.percent
+			j.mutex.Unlock()
+
+			if percent >= 0 {
+				progress.SetPercent(percent)
+			}
+		case <-j.done:
+			return nil
+		case <-ctx.Done():
+			j.cancel()
+			return nil
+		}
+	}
+}
@@ -8,13 +8,16 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
 	"github.com/stashapp/stash/internal/dlna"
 	"github.com/stashapp/stash/internal/log"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/cron"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -22,6 +25,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/pkg"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scene/generate"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
@@ -46,6 +50,8 @@ type Manager struct {
 
 	JobManager      *job.Manager
 	ReadLockManager *fsutil.ReadLockManager
+	// GenerateThrottle limits the resources used by ffmpeg when generating.
+	GenerateThrottle *generate.Throttle
 
 	DownloadStore *DownloadStore
 	SessionStore  *session.Store
@@ -58,6 +64,36 @@ type Manager struct {
 
 	DLNAService *dlna.Service
 
+	libraryWatcher      *file.Watcher
+	libraryWatcherMutex sync.Mutex
+
+	scheduler      *cron.Scheduler
+	scheduledTasks []*config.ScheduledTask
+	schedulerMutex sync.Mutex
+
+	scheduledTaskRunsMutex sync.Mutex
+
+	cleanReport      *CleanReport
+	cleanReportMutex sync.Mutex
+
+	duplicateReport      *DuplicateReport
+	duplicateReportMutex sync.Mutex
+
+	integrityReport      *IntegrityReport
+	integrityReportMutex sync.Mutex
+
+	optimiseReport      *OptimiseDatabaseReport
+	optimiseReportMutex sync.Mutex
+
+	pausableJobs    map[int]PausedJob
+	pausedJobs      []PausedJob
+	pausedJobsMutex sync.Mutex
+
+	jobTaskTypes      map[int]config.NotificationTaskType
+	jobTaskTypesMutex sync.Mutex
+
+	jobLogger *jobLogger
+
 	Database   *sqlite.Database
 	Repository models.Repository
 
@@ -90,6 +126,18 @@ func (s *Manager) SetBlobStoreOptions() {
 	})
 }
 
+// SetDatabaseOptions sets the database connection options from the
+// configuration. The options take effect when the database is next opened.
+func (s *Manager) SetDatabaseOptions() {
+	s.Database.SetOptions(sqlite.DatabaseOptions{
+		BusyTimeout:        time.Duration(s.Config.GetDatabaseBusyTimeout()) * time.Millisecond,
+		CacheSize:          s.Config.GetDatabaseCacheSize(),
+		Synchronous:        s.Config.GetDatabaseSynchronous(),
+		SlowQueryThreshold: time.Duration(s.Config.GetDatabaseSlowQueryThreshold()) * time.Millisecond,
+		EncryptionKey:      s.Config.GetDatabaseEncryptionKey(),
+	})
+}
+
 func (s *Manager) RefreshConfig() {
 	cfg := s.Config
 	*s.Paths = paths.NewPaths(cfg.GetGeneratedPath(), cfg.GetBlobsPath())
@@ -140,7 +188,8 @@ func (s *Manager) RefreshStreamManager() {
 
 	cfg := s.Config
 	cacheDir := cfg.GetCachePath()
-	s.StreamManager = ffmpeg.NewStreamManager(cacheDir, s.FFMpeg, s.FFProbe, cfg, s.ReadLockManager)
+	monitor := liveTranscodeMonitor{jobManager: s.JobManager}
+	s.StreamManager = ffmpeg.NewStreamManager(cacheDir, s.FFMpeg, s.FFProbe, cfg, s.ReadLockManager, monitor)
 }
 
 // RefreshDLNA starts/stops the DLNA service as needed.
@@ -156,6 +205,101 @@ func (s *Manager) RefreshDLNA() {
 	}
 }
 
+// RefreshLibraryWatcher starts/stops watching the library paths for changes
+// as needed. Call this when the stash paths or watch settings change.
+func (s *Manager) RefreshLibraryWatcher() {
+	s.libraryWatcherMutex.Lock()
+	defer s.libraryWatcherMutex.Unlock()
+
+	if s.libraryWatcher != nil {
+		s.libraryWatcher.Stop()
+		s.libraryWatcher = nil
+	}
+
+	if !s.Config.GetWatchLibrary() {
+		return
+	}
+
+	var paths []string
+	for _, p := range s.Config.GetStashPaths() {
+		paths = append(paths, p.Path)
+	}
+
+	if len(paths) == 0 {
+		return
+	}
+
+	debounce := time.Duration(s.Config.GetWatchLibraryDebounce()) * time.Second
+	w := file.NewWatcher(debounce, s.libraryChanged)
+	if err := w.Start(paths); err != nil {
+		logger.Warnf("error watching library paths: %v", err)
+		return
+	}
+
+	logger.Infof("Watching %d library paths for changes", len(paths))
+	s.libraryWatcher = w
+}
+
+// libraryChanged queues a scan of the changed directories, followed by a
+// clean of directories from which files were removed.
+func (s *Manager) libraryChanged(changes file.WatchChanges) {
+	ctx := context.Background()
+
+	scanPaths := append(append([]string{}, changes.Changed...), changes.Removed...)
+	if len(scanPaths) > 0 {
+		logger.Infof("Detected changes in library, scanning %d directories", len(scanPaths))
+
+		input := ScanMetadataInput{
+			Paths: scanPaths,
+		}
+		if defaults := s.Config.GetDefaultScanSettings(); defaults != nil {
+			input.ScanMetadataOptions = *defaults
+		}
+
+		if _, err := s.Scan(ctx, input); err != nil {
+			logger.Warnf("error queuing scan of changed library paths: %v", err)
+		}
+	}
+
+	if len(changes.Removed) > 0 {
+		s.Clean(ctx, CleanMetadataInput{
+			Paths: changes.Removed,
+		})
+	}
+}
+
+// CleanDryRunReport returns the report of the most recent clean dry run.
+// Returns nil if no dry run has completed.
+func (s *Manager) CleanDryRunReport() *CleanReport {
+	s.cleanReportMutex.Lock()
+	defer s.cleanReportMutex.Unlock()
+
+	return s.cleanReport
+}
+
+func (s *Manager) setCleanReport(report *CleanReport) {
+	s.cleanReportMutex.Lock()
+	defer s.cleanReportMutex.Unlock()
+
+	s.cleanReport = report
+}
+
+// OptimiseDatabaseReport returns the report of the most recent optimise
+// database task. Returns nil if the task has not completed.
+func (s *Manager) OptimiseDatabaseReport() *OptimiseDatabaseReport {
+	s.optimiseReportMutex.Lock()
+	defer s.optimiseReportMutex.Unlock()
+
+	return s.optimiseReport
+}
+
+func (s *Manager) setOptimiseDatabaseReport(report *OptimiseDatabaseReport) {
+	s.optimiseReportMutex.Lock()
+	defer s.optimiseReportMutex.Unlock()
+
+	s.optimiseReport = report
+}
+
 func createPackageManager(localPath string, srcPathGetter pkg.SourcePathGetter) *pkg.Manager {
 	const timeout = 10 * time.Second
 	httpClient := &http.Client{
@@ -335,9 +479,78 @@ func (s *Manager) BackupDatabase(download bool) (string, string, error) {
 		return "", "", err
 	}
 
+	if !download {
+		if err := s.Database.PruneBackups(filepath.Dir(backupPath), s.Config.GetBackupRetention()); err != nil {
+			logger.Warnf("error deleting old database backups: %v", err)
+		}
+	}
+
 	return backupPath, backupName, nil
 }
 
+// RestoreDatabase replaces the database with the backup at backupPath. If
+// backupPath is empty, the backup made before the most recent migration is
+// used. Otherwise, backupPath must be a file in the backup directory, and a
+// filename without a directory is resolved against the backup directory.
+// Fails if any jobs are running.
+func (s *Manager) RestoreDatabase(backupPath string) error {
+	if backupPath == "" {
+		backupPath = s.Config.GetMigrationBackupPath()
+		if backupPath == "" {
+			return errors.New("no backup path provided and no migration backup recorded")
+		}
+	} else {
+		var err error
+		backupPath, err = resolveBackupPath(s.Config.GetBackupDirectoryPathOrDefault(), backupPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, j := range s.JobManager.GetQueue() {
+		if j.Status == job.StatusReady || j.Status == job.StatusRunning || j.Status == job.StatusStopping {
+			return errors.New("cannot restore the database while tasks are running")
+		}
+	}
+
+	if err := s.Database.Restore(backupPath); err != nil {
+		var migrationNeededErr *sqlite.MigrationNeededError
+		if !errors.As(err, &migrationNeededErr) {
+			return err
+		}
+
+		// the restored database will be migrated via the UI
+		logger.Warn(err)
+	}
+
+	logger.Infof("Restored database from %s", backupPath)
+	return nil
+}
+
+// resolveBackupPath returns the absolute path of the backup at backupPath.
+// Returns an error if the backup is not within backupDir.
+func resolveBackupPath(backupDir string, backupPath string) (string, error) {
+	if filepath.Base(backupPath) == backupPath {
+		backupPath = filepath.Join(backupDir, backupPath)
+	}
+
+	absDir, err := filepath.Abs(backupDir)
+	if err != nil {
+		return "", err
+	}
+
+	absPath, err := filepath.Abs(backupPath)
+	if err != nil {
+		return "", err
+	}
+
+	if absPath == absDir || !fsutil.IsPathInDir(absDir, absPath) {
+		return "", fmt.Errorf("backup %s is not in the backup directory %s", backupPath, backupDir)
+	}
+
+	return absPath, nil
+}
+
 func (s *Manager) AnonymiseDatabase(download bool) (string, string, error) {
 	var outPath string
 	var outName string
@@ -401,7 +614,7 @@ func (s *Manager) GetSystemStatus() *SystemStatus {
 		ffprobePath = s.FFProbe.Path()
 	}
 
-	return &SystemStatus{
+	ret := &SystemStatus{
 		Os:             runtime.GOOS,
 		WorkingDir:     workingDir,
 		HomeDir:        homeDir,
@@ -413,17 +626,47 @@ func (s *Manager) GetSystemStatus() *SystemStatus {
 		FfmpegPath:     &ffmpegPath,
 		FfprobePath:    &ffprobePath,
 	}
+
+	if s.FFMpeg != nil {
+		if version := s.FFMpeg.Version().String(); version != "" {
+			ret.FfmpegVersion = &version
+		}
+
+		for _, codec := range s.FFMpeg.HardwareCodecs() {
+			ret.FfmpegHardwareCodecs = append(ret.FfmpegHardwareCodecs, string(codec))
+		}
+	}
+
+	if migrationBackupPath := s.Config.GetMigrationBackupPath(); migrationBackupPath != "" {
+		ret.MigrationBackupPath = &migrationBackupPath
+	}
+
+	return ret
 }
 
 // Shutdown gracefully stops the manager
 func (s *Manager) Shutdown() {
 	// TODO: Each part of the manager needs to gracefully stop at some point
 
+	s.libraryWatcherMutex.Lock()
+	if s.libraryWatcher != nil {
+		s.libraryWatcher.Stop()
+		s.libraryWatcher = nil
+	}
+	s.libraryWatcherMutex.Unlock()
+
+	s.stopScheduler()
+
 	if s.StreamManager != nil {
 		s.StreamManager.Shutdown()
 		s.StreamManager = nil
 	}
 
+	// kill any ffmpeg processes not stopped by their owners
+	ffmpeg.ShutdownProcesses()
+
+	removeFrameCache()
+
 	err := s.Database.Close()
 	if err != nil {
 		logger.Errorf("Error closing database: %s", err)
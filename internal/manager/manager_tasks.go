@@ -9,12 +9,14 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/internal/manager/task"
 	"github.com/stashapp/stash/pkg/file"
 	file_image "github.com/stashapp/stash/pkg/file/image"
 	"github.com/stashapp/stash/pkg/file/video"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -126,12 +128,15 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		subscriptions: s.scanSubs,
 	}
 
-	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
+	return s.addPausableJob(ctx, "Scanning...", &scanJob, config.NotificationTaskTypeScan, PausedJob{
+		Type: PausedJobTypeScan,
+		Scan: &input,
+	}), nil
 }
 
 func (s *Manager) Import(ctx context.Context) (int, error) {
-	config := config.GetInstance()
-	metadataPath := config.GetMetadataPath()
+	cfg := config.GetInstance()
+	metadataPath := cfg.GetMetadataPath()
 	if metadataPath == "" {
 		return 0, errors.New("metadata path must be set in config")
 	}
@@ -144,7 +149,7 @@ func (s *Manager) Import(ctx context.Context) (int, error) {
 			Reset:               true,
 			DuplicateBehaviour:  ImportDuplicateEnumFail,
 			MissingRefBehaviour: models.ImportMissingRefEnumFail,
-			fileNamingAlgorithm: config.GetVideoFileNamingAlgorithm(),
+			fileNamingAlgorithm: cfg.GetVideoFileNamingAlgorithm(),
 		}
 		task.Start(ctx)
 
@@ -152,12 +157,14 @@ func (s *Manager) Import(ctx context.Context) (int, error) {
 		return nil
 	})
 
-	return s.JobManager.Add(ctx, "Importing...", j), nil
+	jobID := s.AddJob(ctx, "Importing...", j, config.NotificationTaskTypeImport)
+
+	return jobID, nil
 }
 
 func (s *Manager) Export(ctx context.Context) (int, error) {
-	config := config.GetInstance()
-	metadataPath := config.GetMetadataPath()
+	cfg := config.GetInstance()
+	metadataPath := cfg.GetMetadataPath()
 	if metadataPath == "" {
 		return 0, errors.New("metadata path must be set in config")
 	}
@@ -168,14 +175,16 @@ func (s *Manager) Export(ctx context.Context) (int, error) {
 		task := ExportTask{
 			repository:          s.Repository,
 			full:                true,
-			fileNamingAlgorithm: config.GetVideoFileNamingAlgorithm(),
+			fileNamingAlgorithm: cfg.GetVideoFileNamingAlgorithm(),
 		}
 		task.Start(ctx, &wg)
 		// TODO - return error from task
 		return nil
 	})
 
-	return s.JobManager.Add(ctx, "Exporting...", j), nil
+	jobID := s.AddJob(ctx, "Exporting...", j, config.NotificationTaskTypeExport)
+
+	return jobID, nil
 }
 
 func (s *Manager) RunSingleTask(ctx context.Context, t Task) int {
@@ -205,7 +214,55 @@ func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (in
 		input:      input,
 	}
 
-	return s.JobManager.Add(ctx, "Generating...", j), nil
+	return s.addPausableJob(ctx, "Generating...", j, config.NotificationTaskTypeGenerate, PausedJob{
+		Type:     PausedJobTypeGenerate,
+		Generate: &input,
+	}), nil
+}
+
+// GenerateMarkerPreviews queues generation of the preview files for the
+// provided scene marker. The marker options of the default generate settings
+// are used. Nothing is generated if markers are not enabled in the defaults.
+func (s *Manager) GenerateMarkerPreviews(ctx context.Context, markerID int) {
+	defaults := s.Config.GetDefaultGenerateSettings()
+	if defaults == nil || !defaults.Markers {
+		return
+	}
+
+	input := GenerateMetadataInput{
+		Markers:             true,
+		MarkerImagePreviews: defaults.MarkerImagePreviews,
+		MarkerScreenshots:   defaults.MarkerScreenshots,
+		MarkerIDs:           []string{strconv.Itoa(markerID)},
+	}
+
+	if _, err := s.Generate(ctx, input); err != nil {
+		logger.Warnf("could not generate previews for marker %d: %v", markerID, err)
+	}
+}
+
+// GenerateScenes queues generation of the content enabled in the default
+// generate settings for the provided scenes. Existing files are not
+// overwritten.
+func (s *Manager) GenerateScenes(ctx context.Context, sceneIDs []int) {
+	if len(sceneIDs) == 0 {
+		return
+	}
+
+	defaults := s.Config.GetDefaultGenerateSettings()
+	if defaults == nil {
+		logger.Warnf("not generating content for scenes: default generate settings not set")
+		return
+	}
+
+	input := generateInputFromOptions(*defaults)
+	for _, id := range sceneIDs {
+		input.SceneIDs = append(input.SceneIDs, strconv.Itoa(id))
+	}
+
+	if _, err := s.Generate(ctx, input); err != nil {
+		logger.Warnf("could not generate content for scenes: %v", err)
+	}
 }
 
 func (s *Manager) GenerateDefaultScreenshot(ctx context.Context, sceneId string) int {
@@ -243,6 +300,15 @@ func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *fl
 			return fmt.Errorf("error finding scene for screenshot generation: %w", err)
 		}
 
+		videoFile := scene.Files.Primary()
+		if videoFile == nil {
+			return fmt.Errorf("scene with id %s has no video file", sceneId)
+		}
+
+		if at != nil && (*at < 0 || *at > videoFile.Duration) {
+			return fmt.Errorf("screenshot time %v is outside of the video duration %v", *at, videoFile.Duration)
+		}
+
 		task := GenerateCoverTask{
 			repository:   s.Repository,
 			Scene:        *scene,
@@ -254,7 +320,6 @@ func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *fl
 
 		logger.Infof("Generate screenshot finished")
 
-		// TODO - return error from task
 		return nil
 	})
 
@@ -270,15 +335,36 @@ type AutoTagMetadataInput struct {
 	Studios []string `json:"studios"`
 	// IDs of tags to tag files with, or "*" for all
 	Tags []string `json:"tags"`
+	// IDs of scenes, images and galleries to tag. If any are set, only the
+	// selected objects are tagged, and paths is ignored.
+	SceneIDs   []string `json:"sceneIDs"`
+	ImageIDs   []string `json:"imageIDs"`
+	GalleryIDs []string `json:"galleryIDs"`
+}
+
+func (i AutoTagMetadataInput) hasSelection() bool {
+	return len(i.SceneIDs) > 0 || len(i.ImageIDs) > 0 || len(i.GalleryIDs) > 0
+}
+
+// NewAutoTagCache returns a match cache configured using the auto-tag
+// settings. The same cache settings must be used when previewing auto-tag
+// matches, so that the preview agrees with the auto-tag task.
+func (s *Manager) NewAutoTagCache() *match.Cache {
+	return &match.Cache{
+		PerformerAliases: s.Config.GetAutoTagPerformerAliases(),
+	}
 }
 
 func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
 	j := autoTagJob{
 		repository: s.Repository,
 		input:      input,
+		cache:      s.NewAutoTagCache(),
 	}
 
-	return s.JobManager.Add(ctx, "Auto-tagging...", &j)
+	jobID := s.AddJob(ctx, "Auto-tagging...", &j, config.NotificationTaskTypeAutoTag)
+
+	return jobID
 }
 
 type CleanMetadataInput struct {
@@ -305,63 +391,145 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		scanSubs:     s.scanSubs,
 	}
 
-	return s.JobManager.Add(ctx, "Cleaning...", &j)
+	jobID := s.AddJob(ctx, "Cleaning...", &j, config.NotificationTaskTypeClean)
+
+	return jobID
 }
 
-func (s *Manager) OptimiseDatabase(ctx context.Context) int {
+func (s *Manager) FindDuplicates(ctx context.Context, input FindDuplicatesMetadataInput) int {
+	j := &findDuplicatesJob{
+		repository: s.Repository,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Finding duplicates...", j)
+}
+
+func (s *Manager) OptimiseDatabase(ctx context.Context, input OptimiseDatabaseInput) int {
 	j := OptimiseDatabaseJob{
 		Optimiser: s.Database,
+		Input:     input,
 	}
 
 	return s.JobManager.Add(ctx, "Optimising database...", &j)
 }
 
 func (s *Manager) MigrateHash(ctx context.Context) int {
-	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
-		fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
-		logger.Infof("Migrating generated files for %s naming hash", fileNamingAlgo.String())
+	j := job.MakeJobExec(s.migrateHashNaming)
 
-		var scenes []*models.Scene
-		if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
-			var err error
-			scenes, err = s.Repository.Scene.All(ctx)
-			return err
-		}); err != nil {
-			return fmt.Errorf("failed to fetch list of scenes for migration: %w", err)
+	return s.JobManager.Add(ctx, "Migrating scene hashes...", j)
+}
+
+func (s *Manager) migrateHashNaming(ctx context.Context, progress *job.Progress) error {
+	fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
+	logger.Infof("Migrating generated files for %s naming hash", fileNamingAlgo.String())
+
+	var scenes []*models.Scene
+	if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		var err error
+		scenes, err = s.Repository.Scene.All(ctx)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to fetch list of scenes for migration: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	total := len(scenes)
+	progress.SetTotal(total)
+
+	for _, scene := range scenes {
+		progress.Increment()
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
 		}
 
-		var wg sync.WaitGroup
-		total := len(scenes)
-		progress.SetTotal(total)
+		if scene == nil {
+			logger.Errorf("nil scene, skipping migrate")
+			continue
+		}
 
-		for _, scene := range scenes {
-			progress.Increment()
-			if job.IsCancelled(ctx) {
-				logger.Info("Stopping due to user request")
-				return nil
-			}
+		wg.Add(1)
 
-			if scene == nil {
-				logger.Errorf("nil scene, skipping migrate")
-				continue
+		t := MigrateHashTask{Scene: scene, fileNamingAlgorithm: fileNamingAlgo}
+		go func() {
+			t.Start()
+			wg.Done()
+		}()
+
+		wg.Wait()
+	}
+
+	logger.Info("Finished migrating")
+	return nil
+}
+
+type MigrateGeneratedInput struct {
+	// Previous generated directory to move generated files from. If not set,
+	// generated files are not moved.
+	OldGeneratedPath *string `json:"oldGeneratedPath"`
+	// Rename generated files to match the current file naming hash. Defaults
+	// to true.
+	MigrateHashNaming *bool `json:"migrateHashNaming"`
+	// Delete generated files that do not belong to any scene, marker or image
+	// after migrating. Defaults to false.
+	CleanOrphans *bool `json:"cleanOrphans"`
+	// Log orphaned files instead of deleting them
+	DryRun *bool `json:"dryRun"`
+}
+
+// MigrateGenerated queues a job to migrate generated files after the
+// generated path or the file naming hash has changed. Files are moved from
+// the old generated directory, renamed to the current naming hash and
+// optionally cleaned of orphaned files, in that order.
+func (s *Manager) MigrateGenerated(ctx context.Context, input MigrateGeneratedInput) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		if input.OldGeneratedPath != nil && *input.OldGeneratedPath != "" {
+			relocate := &task.RelocateGeneratedJob{
+				OldPath: *input.OldGeneratedPath,
+				NewPath: s.Config.GetGeneratedPath(),
 			}
+			for _, stash := range s.Config.GetStashPaths() {
+				relocate.LibraryPaths = append(relocate.LibraryPaths, stash.Path)
+			}
+			if err := relocate.Execute(ctx, progress); err != nil {
+				return err
+			}
+		}
 
-			wg.Add(1)
+		if job.IsCancelled(ctx) {
+			return nil
+		}
 
-			task := MigrateHashTask{Scene: scene, fileNamingAlgorithm: fileNamingAlgo}
-			go func() {
-				task.Start()
-				wg.Done()
-			}()
+		if input.MigrateHashNaming == nil || *input.MigrateHashNaming {
+			if err := s.migrateHashNaming(ctx, progress); err != nil {
+				return err
+			}
+		}
 
-			wg.Wait()
+		if job.IsCancelled(ctx) || input.CleanOrphans == nil || !*input.CleanOrphans {
+			return nil
 		}
 
-		logger.Info("Finished migrating")
-		return nil
+		clean := &task.CleanGeneratedJob{
+			Options: task.CleanGeneratedOptions{
+				Sprites:         true,
+				Screenshots:     true,
+				Transcodes:      true,
+				Markers:         true,
+				ImageThumbnails: true,
+				DryRun:          input.DryRun != nil && *input.DryRun,
+			},
+			Paths:                    s.Paths,
+			BlobsStorageType:         s.Config.GetBlobsStorage(),
+			VideoFileNamingAlgorithm: s.Config.GetVideoFileNamingAlgorithm(),
+			Repository:               s.Repository,
+			BlobCleaner:              s.Repository.Blob,
+		}
+		return clean.Execute(ctx, progress)
 	})
 
-	return s.JobManager.Add(ctx, "Migrating scene hashes...", j)
+	return s.JobManager.Add(ctx, "Migrating generated files...", j)
 }
 
 // If neither ids nor names are set, tag all items
@@ -0,0 +1,20 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAutoTagCache(t *testing.T) {
+	cfg := config.InitializeEmpty()
+	s := &Manager{
+		Config: cfg,
+	}
+
+	assert.False(t, s.NewAutoTagCache().PerformerAliases)
+
+	cfg.SetBool(config.AutoTagPerformerAliases, true)
+	assert.True(t, s.NewAutoTagCache().PerformerAliases)
+}
@@ -0,0 +1,73 @@
+package manager
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshLibraryWatcher(t *testing.T) {
+	cfg := config.InitializeEmpty()
+	s := &Manager{
+		Config: cfg,
+	}
+
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+
+	setStashPaths := func(paths ...string) {
+		var stashes []*config.StashConfigInput
+		for _, p := range paths {
+			stashes = append(stashes, &config.StashConfigInput{Path: p})
+		}
+		cfg.SetInterface(config.Stash, stashes)
+	}
+
+	watchedPaths := func() []string {
+		s.libraryWatcherMutex.Lock()
+		defer s.libraryWatcherMutex.Unlock()
+
+		if s.libraryWatcher == nil {
+			return nil
+		}
+		return s.libraryWatcher.Paths()
+	}
+
+	setStashPaths(dir1)
+
+	// not watched unless enabled
+	s.RefreshLibraryWatcher()
+	assert.Nil(t, watchedPaths())
+
+	cfg.SetBool(config.WatchLibrary, true)
+	s.RefreshLibraryWatcher()
+	assert.Equal(t, []string{dir1}, watchedPaths())
+
+	// add a path
+	setStashPaths(dir1, dir2)
+	s.RefreshLibraryWatcher()
+	assert.Equal(t, []string{dir1, dir2}, watchedPaths())
+
+	// remove a path
+	setStashPaths(dir2)
+	s.RefreshLibraryWatcher()
+	assert.Equal(t, []string{dir2}, watchedPaths())
+
+	// concurrent refreshes must leave a single watcher running
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.RefreshLibraryWatcher()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []string{dir2}, watchedPaths())
+
+	setStashPaths()
+	s.RefreshLibraryWatcher()
+	assert.Nil(t, watchedPaths())
+}
@@ -15,6 +15,12 @@ type SystemStatus struct {
 	HomeDir        string           `json:"home_dir"`
 	FfmpegPath     *string          `json:"ffmpegPath"`
 	FfprobePath    *string          `json:"ffprobePath"`
+	// Detected version of ffmpeg
+	FfmpegVersion *string `json:"ffmpegVersion"`
+	// Hardware encoders that ffmpeg was found to support
+	FfmpegHardwareCodecs []string `json:"ffmpegHardwareCodecs"`
+	// Path of the backup made before the most recent migration
+	MigrationBackupPath *string `json:"migrationBackupPath"`
 }
 
 type SetupInput struct {
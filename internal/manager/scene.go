@@ -44,11 +44,21 @@ var (
 		mimeType:  ffmpeg.MimeWebmVideo,
 		extension: ".webm",
 	}
+	webmAV1EndpointType = endpointType{
+		label:     "WEBM AV1",
+		mimeType:  ffmpeg.MimeWebmVideo,
+		extension: "_av1.webm",
+	}
 	hlsEndpointType = endpointType{
 		label:     "HLS",
 		mimeType:  ffmpeg.MimeHLS,
 		extension: ".m3u8",
 	}
+	hlsMasterEndpointType = endpointType{
+		label:     "HLS Adaptive",
+		mimeType:  ffmpeg.MimeHLS,
+		extension: "_master.m3u8",
+	}
 	dashEndpointType = endpointType{
 		label:     "DASH",
 		mimeType:  ffmpeg.MimeDASH,
@@ -166,12 +176,14 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 
 	mp4Streams := []*SceneStreamEndpoint{}
 	webmStreams := []*SceneStreamEndpoint{}
+	webmAV1Streams := []*SceneStreamEndpoint{}
 	hlsStreams := []*SceneStreamEndpoint{}
 	dashStreams := []*SceneStreamEndpoint{}
 
 	if includeSceneStreamPath(models.StreamingResolutionEnumOriginal) {
 		mp4Streams = append(mp4Streams, makeStreamEndpoint(mp4EndpointType, models.StreamingResolutionEnumOriginal))
 		webmStreams = append(webmStreams, makeStreamEndpoint(webmEndpointType, models.StreamingResolutionEnumOriginal))
+		webmAV1Streams = append(webmAV1Streams, makeStreamEndpoint(webmAV1EndpointType, models.StreamingResolutionEnumOriginal))
 		hlsStreams = append(hlsStreams, makeStreamEndpoint(hlsEndpointType, models.StreamingResolutionEnumOriginal))
 		dashStreams = append(dashStreams, makeStreamEndpoint(dashEndpointType, models.StreamingResolutionEnumOriginal))
 	}
@@ -179,6 +191,7 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	if includeSceneStreamPath(models.StreamingResolutionEnumFourK) {
 		mp4Streams = append(mp4Streams, makeStreamEndpoint(mp4EndpointType, models.StreamingResolutionEnumFourK))
 		webmStreams = append(webmStreams, makeStreamEndpoint(webmEndpointType, models.StreamingResolutionEnumFourK))
+		webmAV1Streams = append(webmAV1Streams, makeStreamEndpoint(webmAV1EndpointType, models.StreamingResolutionEnumFourK))
 		hlsStreams = append(hlsStreams, makeStreamEndpoint(hlsEndpointType, models.StreamingResolutionEnumFourK))
 		dashStreams = append(dashStreams, makeStreamEndpoint(dashEndpointType, models.StreamingResolutionEnumFourK))
 	}
@@ -186,6 +199,7 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	if includeSceneStreamPath(models.StreamingResolutionEnumFullHd) {
 		mp4Streams = append(mp4Streams, makeStreamEndpoint(mp4EndpointType, models.StreamingResolutionEnumFullHd))
 		webmStreams = append(webmStreams, makeStreamEndpoint(webmEndpointType, models.StreamingResolutionEnumFullHd))
+		webmAV1Streams = append(webmAV1Streams, makeStreamEndpoint(webmAV1EndpointType, models.StreamingResolutionEnumFullHd))
 		hlsStreams = append(hlsStreams, makeStreamEndpoint(hlsEndpointType, models.StreamingResolutionEnumFullHd))
 		dashStreams = append(dashStreams, makeStreamEndpoint(dashEndpointType, models.StreamingResolutionEnumFullHd))
 	}
@@ -193,6 +207,7 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	if includeSceneStreamPath(models.StreamingResolutionEnumStandardHd) {
 		mp4Streams = append(mp4Streams, makeStreamEndpoint(mp4EndpointType, models.StreamingResolutionEnumStandardHd))
 		webmStreams = append(webmStreams, makeStreamEndpoint(webmEndpointType, models.StreamingResolutionEnumStandardHd))
+		webmAV1Streams = append(webmAV1Streams, makeStreamEndpoint(webmAV1EndpointType, models.StreamingResolutionEnumStandardHd))
 		hlsStreams = append(hlsStreams, makeStreamEndpoint(hlsEndpointType, models.StreamingResolutionEnumStandardHd))
 		dashStreams = append(dashStreams, makeStreamEndpoint(dashEndpointType, models.StreamingResolutionEnumStandardHd))
 	}
@@ -200,6 +215,7 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	if includeSceneStreamPath(models.StreamingResolutionEnumStandard) {
 		mp4Streams = append(mp4Streams, makeStreamEndpoint(mp4EndpointType, models.StreamingResolutionEnumStandard))
 		webmStreams = append(webmStreams, makeStreamEndpoint(webmEndpointType, models.StreamingResolutionEnumStandard))
+		webmAV1Streams = append(webmAV1Streams, makeStreamEndpoint(webmAV1EndpointType, models.StreamingResolutionEnumStandard))
 		hlsStreams = append(hlsStreams, makeStreamEndpoint(hlsEndpointType, models.StreamingResolutionEnumStandard))
 		dashStreams = append(dashStreams, makeStreamEndpoint(dashEndpointType, models.StreamingResolutionEnumStandard))
 	}
@@ -207,12 +223,18 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	if includeSceneStreamPath(models.StreamingResolutionEnumLow) {
 		mp4Streams = append(mp4Streams, makeStreamEndpoint(mp4EndpointType, models.StreamingResolutionEnumLow))
 		webmStreams = append(webmStreams, makeStreamEndpoint(webmEndpointType, models.StreamingResolutionEnumLow))
+		webmAV1Streams = append(webmAV1Streams, makeStreamEndpoint(webmAV1EndpointType, models.StreamingResolutionEnumLow))
 		hlsStreams = append(hlsStreams, makeStreamEndpoint(hlsEndpointType, models.StreamingResolutionEnumLow))
 		dashStreams = append(dashStreams, makeStreamEndpoint(dashEndpointType, models.StreamingResolutionEnumLow))
 	}
 
 	endpoints = append(endpoints, mp4Streams...)
 	endpoints = append(endpoints, webmStreams...)
+	endpoints = append(endpoints, webmAV1Streams...)
+	// the master playlist lists the HLS streams as renditions
+	if len(hlsStreams) > 0 {
+		endpoints = append(endpoints, makeStreamEndpoint(hlsMasterEndpointType, ""))
+	}
 	endpoints = append(endpoints, hlsStreams...)
 	endpoints = append(endpoints, dashStreams...)
 
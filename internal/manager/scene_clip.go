@@ -0,0 +1,148 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+type ExtractClipOptions struct {
+	// Start and End of the clip in seconds
+	Start float64
+	End   float64
+	// Reencode re-encodes the clip so that it starts exactly at Start
+	Reencode bool
+	// CreateScene writes the clip next to the scene file and scans it as a
+	// new scene, instead of writing it to the downloads directory
+	CreateScene bool
+}
+
+func (o ExtractClipOptions) validate(f *models.VideoFile) error {
+	if o.Start < 0 {
+		return errors.New("start must not be negative")
+	}
+	if o.End <= o.Start {
+		return errors.New("end must be after start")
+	}
+	if f.Duration > 0 && o.Start >= f.Duration {
+		return errors.New("start must be before the end of the scene")
+	}
+
+	return nil
+}
+
+// clipName returns the file name of the clip of path, without an extension.
+func clipName(path string, start float64, end float64) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return fmt.Sprintf("%s_clip_%d-%d", base, int(start), int(end))
+}
+
+// ExtractClip validates options and queues a job that extracts a clip of
+// the primary file of the scene. Returns the ID of the job.
+//
+// If options.CreateScene is true, the clip is written to the directory of the
+// scene file and a scan of the clip is queued, which adds it as a new scene.
+// The result of the job is the path of the clip file. Otherwise the clip is
+// written to the downloads directory, and the result of the job is a link to
+// download it, prefixed with baseURL.
+func (s *Manager) ExtractClip(ctx context.Context, scene *models.Scene, options ExtractClipOptions, baseURL string) (int, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return 0, err
+	}
+
+	f := scene.Files.Primary()
+	if f == nil {
+		return 0, fmt.Errorf("scene %d has no files", scene.ID)
+	}
+
+	if err := options.validate(f); err != nil {
+		return 0, err
+	}
+
+	end := options.End
+	if f.Duration > 0 && end > f.Duration {
+		end = f.Duration
+	}
+
+	var output string
+	if options.CreateScene {
+		output = filepath.Join(filepath.Dir(f.Path), clipName(f.Path, options.Start, end))
+		for _, ext := range []string{filepath.Ext(f.Path), ".mp4"} {
+			if exists, _ := fsutil.FileExists(output + ext); exists {
+				return 0, fmt.Errorf("clip %s already exists", output+ext)
+			}
+		}
+	} else {
+		name := fmt.Sprintf("%s_%d", clipName(f.Path, options.Start, end), time.Now().UnixNano())
+		output = filepath.Join(s.Paths.Generated.Downloads, name)
+	}
+
+	path := f.Path
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		ret, err := s.extractClip(ctx, path, output, generate.ClipOptions{
+			Start:    options.Start,
+			Duration: end - options.Start,
+			Reencode: options.Reencode,
+		})
+		if err != nil {
+			return err
+		}
+
+		if options.CreateScene {
+			input := ScanMetadataInput{
+				Paths: []string{ret},
+			}
+			if defaults := s.Config.GetDefaultScanSettings(); defaults != nil {
+				input.ScanMetadataOptions = *defaults
+			}
+
+			if _, err := s.Scan(ctx, input); err != nil {
+				return fmt.Errorf("queuing scan of clip: %w", err)
+			}
+
+			progress.SetResult(ret)
+			return nil
+		}
+
+		downloadHash, err := s.DownloadStore.RegisterFile(ret, "", false)
+		if err != nil {
+			return fmt.Errorf("registering clip for download: %w", err)
+		}
+
+		progress.SetResult(baseURL + "/downloads/" + downloadHash + "/" + filepath.Base(ret))
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, fmt.Sprintf("Extracting clip of %s...", f.Basename), j), nil
+}
+
+func (s *Manager) extractClip(ctx context.Context, path string, output string, options generate.ClipOptions) (string, error) {
+	if err := fsutil.EnsureDir(filepath.Dir(output)); err != nil {
+		return "", fmt.Errorf("could not create directory for clip: %w", err)
+	}
+
+	g := generate.Generator{
+		Encoder:      s.FFMpeg,
+		FFMpegConfig: s.Config,
+		LockManager:  s.ReadLockManager,
+		ScenePaths:   s.Paths.Scene,
+	}
+
+	logger.Infof("[clip] extracting %v-%v of %s", options.Start, options.Start+options.Duration, path)
+
+	ret, err := g.Clip(ctx, path, output, options)
+	if err != nil {
+		return "", fmt.Errorf("extracting clip: %w", err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,201 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFFMpeg writes an ffmpeg script that writes to the output file, which is
+// its last argument, or fails if fail is true.
+func fakeFFMpeg(t *testing.T, fail bool) *ffmpeg.FFMpeg {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg is a shell script")
+	}
+
+	script := `#!/bin/sh
+for last; do :; done
+case "$last" in -*) exit 0;; esac
+echo clip > "$last"
+`
+	if fail {
+		script = "#!/bin/sh\nexit 1\n"
+	}
+
+	fn := filepath.Join(t.TempDir(), "ffmpeg")
+	if err := os.WriteFile(fn, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return ffmpeg.NewEncoder(fn)
+}
+
+func newClipTestManager(t *testing.T, encoder *ffmpeg.FFMpeg) *Manager {
+	t.Helper()
+
+	p := paths.NewPaths(t.TempDir(), "")
+	if err := p.Generated.EnsureTmpDir(); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Manager{
+		Config:          config.InitializeEmpty(),
+		JobManager:      job.NewManager(),
+		Paths:           &p,
+		FFMpeg:          encoder,
+		FFProbe:         "ffprobe",
+		ReadLockManager: fsutil.NewReadLockManager(),
+		DownloadStore:   NewDownloadStore(),
+	}
+	t.Cleanup(s.JobManager.Stop)
+
+	return s
+}
+
+func clipTestScene(path string) *models.Scene {
+	return &models.Scene{
+		ID: 1,
+		Files: models.NewRelatedVideoFiles([]*models.VideoFile{
+			{
+				BaseFile: &models.BaseFile{
+					Path:     path,
+					Basename: filepath.Base(path),
+				},
+				Duration: 60,
+			},
+		}),
+	}
+}
+
+func waitForJob(t *testing.T, s *Manager, jobID int) *job.Job {
+	t.Helper()
+
+	var ret *job.Job
+	assert.Eventually(t, func() bool {
+		ret = s.JobManager.GetJob(jobID)
+		return ret != nil && (ret.Status == job.StatusFinished || ret.Status == job.StatusFailed)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	return ret
+}
+
+func TestExtractClipOptions_validate(t *testing.T) {
+	f := &models.VideoFile{Duration: 60}
+
+	tests := []struct {
+		name    string
+		options ExtractClipOptions
+		wantErr bool
+	}{
+		{"valid", ExtractClipOptions{Start: 10, End: 20}, false},
+		{"end after duration", ExtractClipOptions{Start: 10, End: 90}, false},
+		{"negative start", ExtractClipOptions{Start: -1, End: 20}, true},
+		{"end before start", ExtractClipOptions{Start: 20, End: 10}, true},
+		{"empty", ExtractClipOptions{Start: 10, End: 10}, true},
+		{"start after duration", ExtractClipOptions{Start: 60, End: 70}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.validate(f)
+			assert.Equal(t, tt.wantErr, err != nil, "validate() error = %v", err)
+		})
+	}
+}
+
+func TestManager_ExtractClip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	scenePath := filepath.Join(dir, "scene.mp4")
+
+	s := newClipTestManager(t, fakeFFMpeg(t, false))
+
+	jobID, err := s.ExtractClip(ctx, clipTestScene(scenePath), ExtractClipOptions{
+		Start: 10,
+		End:   90,
+	}, "http://localhost:9999")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	j := waitForJob(t, s, jobID)
+	if !assert.Equal(t, job.StatusFinished, j.Status) || !assert.NotNil(t, j.Result) {
+		return
+	}
+
+	// the clip is written to the downloads directory and a link is returned
+	assert.True(t, strings.HasPrefix(*j.Result, "http://localhost:9999/downloads/"), *j.Result)
+
+	name := filepath.Base(*j.Result)
+	assert.True(t, strings.HasPrefix(name, "scene_clip_10-60_"), name)
+	assert.FileExists(t, filepath.Join(s.Paths.Generated.Downloads, name))
+}
+
+func TestManager_ExtractClipFailed(t *testing.T) {
+	ctx := context.Background()
+	scenePath := filepath.Join(t.TempDir(), "scene.mp4")
+
+	s := newClipTestManager(t, fakeFFMpeg(t, true))
+
+	jobID, err := s.ExtractClip(ctx, clipTestScene(scenePath), ExtractClipOptions{
+		Start: 10,
+		End:   20,
+	}, "")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	j := waitForJob(t, s, jobID)
+	assert.Equal(t, job.StatusFailed, j.Status)
+	assert.NotNil(t, j.Error)
+	assert.Nil(t, j.Result)
+}
+
+func TestManager_ExtractClipInvalid(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	scenePath := filepath.Join(dir, "scene.mp4")
+
+	// an existing clip is not overwritten
+	if err := os.WriteFile(filepath.Join(dir, "scene_clip_10-20.mp4"), []byte("clip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	options := ExtractClipOptions{Start: 10, End: 20}
+
+	tests := []struct {
+		name    string
+		s       *Manager
+		scene   *models.Scene
+		options ExtractClipOptions
+	}{
+		{"no ffmpeg", newClipTestManager(t, nil), clipTestScene(scenePath), options},
+		{"no files", newClipTestManager(t, fakeFFMpeg(t, false)), &models.Scene{ID: 1, Files: models.NewRelatedVideoFiles(nil)}, options},
+		{"invalid options", newClipTestManager(t, fakeFFMpeg(t, false)), clipTestScene(scenePath), ExtractClipOptions{Start: 20, End: 10}},
+		{"clip exists", newClipTestManager(t, fakeFFMpeg(t, false)), clipTestScene(scenePath), ExtractClipOptions{Start: 10, End: 20, CreateScene: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.s.ExtractClip(ctx, tt.scene, tt.options, "")
+			assert.NotNil(t, err)
+
+			// no job is queued
+			assert.Empty(t, tt.s.JobManager.GetQueue())
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+const (
+	frameCacheDir = "frames"
+
+	// frameCacheMaxSize is the maximum total size in bytes of cached frames.
+	// The least recently used frames are removed when the limit is exceeded.
+	frameCacheMaxSize = 256 * 1024 * 1024
+)
+
+// frameWidths are the widths that requested frame widths are rounded up to,
+// so that a bounded number of frames is cached for each time.
+var frameWidths = []int{160, 320, 640, 1280, 1920}
+
+// roundFrameWidth rounds width up to the nearest of frameWidths. Returns 0,
+// meaning the full width, if width is 0 or the rounded width would not be
+// smaller than fileWidth.
+func roundFrameWidth(width int, fileWidth int) int {
+	if width <= 0 {
+		return 0
+	}
+
+	for _, w := range frameWidths {
+		if w >= width {
+			if w >= fileWidth {
+				return 0
+			}
+			return w
+		}
+	}
+
+	return 0
+}
+
+// roundFrameTime rounds the time in seconds to the nearest whole second
+// within duration.
+func roundFrameTime(at float64, duration float64) int64 {
+	ret := math.Round(at)
+	if ret > duration {
+		ret = math.Floor(duration)
+	}
+	return int64(ret)
+}
+
+// frameCachePath returns the path of the cached frame of the scene at the
+// provided time in seconds and width. Returns an empty string if the cache
+// path is not set.
+func frameCachePath(sceneHash string, seconds int64, width int) string {
+	cachePath := config.GetInstance().GetCachePath()
+	if cachePath == "" || sceneHash == "" {
+		return ""
+	}
+
+	return filepath.Join(cachePath, frameCacheDir, sceneHash, fmt.Sprintf("%d_%d.jpg", seconds, width))
+}
+
+type frameCacheEntry struct {
+	size         int64
+	lastAccessed time.Time
+}
+
+// frameCache tracks the size and last access time of the cached frames, and
+// removes the least recently used frames when the total size exceeds
+// maxSize.
+type frameCache struct {
+	mutex   sync.Mutex
+	maxSize int64
+	entries map[string]*frameCacheEntry
+	size    int64
+}
+
+var cachedFrames = &frameCache{
+	maxSize: frameCacheMaxSize,
+}
+
+// load adds the frames already in dir to the cache, if the cache has not been
+// loaded. Assumes lock held.
+func (c *frameCache) load(dir string) {
+	if c.entries != nil {
+		return
+	}
+
+	c.entries = make(map[string]*frameCacheEntry)
+	c.size = 0
+
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil {
+			c.entries[path] = &frameCacheEntry{size: info.Size(), lastAccessed: info.ModTime()}
+			c.size += info.Size()
+		}
+		return nil
+	})
+}
+
+// get returns true if the frame at path is cached, and marks it as
+// recently used.
+func (c *frameCache) get(dir string, path string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.load(dir)
+
+	e, found := c.entries[path]
+	if !found {
+		return false
+	}
+
+	if exists, _ := fsutil.FileExists(path); !exists {
+		c.size -= e.size
+		delete(c.entries, path)
+		return false
+	}
+
+	e.lastAccessed = time.Now()
+	return true
+}
+
+// add records the frame written to path, removing the least recently used
+// frames if the cache is too large.
+func (c *frameCache) add(dir string, path string, size int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.load(dir)
+
+	if e, found := c.entries[path]; found {
+		c.size -= e.size
+	}
+
+	c.entries[path] = &frameCacheEntry{size: size, lastAccessed: time.Now()}
+	c.size += size
+
+	c.evict()
+}
+
+// evict removes the least recently used frames until the cache is within
+// maxSize. Assumes lock held.
+func (c *frameCache) evict() {
+	if c.size <= c.maxSize {
+		return
+	}
+
+	paths := make([]string, 0, len(c.entries))
+	for path := range c.entries {
+		paths = append(paths, path)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return c.entries[paths[i]].lastAccessed.Before(c.entries[paths[j]].lastAccessed)
+	})
+
+	for _, path := range paths {
+		if c.size <= c.maxSize {
+			break
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("error removing cached frame %s: %v", path, err)
+			continue
+		}
+
+		c.size -= c.entries[path].size
+		delete(c.entries, path)
+	}
+}
+
+// reset clears the cache, so that it is reloaded from disk when next used.
+func (c *frameCache) reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = nil
+	c.size = 0
+}
+
+// removeFrameCache removes all cached frames.
+func removeFrameCache() {
+	cachePath := config.GetInstance().GetCachePath()
+	if cachePath == "" {
+		return
+	}
+
+	if err := os.RemoveAll(filepath.Join(cachePath, frameCacheDir)); err != nil {
+		logger.Warnf("error removing cached frames: %v", err)
+	}
+
+	cachedFrames.reset()
+}
+
+// writeFrameCache writes the frame to path, using a temporary file so that
+// a partially written frame is never served.
+func writeFrameCache(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := fsutil.EnsureDirAll(dir); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// ServeFrame serves a JPEG image of the frame of the scene at the time in
+// seconds given by the t query parameter. The time is rounded to the nearest
+// second. The image is scaled to the width query parameter if set, rounded up
+// to one of frameWidths. Frames are cached in the cache directory until stash
+// is shut down, up to frameCacheMaxSize.
+func (s *SceneServer) ServeFrame(scene *models.Scene, w http.ResponseWriter, r *http.Request) {
+	f := scene.Files.Primary()
+	if f == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	at, err := strconv.ParseFloat(query.Get("t"), 64)
+	if err != nil || at < 0 || at > f.Duration {
+		http.Error(w, "t must be a time in seconds within the scene duration", http.StatusBadRequest)
+		return
+	}
+
+	var width int
+	if v := query.Get("width"); v != "" {
+		width, err = strconv.Atoi(v)
+		if err != nil || width < 0 {
+			http.Error(w, "width must be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// round to a bounded set of frames so that the cache is reused, and
+	// don't scale frames up
+	width = roundFrameWidth(width, f.Width)
+	seconds := roundFrameTime(at, f.Duration)
+
+	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
+	cachePath := frameCachePath(sceneHash, seconds, width)
+	cacheDir := filepath.Join(config.GetInstance().GetCachePath(), frameCacheDir)
+
+	if cachePath != "" {
+		if cachedFrames.get(cacheDir, cachePath) {
+			w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+			http.ServeFile(w, r, cachePath)
+			return
+		}
+	}
+
+	g := generate.Generator{
+		Encoder:      instance.FFMpeg,
+		FFMpegConfig: instance.Config,
+		LockManager:  instance.ReadLockManager,
+		ScenePaths:   instance.Paths.Scene,
+	}
+
+	data, err := g.Frame(r.Context(), f.Path, float64(seconds), width)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || r.Context().Err() != nil {
+			return
+		}
+		logger.Errorf("error generating frame at %v for %s: %v", at, f.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if cachePath != "" {
+		if err := writeFrameCache(cachePath, data); err != nil {
+			logger.Warnf("error caching frame: %v", err)
+		} else {
+			cachedFrames.add(cacheDir, cachePath, int64(len(data)))
+		}
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	utils.ServeImage(w, r, data)
+}
@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundFrameWidth(t *testing.T) {
+	tests := []struct {
+		name      string
+		width     int
+		fileWidth int
+		want      int
+	}{
+		{"full width", 0, 1920, 0},
+		{"exact", 320, 1920, 320},
+		{"rounded up", 321, 1920, 640},
+		{"not smaller than file", 700, 1000, 0},
+		{"larger than all widths", 4000, 7680, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, roundFrameWidth(tt.width, tt.fileWidth))
+		})
+	}
+}
+
+func TestRoundFrameTime(t *testing.T) {
+	assert.Equal(t, int64(10), roundFrameTime(10.4, 60))
+	assert.Equal(t, int64(11), roundFrameTime(10.5, 60))
+	assert.Equal(t, int64(59), roundFrameTime(59.9, 59.6))
+}
+
+func TestFrameCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	c := &frameCache{maxSize: 10}
+
+	write := func(name string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("12345"), 0644); err != nil {
+			t.Fatalf("writing frame: %v", err)
+		}
+		c.add(dir, path, 5)
+		return path
+	}
+
+	a := write("a.jpg")
+	b := write("b.jpg")
+
+	// make a the most recently used
+	time.Sleep(time.Millisecond)
+	assert.True(t, c.get(dir, a))
+
+	cc := write("c.jpg")
+
+	assert.True(t, c.get(dir, a))
+	assert.False(t, c.get(dir, b), "least recently used frame should be evicted")
+	assert.True(t, c.get(dir, cc))
+
+	_, err := os.Stat(b)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -6,3 +6,11 @@ type Task interface {
 	Start(context.Context)
 	GetDescription() string
 }
+
+// ProgressTask is a Task that reports its progress while running.
+type ProgressTask interface {
+	Task
+	// StartProgress starts the task, calling progress with the progress of
+	// the task from 0 to 1.
+	StartProgress(ctx context.Context, progress func(percent float64))
+}
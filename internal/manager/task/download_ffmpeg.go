@@ -3,6 +3,8 @@ package task
 import (
 	"archive/zip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/fsutil"
@@ -20,7 +23,7 @@ import (
 type DownloadFFmpegJob struct {
 	ConfigDirectory string
 	OnComplete      func(ctx context.Context)
-	urls            []string
+	downloads       []ffmpeg.Download
 	downloaded      int
 }
 
@@ -40,16 +43,16 @@ func (s *DownloadFFmpegJob) Execute(ctx context.Context, progress *job.Progress)
 }
 
 func (s *DownloadFFmpegJob) setTaskProgress(taskProgress float64, progress *job.Progress) {
-	progress.SetPercent((float64(s.downloaded) + taskProgress) / float64(len(s.urls)))
+	progress.SetPercent((float64(s.downloaded) + taskProgress) / float64(len(s.downloads)))
 }
 
 func (s *DownloadFFmpegJob) download(ctx context.Context, progress *job.Progress) error {
-	s.urls = ffmpeg.GetFFmpegURL()
+	s.downloads = ffmpeg.GetFFmpegDownloads()
 
 	// set steps based on the number of URLs
 
-	for _, url := range s.urls {
-		err := s.downloadSingle(ctx, url, progress)
+	for _, d := range s.downloads {
+		err := s.downloadSingle(ctx, d, progress)
 		if err != nil {
 			return err
 		}
@@ -87,11 +90,17 @@ func (r *downloadProgressReader) Read(p []byte) (int, error) {
 	return read, err
 }
 
-func (s *DownloadFFmpegJob) downloadSingle(ctx context.Context, url string, progress *job.Progress) error {
+func (s *DownloadFFmpegJob) downloadSingle(ctx context.Context, d ffmpeg.Download, progress *job.Progress) error {
+	url := d.URL
 	if url == "" {
 		return fmt.Errorf("no ffmpeg url for this platform")
 	}
 
+	// refuse archives that cannot be verified
+	if d.SHA256 == "" {
+		return fmt.Errorf("no checksum is known for %s. Install ffmpeg and ffprobe manually and set their paths in the system settings", url)
+	}
+
 	configDirectory := s.ConfigDirectory
 
 	// Configure where we want to download the archive
@@ -116,6 +125,15 @@ func (s *DownloadFFmpegJob) downloadSingle(ctx context.Context, url string, prog
 
 	logger.Info("Downloading complete")
 
+	progress.ExecuteTask(fmt.Sprintf("Verifying %s", archivePath), func() {
+		err = verifyChecksum(archivePath, d.SHA256)
+	})
+
+	if err != nil {
+		_ = os.Remove(archivePath)
+		return fmt.Errorf("failed to verify ffmpeg archive from %s: %w", url, err)
+	}
+
 	logger.Infof("Unzipping %s...", archivePath)
 	progress.ExecuteTask(fmt.Sprintf("Unzipping %s", archivePath), func() {
 		err = s.unzip(archivePath)
@@ -200,6 +218,29 @@ func (s *DownloadFFmpegJob) downloadFile(ctx context.Context, url string, out *o
 	return nil
 }
 
+// verifyChecksum compares the SHA-256 checksum of the file at path with the
+// expected hex encoded checksum.
+func verifyChecksum(path string, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+
+	if !strings.EqualFold(expected, actual) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+
+	logger.Infof("Verified checksum of %s", filepath.Base(path))
+	return nil
+}
+
 func (s *DownloadFFmpegJob) unzip(src string) error {
 	zipReader, err := zip.OpenReader(src)
 	if err != nil {
@@ -0,0 +1,52 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.zip")
+	if err := os.WriteFile(path, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// sha256sum of "archive"
+	const sum = "0eb3e36bfb24dcd9bb1d1bece1531216b59539a8fde17ee80224af0653c92aa3"
+
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{"match", sum, false},
+		{"match upper case", strings.ToUpper(sum), false},
+		{"mismatch", strings.Repeat("0", 64), true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyChecksum(path, tt.expected)
+			assert.Equal(t, tt.wantErr, err != nil, "verifyChecksum() error = %v", err)
+		})
+	}
+}
+
+func TestDownloadSingleWithoutChecksum(t *testing.T) {
+	dir := t.TempDir()
+	j := &DownloadFFmpegJob{ConfigDirectory: dir}
+
+	// fails before anything is downloaded
+	err := j.downloadSingle(context.Background(), ffmpeg.Download{URL: "https://example.com/ffmpeg.zip"}, nil)
+	assert.NotNil(t, err)
+
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+}
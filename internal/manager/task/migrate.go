@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/stashapp/stash/pkg/job"
@@ -15,6 +14,8 @@ import (
 type migrateJobConfig interface {
 	GetBackupDirectoryPath() string
 	GetBackupDirectoryPathOrDefault() string
+	SetMigrationBackupPath(path string) error
+	GetBackupRetention() int
 }
 
 type MigrateJob struct {
@@ -48,10 +49,12 @@ func (s *MigrateJob) Execute(ctx context.Context, progress *job.Progress) error
 	database := s.Database
 
 	// always backup so that we can roll back to the previous version if
-	// migration fails
+	// migration fails. The backup is kept and recorded so that the database
+	// can be restored using the restoreDatabase mutation if problems are
+	// found after migrating.
 	backupPath := s.BackupPath
 	if backupPath == "" {
-		backupPath = database.DatabaseBackupPath(s.Config.GetBackupDirectoryPath())
+		backupPath = database.DatabaseMigrationBackupPath(s.Config.GetBackupDirectoryPathOrDefault())
 	} else {
 		// check if backup path is a filename or path
 		// filename goes into backup directory, path is kept as is
@@ -66,6 +69,14 @@ func (s *MigrateJob) Execute(ctx context.Context, progress *job.Progress) error
 		return fmt.Errorf("error backing up database: %s", err)
 	}
 
+	if err := s.Config.SetMigrationBackupPath(backupPath); err != nil {
+		logger.Warnf("error recording migration backup path: %v", err)
+	}
+
+	if err := database.PruneBackups(filepath.Dir(backupPath), s.Config.GetBackupRetention()); err != nil {
+		logger.Warnf("error deleting old database backups: %v", err)
+	}
+
 	if err := s.runMigrations(ctx, progress); err != nil {
 		errStr := fmt.Sprintf("error performing migration: %s", err)
 
@@ -75,19 +86,17 @@ func (s *MigrateJob) Execute(ctx context.Context, progress *job.Progress) error
 			errStr = fmt.Sprintf("ERROR: unable to restore database from backup after migration failure: %s\n%s", restoreErr.Error(), errStr)
 		} else {
 			errStr = "An error occurred migrating the database to the latest schema version. The backup database file was automatically renamed to restore the database.\n" + errStr
+
+			// the backup file no longer exists
+			if err := s.Config.SetMigrationBackupPath(""); err != nil {
+				logger.Warnf("error clearing migration backup path: %v", err)
+			}
 		}
 
 		return errors.New(errStr)
 	}
 
-	// if no backup path was provided, then delete the created backup
-	if s.BackupPath == "" {
-		if err := os.Remove(backupPath); err != nil {
-			logger.Warnf("error removing unwanted database backup (%s): %s", backupPath, err.Error())
-		}
-	}
-
-	logger.Infof("Database migration complete")
+	logger.Infof("Database migration complete. The database was backed up to %s", backupPath)
 
 	return nil
 }
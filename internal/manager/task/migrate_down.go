@@ -0,0 +1,105 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/sqlite"
+)
+
+// MigrateDownJob reverts the database to an earlier schema version, so that
+// it can be used with an earlier version of stash. The database is closed
+// while reverting, and is left closed afterwards, since the application
+// requires the current schema version.
+type MigrateDownJob struct {
+	SchemaVersion uint
+	BackupPath    string
+	Config        migrateJobConfig
+	Database      *sqlite.Database
+}
+
+func (s *MigrateDownJob) Execute(ctx context.Context, progress *job.Progress) error {
+	database := s.Database
+
+	m, err := sqlite.NewMigrator(database)
+	if err != nil {
+		return err
+	}
+
+	defer m.Close()
+
+	// check that all migrations can be reverted before making any changes
+	migrations, err := m.RevertMigrations(s.SchemaVersion)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Reverting database from schema version %d to %d", m.CurrentSchemaVersion(), s.SchemaVersion)
+
+	progress.SetTotal(len(migrations))
+
+	backupPath := s.BackupPath
+	if backupPath == "" {
+		backupPath = database.DatabaseMigrationBackupPath(s.Config.GetBackupDirectoryPathOrDefault())
+	} else {
+		// check if backup path is a filename or path
+		// filename goes into backup directory, path is kept as is
+		filename := filepath.Base(backupPath)
+		if backupPath == filename {
+			backupPath = filepath.Join(s.Config.GetBackupDirectoryPathOrDefault(), filename)
+		}
+	}
+
+	if err := database.Backup(backupPath); err != nil {
+		return fmt.Errorf("error backing up database: %s", err)
+	}
+
+	if err := s.Config.SetMigrationBackupPath(backupPath); err != nil {
+		logger.Warnf("error recording migration backup path: %v", err)
+	}
+
+	if err := database.PruneBackups(filepath.Dir(backupPath), s.Config.GetBackupRetention()); err != nil {
+		logger.Warnf("error deleting old database backups: %v", err)
+	}
+
+	// close the database so that it is not used while the schema changes
+	if err := database.Close(); err != nil {
+		return fmt.Errorf("error closing database: %s", err)
+	}
+
+	for _, mig := range migrations {
+		progress.ExecuteTask(fmt.Sprintf("Reverting database migration %d (%s)", mig.Version, mig.Name), func() {
+			err = m.RevertMigration(ctx, mig.Version)
+		})
+
+		if err != nil {
+			break
+		}
+
+		progress.Increment()
+	}
+
+	if err != nil {
+		errStr := fmt.Sprintf("error reverting migration: %s", err)
+
+		// the migrator connection must be closed before replacing the file
+		m.Close()
+
+		restoreErr := database.Restore(backupPath)
+		if restoreErr != nil {
+			errStr = fmt.Sprintf("ERROR: unable to restore database from backup after failure: %s\n%s", restoreErr.Error(), errStr)
+		} else {
+			errStr = "An error occurred reverting the database schema. The database was restored from the backup.\n" + errStr
+		}
+
+		return errors.New(errStr)
+	}
+
+	logger.Infof("Database reverted to schema version %d. The database was backed up to %s", s.SchemaVersion, backupPath)
+
+	return nil
+}
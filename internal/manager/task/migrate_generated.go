@@ -0,0 +1,227 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// generated directories that are relocated. Transient directories such as
+// tmp and download_stage are not relocated, nor is anything else found in the
+// old directory.
+var relocateGeneratedDirs = []string{
+	"screenshots",
+	"thumbnails",
+	"vtt",
+	"markers",
+	"transcodes",
+	"interactive_heatmaps",
+}
+
+// RelocateGeneratedJob moves generated files from a previous generated
+// directory into the current generated directory. Only the known generated
+// subdirectories are moved. Files that already exist in the new directory are
+// left in the old directory.
+type RelocateGeneratedJob struct {
+	OldPath string
+	NewPath string
+	// LibraryPaths are refused as the old generated path
+	LibraryPaths []string
+}
+
+func (j *RelocateGeneratedJob) validate() error {
+	oldPath, err := filepath.Abs(j.OldPath)
+	if err != nil {
+		return err
+	}
+	newPath, err := filepath.Abs(j.NewPath)
+	if err != nil {
+		return err
+	}
+
+	if oldPath == newPath {
+		return errors.New("old generated path is the same as the current generated path")
+	}
+
+	if fsutil.IsPathInDir(oldPath, newPath) || fsutil.IsPathInDir(newPath, oldPath) {
+		return errors.New("generated paths must not be inside each other")
+	}
+
+	for _, libraryPath := range j.LibraryPaths {
+		libraryPath, err := filepath.Abs(libraryPath)
+		if err != nil {
+			return err
+		}
+
+		if fsutil.IsPathInDir(libraryPath, oldPath) || fsutil.IsPathInDir(oldPath, libraryPath) {
+			return fmt.Errorf("old generated path %q overlaps library path %q", j.OldPath, libraryPath)
+		}
+	}
+
+	if exists, _ := fsutil.DirExists(oldPath); !exists {
+		return fmt.Errorf("old generated path %q does not exist", j.OldPath)
+	}
+
+	if !isGeneratedDir(oldPath) {
+		return fmt.Errorf("old generated path %q does not contain any generated directories", j.OldPath)
+	}
+
+	return nil
+}
+
+// isGeneratedDir returns true if path contains at least one of the known
+// generated subdirectories.
+func isGeneratedDir(path string) bool {
+	for _, dir := range relocateGeneratedDirs {
+		if exists, _ := fsutil.DirExists(filepath.Join(path, dir)); exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (j *RelocateGeneratedJob) Execute(ctx context.Context, progress *job.Progress) error {
+	if err := j.validate(); err != nil {
+		return err
+	}
+
+	logger.Infof("Moving generated files from %s to %s", j.OldPath, j.NewPath)
+
+	var err error
+	progress.ExecuteTask("Counting files", func() {
+		var count int
+		count, err = j.countFiles(ctx)
+		progress.SetTotal(count)
+	})
+
+	if err != nil {
+		return fmt.Errorf("error counting files: %w", err)
+	}
+
+	moved := 0
+	skipped := 0
+	err = j.walk(ctx, func(path string, rel string) {
+		progress.ExecuteTask("Moving file "+rel, func() {
+			defer progress.Increment()
+
+			dest := filepath.Join(j.NewPath, rel)
+			if exists, _ := fsutil.FileExists(dest); exists {
+				logger.Warnf("Not moving %s: %s already exists", path, dest)
+				skipped++
+				return
+			}
+
+			if err := fsutil.EnsureDirAll(filepath.Dir(dest)); err != nil {
+				logger.Errorf("Error creating directory for %s: %v", dest, err)
+				skipped++
+				return
+			}
+
+			if err := fsutil.SafeMove(path, dest); err != nil {
+				logger.Errorf("Error moving %s: %v", path, err)
+				skipped++
+				return
+			}
+
+			moved++
+		})
+	})
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error moving generated files: %w", err)
+	}
+
+	for _, dir := range relocateGeneratedDirs {
+		removeEmptyDirs(filepath.Join(j.OldPath, dir))
+	}
+
+	logger.Infof("Moved %d generated files, %d files not moved", moved, skipped)
+	return nil
+}
+
+func (j *RelocateGeneratedJob) countFiles(ctx context.Context) (int, error) {
+	ret := 0
+	err := j.walk(ctx, func(string, string) {
+		ret++
+	})
+	return ret, err
+}
+
+// walk calls fn for each file in the generated subdirectories of the old
+// generated directory. rel is the path of the file relative to the generated
+// directory.
+func (j *RelocateGeneratedJob) walk(ctx context.Context, fn func(path string, rel string)) error {
+	for _, dir := range relocateGeneratedDirs {
+		root := filepath.Join(j.OldPath, dir)
+		if exists, _ := fsutil.DirExists(root); !exists {
+			continue
+		}
+
+		if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if job.IsCancelled(ctx) {
+				return filepath.SkipAll
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(j.OldPath, path)
+			if err != nil {
+				return err
+			}
+
+			fn(path, rel)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// removeEmptyDirs removes empty directories under root, including root
+// itself if it is empty.
+func removeEmptyDirs(root string) {
+	var dirs []string
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+
+	// remove the deepest directories first
+	for i := len(dirs) - 1; i >= 0; i-- {
+		entries, err := os.ReadDir(dirs[i])
+		if err != nil || len(entries) > 0 {
+			continue
+		}
+
+		if err := os.Remove(dirs[i]); err != nil {
+			logger.Warnf("Error removing directory %s: %v", dirs[i], err)
+		}
+	}
+}
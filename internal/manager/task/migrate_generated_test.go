@@ -0,0 +1,143 @@
+package task
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFile(t *testing.T, path string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(filepath.Base(path)), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func executeRelocate(t *testing.T, j *RelocateGeneratedJob) error {
+	t.Helper()
+
+	m := job.NewManager()
+	defer m.Stop()
+
+	done := make(chan error, 1)
+	m.Start(context.Background(), "relocate", job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		err := j.Execute(ctx, progress)
+		done <- err
+		return err
+	}))
+
+	return <-done
+}
+
+func TestRelocateGeneratedJobValidate(t *testing.T) {
+	root := t.TempDir()
+
+	generated := filepath.Join(root, "generated")
+	writeTestFile(t, filepath.Join(generated, "screenshots", "a.jpg"))
+
+	notGenerated := filepath.Join(root, "documents")
+	writeTestFile(t, filepath.Join(notGenerated, "notes.txt"))
+
+	library := filepath.Join(root, "library")
+	writeTestFile(t, filepath.Join(library, "screenshots", "a.jpg"))
+
+	newPath := filepath.Join(root, "new")
+
+	tests := []struct {
+		name    string
+		oldPath string
+		newPath string
+		wantErr bool
+	}{
+		{"generated", generated, newPath, false},
+		{"same as destination", newPath, newPath, true},
+		{"destination inside source", generated, filepath.Join(generated, "new"), true},
+		{"source inside destination", generated, root, true},
+		{"missing", filepath.Join(root, "missing"), newPath, true},
+		{"not generated", notGenerated, newPath, true},
+		{"library path", library, newPath, true},
+		{"inside library path", filepath.Join(library, "screenshots"), newPath, true},
+		{"contains library path", root, filepath.Join(t.TempDir(), "new"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &RelocateGeneratedJob{
+				OldPath:      tt.oldPath,
+				NewPath:      tt.newPath,
+				LibraryPaths: []string{library},
+			}
+
+			err := j.validate()
+			assert.Equal(t, tt.wantErr, err != nil, "validate() error = %v", err)
+		})
+	}
+}
+
+func TestRelocateGeneratedJobExecute(t *testing.T) {
+	oldPath := t.TempDir()
+	newPath := t.TempDir()
+
+	writeTestFile(t, filepath.Join(oldPath, "screenshots", "a.jpg"))
+	writeTestFile(t, filepath.Join(oldPath, "thumbnails", "ab", "cd", "abcd_320.jpg"))
+	writeTestFile(t, filepath.Join(oldPath, "vtt", "a_thumbs.vtt"))
+	writeTestFile(t, filepath.Join(oldPath, "tmp", "partial.mp4"))
+	writeTestFile(t, filepath.Join(oldPath, "other", "b.txt"))
+	writeTestFile(t, filepath.Join(oldPath, "c.txt"))
+
+	// existing files are not overwritten
+	writeTestFile(t, filepath.Join(oldPath, "markers", "exists.mp4"))
+	writeTestFile(t, filepath.Join(newPath, "markers", "exists.mp4"))
+
+	err := executeRelocate(t, &RelocateGeneratedJob{
+		OldPath: oldPath,
+		NewPath: newPath,
+	})
+	assert.Nil(t, err)
+
+	for _, moved := range []string{
+		filepath.Join("screenshots", "a.jpg"),
+		filepath.Join("thumbnails", "ab", "cd", "abcd_320.jpg"),
+		filepath.Join("vtt", "a_thumbs.vtt"),
+	} {
+		assert.FileExists(t, filepath.Join(newPath, moved))
+		assert.NoFileExists(t, filepath.Join(oldPath, moved))
+	}
+
+	// the emptied generated directories are removed
+	assert.NoDirExists(t, filepath.Join(oldPath, "screenshots"))
+	assert.NoDirExists(t, filepath.Join(oldPath, "thumbnails"))
+
+	// files outside the generated directories are left in place
+	for _, kept := range []string{
+		filepath.Join("tmp", "partial.mp4"),
+		filepath.Join("other", "b.txt"),
+		"c.txt",
+		filepath.Join("markers", "exists.mp4"),
+	} {
+		assert.FileExists(t, filepath.Join(oldPath, kept))
+		if kept != filepath.Join("markers", "exists.mp4") {
+			assert.NoFileExists(t, filepath.Join(newPath, kept))
+		}
+	}
+
+	// the root is never removed, even when emptied
+	emptied := t.TempDir()
+	writeTestFile(t, filepath.Join(emptied, "screenshots", "a.jpg"))
+
+	err = executeRelocate(t, &RelocateGeneratedJob{
+		OldPath: emptied,
+		NewPath: filepath.Join(t.TempDir(), "new"),
+	})
+	assert.Nil(t, err)
+	assert.DirExists(t, emptied)
+	assert.NoDirExists(t, filepath.Join(emptied, "screenshots"))
+}
@@ -16,23 +16,28 @@ import (
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
 type autoTagJob struct {
 	repository models.Repository
 	input      AutoTagMetadataInput
 
-	cache match.Cache
+	cache *match.Cache
 }
 
 func (j *autoTagJob) Execute(ctx context.Context, progress *job.Progress) error {
 	begin := time.Now()
 
 	input := j.input
-	if j.isFileBasedAutoTag(input) {
+	switch {
+	case input.hasSelection():
+		// doing auto-tag of selected scenes/images/galleries
+		j.autoTagSelected(ctx, progress)
+	case j.isFileBasedAutoTag(input):
 		// doing file-based auto-tag
 		j.autoTagFiles(ctx, progress, input.Paths, len(input.Performers) > 0, len(input.Studios) > 0, len(input.Tags) > 0)
-	} else {
+	default:
 		// doing specific performer/studio/tag auto-tag
 		j.autoTagSpecific(ctx, progress)
 	}
@@ -58,12 +63,43 @@ func (j *autoTagJob) autoTagFiles(ctx context.Context, progress *job.Progress, p
 		tags:       tags,
 		progress:   progress,
 		repository: j.repository,
-		cache:      &j.cache,
+		cache:      j.cache,
 	}
 
 	t.process(ctx)
 }
 
+func (j *autoTagJob) autoTagSelected(ctx context.Context, progress *job.Progress) {
+	input := j.input
+
+	sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIDs)
+	if err != nil {
+		logger.Errorf("auto-tag error: converting scene ids: %v", err)
+		return
+	}
+	imageIDs, err := stringslice.StringSliceToIntSlice(input.ImageIDs)
+	if err != nil {
+		logger.Errorf("auto-tag error: converting image ids: %v", err)
+		return
+	}
+	galleryIDs, err := stringslice.StringSliceToIntSlice(input.GalleryIDs)
+	if err != nil {
+		logger.Errorf("auto-tag error: converting gallery ids: %v", err)
+		return
+	}
+
+	t := autoTagFilesTask{
+		performers: len(input.Performers) > 0,
+		studios:    len(input.Studios) > 0,
+		tags:       len(input.Tags) > 0,
+		progress:   progress,
+		repository: j.repository,
+		cache:      j.cache,
+	}
+
+	t.processSelected(ctx, sceneIDs, imageIDs, galleryIDs)
+}
+
 func (j *autoTagJob) autoTagSpecific(ctx context.Context, progress *job.Progress) {
 	input := j.input
 	performerIds := input.Performers
@@ -127,7 +163,7 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 	r := j.repository
 	tagger := autotag.Tagger{
 		TxnManager: r.TxnManager,
-		Cache:      &j.cache,
+		Cache:      j.cache,
 	}
 
 	for _, performerId := range performerIds {
@@ -179,6 +215,12 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 					return nil
 				}
 
+				if j.cache.PerformerAliases {
+					if err := performer.LoadAliases(ctx, r.Performer); err != nil {
+						return fmt.Errorf("loading aliases for performer %d: %w", performer.ID, err)
+					}
+				}
+
 				err := func() error {
 					if err := tagger.PerformerScenes(ctx, performer, paths, r.Scene); err != nil {
 						return fmt.Errorf("processing scenes: %w", err)
@@ -224,7 +266,7 @@ func (j *autoTagJob) autoTagStudios(ctx context.Context, progress *job.Progress,
 	r := j.repository
 	tagger := autotag.Tagger{
 		TxnManager: r.TxnManager,
-		Cache:      &j.cache,
+		Cache:      j.cache,
 	}
 
 	for _, studioId := range studioIds {
@@ -322,7 +364,7 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 	r := j.repository
 	tagger := autotag.Tagger{
 		TxnManager: r.TxnManager,
-		Cache:      &j.cache,
+		Cache:      j.cache,
 	}
 
 	for _, tagId := range tagIds {
@@ -729,6 +771,107 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context) {
 	}
 }
 
+// processSelected auto-tags the scenes, images and galleries with the
+// provided IDs. Organized objects are skipped.
+func (t *autoTagFilesTask) processSelected(ctx context.Context, sceneIDs, imageIDs, galleryIDs []int) {
+	total := len(sceneIDs) + len(imageIDs) + len(galleryIDs)
+	t.progress.SetTotal(total)
+	logger.Infof("Starting auto-tag of %d selected objects", total)
+
+	r := t.repository
+
+	// find returns false if the object should be skipped
+	forEach := func(ids []int, typ string, find func(ctx context.Context, id int) (bool, error), tag func()) bool {
+		for _, id := range ids {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping auto-tag due to user request")
+				return false
+			}
+
+			var found bool
+			if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+				var err error
+				found, err = find(ctx, id)
+				return err
+			}); err != nil {
+				if !job.IsCancelled(ctx) {
+					logger.Errorf("error finding %s %d for auto-tag: %v", typ, id, err)
+				}
+				return false
+			}
+
+			if found {
+				tag()
+			}
+
+			t.progress.Increment()
+		}
+
+		return true
+	}
+
+	var s *models.Scene
+	if !forEach(sceneIDs, "scene", func(ctx context.Context, id int) (bool, error) {
+		var err error
+		s, err = r.Scene.Find(ctx, id)
+		return s != nil && !s.Organized, err
+	}, func() {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		tt := autoTagSceneTask{
+			repository: r,
+			scene:      s,
+			performers: t.performers,
+			studios:    t.studios,
+			tags:       t.tags,
+			cache:      t.cache,
+		}
+		tt.Start(ctx, &wg)
+	}) {
+		return
+	}
+
+	var i *models.Image
+	if !forEach(imageIDs, "image", func(ctx context.Context, id int) (bool, error) {
+		var err error
+		i, err = r.Image.Find(ctx, id)
+		return i != nil && !i.Organized, err
+	}, func() {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		tt := autoTagImageTask{
+			repository: r,
+			image:      i,
+			performers: t.performers,
+			studios:    t.studios,
+			tags:       t.tags,
+			cache:      t.cache,
+		}
+		tt.Start(ctx, &wg)
+	}) {
+		return
+	}
+
+	var g *models.Gallery
+	forEach(galleryIDs, "gallery", func(ctx context.Context, id int) (bool, error) {
+		var err error
+		g, err = r.Gallery.Find(ctx, id)
+		return g != nil && !g.Organized && g.Path != "", err
+	}, func() {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		tt := autoTagGalleryTask{
+			repository: r,
+			gallery:    g,
+			performers: t.performers,
+			studios:    t.studios,
+			tags:       t.tags,
+			cache:      t.cache,
+		}
+		tt.Start(ctx, &wg)
+	})
+}
+
 func (t *autoTagFilesTask) process(ctx context.Context) {
 	if err := t.repository.WithReadTxn(ctx, func(ctx context.Context) error {
 		total, err := t.getCount(ctx)
@@ -20,7 +20,25 @@ import (
 )
 
 type cleaner interface {
-	Clean(ctx context.Context, options file.CleanOptions, progress *job.Progress)
+	Clean(ctx context.Context, options file.CleanOptions, progress *job.Progress) ([]file.CleanedItem, error)
+}
+
+// CleanReportItem is a file or folder that would be removed by a clean.
+type CleanReportItem struct {
+	Path   string
+	Reason file.CleanReason
+}
+
+// CleanReport contains the results of a clean dry run.
+type CleanReport struct {
+	Time    time.Time
+	Paths   []string
+	Files   []CleanReportItem
+	Folders []CleanReportItem
+	// Scenes, images and galleries that would be removed
+	SceneIDs   []int
+	ImageIDs   []int
+	GalleryIDs []int
 }
 
 type cleanJob struct {
@@ -39,18 +57,32 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) error {
 		logger.Infof("Running in Dry Mode")
 	}
 
-	j.cleaner.Clean(ctx, file.CleanOptions{
+	cleaned, err := j.cleaner.Clean(ctx, file.CleanOptions{
 		Paths:      j.input.Paths,
 		DryRun:     j.input.DryRun,
 		PathFilter: newCleanFilter(instance.Config),
 	}, progress)
+	if err != nil {
+		return fmt.Errorf("cleaning files: %w", err)
+	}
 
 	if job.IsCancelled(ctx) {
 		logger.Info("Stopping due to user request")
 		return nil
 	}
 
-	j.cleanEmptyGalleries(ctx)
+	emptyGalleries := j.cleanEmptyGalleries(ctx)
+
+	if j.input.DryRun {
+		report, err := j.makeDryRunReport(ctx, cleaned, emptyGalleries)
+		if err != nil {
+			return fmt.Errorf("creating dry run report: %w", err)
+		}
+
+		logger.Infof("Clean dry run would remove %d files, %d folders, %d scenes, %d images and %d galleries",
+			len(report.Files), len(report.Folders), len(report.SceneIDs), len(report.ImageIDs), len(report.GalleryIDs))
+		instance.setCleanReport(report)
+	}
 
 	j.scanSubs.notify()
 	elapsed := time.Since(start)
@@ -58,7 +90,9 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) error {
 	return nil
 }
 
-func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
+// cleanEmptyGalleries deletes galleries without images. Returns the IDs of the
+// galleries that were, or for a dry run would be, deleted.
+func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) []int {
 	const batchSize = 1000
 	var toClean []int
 	findFilter := models.BatchFindFilter(batchSize)
@@ -98,7 +132,7 @@ func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
 		return nil
 	}); err != nil {
 		logger.Errorf("Error finding empty galleries: %v", err)
-		return
+		return nil
 	}
 
 	if !j.input.DryRun {
@@ -106,6 +140,146 @@ func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
 			j.deleteGallery(ctx, id)
 		}
 	}
+
+	return toClean
+}
+
+// makeDryRunReport returns a report of the files and folders that would be
+// cleaned, along with the scenes, images and galleries that would be removed
+// as a result.
+func (j *cleanJob) makeDryRunReport(ctx context.Context, cleaned []file.CleanedItem, emptyGalleries []int) (*CleanReport, error) {
+	ret := &CleanReport{
+		Time:  time.Now(),
+		Paths: j.input.Paths,
+	}
+
+	cleanedFiles := make(map[models.FileID]bool)
+	var cleanedFolders []models.FolderID
+	for _, c := range cleaned {
+		item := CleanReportItem{
+			Path:   c.Path,
+			Reason: c.Reason,
+		}
+
+		if c.FileID != 0 {
+			cleanedFiles[c.FileID] = true
+			ret.Files = append(ret.Files, item)
+		} else {
+			cleanedFolders = append(cleanedFolders, c.FolderID)
+			ret.Folders = append(ret.Folders, item)
+		}
+	}
+
+	// objects are only removed when all of their files are cleaned
+	allCleaned := func(files []models.File) bool {
+		for _, f := range files {
+			if !cleanedFiles[f.Base().ID] {
+				return false
+			}
+		}
+		return true
+	}
+
+	sceneIDs := make(map[int]bool)
+	imageIDs := make(map[int]bool)
+	galleryIDs := make(map[int]bool)
+
+	addGallery := func(id int) {
+		if !galleryIDs[id] {
+			galleryIDs[id] = true
+			ret.GalleryIDs = append(ret.GalleryIDs, id)
+		}
+	}
+
+	r := j.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		for fileID := range cleanedFiles {
+			scenes, err := r.Scene.FindByFileID(ctx, fileID)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				if sceneIDs[s.ID] {
+					continue
+				}
+
+				if err := s.LoadFiles(ctx, r.Scene); err != nil {
+					return err
+				}
+
+				var files []models.File
+				for _, f := range s.Files.List() {
+					files = append(files, f)
+				}
+
+				if allCleaned(files) {
+					sceneIDs[s.ID] = true
+					ret.SceneIDs = append(ret.SceneIDs, s.ID)
+				}
+			}
+
+			images, err := r.Image.FindByFileID(ctx, fileID)
+			if err != nil {
+				return err
+			}
+
+			for _, i := range images {
+				if imageIDs[i.ID] {
+					continue
+				}
+
+				if err := i.LoadFiles(ctx, r.Image); err != nil {
+					return err
+				}
+
+				if allCleaned(i.Files.List()) {
+					imageIDs[i.ID] = true
+					ret.ImageIDs = append(ret.ImageIDs, i.ID)
+				}
+			}
+
+			galleries, err := r.Gallery.FindByFileID(ctx, fileID)
+			if err != nil {
+				return err
+			}
+
+			for _, g := range galleries {
+				if galleryIDs[g.ID] {
+					continue
+				}
+
+				if err := g.LoadFiles(ctx, r.Gallery); err != nil {
+					return err
+				}
+
+				if allCleaned(g.Files.List()) {
+					addGallery(g.ID)
+				}
+			}
+		}
+
+		for _, folderID := range cleanedFolders {
+			galleries, err := r.Gallery.FindByFolderID(ctx, folderID)
+			if err != nil {
+				return err
+			}
+
+			for _, g := range galleries {
+				addGallery(g.ID)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, id := range emptyGalleries {
+		addGallery(id)
+	}
+
+	return ret, nil
 }
 
 func (j *cleanJob) deleteGallery(ctx context.Context, id int) {
@@ -127,6 +301,10 @@ func (j *cleanJob) deleteGallery(ctx context.Context, id int) {
 			return err
 		}
 
+		if err := KeepDeletedGallery(ctx, r, g); err != nil {
+			return err
+		}
+
 		if err := qb.Destroy(ctx, id); err != nil {
 			return err
 		}
@@ -154,6 +332,7 @@ func newCleanFilter(c *config.Config) *cleanFilter {
 			generatedPath:     c.GetGeneratedPath(),
 			videoExcludeRegex: generateRegexps(c.GetExcludes()),
 			imageExcludeRegex: generateRegexps(c.GetImageExcludes()),
+			stashExcludeRegex: generateStashExcludeRegexps(c.GetStashPaths()),
 		},
 	}
 }
@@ -192,7 +371,7 @@ func (f *cleanFilter) Accept(ctx context.Context, path string, info fs.FileInfo)
 func (f *cleanFilter) shouldCleanFolder(path string, s *config.StashConfig) bool {
 	// only delete folders where it is excluded from everything
 	pathExcludeTest := path + string(filepath.Separator)
-	if (s.ExcludeVideo || matchFileRegex(pathExcludeTest, f.videoExcludeRegex)) && (s.ExcludeImage || matchFileRegex(pathExcludeTest, f.imageExcludeRegex)) {
+	if (s.ExcludeVideo || f.matchVideoExclude(pathExcludeTest, s)) && (s.ExcludeImage || f.matchImageExclude(pathExcludeTest, s)) {
 		logger.Infof("Folder is excluded from both video and image. Marking to clean: \"%s\"", path)
 		return true
 	}
@@ -220,7 +399,7 @@ func (f *cleanFilter) shouldCleanVideoFile(path string, stash *config.StashConfi
 		return true
 	}
 
-	if matchFileRegex(path, f.videoExcludeRegex) {
+	if f.matchVideoExclude(path, stash) {
 		logger.Infof("File matched regex. Marking to clean: \"%s\"", path)
 		return true
 	}
@@ -234,7 +413,7 @@ func (f *cleanFilter) shouldCleanGallery(path string, stash *config.StashConfig)
 		return true
 	}
 
-	if matchFileRegex(path, f.imageExcludeRegex) {
+	if f.matchImageExclude(path, stash) {
 		logger.Infof("File matched regex. Marking to clean: \"%s\"", path)
 		return true
 	}
@@ -248,7 +427,7 @@ func (f *cleanFilter) shouldCleanImage(path string, stash *config.StashConfig) b
 		return true
 	}
 
-	if matchFileRegex(path, f.imageExcludeRegex) {
+	if f.matchImageExclude(path, stash) {
 		logger.Infof("File matched regex. Marking to clean: \"%s\"", path)
 		return true
 	}
@@ -300,6 +479,10 @@ func (h *cleanHandler) handleRelatedScenes(ctx context.Context, fileDeleter *fil
 		// only delete if the scene has no other files
 		if len(scene.Files.List()) <= 1 {
 			logger.Infof("Deleting scene %q since it has no other related files", scene.DisplayName())
+			if err := KeepDeletedScene(ctx, mgr.Repository, scene); err != nil {
+				return err
+			}
+
 			if err := mgr.SceneService.Destroy(ctx, scene, sceneFileDeleter, true, false); err != nil {
 				return err
 			}
@@ -347,6 +530,10 @@ func (h *cleanHandler) handleRelatedGalleries(ctx context.Context, fileID models
 		// only delete if the gallery has no other files
 		if len(g.Files.List()) <= 1 {
 			logger.Infof("Deleting gallery %q since it has no other related files", g.DisplayName())
+			if err := KeepDeletedGallery(ctx, mgr.Repository, g); err != nil {
+				return err
+			}
+
 			if err := qb.Destroy(ctx, g.ID); err != nil {
 				return err
 			}
@@ -387,6 +574,10 @@ func (h *cleanHandler) deleteRelatedFolderGalleries(ctx context.Context, folderI
 
 	for _, g := range galleries {
 		logger.Infof("Deleting folder-based gallery %q since the folder no longer exists", g.DisplayName())
+		if err := KeepDeletedGallery(ctx, mgr.Repository, g); err != nil {
+			return err
+		}
+
 		if err := qb.Destroy(ctx, g.ID); err != nil {
 			return err
 		}
@@ -410,8 +601,9 @@ func (h *cleanHandler) handleRelatedImages(ctx context.Context, fileDeleter *fil
 	}
 
 	imageFileDeleter := &image.FileDeleter{
-		Deleter: fileDeleter,
-		Paths:   mgr.Paths,
+		Deleter:       fileDeleter,
+		Paths:         mgr.Paths,
+		ThumbnailSize: mgr.Config.GetImageThumbnailSize(),
 	}
 
 	for _, i := range images {
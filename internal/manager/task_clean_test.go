@@ -0,0 +1,95 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCleanJob_makeDryRunReport(t *testing.T) {
+	const (
+		// cleaned files and folders
+		sceneFileID   models.FileID   = 1
+		zipFileID     models.FileID   = 2
+		folderID      models.FolderID = 3
+		keptFileID    models.FileID   = 4
+		removedScene                  = 10
+		keptScene                     = 11
+		removedImage                  = 20
+		keptImage                     = 21
+		zipGallery                    = 30
+		folderGallery                 = 31
+		emptyGallery                  = 32
+	)
+
+	ctx := context.Background()
+	db := mocks.NewDatabase()
+
+	videoFile := func(id models.FileID) *models.VideoFile {
+		return &models.VideoFile{BaseFile: &models.BaseFile{ID: id}}
+	}
+	baseFile := func(id models.FileID) models.File {
+		return &models.BaseFile{ID: id}
+	}
+
+	db.Scene.On("FindByFileID", mock.Anything, sceneFileID).Return([]*models.Scene{
+		{ID: removedScene, Files: models.NewRelatedVideoFiles([]*models.VideoFile{videoFile(sceneFileID)})},
+		// scenes with files that are not cleaned are kept
+		{ID: keptScene, Files: models.NewRelatedVideoFiles([]*models.VideoFile{videoFile(sceneFileID), videoFile(keptFileID)})},
+	}, nil).Once()
+	db.Image.On("FindByFileID", mock.Anything, sceneFileID).Return(nil, nil).Once()
+	db.Gallery.On("FindByFileID", mock.Anything, sceneFileID).Return(nil, nil).Once()
+
+	db.Scene.On("FindByFileID", mock.Anything, zipFileID).Return(nil, nil).Once()
+	db.Image.On("FindByFileID", mock.Anything, zipFileID).Return([]*models.Image{
+		{ID: removedImage, Files: models.NewRelatedFiles([]models.File{baseFile(zipFileID)})},
+		{ID: keptImage, Files: models.NewRelatedFiles([]models.File{baseFile(zipFileID), baseFile(keptFileID)})},
+	}, nil).Once()
+	db.Gallery.On("FindByFileID", mock.Anything, zipFileID).Return([]*models.Gallery{
+		{ID: zipGallery, Files: models.NewRelatedFiles([]models.File{baseFile(zipFileID)})},
+	}, nil).Once()
+
+	db.Gallery.On("FindByFolderID", mock.Anything, folderID).Return([]*models.Gallery{
+		{ID: folderGallery},
+	}, nil).Once()
+
+	j := &cleanJob{
+		repository: db.Repository(),
+		input: CleanMetadataInput{
+			Paths:  []string{"/stash"},
+			DryRun: true,
+		},
+	}
+
+	cleaned := []file.CleanedItem{
+		{FileID: sceneFileID, Path: "/stash/scene.mp4", Reason: file.CleanReasonMissing},
+		{FileID: zipFileID, Path: "/stash/images.zip", Reason: file.CleanReasonExcluded},
+		{FolderID: folderID, Path: "/stash/folder", Reason: file.CleanReasonMissing},
+	}
+
+	report, err := j.makeDryRunReport(ctx, cleaned, []int{folderGallery, emptyGallery})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	db.AssertExpectations(t)
+
+	assert.Equal(t, []string{"/stash"}, report.Paths)
+	assert.Equal(t, []CleanReportItem{
+		{Path: "/stash/scene.mp4", Reason: file.CleanReasonMissing},
+		{Path: "/stash/images.zip", Reason: file.CleanReasonExcluded},
+	}, report.Files)
+	assert.Equal(t, []CleanReportItem{
+		{Path: "/stash/folder", Reason: file.CleanReasonMissing},
+	}, report.Folders)
+
+	assert.Equal(t, []int{removedScene}, report.SceneIDs)
+	assert.Equal(t, []int{removedImage}, report.ImageIDs)
+	// galleries are not repeated
+	assert.ElementsMatch(t, []int{zipGallery, folderGallery, emptyGallery}, report.GalleryIDs)
+}
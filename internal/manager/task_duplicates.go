@@ -0,0 +1,345 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+const (
+	duplicateReportFilename = "duplicates.json"
+
+	// defaultDuplicateDistance matches the medium accuracy of the duplicate
+	// checker in the UI
+	defaultDuplicateDistance = 8
+)
+
+type DuplicateConfidence string
+
+const (
+	// DuplicateConfidenceExact indicates the scenes have files with the same
+	// checksum or oshash.
+	DuplicateConfidenceExact DuplicateConfidence = "EXACT"
+	// DuplicateConfidenceHigh indicates the scenes have files with the same
+	// phash.
+	DuplicateConfidenceHigh DuplicateConfidence = "HIGH"
+	// DuplicateConfidenceMedium indicates the scenes have files with phashes
+	// within the configured distance.
+	DuplicateConfidenceMedium DuplicateConfidence = "MEDIUM"
+)
+
+type FindDuplicatesMetadataInput struct {
+	// Maximum phash distance for medium confidence duplicates. Defaults to 8.
+	Distance *int `json:"distance"`
+	// Maximum difference in seconds between scene durations. Negative values
+	// disable the duration check. Defaults to -1.
+	DurationDiff *float64 `json:"duration_diff"`
+}
+
+// DuplicateGroup is a group of scenes that are likely to be duplicates.
+type DuplicateGroup struct {
+	Confidence DuplicateConfidence `json:"confidence"`
+	SceneIDs   []int               `json:"scene_ids"`
+}
+
+// DuplicateReport contains the results of a find duplicates task.
+type DuplicateReport struct {
+	Time         time.Time        `json:"time"`
+	Distance     int              `json:"distance"`
+	DurationDiff float64          `json:"duration_diff"`
+	Groups       []DuplicateGroup `json:"groups"`
+}
+
+type findDuplicatesJob struct {
+	repository models.Repository
+	input      FindDuplicatesMetadataInput
+}
+
+func (j *findDuplicatesJob) Execute(ctx context.Context, progress *job.Progress) error {
+	logger.Info("Finding duplicate scenes")
+	start := time.Now()
+
+	distance := defaultDuplicateDistance
+	if j.input.Distance != nil {
+		distance = *j.input.Distance
+	}
+	durationDiff := -1.
+	if j.input.DurationDiff != nil {
+		durationDiff = *j.input.DurationDiff
+	}
+
+	var exact, high, medium [][]int
+
+	progress.SetTotal(3)
+
+	r := j.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+
+		progress.ExecuteTask("Finding scenes with matching hashes", func() {
+			exact, err = j.findHashDuplicates(ctx)
+		})
+		if err != nil {
+			return err
+		}
+		progress.Increment()
+
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		progress.ExecuteTask("Finding scenes with matching phashes", func() {
+			high, err = j.findPhashDuplicates(ctx, 0, durationDiff)
+		})
+		if err != nil {
+			return err
+		}
+		progress.Increment()
+
+		if job.IsCancelled(ctx) || distance <= 0 {
+			return nil
+		}
+
+		progress.ExecuteTask("Finding scenes with similar phashes", func() {
+			medium, err = j.findPhashDuplicates(ctx, distance, durationDiff)
+		})
+		if err != nil {
+			return err
+		}
+		progress.Increment()
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("finding duplicates: %w", err)
+	}
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	report := &DuplicateReport{
+		Time:         time.Now(),
+		Distance:     distance,
+		DurationDiff: durationDiff,
+		Groups:       buildDuplicateGroups(exact, high, medium),
+	}
+
+	instance.setDuplicateReport(report)
+
+	logger.Infof("Found %d groups of duplicate scenes in %s", len(report.Groups), time.Since(start))
+	return nil
+}
+
+// findHashDuplicates returns groups of scene IDs with files sharing a
+// checksum or oshash.
+func (j *findDuplicatesJob) findHashDuplicates(ctx context.Context) ([][]int, error) {
+	r := j.repository
+	byHash := make(map[string][]int)
+
+	if err := scene.BatchProcess(ctx, r.Scene, nil, nil, func(s *models.Scene) error {
+		if err := s.LoadFiles(ctx, r.Scene); err != nil {
+			return err
+		}
+
+		for _, f := range s.Files.List() {
+			for _, t := range []string{models.FingerprintTypeMD5, models.FingerprintTypeOshash} {
+				if v := f.Fingerprints.GetString(t); v != "" {
+					key := t + ":" + v
+					byHash[key] = append(byHash[key], s.ID)
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var ret [][]int
+	for _, ids := range byHash {
+		if len(ids) > 1 {
+			ret = append(ret, ids)
+		}
+	}
+
+	return mergeDuplicateGroups(ret), nil
+}
+
+func (j *findDuplicatesJob) findPhashDuplicates(ctx context.Context, distance int, durationDiff float64) ([][]int, error) {
+	dupes, err := j.repository.Scene.FindDuplicates(ctx, distance, durationDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([][]int, len(dupes))
+	for i, scenes := range dupes {
+		for _, s := range scenes {
+			ret[i] = append(ret[i], s.ID)
+		}
+	}
+
+	return ret, nil
+}
+
+// mergeDuplicateGroups merges groups that share a scene, returning groups
+// with unique, sorted scene IDs.
+func mergeDuplicateGroups(groups [][]int) [][]int {
+	parent := make(map[int]int)
+
+	var find func(id int) int
+	find = func(id int) int {
+		p, found := parent[id]
+		if !found {
+			parent[id] = id
+			return id
+		}
+		if p == id {
+			return id
+		}
+
+		root := find(p)
+		parent[id] = root
+		return root
+	}
+
+	for _, g := range groups {
+		for _, id := range g[1:] {
+			parent[find(id)] = find(g[0])
+		}
+	}
+
+	byRoot := make(map[int][]int)
+	for id := range parent {
+		root := find(id)
+		byRoot[root] = append(byRoot[root], id)
+	}
+
+	var ret [][]int
+	for _, ids := range byRoot {
+		if len(ids) > 1 {
+			sort.Ints(ids)
+			ret = append(ret, ids)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i][0] < ret[j][0]
+	})
+
+	return ret
+}
+
+// buildDuplicateGroups returns the duplicate groups for each confidence
+// level. Groups whose scenes are all contained in a group of a higher
+// confidence are omitted.
+func buildDuplicateGroups(exact, high, medium [][]int) []DuplicateGroup {
+	var ret []DuplicateGroup
+	var reported []map[int]bool
+
+	add := func(confidence DuplicateConfidence, groups [][]int) {
+		for _, ids := range mergeDuplicateGroups(groups) {
+			if duplicatesReported(ids, reported) {
+				continue
+			}
+
+			set := make(map[int]bool)
+			for _, id := range ids {
+				set[id] = true
+			}
+			reported = append(reported, set)
+
+			ret = append(ret, DuplicateGroup{
+				Confidence: confidence,
+				SceneIDs:   ids,
+			})
+		}
+	}
+
+	add(DuplicateConfidenceExact, exact)
+	add(DuplicateConfidenceHigh, high)
+	add(DuplicateConfidenceMedium, medium)
+
+	return ret
+}
+
+func duplicatesReported(ids []int, reported []map[int]bool) bool {
+	for _, set := range reported {
+		contained := true
+		for _, id := range ids {
+			if !set[id] {
+				contained = false
+				break
+			}
+		}
+
+		if contained {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Manager) duplicateReportPath() string {
+	return filepath.Join(s.Config.GetCachePath(), duplicateReportFilename)
+}
+
+// DuplicateReport returns the report of the most recent find duplicates
+// task. The report is persisted in the cache directory, so that it is
+// available after a restart. Returns nil if no report exists.
+func (s *Manager) DuplicateReport() *DuplicateReport {
+	s.duplicateReportMutex.Lock()
+	defer s.duplicateReportMutex.Unlock()
+
+	if s.duplicateReport != nil || s.Config.GetCachePath() == "" {
+		return s.duplicateReport
+	}
+
+	data, err := os.ReadFile(s.duplicateReportPath())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("error reading duplicate report: %v", err)
+		}
+		return nil
+	}
+
+	var report DuplicateReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		logger.Warnf("error reading duplicate report: %v", err)
+		return nil
+	}
+
+	s.duplicateReport = &report
+	return s.duplicateReport
+}
+
+func (s *Manager) setDuplicateReport(report *DuplicateReport) {
+	s.duplicateReportMutex.Lock()
+	defer s.duplicateReportMutex.Unlock()
+
+	s.duplicateReport = report
+
+	if s.Config.GetCachePath() == "" {
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err == nil {
+		err = os.WriteFile(s.duplicateReportPath(), data, 0644)
+	}
+
+	if err != nil {
+		logger.Warnf("error writing duplicate report: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeDuplicateGroups(t *testing.T) {
+	groups := [][]int{
+		{3, 1},
+		{5, 6},
+		{1, 4},
+		{6, 6},
+	}
+
+	assert.Equal(t, [][]int{{1, 3, 4}, {5, 6}}, mergeDuplicateGroups(groups))
+}
+
+func TestBuildDuplicateGroups(t *testing.T) {
+	exact := [][]int{{1, 2}}
+	high := [][]int{{1, 2}, {3, 4}}
+	medium := [][]int{{1, 2, 5}, {3, 4}, {6, 7}}
+
+	want := []DuplicateGroup{
+		{Confidence: DuplicateConfidenceExact, SceneIDs: []int{1, 2}},
+		{Confidence: DuplicateConfidenceHigh, SceneIDs: []int{3, 4}},
+		{Confidence: DuplicateConfidenceMedium, SceneIDs: []int{1, 2, 5}},
+		{Confidence: DuplicateConfidenceMedium, SceneIDs: []int{6, 7}},
+	}
+
+	assert.Equal(t, want, buildDuplicateGroups(exact, high, medium))
+}
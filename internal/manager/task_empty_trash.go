@@ -0,0 +1,106 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+var errTrashNotConfigured = errors.New("trash path is not set")
+
+type EmptyTrashInput struct {
+	// Delete all trashed files, regardless of the trash retention setting.
+	// Defaults to false.
+	All *bool `json:"all"`
+}
+
+type emptyTrashJob struct {
+	trash     file.Trash
+	retention int
+	all       bool
+}
+
+func (j *emptyTrashJob) Execute(ctx context.Context, progress *job.Progress) error {
+	var before time.Time
+	if !j.all {
+		if j.retention <= 0 {
+			logger.Info("Trash retention is not set. Not deleting any trashed files.")
+			return nil
+		}
+
+		before = time.Now().AddDate(0, 0, -j.retention)
+	}
+
+	deleted, err := j.trash.Empty(before)
+	if err != nil {
+		return fmt.Errorf("emptying trash: %w", err)
+	}
+
+	logger.Infof("Deleted %d files from the trash", deleted)
+	return nil
+}
+
+func (s *Manager) trash() (file.Trash, error) {
+	trashPath := s.Config.GetTrashPath()
+	if trashPath == "" {
+		return file.Trash{}, errTrashNotConfigured
+	}
+
+	return file.Trash{Path: trashPath}, nil
+}
+
+// EmptyTrash queues a job to permanently delete files from the trash
+// directory. Unless all is set, only files that have been in the trash for
+// longer than the trash retention setting are deleted.
+func (s *Manager) EmptyTrash(ctx context.Context, input EmptyTrashInput) (int, error) {
+	trash, err := s.trash()
+	if err != nil {
+		return 0, err
+	}
+
+	j := &emptyTrashJob{
+		trash:     trash,
+		retention: s.Config.GetTrashRetention(),
+		all:       input.All != nil && *input.All,
+	}
+
+	return s.JobManager.Add(ctx, "Emptying trash...", j), nil
+}
+
+// TrashedFiles returns the files in the trash directory, most recently
+// deleted first.
+func (s *Manager) TrashedFiles() ([]file.TrashedFile, error) {
+	trash, err := s.trash()
+	if err != nil {
+		// nothing has been trashed
+		return nil, nil
+	}
+
+	return trash.List()
+}
+
+// RestoreTrashedFiles moves the trashed files with the provided names back
+// to their original locations. The restored files are added to the library
+// by the next scan.
+func (s *Manager) RestoreTrashedFiles(names []string) error {
+	trash, err := s.trash()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		restored, err := trash.Restore(name)
+		if err != nil {
+			return fmt.Errorf("restoring %q: %w", name, err)
+		}
+
+		logger.Infof("Restored %q from the trash", restored)
+	}
+
+	return nil
+}
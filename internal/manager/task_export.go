@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/stashapp/stash/internal/build"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/gallery"
@@ -47,6 +48,9 @@ type ExportTask struct {
 	studios    *exportSpec
 	galleries  *exportSpec
 
+	sceneFilter     *models.SceneFilterType
+	performerFilter *models.PerformerFilterType
+
 	includeDependencies bool
 
 	DownloadHash string
@@ -58,15 +62,17 @@ type ExportObjectTypeInput struct {
 }
 
 type ExportObjectsInput struct {
-	Scenes              *ExportObjectTypeInput `json:"scenes"`
-	Images              *ExportObjectTypeInput `json:"images"`
-	Studios             *ExportObjectTypeInput `json:"studios"`
-	Performers          *ExportObjectTypeInput `json:"performers"`
-	Tags                *ExportObjectTypeInput `json:"tags"`
-	Groups              *ExportObjectTypeInput `json:"groups"`
-	Movies              *ExportObjectTypeInput `json:"movies"` // deprecated
-	Galleries           *ExportObjectTypeInput `json:"galleries"`
-	IncludeDependencies *bool                  `json:"includeDependencies"`
+	Scenes              *ExportObjectTypeInput      `json:"scenes"`
+	Images              *ExportObjectTypeInput      `json:"images"`
+	Studios             *ExportObjectTypeInput      `json:"studios"`
+	Performers          *ExportObjectTypeInput      `json:"performers"`
+	Tags                *ExportObjectTypeInput      `json:"tags"`
+	Groups              *ExportObjectTypeInput      `json:"groups"`
+	Movies              *ExportObjectTypeInput      `json:"movies"` // deprecated
+	Galleries           *ExportObjectTypeInput      `json:"galleries"`
+	SceneFilter         *models.SceneFilterType     `json:"sceneFilter"`
+	PerformerFilter     *models.PerformerFilterType `json:"performerFilter"`
+	IncludeDependencies *bool                       `json:"includeDependencies"`
 }
 
 type exportSpec struct {
@@ -92,6 +98,21 @@ func newExportSpec(input *ExportObjectTypeInput) *exportSpec {
 	return ret
 }
 
+// addIDs adds the ids that are not already included.
+func (s *exportSpec) addIDs(ids []int) {
+	existing := make(map[int]struct{}, len(s.IDs)+len(ids))
+	for _, id := range s.IDs {
+		existing[id] = struct{}{}
+	}
+
+	for _, id := range ids {
+		if _, found := existing[id]; !found {
+			existing[id] = struct{}{}
+			s.IDs = append(s.IDs, id)
+		}
+	}
+}
+
 func CreateExportTask(a models.HashAlgorithm, input ExportObjectsInput) *ExportTask {
 	includeDeps := false
 	if input.IncludeDependencies != nil {
@@ -114,6 +135,8 @@ func CreateExportTask(a models.HashAlgorithm, input ExportObjectsInput) *ExportT
 		tags:                newExportSpec(input.Tags),
 		studios:             newExportSpec(input.Studios),
 		galleries:           newExportSpec(input.Galleries),
+		sceneFilter:         input.SceneFilter,
+		performerFilter:     input.PerformerFilter,
 		includeDependencies: includeDeps,
 	}
 }
@@ -158,6 +181,10 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 	txnErr := t.repository.WithTxn(ctx, func(ctx context.Context) error {
 		// include group scenes and gallery images
 		if !t.full {
+			if err := t.populateFromFilters(ctx); err != nil {
+				return err
+			}
+
 			// only include group scenes if includeDependencies is also set
 			if !t.scenes.all && t.includeDependencies {
 				t.populateGroupScenes(ctx)
@@ -183,6 +210,10 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 		logger.Warnf("error while running export transaction: %v", txnErr)
 	}
 
+	if err := t.exportManifest(startTime); err != nil {
+		logger.Errorf("error writing export manifest: %v", err)
+	}
+
 	if !t.full {
 		err := t.generateDownload()
 		if err != nil {
@@ -193,6 +224,21 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 	logger.Infof("Export complete in %s.", time.Since(startTime))
 }
 
+// exportManifest writes the manifest file, which records the format version
+// of the export so that it can be checked on import.
+func (t *ExportTask) exportManifest(startTime time.Time) error {
+	version, _, _ := build.Version()
+
+	manifest := &jsonschema.Manifest{
+		Version:       jsonschema.ManifestVersion,
+		StashVersion:  version,
+		SchemaVersion: GetInstance().Database.AppSchemaVersion(),
+		CreatedAt:     json.JSONTime{Time: startTime},
+	}
+
+	return t.json.saveManifest(manifest)
+}
+
 func (t *ExportTask) generateDownload() error {
 	// zip the files and register a download link
 	if err := fsutil.EnsureDir(instance.Paths.Generated.Downloads); err != nil {
@@ -225,6 +271,11 @@ func (t *ExportTask) zipFiles(w io.Writer) error {
 		json: *paths.GetJSONPaths(""),
 	}
 
+	// the manifest is required to check the export version on import
+	if err := t.zipFile(t.json.json.Manifest, "", z); err != nil {
+		return err
+	}
+
 	walkWarn(t.json.json.Tags, t.zipWalkFunc(u.json.Tags, z))
 	walkWarn(t.json.json.Galleries, t.zipWalkFunc(u.json.Galleries, z))
 	walkWarn(t.json.json.Performers, t.zipWalkFunc(u.json.Performers, z))
@@ -282,6 +333,38 @@ func (t *ExportTask) zipFile(fn, outDir string, z *zip.Writer) error {
 	return nil
 }
 
+// populateFromFilters adds the scenes and performers matching the provided
+// filters to the objects to export.
+func (t *ExportTask) populateFromFilters(ctx context.Context) error {
+	r := t.repository
+
+	if t.sceneFilter != nil && !t.scenes.all {
+		var ids []int
+		if err := scene.BatchProcess(ctx, r.Scene, t.sceneFilter, nil, func(s *models.Scene) error {
+			ids = append(ids, s.ID)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("finding scenes matching filter: %w", err)
+		}
+
+		t.scenes.addIDs(ids)
+	}
+
+	if t.performerFilter != nil && !t.performers.all {
+		perPage := models.PerPageAll
+		performers, _, err := r.Performer.Query(ctx, t.performerFilter, &models.FindFilterType{
+			PerPage: &perPage,
+		})
+		if err != nil {
+			return fmt.Errorf("finding performers matching filter: %w", err)
+		}
+
+		t.performers.addIDs(performer.GetIDs(performers))
+	}
+
+	return nil
+}
+
 func (t *ExportTask) populateGroupScenes(ctx context.Context) {
 	r := t.repository
 	reader := r.Group
@@ -0,0 +1,180 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+type ExportNFOInput struct {
+	// Paths to export NFO files for, null for all scenes
+	Paths []string `json:"paths"`
+	// Replace existing NFO and image files. Defaults to false.
+	Overwrite *bool `json:"overwrite"`
+	// Write the scene cover as poster and fanart images. Defaults to true.
+	Images *bool `json:"images"`
+}
+
+type exportNFOJob struct {
+	repository models.Repository
+	input      ExportNFOInput
+}
+
+func (j *exportNFOJob) Execute(ctx context.Context, progress *job.Progress) error {
+	logger.Info("Exporting NFO files")
+	start := time.Now()
+
+	exported := 0
+
+	if err := processNFOScenes(ctx, j.repository, j.input.Paths, progress, func(s *models.Scene) {
+		progress.ExecuteTask(fmt.Sprintf("Exporting NFO for %s", s.DisplayName()), func() {
+			written, err := j.exportScene(ctx, s)
+			if err != nil {
+				logger.Errorf("error exporting NFO for %s: %v", s.DisplayName(), err)
+				return
+			}
+
+			if written {
+				exported++
+			}
+		})
+	}); err != nil {
+		return err
+	}
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	logger.Infof("Exported NFO files for %d scenes in %s", exported, time.Since(start))
+	return nil
+}
+
+func (j *exportNFOJob) overwrite() bool {
+	return j.input.Overwrite != nil && *j.input.Overwrite
+}
+
+func (j *exportNFOJob) exportScene(ctx context.Context, s *models.Scene) (bool, error) {
+	if s.Path == "" {
+		return false, nil
+	}
+
+	nfoPath := scene.NFOPath(s.Path)
+	if !j.overwrite() {
+		if exists, _ := fsutil.FileExists(nfoPath); exists {
+			return false, nil
+		}
+	}
+
+	writeImages := j.input.Images == nil || *j.input.Images
+
+	var nfo *scene.NFO
+	var cover []byte
+	r := j.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		nfo, err = scene.ToNFO(ctx, r.Studio, r.Performer, r.Tag, s)
+		if err != nil {
+			return err
+		}
+
+		if writeImages {
+			cover, err = r.Scene.GetCover(ctx, s.ID)
+			if err != nil {
+				return fmt.Errorf("getting cover: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	if len(cover) > 0 {
+		ext := coverExtension(cover)
+		for _, kind := range []string{"poster", "fanart"} {
+			imagePath := scene.NFOImagePath(s.Path, kind, ext)
+			if err := j.writeFile(imagePath, cover); err != nil {
+				return false, err
+			}
+
+			// relative to the NFO file
+			nfo.Thumbs = append(nfo.Thumbs, scene.NFOThumb{
+				Aspect: kind,
+				Path:   scene.NFOImagePath(filepath.Base(s.Path), kind, ext),
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := scene.WriteNFO(&buf, nfo); err != nil {
+		return false, fmt.Errorf("encoding NFO: %w", err)
+	}
+
+	if err := j.writeFile(nfoPath, buf.Bytes()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// writeFile writes data to path, unless the file exists and overwrite is not
+// set.
+func (j *exportNFOJob) writeFile(path string, data []byte) error {
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !j.overwrite() {
+		flag |= os.O_EXCL
+	}
+
+	f, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil
+		}
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func coverExtension(data []byte) string {
+	switch contentType := http.DetectContentType(data); {
+	case strings.HasPrefix(contentType, "image/png"):
+		return ".png"
+	case strings.HasPrefix(contentType, "image/webp"):
+		return ".webp"
+	case strings.HasPrefix(contentType, "image/gif"):
+		return ".gif"
+	default:
+		return ".jpg"
+	}
+}
+
+// ExportNFO queues a job to write Kodi compatible NFO files, along with
+// poster and fanart images, next to the scene video files.
+func (s *Manager) ExportNFO(ctx context.Context, input ExportNFOInput) int {
+	j := &exportNFOJob{
+		repository: s.Repository,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Exporting NFO files...", j)
+}
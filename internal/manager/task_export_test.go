@@ -0,0 +1,109 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportZip writes an export directory containing a single tag and the
+// provided manifest, and returns the zip created from it.
+func exportZip(t *testing.T, manifest *jsonschema.Manifest) string {
+	t.Helper()
+
+	exportDir := t.TempDir()
+	paths.EnsureJSONDirs(exportDir)
+
+	task := &ExportTask{
+		json: jsonUtils{
+			json: *paths.GetJSONPaths(exportDir),
+		},
+	}
+
+	if err := jsonschema.SaveTagFile(filepath.Join(task.json.json.Tags, "tag.json"), &jsonschema.Tag{Name: "tag"}); err != nil {
+		t.Fatalf("writing tag: %v", err)
+	}
+	if err := task.json.saveManifest(manifest); err != nil {
+		t.Fatalf("writing manifest: %v", err)
+	}
+
+	zipPath := filepath.Join(t.TempDir(), "export.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("creating zip: %v", err)
+	}
+	defer f.Close()
+
+	if err := task.zipFiles(f); err != nil {
+		t.Fatalf("zipping export: %v", err)
+	}
+
+	return zipPath
+}
+
+// importZip unzips the export into an import directory and checks its
+// manifest, as done by ImportTask.Start.
+func importZip(t *testing.T, zipPath string) (*ImportTask, error) {
+	t.Helper()
+
+	task := &ImportTask{
+		BaseDir: t.TempDir(),
+		TmpZip:  zipPath,
+	}
+
+	if err := task.unzipFile(); err != nil {
+		t.Fatalf("unzipping export: %v", err)
+	}
+
+	task.json = jsonUtils{
+		json: *paths.GetJSONPaths(task.BaseDir),
+	}
+
+	return task, task.checkManifest()
+}
+
+func TestExportImportManifest(t *testing.T) {
+	t.Run("current version", func(t *testing.T) {
+		task, err := importZip(t, exportZip(t, &jsonschema.Manifest{
+			Version:      jsonschema.ManifestVersion,
+			StashVersion: "v0.0.0",
+		}))
+		assert.Nil(t, err)
+
+		manifest, err := jsonschema.LoadManifestFile(task.json.json.Manifest)
+		if assert.Nil(t, err) {
+			assert.Equal(t, jsonschema.ManifestVersion, manifest.Version)
+			assert.Equal(t, "v0.0.0", manifest.StashVersion)
+		}
+
+		tag, err := jsonschema.LoadTagFile(filepath.Join(task.json.json.Tags, "tag.json"))
+		if assert.Nil(t, err) {
+			assert.Equal(t, "tag", tag.Name)
+		}
+	})
+
+	t.Run("newer version", func(t *testing.T) {
+		_, err := importZip(t, exportZip(t, &jsonschema.Manifest{
+			Version: jsonschema.ManifestVersion + 1,
+		}))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestExportSpec_addIDs(t *testing.T) {
+	s := &exportSpec{IDs: []int{3, 1}}
+
+	s.addIDs([]int{1, 2, 2, 4, 3})
+	assert.Equal(t, []int{3, 1, 2, 4}, s.IDs)
+
+	s.addIDs(nil)
+	assert.Equal(t, []int{3, 1, 2, 4}, s.IDs)
+
+	empty := &exportSpec{}
+	empty.addIDs([]int{5, 5})
+	assert.Equal(t, []int{5}, empty.IDs)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
@@ -36,8 +37,12 @@ type GenerateMetadataInput struct {
 	SceneIDs []string `json:"sceneIDs"`
 	// marker ids to generate for
 	MarkerIDs []string `json:"markerIDs"`
+	// generate for scenes matching the filter, in addition to scenes selected by ID
+	SceneFilter *models.SceneFilterType `json:"sceneFilter"`
 	// overwrite existing media
 	Overwrite bool `json:"overwrite"`
+	// overwrite existing media of these types only
+	OverwriteTypes []models.GeneratedArtifactType `json:"overwriteTypes"`
 }
 
 type GeneratePreviewOptionsInput struct {
@@ -51,6 +56,10 @@ type GeneratePreviewOptionsInput struct {
 	PreviewExcludeEnd *string `json:"previewExcludeEnd"`
 	// Preset when generating preview
 	PreviewPreset *models.PreviewPreset `json:"previewPreset"`
+	// Width of the preview, in pixels
+	PreviewWidth *int `json:"previewWidth"`
+	// Include audio stream in the preview
+	PreviewAudio *bool `json:"previewAudio"`
 }
 
 const generateQueueSize = 200000
@@ -63,6 +72,9 @@ type GenerateJob struct {
 	fileNamingAlgo models.HashAlgorithm
 
 	totals totalsGenerate
+
+	// existing generated files that are not yet recorded in the database
+	unrecorded []sceneGeneratedArtifact
 }
 
 type totalsGenerate struct {
@@ -113,17 +125,30 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 			MarkerPaths:  instance.Paths.SceneMarkers,
 			ScenePaths:   instance.Paths.Scene,
 			Overwrite:    j.overwrite,
+			Throttle:     instance.GenerateThrottle,
 		}
 
 		r := j.repository
 		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
 			qb := r.Scene
-			if len(j.input.SceneIDs) == 0 && len(j.input.MarkerIDs) == 0 {
+			if len(j.input.SceneIDs) == 0 && len(j.input.MarkerIDs) == 0 && j.input.SceneFilter == nil {
 				j.queueTasks(ctx, g, queue)
 			} else {
+				// scenes may match the filter and also be selected by id
+				queued := make(map[int]bool)
+
+				if j.input.SceneFilter != nil {
+					j.queueScenesTasks(ctx, g, j.input.SceneFilter, queued, queue)
+				}
+
 				if len(j.input.SceneIDs) > 0 {
 					scenes, err = qb.FindMany(ctx, sceneIDs)
 					for _, s := range scenes {
+						if queued[s.ID] {
+							continue
+						}
+						queued[s.ID] = true
+
 						if err := s.LoadFiles(ctx, qb); err != nil {
 							return err
 						}
@@ -149,6 +174,12 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 			return
 		}
 
+		if len(j.unrecorded) > 0 {
+			logger.Infof("Recording %d existing generated files", len(j.unrecorded))
+			recordGeneratedArtifacts(ctx, r, j.unrecorded)
+			j.unrecorded = nil
+		}
+
 		totals := j.totals
 		logMsg := "Generating"
 		if j.input.Covers {
@@ -212,8 +243,12 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		// #1879 - need to make a copy of f - otherwise there is a race condition
 		// where f is changed when the goroutine runs
 		localTask := f
-		go progress.ExecuteTask(localTask.GetDescription(), func() {
-			localTask.Start(ctx)
+		go progress.ExecuteTaskProgress(localTask.GetDescription(), func(setPercent func(float64)) {
+			if pt, ok := localTask.(ProgressTask); ok {
+				pt.StartProgress(ctx, setPercent)
+			} else {
+				localTask.Start(ctx)
+			}
 			wg.Done()
 			progress.Increment()
 		})
@@ -234,11 +269,14 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 func (j *GenerateJob) queueTasks(ctx context.Context, g *generate.Generator, queue chan<- Task) {
 	j.totals = totalsGenerate{}
 
-	j.queueScenesTasks(ctx, g, queue)
+	j.queueScenesTasks(ctx, g, nil, nil, queue)
 	j.queueImagesTasks(ctx, g, queue)
 }
 
-func (j *GenerateJob) queueScenesTasks(ctx context.Context, g *generate.Generator, queue chan<- Task) {
+// queueScenesTasks queues the generate tasks for the scenes matching
+// sceneFilter. If queued is not nil, the ids of the queued scenes are added
+// to it, and scenes already in it are skipped.
+func (j *GenerateJob) queueScenesTasks(ctx context.Context, g *generate.Generator, sceneFilter *models.SceneFilterType, queued map[int]bool, queue chan<- Task) {
 	const batchSize = 1000
 
 	findFilter := models.BatchFindFilter(batchSize)
@@ -250,7 +288,7 @@ func (j *GenerateJob) queueScenesTasks(ctx context.Context, g *generate.Generato
 			return
 		}
 
-		scenes, err := scene.Query(ctx, r.Scene, nil, findFilter)
+		scenes, err := scene.Query(ctx, r.Scene, sceneFilter, findFilter)
 		if err != nil {
 			logger.Errorf("Error encountered queuing files to scan: %s", err.Error())
 			return
@@ -261,6 +299,13 @@ func (j *GenerateJob) queueScenesTasks(ctx context.Context, g *generate.Generato
 				return
 			}
 
+			if queued != nil {
+				if queued[ss.ID] {
+					continue
+				}
+				queued[ss.ID] = true
+			}
+
 			if err := ss.LoadFiles(ctx, r.Scene); err != nil {
 				logger.Errorf("Error encountered queuing files to scan: %s", err.Error())
 				return
@@ -325,6 +370,7 @@ func getGeneratePreviewOptions(optionsInput GeneratePreviewOptionsInput) generat
 		ExcludeStart:    config.GetPreviewExcludeStart(),
 		ExcludeEnd:      config.GetPreviewExcludeEnd(),
 		Preset:          config.GetPreviewPreset().String(),
+		Width:           config.GetPreviewWidth(),
 		Audio:           config.GetPreviewAudio(),
 	}
 
@@ -348,12 +394,70 @@ func getGeneratePreviewOptions(optionsInput GeneratePreviewOptionsInput) generat
 		ret.Preset = optionsInput.PreviewPreset.String()
 	}
 
+	if optionsInput.PreviewWidth != nil && *optionsInput.PreviewWidth > 0 {
+		ret.Width = *optionsInput.PreviewWidth
+	}
+
+	if optionsInput.PreviewAudio != nil {
+		ret.Audio = *optionsInput.PreviewAudio
+	}
+
 	return ret
 }
 
+// overwriteType returns true if existing generated files of type t should be
+// overwritten.
+func (j *GenerateJob) overwriteType(t models.GeneratedArtifactType) bool {
+	return j.overwrite || sliceutil.Contains(j.input.OverwriteTypes, t)
+}
+
+// overwriteGenerator returns a generator that overwrites existing files if
+// any of the provided types are to be overwritten.
+func (j *GenerateJob) overwriteGenerator(g *generate.Generator, types ...models.GeneratedArtifactType) *generate.Generator {
+	if g.Overwrite {
+		return g
+	}
+
+	for _, t := range types {
+		if j.overwriteType(t) {
+			ret := *g
+			ret.Overwrite = true
+			return &ret
+		}
+	}
+
+	return g
+}
+
 func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator, scene *models.Scene, queue chan<- Task) {
 	r := j.repository
 
+	sceneHash := scene.GetHash(j.fileNamingAlgo)
+	generated, err := r.Scene.GetGeneratedArtifacts(ctx, scene.ID)
+	if err != nil {
+		logger.Warnf("error getting generated files for scene %d: %v", scene.ID, err)
+	}
+
+	// isGenerated returns true if the artifact is recorded as generated, its
+	// files exist and it should not be overwritten
+	isGenerated := func(t models.GeneratedArtifactType) bool {
+		return !j.overwriteType(t) && artifactGenerated(instance.Paths, generated, t, sceneHash)
+	}
+
+	// notRequired records generated files that exist but were not recorded
+	notRequired := func(t models.GeneratedArtifactType) {
+		if models.FindGeneratedArtifact(generated, t, sceneHash) == nil && generatedArtifactExists(instance.Paths, t, sceneHash) {
+			j.unrecorded = append(j.unrecorded, sceneGeneratedArtifact{
+				sceneID: scene.ID,
+				artifact: models.GeneratedArtifact{
+					Type:        t,
+					Hash:        sceneHash,
+					GeneratedAt: time.Now(),
+				},
+			})
+		}
+	}
+
 	if j.input.Covers {
 		task := &GenerateCoverTask{
 			repository: r,
@@ -368,10 +472,11 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
-	if j.input.Sprites {
+	if j.input.Sprites && !isGenerated(models.GeneratedArtifactTypeSprite) {
 		task := &GenerateSpriteTask{
+			repository:          r,
 			Scene:               *scene,
-			Overwrite:           j.overwrite,
+			Overwrite:           j.overwriteType(models.GeneratedArtifactTypeSprite),
 			fileNamingAlgorithm: j.fileNamingAlgo,
 		}
 
@@ -379,6 +484,8 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			j.totals.sprites++
 			j.totals.tasks++
 			queue <- task
+		} else {
+			notRequired(models.GeneratedArtifactTypeSprite)
 		}
 	}
 
@@ -390,22 +497,39 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 
 	if j.input.Previews {
 		task := &GeneratePreviewTask{
+			repository:          r,
 			Scene:               *scene,
 			ImagePreview:        j.input.ImagePreviews,
 			Options:             options,
-			Overwrite:           j.overwrite,
+			Overwrite:           j.overwriteType(models.GeneratedArtifactTypePreview),
+			OverwriteImage:      j.overwriteType(models.GeneratedArtifactTypeImagePreview),
 			fileNamingAlgorithm: j.fileNamingAlgo,
-			generator:           g,
+			generator:           j.overwriteGenerator(g, models.GeneratedArtifactTypePreview, models.GeneratedArtifactTypeImagePreview),
 		}
 
-		if task.required() {
-			if task.videoPreviewRequired() {
-				j.totals.previews++
-			}
-			if task.imagePreviewRequired() {
-				j.totals.imagePreviews++
-			}
+		// the files of recorded previews have already been checked
+		recorded := true
+		if isGenerated(models.GeneratedArtifactTypePreview) {
+			task.videoPreviewExists = &recorded
+		}
+		if isGenerated(models.GeneratedArtifactTypeImagePreview) {
+			task.imagePreviewExists = &recorded
+		}
 
+		videoRequired := task.videoPreviewRequired()
+		imageRequired := task.imagePreviewRequired()
+		if videoRequired {
+			j.totals.previews++
+		} else {
+			notRequired(models.GeneratedArtifactTypePreview)
+		}
+		if imageRequired {
+			j.totals.imagePreviews++
+		} else if j.input.ImagePreviews {
+			notRequired(models.GeneratedArtifactTypeImagePreview)
+		}
+
+		if videoRequired || imageRequired {
 			j.totals.tasks++
 			queue <- task
 		}
@@ -432,19 +556,22 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
-	if j.input.Transcodes {
+	if j.input.Transcodes && !isGenerated(models.GeneratedArtifactTypeTranscode) {
 		forceTranscode := j.input.ForceTranscodes
 		task := &GenerateTranscodeTask{
+			repository:          r,
 			Scene:               *scene,
-			Overwrite:           j.overwrite,
+			Overwrite:           j.overwriteType(models.GeneratedArtifactTypeTranscode),
 			Force:               forceTranscode,
 			fileNamingAlgorithm: j.fileNamingAlgo,
-			g:                   g,
+			g:                   j.overwriteGenerator(g, models.GeneratedArtifactTypeTranscode),
 		}
 		if task.required() {
 			j.totals.transcodes++
 			j.totals.tasks++
 			queue <- task
+		} else {
+			notRequired(models.GeneratedArtifactTypeTranscode)
 		}
 	}
 
@@ -466,11 +593,11 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
-	if j.input.InteractiveHeatmapsSpeeds {
+	if j.input.InteractiveHeatmapsSpeeds && !isGenerated(models.GeneratedArtifactTypeInteractiveHeatmap) {
 		task := &GenerateInteractiveHeatmapSpeedTask{
 			repository:          r,
 			Scene:               *scene,
-			Overwrite:           j.overwrite,
+			Overwrite:           j.overwriteType(models.GeneratedArtifactTypeInteractiveHeatmap),
 			fileNamingAlgorithm: j.fileNamingAlgo,
 		}
 
@@ -478,6 +605,8 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			j.totals.interactiveHeatmapSpeeds++
 			j.totals.tasks++
 			queue <- task
+		} else {
+			notRequired(models.GeneratedArtifactTypeInteractiveHeatmap)
 		}
 	}
 }
@@ -488,6 +617,8 @@ func (j *GenerateJob) queueMarkerJob(g *generate.Generator, marker *models.Scene
 		Marker:              marker,
 		Overwrite:           j.overwrite,
 		fileNamingAlgorithm: j.fileNamingAlgo,
+		ImagePreview:        j.input.MarkerImagePreviews,
+		Screenshot:          j.input.MarkerScreenshots,
 		generator:           g,
 	}
 	j.totals.markers++
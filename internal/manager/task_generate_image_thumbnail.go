@@ -25,15 +25,16 @@ func (t *GenerateImageThumbnailTask) Start(ctx context.Context) {
 		return
 	}
 
-	thumbPath := GetInstance().Paths.Generated.GetThumbnailPath(t.Image.Checksum, models.DefaultGthumbWidth)
+	mgr := GetInstance()
+	c := mgr.Config
+
+	thumbSize := c.GetImageThumbnailSize()
+	thumbPath := mgr.Paths.Generated.GetThumbnailPath(t.Image.Checksum, thumbSize)
 	f := t.Image.Files.Primary()
 	path := f.Base().Path
 
 	logger.Debugf("Generating thumbnail for %s", path)
 
-	mgr := GetInstance()
-	c := mgr.Config
-
 	clipPreviewOptions := image.ClipPreviewOptions{
 		InputArgs:  c.GetTranscodeInputArgs(),
 		OutputArgs: c.GetTranscodeOutputArgs(),
@@ -41,7 +42,7 @@ func (t *GenerateImageThumbnailTask) Start(ctx context.Context) {
 	}
 
 	encoder := image.NewThumbnailEncoder(mgr.FFMpeg, mgr.FFProbe, clipPreviewOptions)
-	data, err := encoder.GetThumbnail(f, models.DefaultGthumbWidth)
+	data, err := encoder.GetThumbnail(f, thumbSize)
 
 	if err != nil {
 		// don't log for animated images
@@ -64,7 +65,8 @@ func (t *GenerateImageThumbnailTask) required() bool {
 		return false
 	}
 
-	if vf.GetHeight() <= models.DefaultGthumbWidth && vf.GetWidth() <= models.DefaultGthumbWidth {
+	thumbSize := GetInstance().Config.GetImageThumbnailSize()
+	if vf.GetHeight() <= thumbSize && vf.GetWidth() <= thumbSize {
 		return false
 	}
 
@@ -72,7 +74,7 @@ func (t *GenerateImageThumbnailTask) required() bool {
 		return true
 	}
 
-	thumbPath := GetInstance().Paths.Generated.GetThumbnailPath(t.Image.Checksum, models.DefaultGthumbWidth)
+	thumbPath := GetInstance().Paths.Generated.GetThumbnailPath(t.Image.Checksum, thumbSize)
 	exists, _ := fsutil.FileExists(thumbPath)
 
 	return !exists
@@ -50,7 +50,10 @@ func (t *GenerateInteractiveHeatmapSpeedTask) Start(ctx context.Context) {
 		return qb.Update(ctx, primaryFile)
 	}); err != nil && ctx.Err() == nil {
 		logger.Error(err.Error())
+		return
 	}
+
+	recordGeneratedArtifact(ctx, r, t.Scene.ID, models.GeneratedArtifactTypeInteractiveHeatmap, videoChecksum)
 }
 
 func (t *GenerateInteractiveHeatmapSpeedTask) required() bool {
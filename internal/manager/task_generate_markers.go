@@ -109,7 +109,10 @@ func (t *GenerateMarkersTask) generateMarker(videoFile *models.VideoFile, scene
 
 	g := t.generator
 
-	if err := g.MarkerPreviewVideo(context.TODO(), videoFile.Path, sceneHash, seconds, instance.Config.GetPreviewAudio()); err != nil {
+	// skip the audio stream if the file has none
+	includeAudio := instance.Config.GetPreviewAudio() && videoFile.AudioCodec != ""
+
+	if err := g.MarkerPreviewVideo(context.TODO(), videoFile.Path, sceneHash, seconds, includeAudio); err != nil {
 		logger.Errorf("[generator] failed to generate marker video: %v", err)
 		logErrorOutput(err)
 	}
@@ -11,12 +11,16 @@ import (
 )
 
 type GeneratePreviewTask struct {
+	repository   models.Repository
 	Scene        models.Scene
 	ImagePreview bool
 
 	Options generate.PreviewOptions
 
-	Overwrite           bool
+	// Overwrite overwrites the video preview
+	Overwrite bool
+	// OverwriteImage overwrites the image preview
+	OverwriteImage      bool
 	fileNamingAlgorithm models.HashAlgorithm
 
 	generator *generate.Generator
@@ -30,6 +34,10 @@ func (t *GeneratePreviewTask) GetDescription() string {
 }
 
 func (t *GeneratePreviewTask) Start(ctx context.Context) {
+	t.StartProgress(ctx, nil)
+}
+
+func (t *GeneratePreviewTask) StartProgress(ctx context.Context, progress func(percent float64)) {
 	videoChecksum := t.Scene.GetHash(t.fileNamingAlgorithm)
 
 	if t.videoPreviewRequired() {
@@ -40,22 +48,33 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 			return
 		}
 
-		if err := t.generateVideo(videoChecksum, videoFile.VideoStreamDuration, videoFile.FrameRate); err != nil {
+		options := t.Options
+		if options.Audio && videoFile.AudioStream == nil {
+			logger.Debugf("[generator] %s has no audio stream, generating preview without audio", t.Scene.Path)
+			options.Audio = false
+		}
+
+		if err := t.generateVideo(videoChecksum, videoFile.VideoStreamDuration, videoFile.FrameRate, options, progress); err != nil {
 			logger.Errorf("error generating preview: %v", err)
 			logErrorOutput(err)
 			return
 		}
+
+		recordGeneratedArtifact(ctx, t.repository, t.Scene.ID, models.GeneratedArtifactTypePreview, videoChecksum)
 	}
 
 	if t.imagePreviewRequired() {
 		if err := t.generateWebp(videoChecksum); err != nil {
 			logger.Errorf("error generating preview webp: %v", err)
 			logErrorOutput(err)
+			return
 		}
+
+		recordGeneratedArtifact(ctx, t.repository, t.Scene.ID, models.GeneratedArtifactTypeImagePreview, videoChecksum)
 	}
 }
 
-func (t *GeneratePreviewTask) generateVideo(videoChecksum string, videoDuration float64, videoFrameRate float64) error {
+func (t *GeneratePreviewTask) generateVideo(videoChecksum string, videoDuration float64, videoFrameRate float64, options generate.PreviewOptions, progress func(percent float64)) error {
 	videoFilename := t.Scene.Path
 	useVsync2 := false
 
@@ -64,9 +83,12 @@ func (t *GeneratePreviewTask) generateVideo(videoChecksum string, videoDuration
 		useVsync2 = true
 	}
 
-	if err := t.generator.PreviewVideo(context.TODO(), videoFilename, videoDuration, videoChecksum, t.Options, false, useVsync2); err != nil {
+	g := *t.generator
+	g.Progress = progress
+
+	if err := g.PreviewVideo(context.TODO(), videoFilename, videoDuration, videoChecksum, options, false, useVsync2); err != nil {
 		logger.Warnf("[generator] failed generating scene preview, trying fallback")
-		if err := t.generator.PreviewVideo(context.TODO(), videoFilename, videoDuration, videoChecksum, t.Options, true, useVsync2); err != nil {
+		if err := g.PreviewVideo(context.TODO(), videoFilename, videoDuration, videoChecksum, options, true, useVsync2); err != nil {
 			return err
 		}
 	}
@@ -114,7 +136,7 @@ func (t *GeneratePreviewTask) imagePreviewRequired() bool {
 		return false
 	}
 
-	if t.Overwrite {
+	if t.OverwriteImage {
 		return true
 	}
 
@@ -10,6 +10,7 @@ import (
 )
 
 type GenerateSpriteTask struct {
+	repository          models.Repository
 	Scene               models.Scene
 	Overwrite           bool
 	fileNamingAlgorithm models.HashAlgorithm
@@ -20,6 +21,10 @@ func (t *GenerateSpriteTask) GetDescription() string {
 }
 
 func (t *GenerateSpriteTask) Start(ctx context.Context) {
+	t.StartProgress(ctx, nil)
+}
+
+func (t *GenerateSpriteTask) StartProgress(ctx context.Context, progress func(percent float64)) {
 	if !t.required() {
 		return
 	}
@@ -41,12 +46,15 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 		return
 	}
 	generator.Overwrite = t.Overwrite
+	generator.Progress = progress
 
 	if err := generator.Generate(); err != nil {
 		logger.Errorf("error generating sprite: %s", err.Error())
 		logErrorOutput(err)
 		return
 	}
+
+	recordGeneratedArtifact(ctx, t.repository, t.Scene.ID, models.GeneratedArtifactTypeSprite, sceneHash)
 }
 
 // required returns true if the sprite needs to be generated
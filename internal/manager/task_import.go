@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/stashapp/stash/pkg/file"
@@ -107,6 +108,11 @@ func (t *ImportTask) Start(ctx context.Context) {
 		json: *paths.GetJSONPaths(t.BaseDir),
 	}
 
+	if err := t.checkManifest(); err != nil {
+		logger.Errorf("error importing metadata: %v", err)
+		return
+	}
+
 	// set default behaviour if not provided
 	if !t.DuplicateBehaviour.IsValid() {
 		t.DuplicateBehaviour = ImportDuplicateEnumFail
@@ -135,6 +141,29 @@ func (t *ImportTask) Start(ctx context.Context) {
 	t.ImportImages(ctx)
 }
 
+// checkManifest returns an error if the manifest indicates that the export
+// was written in a format newer than this version supports. Exports made
+// before the manifest was introduced have no manifest and are assumed to be
+// compatible.
+func (t *ImportTask) checkManifest() error {
+	manifest, err := jsonschema.LoadManifestFile(t.json.json.Manifest)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Debug("no manifest found in import, assuming legacy export")
+			return nil
+		}
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	if manifest.Version > jsonschema.ManifestVersion {
+		return fmt.Errorf("export version %d is newer than the supported version %d", manifest.Version, jsonschema.ManifestVersion)
+	}
+
+	logger.Infof("Importing metadata exported by stash version %q at %s", manifest.StashVersion, manifest.CreatedAt.Time.Format(time.RFC3339))
+
+	return nil
+}
+
 func (t *ImportTask) unzipFile() error {
 	defer func() {
 		err := os.Remove(t.TmpZip)
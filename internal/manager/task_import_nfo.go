@@ -0,0 +1,168 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+type ImportNFOInput struct {
+	// Paths to import NFO files for, null for all scenes
+	Paths []string `json:"paths"`
+	// Replace existing title, details, director, date and studio values.
+	// Defaults to false.
+	Overwrite *bool `json:"overwrite"`
+	// Create missing studios, performers and tags. Defaults to true.
+	CreateMissing *bool `json:"createMissing"`
+}
+
+type importNFOJob struct {
+	repository      models.Repository
+	input           ImportNFOInput
+	videoExtensions []string
+}
+
+func (j *importNFOJob) Execute(ctx context.Context, progress *job.Progress) error {
+	logger.Info("Importing NFO files")
+	start := time.Now()
+
+	r := j.repository
+
+	missingRefBehaviour := models.ImportMissingRefEnumCreate
+	if j.input.CreateMissing != nil && !*j.input.CreateMissing {
+		missingRefBehaviour = models.ImportMissingRefEnumIgnore
+	}
+
+	updater := scene.NFOUpdater{
+		ReaderWriter:        r.Scene,
+		StudioWriter:        r.Studio,
+		PerformerWriter:     r.Performer,
+		TagWriter:           r.Tag,
+		Overwrite:           j.input.Overwrite != nil && *j.input.Overwrite,
+		MissingRefBehaviour: missingRefBehaviour,
+	}
+
+	updated := 0
+
+	if err := processNFOScenes(ctx, r, j.input.Paths, progress, func(s *models.Scene) {
+		progress.ExecuteTask(fmt.Sprintf("Importing NFO for %s", s.DisplayName()), func() {
+			changed, err := j.importScene(ctx, &updater, s)
+			if err != nil {
+				logger.Errorf("error importing NFO for %s: %v", s.DisplayName(), err)
+				return
+			}
+
+			if changed {
+				updated++
+			}
+		})
+	}); err != nil {
+		return err
+	}
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	logger.Infof("Updated %d scenes from NFO files in %s", updated, time.Since(start))
+	return nil
+}
+
+// processNFOScenes calls fn for each scene with a file in paths, or for all
+// scenes if paths is empty. Progress is incremented after each scene. Stops
+// without error if the job is cancelled.
+func processNFOScenes(ctx context.Context, r models.Repository, paths []string, progress *job.Progress, fn func(s *models.Scene)) error {
+	var sceneFilter *models.SceneFilterType
+	if len(paths) > 0 {
+		sceneFilter = scene.FilterFromPaths(paths)
+	}
+
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		pp := 0
+		result, err := r.Scene.Query(ctx, models.SceneQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: &models.FindFilterType{PerPage: &pp},
+				Count:      true,
+			},
+			SceneFilter: sceneFilter,
+		})
+		if err != nil {
+			return err
+		}
+		progress.SetTotal(result.Count)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("counting scenes: %w", err)
+	}
+
+	findFilter := models.BatchFindFilter(1000)
+	for more := true; more; {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		var scenes []*models.Scene
+		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			scenes, err = scene.Query(ctx, r.Scene, sceneFilter, findFilter)
+			return err
+		}); err != nil {
+			return fmt.Errorf("querying scenes: %w", err)
+		}
+
+		for _, s := range scenes {
+			if job.IsCancelled(ctx) {
+				return nil
+			}
+
+			fn(s)
+			progress.Increment()
+		}
+
+		if len(scenes) != *findFilter.PerPage {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
+
+	return nil
+}
+
+func (j *importNFOJob) importScene(ctx context.Context, updater *scene.NFOUpdater, s *models.Scene) (bool, error) {
+	if s.Path == "" {
+		return false, nil
+	}
+
+	nfo, err := scene.FindNFO(s.Path, j.videoExtensions)
+	if err != nil || nfo == nil {
+		return false, err
+	}
+
+	changed := false
+	err = j.repository.WithTxn(ctx, func(ctx context.Context) error {
+		var err error
+		changed, err = updater.Update(ctx, s, nfo)
+		return err
+	})
+
+	return changed, err
+}
+
+// ImportNFO queues a job to set scene metadata from Kodi and Plex NFO files
+// next to the scene video files.
+func (s *Manager) ImportNFO(ctx context.Context, input ImportNFOInput) int {
+	j := &importNFOJob{
+		repository:      s.Repository,
+		input:           input,
+		videoExtensions: s.Config.GetVideoExtensions(),
+	}
+
+	return s.JobManager.Add(ctx, "Importing NFO files...", j)
+}
@@ -0,0 +1,411 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/hash/oshash"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/paths"
+)
+
+const integrityReportFilename = "integrity.json"
+
+type IntegrityProblem string
+
+const (
+	// IntegrityProblemFileMissing indicates a file in the database does not
+	// exist on disk.
+	IntegrityProblemFileMissing IntegrityProblem = "FILE_MISSING"
+	// IntegrityProblemFileSizeChanged indicates the size of a file on disk
+	// differs from the size in the database.
+	IntegrityProblemFileSizeChanged IntegrityProblem = "FILE_SIZE_CHANGED"
+	// IntegrityProblemChecksumMismatch indicates the checksum of a file on
+	// disk differs from the checksum in the database.
+	IntegrityProblemChecksumMismatch IntegrityProblem = "CHECKSUM_MISMATCH"
+	// IntegrityProblemGeneratedEmpty indicates a generated file is empty.
+	IntegrityProblemGeneratedEmpty IntegrityProblem = "GENERATED_EMPTY"
+	// IntegrityProblemGeneratedCorrupt indicates the content of a generated
+	// file does not match its file type.
+	IntegrityProblemGeneratedCorrupt IntegrityProblem = "GENERATED_CORRUPT"
+)
+
+type IntegrityCheckInput struct {
+	// Paths to check files in, null for all files
+	Paths []string `json:"paths"`
+	// Recalculate the checksums of each file and compare them with the
+	// database. This reads every file in full. Defaults to false.
+	VerifyChecksums *bool `json:"verifyChecksums"`
+	// Check generated files for empty or corrupt files. Defaults to true.
+	CheckGenerated *bool `json:"checkGenerated"`
+}
+
+// IntegrityReportItem is a problem found by the integrity check task.
+type IntegrityReportItem struct {
+	Path string `json:"path"`
+	// ID of the library file. Zero for generated files.
+	FileID  models.FileID    `json:"file_id,omitempty"`
+	Problem IntegrityProblem `json:"problem"`
+	Detail  string           `json:"detail,omitempty"`
+}
+
+// IntegrityReport contains the results of an integrity check task.
+type IntegrityReport struct {
+	Time             time.Time             `json:"time"`
+	Paths            []string              `json:"paths"`
+	FilesChecked     int                   `json:"files_checked"`
+	GeneratedChecked int                   `json:"generated_checked"`
+	Items            []IntegrityReportItem `json:"items"`
+}
+
+type integrityCheckJob struct {
+	repository models.Repository
+	paths      *paths.Paths
+	input      IntegrityCheckInput
+	report     *IntegrityReport
+}
+
+func (j *integrityCheckJob) Execute(ctx context.Context, progress *job.Progress) error {
+	logger.Info("Checking library integrity")
+	start := time.Now()
+
+	j.report = &IntegrityReport{
+		Paths: j.input.Paths,
+	}
+
+	if err := j.checkFiles(ctx, progress); err != nil {
+		return fmt.Errorf("checking files: %w", err)
+	}
+
+	checkGenerated := j.input.CheckGenerated == nil || *j.input.CheckGenerated
+	if checkGenerated && !job.IsCancelled(ctx) {
+		j.checkGenerated(ctx, progress)
+	}
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	j.report.Time = time.Now()
+	instance.setIntegrityReport(j.report)
+
+	logger.Infof("Found %d integrity problems in %s", len(j.report.Items), time.Since(start))
+	return nil
+}
+
+func (j *integrityCheckJob) addItem(item IntegrityReportItem) {
+	logger.Warnf("Integrity check: %s: %s %s", item.Path, item.Problem, item.Detail)
+	j.report.Items = append(j.report.Items, item)
+}
+
+func (j *integrityCheckJob) checkFiles(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		total, err := r.File.CountAllInPaths(ctx, j.input.Paths)
+		if err != nil {
+			return err
+		}
+		progress.SetTotal(total)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	const batchSize = 1000
+	verifyChecksums := j.input.VerifyChecksums != nil && *j.input.VerifyChecksums
+
+	for offset := 0; ; offset += batchSize {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		var files []models.File
+		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			files, err = r.File.FindAllInPaths(ctx, j.input.Paths, batchSize, offset)
+			return err
+		}); err != nil {
+			return err
+		}
+
+		for _, f := range files {
+			if job.IsCancelled(ctx) {
+				return nil
+			}
+
+			base := f.Base()
+
+			// the containing zip file is checked instead
+			if base.ZipFileID == nil {
+				progress.ExecuteTask(fmt.Sprintf("Checking %s", base.Path), func() {
+					j.checkFile(base, verifyChecksums)
+				})
+			}
+
+			j.report.FilesChecked++
+			progress.Increment()
+		}
+
+		if len(files) != batchSize {
+			return nil
+		}
+	}
+}
+
+func (j *integrityCheckJob) checkFile(f *models.BaseFile, verifyChecksums bool) {
+	item := IntegrityReportItem{
+		Path:   f.Path,
+		FileID: f.ID,
+	}
+
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		item.Problem = IntegrityProblemFileMissing
+		if !errors.Is(err, fs.ErrNotExist) {
+			item.Detail = err.Error()
+		}
+		j.addItem(item)
+		return
+	}
+
+	if info.Size() != f.Size {
+		item.Problem = IntegrityProblemFileSizeChanged
+		item.Detail = fmt.Sprintf("expected %d bytes, found %d", f.Size, info.Size())
+		j.addItem(item)
+		return
+	}
+
+	if !verifyChecksums {
+		return
+	}
+
+	if detail := verifyFileChecksums(f); detail != "" {
+		item.Problem = IntegrityProblemChecksumMismatch
+		item.Detail = detail
+		j.addItem(item)
+	}
+}
+
+// verifyFileChecksums recalculates the md5 and oshash fingerprints of the
+// file, returning a description of any mismatch, or an empty string if the
+// fingerprints match.
+func verifyFileChecksums(f *models.BaseFile) string {
+	type calculator func(path string) (string, error)
+	calculators := []struct {
+		fpType string
+		fn     calculator
+	}{
+		{models.FingerprintTypeOshash, oshash.FromFilePath},
+		{models.FingerprintTypeMD5, md5.FromFilePath},
+	}
+
+	for _, c := range calculators {
+		expected := f.Fingerprints.GetString(c.fpType)
+		if expected == "" {
+			continue
+		}
+
+		actual, err := c.fn(f.Path)
+		if err != nil {
+			return fmt.Sprintf("calculating %s: %v", c.fpType, err)
+		}
+
+		if actual != expected {
+			return fmt.Sprintf("%s is %s, expected %s", c.fpType, actual, expected)
+		}
+	}
+
+	return ""
+}
+
+func (j *integrityCheckJob) checkGenerated(ctx context.Context, progress *job.Progress) {
+	if j.paths == nil || j.paths.Generated == nil {
+		return
+	}
+
+	g := j.paths.Generated
+	dirs := []string{
+		g.Screenshots,
+		g.Thumbnails,
+		g.Vtt,
+		g.Markers,
+		g.Transcodes,
+		g.InteractiveHeatmap,
+	}
+
+	progress.ExecuteTask("Checking generated files", func() {
+		for _, dir := range dirs {
+			err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					if errors.Is(err, fs.ErrNotExist) {
+						return nil
+					}
+					return err
+				}
+
+				if job.IsCancelled(ctx) {
+					return filepath.SkipAll
+				}
+
+				if d.IsDir() {
+					return nil
+				}
+
+				j.report.GeneratedChecked++
+				if problem, detail := checkGeneratedFile(path); problem != "" {
+					j.addItem(IntegrityReportItem{
+						Path:    path,
+						Problem: problem,
+						Detail:  detail,
+					})
+				}
+
+				return nil
+			})
+
+			if err != nil {
+				logger.Errorf("error checking generated files in %s: %v", dir, err)
+			}
+		}
+	})
+}
+
+var (
+	jpegHeader = []byte{0xff, 0xd8, 0xff}
+	jpegFooter = []byte{0xff, 0xd9}
+	pngHeader  = []byte{0x89, 'P', 'N', 'G'}
+)
+
+// checkGeneratedFile returns the problem with a generated file, or an empty
+// problem if the file appears valid. Files are checked for zero size, and
+// for a valid header based on the file extension.
+func checkGeneratedFile(path string) (IntegrityProblem, string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return IntegrityProblemGeneratedCorrupt, err.Error()
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return IntegrityProblemGeneratedCorrupt, err.Error()
+	}
+
+	if info.Size() == 0 {
+		return IntegrityProblemGeneratedEmpty, ""
+	}
+
+	header := make([]byte, 12)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return IntegrityProblemGeneratedCorrupt, err.Error()
+	}
+	header = header[:n]
+
+	valid := true
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		valid = bytes.HasPrefix(header, jpegHeader)
+		if valid {
+			// truncated jpegs are missing the end of image marker
+			footer := make([]byte, len(jpegFooter))
+			if _, err := f.ReadAt(footer, info.Size()-int64(len(footer))); err != nil || !bytes.Equal(footer, jpegFooter) {
+				return IntegrityProblemGeneratedCorrupt, "truncated jpeg"
+			}
+		}
+	case ".png":
+		valid = bytes.HasPrefix(header, pngHeader)
+	case ".webp":
+		valid = len(header) == 12 && string(header[0:4]) == "RIFF" && string(header[8:12]) == "WEBP"
+	case ".mp4":
+		valid = len(header) >= 8 && string(header[4:8]) == "ftyp"
+	case ".vtt":
+		valid = strings.HasPrefix(strings.TrimPrefix(string(header), "\ufeff"), "WEBVTT")
+	}
+
+	if !valid {
+		return IntegrityProblemGeneratedCorrupt, "invalid file header"
+	}
+
+	return "", ""
+}
+
+func (s *Manager) integrityReportPath() string {
+	return filepath.Join(s.Config.GetCachePath(), integrityReportFilename)
+}
+
+// IntegrityReport returns the report of the most recent integrity check
+// task. The report is persisted in the cache directory, so that it is
+// available after a restart. Returns nil if no report exists.
+func (s *Manager) IntegrityReport() *IntegrityReport {
+	s.integrityReportMutex.Lock()
+	defer s.integrityReportMutex.Unlock()
+
+	if s.integrityReport != nil || s.Config.GetCachePath() == "" {
+		return s.integrityReport
+	}
+
+	data, err := os.ReadFile(s.integrityReportPath())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("error reading integrity report: %v", err)
+		}
+		return nil
+	}
+
+	var report IntegrityReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		logger.Warnf("error reading integrity report: %v", err)
+		return nil
+	}
+
+	s.integrityReport = &report
+	return s.integrityReport
+}
+
+func (s *Manager) setIntegrityReport(report *IntegrityReport) {
+	s.integrityReportMutex.Lock()
+	defer s.integrityReportMutex.Unlock()
+
+	s.integrityReport = report
+
+	if s.Config.GetCachePath() == "" {
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err == nil {
+		err = os.WriteFile(s.integrityReportPath(), data, 0644)
+	}
+
+	if err != nil {
+		logger.Warnf("error writing integrity report: %v", err)
+	}
+}
+
+// CheckIntegrity queues a job to check that library files exist and are
+// unchanged, and that generated files are not empty or corrupt. The results
+// are available from IntegrityReport once the job completes.
+func (s *Manager) CheckIntegrity(ctx context.Context, input IntegrityCheckInput) int {
+	j := &integrityCheckJob{
+		repository: s.Repository,
+		paths:      s.Paths,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Checking library integrity...", j)
+}
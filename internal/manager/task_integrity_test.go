@@ -0,0 +1,45 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckGeneratedFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    IntegrityProblem
+	}{
+		{"empty.jpg", []byte{}, IntegrityProblemGeneratedEmpty},
+		{"valid.jpg", []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0xff, 0xd9}, ""},
+		{"truncated.jpg", []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x00}, IntegrityProblemGeneratedCorrupt},
+		{"html.jpg", []byte("<html></html>"), IntegrityProblemGeneratedCorrupt},
+		{"valid.webp", []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), ""},
+		{"invalid.webp", []byte("RIFF\x00\x00\x00\x00WAVE"), IntegrityProblemGeneratedCorrupt},
+		{"valid.mp4", []byte("\x00\x00\x00\x18ftypmp42"), ""},
+		{"invalid.mp4", []byte("\x00\x00\x00\x00\x00\x00"), IntegrityProblemGeneratedCorrupt},
+		{"valid.png", []byte("\x89PNG\r\n\x1a\n"), ""},
+		{"valid.vtt", []byte("WEBVTT\n\n"), ""},
+		{"bom.vtt", []byte("\ufeffWEBVTT\n\n"), ""},
+		{"invalid.vtt", []byte("00:00.000 --> 00:01.000"), IntegrityProblemGeneratedCorrupt},
+		{"unknown.m3u8", []byte("#EXTM3U"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			if err := os.WriteFile(path, tt.content, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, _ := checkGeneratedFile(path)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
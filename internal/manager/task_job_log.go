@@ -0,0 +1,201 @@
+package manager
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stashapp/stash/internal/log"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	jobLogDir    = "job_logs"
+	jobLogSuffix = ".log"
+	maxJobLogs   = 50
+)
+
+// jobLogger writes the log output of each job to a file in the cache
+// directory, so that the log of a job can be retrieved after it finishes.
+// Log entries are attributed to all jobs running at the time, so the log of a
+// job may include entries from jobs started concurrently.
+type jobLogger struct {
+	logger *log.Logger
+	dir    func() string
+
+	mutex  sync.Mutex
+	active map[int]*activeJobLog
+}
+
+type activeJobLog struct {
+	file *os.File
+	stop func()
+}
+
+func newJobLogger(l *log.Logger, dir func() string) *jobLogger {
+	return &jobLogger{
+		logger: l,
+		dir:    dir,
+		active: make(map[int]*activeJobLog),
+	}
+}
+
+func jobLogFilename(dir string, jobID int) string {
+	return filepath.Join(dir, strconv.Itoa(jobID)+jobLogSuffix)
+}
+
+// clear removes all job logs. Job IDs are not unique across restarts, so
+// logs from previous runs are removed at startup.
+func (l *jobLogger) clear() {
+	dir := l.dir()
+	if dir == "" {
+		return
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		logger.Warnf("error removing job logs: %v", err)
+	}
+}
+
+func (l *jobLogger) JobStarted(j job.Job) {
+	dir := l.dir()
+	if dir == "" {
+		return
+	}
+
+	if err := fsutil.EnsureDirAll(dir); err != nil {
+		logger.Warnf("error creating job log directory: %v", err)
+		return
+	}
+
+	f, err := os.Create(jobLogFilename(dir, j.ID))
+	if err != nil {
+		logger.Warnf("error creating log for job %d: %v", j.ID, err)
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.active[j.ID] = &activeJobLog{
+		file: f,
+		stop: l.logger.Capture(f),
+	}
+}
+
+func (l *jobLogger) JobFinished(j job.Job) {
+	l.mutex.Lock()
+	a := l.active[j.ID]
+	delete(l.active, j.ID)
+	l.mutex.Unlock()
+
+	if a == nil {
+		return
+	}
+
+	a.stop()
+	if err := a.file.Close(); err != nil {
+		logger.Warnf("error closing log for job %d: %v", j.ID, err)
+	}
+
+	l.removeOldLogs(filepath.Dir(a.file.Name()))
+}
+
+// removeOldLogs removes the oldest job logs, keeping at most maxJobLogs.
+func (l *jobLogger) removeOldLogs(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var ids []int
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), jobLogSuffix)
+		if !ok {
+			continue
+		}
+
+		id, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	if len(ids) <= maxJobLogs {
+		return
+	}
+
+	sort.Ints(ids)
+	for _, id := range ids[:len(ids)-maxJobLogs] {
+		if err := os.Remove(jobLogFilename(dir, id)); err != nil {
+			logger.Warnf("error removing log for job %d: %v", id, err)
+		}
+	}
+}
+
+// read returns the log entries of the job with the provided ID. Returns nil
+// if there is no log for the job.
+func (l *jobLogger) read(jobID int) ([]log.LogItem, error) {
+	dir := l.dir()
+	if dir == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(jobLogFilename(dir, jobID))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	ret, err := log.ReadLogItems(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// an empty log is distinct from no log
+	if ret == nil {
+		ret = []log.LogItem{}
+	}
+
+	return ret, nil
+}
+
+func (s *Manager) startJobLogs() {
+	if s.Logger == nil {
+		return
+	}
+
+	s.jobLogger = newJobLogger(s.Logger, func() string {
+		cachePath := s.Config.GetCachePath()
+		if cachePath == "" {
+			return ""
+		}
+		return filepath.Join(cachePath, jobLogDir)
+	})
+
+	s.jobLogger.clear()
+	s.JobManager.AddHook(s.jobLogger)
+}
+
+// JobLog returns the log entries written while the job with the provided ID
+// was running, oldest first. Logs are kept for the most recent jobs until
+// stash is restarted. Returns nil if there is no log for the job.
+func (s *Manager) JobLog(jobID int) ([]log.LogItem, error) {
+	if s.jobLogger == nil {
+		return nil, nil
+	}
+
+	return s.jobLogger.read(jobID)
+}
@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/notification"
+)
+
+const notificationTimeout = time.Minute
+
+// ValidateTaskNotifications returns an error if any of the provided task
+// notifications are invalid.
+func ValidateTaskNotifications(notifications []*config.TaskNotification) error {
+	for _, n := range notifications {
+		if !n.Type.IsValid() {
+			return fmt.Errorf("invalid notification type %q", n.Type)
+		}
+
+		for _, t := range n.Tasks {
+			if !t.IsValid() {
+				return fmt.Errorf("invalid task type %q", t)
+			}
+		}
+
+		switch n.Type {
+		case config.NotificationTypeEmail:
+			if len(n.To) == 0 {
+				return fmt.Errorf("email notification requires at least one recipient")
+			}
+		default:
+			if n.URL == "" {
+				return fmt.Errorf("%s notification requires a url", n.Type)
+			}
+		}
+	}
+
+	return nil
+}
+
+func newNotifier(n config.TaskNotification, smtp config.SMTPSettings) (notification.Notifier, error) {
+	switch n.Type {
+	case config.NotificationTypeWebhook:
+		return notification.Webhook{URL: n.URL}, nil
+	case config.NotificationTypeGotify:
+		return notification.Gotify{URL: n.URL, Token: n.Token}, nil
+	case config.NotificationTypeNtfy:
+		return notification.Ntfy{URL: n.URL, Token: n.Token}, nil
+	case config.NotificationTypeEmail:
+		return notification.Email{
+			SMTP: notification.SMTPSettings{
+				Host:     smtp.Host,
+				Port:     smtp.Port,
+				Username: smtp.Username,
+				Password: smtp.Password,
+				From:     smtp.From,
+			},
+			To: n.To,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown notification type %q", n.Type)
+	}
+}
+
+// AddJob queues a job and records its task type, so that notifications can
+// be filtered by task type when the job finishes. The type is recorded before
+// the job can be removed from the queue, so it is available even if the job
+// finishes immediately.
+func (s *Manager) AddJob(ctx context.Context, description string, e job.JobExec, t config.NotificationTaskType) int {
+	s.jobTaskTypesMutex.Lock()
+	defer s.jobTaskTypesMutex.Unlock()
+
+	if s.jobTaskTypes == nil {
+		s.jobTaskTypes = make(map[int]config.NotificationTaskType)
+	}
+
+	jobID := s.JobManager.Add(ctx, description, e)
+	s.jobTaskTypes[jobID] = t
+	return jobID
+}
+
+func (s *Manager) popJobTaskType(jobID int) config.NotificationTaskType {
+	s.jobTaskTypesMutex.Lock()
+	defer s.jobTaskTypesMutex.Unlock()
+
+	ret := s.jobTaskTypes[jobID]
+	delete(s.jobTaskTypes, jobID)
+	return ret
+}
+
+// startTaskNotifications sends the configured task notifications when jobs
+// finish or fail, until ctx is cancelled.
+func (s *Manager) startTaskNotifications(ctx context.Context) {
+	sub := s.JobManager.Subscribe(ctx)
+	go func() {
+		for j := range sub.RemovedJob {
+			s.notifyJobFinished(j)
+		}
+	}()
+}
+
+func (s *Manager) notifyJobFinished(j job.Job) {
+	taskType := s.popJobTaskType(j.ID)
+
+	// cancelled and paused jobs are not reported
+	if j.Status != job.StatusFinished && j.Status != job.StatusFailed {
+		return
+	}
+
+	failed := j.Status == job.StatusFailed
+	m := jobNotificationMessage(j)
+
+	var smtp *config.SMTPSettings
+	for _, n := range s.Config.GetTaskNotifications() {
+		if !n.Matches(taskType, failed) {
+			continue
+		}
+
+		if smtp == nil {
+			settings := s.Config.GetSMTPSettings()
+			smtp = &settings
+		}
+
+		notifier, err := newNotifier(*n, *smtp)
+		if err != nil {
+			logger.Errorf("error sending %s task notification: %v", n.Type, err)
+			continue
+		}
+
+		go func(t config.NotificationType) {
+			ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+			defer cancel()
+
+			if err := notifier.Notify(ctx, m); err != nil {
+				logger.Errorf("error sending %s task notification: %v", t, err)
+			}
+		}(n.Type)
+	}
+}
+
+func jobNotificationMessage(j job.Job) notification.Message {
+	desc := strings.TrimRight(j.Description, ".")
+
+	var elapsed string
+	if j.StartTime != nil && j.EndTime != nil {
+		elapsed = " in " + formatDuration(j.EndTime.Sub(*j.StartTime))
+	}
+
+	if j.Status == job.StatusFailed {
+		text := fmt.Sprintf("Task %q failed%s.", desc, elapsed)
+		if j.Error != nil {
+			text += "\n" + *j.Error
+		}
+
+		return notification.Message{
+			Title:  "Stash task failed",
+			Text:   text,
+			Failed: true,
+		}
+	}
+
+	return notification.Message{
+		Title: "Stash task finished",
+		Text:  fmt.Sprintf("Task %q finished%s.", desc, elapsed),
+	}
+}
+
+// TestTaskNotification sends a test message using the provided notification
+// settings.
+func (s *Manager) TestTaskNotification(ctx context.Context, n config.TaskNotification) error {
+	if err := ValidateTaskNotifications([]*config.TaskNotification{&n}); err != nil {
+		return err
+	}
+
+	notifier, err := newNotifier(n, s.Config.GetSMTPSettings())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+
+	return notifier.Notify(ctx, notification.Message{
+		Title: "Stash test notification",
+		Text:  "Task notifications are configured correctly.",
+	})
+}
@@ -0,0 +1,37 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddJobTaskType(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &Manager{
+		JobManager: job.NewManager(),
+	}
+	sub := s.JobManager.Subscribe(ctx)
+
+	// the job finishes as soon as it is started
+	exec := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		return nil
+	})
+	jobID := s.AddJob(ctx, "test", exec, config.NotificationTaskTypeScan)
+
+	select {
+	case j := <-sub.RemovedJob:
+		assert.Equal(t, jobID, j.ID)
+		assert.Equal(t, config.NotificationTaskTypeScan, s.popJobTaskType(j.ID))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for job to finish")
+	}
+
+	assert.Empty(t, s.jobTaskTypes, "task type should be removed once read")
+}
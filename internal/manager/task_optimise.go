@@ -12,45 +12,153 @@ import (
 type Optimiser interface {
 	Analyze(ctx context.Context) error
 	Vacuum(ctx context.Context) error
+	IntegrityCheck(ctx context.Context) ([]string, error)
+	Size(ctx context.Context) (int64, error)
+}
+
+type OptimiseDatabaseInput struct {
+	// Run ANALYZE on the database. Defaults to true.
+	Analyze *bool `json:"analyze"`
+	// Run VACUUM on the database. Defaults to true.
+	Vacuum *bool `json:"vacuum"`
+	// Check the integrity of the database before optimising. Defaults to false.
+	IntegrityCheck *bool `json:"integrityCheck"`
+}
+
+func (i OptimiseDatabaseInput) analyze() bool {
+	return i.Analyze == nil || *i.Analyze
+}
+
+func (i OptimiseDatabaseInput) vacuum() bool {
+	return i.Vacuum == nil || *i.Vacuum
+}
+
+func (i OptimiseDatabaseInput) integrityCheck() bool {
+	return i.IntegrityCheck != nil && *i.IntegrityCheck
+}
+
+// OptimiseDatabaseReport contains the results of an optimise database task.
+type OptimiseDatabaseReport struct {
+	Time             time.Time
+	SizeBefore       int64
+	SizeAfter        int64
+	Analyzed         bool
+	Vacuumed         bool
+	IntegrityChecked bool
+	// IntegrityErrors are the problems found by the integrity check
+	IntegrityErrors []string
 }
 
 type OptimiseDatabaseJob struct {
 	Optimiser Optimiser
+	Input     OptimiseDatabaseInput
 }
 
 func (j *OptimiseDatabaseJob) Execute(ctx context.Context, progress *job.Progress) error {
 	logger.Info("Optimising database")
-	progress.SetTotal(2)
-
 	start := time.Now()
 
-	var err error
+	report, err := j.optimise(ctx, progress)
+	if report != nil {
+		instance.setOptimiseDatabaseReport(report)
+	}
+	if err != nil {
+		return err
+	}
 
-	progress.ExecuteTask("Analyzing database", func() {
-		err = j.Optimiser.Analyze(ctx)
-		progress.Increment()
-	})
 	if job.IsCancelled(ctx) {
 		logger.Info("Stopping due to user request")
 		return nil
 	}
+
+	elapsed := time.Since(start)
+	logger.Infof("Finished optimising database after %s. Database size was %d bytes, now %d bytes", elapsed, report.SizeBefore, report.SizeAfter)
+	return nil
+}
+
+// optimise runs the selected operations, returning the report. The report
+// is nil if the task was cancelled or if the database size could not be
+// read.
+func (j *OptimiseDatabaseJob) optimise(ctx context.Context, progress *job.Progress) (*OptimiseDatabaseReport, error) {
+	total := 0
+	for _, selected := range []bool{j.Input.integrityCheck(), j.Input.analyze(), j.Input.vacuum()} {
+		if selected {
+			total++
+		}
+	}
+	progress.SetTotal(total)
+
+	size, err := j.Optimiser.Size(ctx)
 	if err != nil {
-		return fmt.Errorf("Error analyzing database: %w", err)
+		return nil, fmt.Errorf("getting database size: %w", err)
 	}
 
-	progress.ExecuteTask("Vacuuming database", func() {
-		err = j.Optimiser.Vacuum(ctx)
-		progress.Increment()
-	})
-	if job.IsCancelled(ctx) {
-		logger.Info("Stopping due to user request")
-		return nil
+	report := &OptimiseDatabaseReport{
+		SizeBefore: size,
+		SizeAfter:  size,
+	}
+
+	if j.Input.integrityCheck() {
+		var problems []string
+		progress.ExecuteTask("Checking database integrity", func() {
+			problems, err = j.Optimiser.IntegrityCheck(ctx)
+			progress.Increment()
+		})
+		if job.IsCancelled(ctx) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error checking database integrity: %w", err)
+		}
+
+		report.IntegrityChecked = true
+		report.IntegrityErrors = problems
+		report.Time = time.Now()
+
+		if len(problems) > 0 {
+			for _, p := range problems {
+				logger.Errorf("Database integrity check: %s", p)
+			}
+			// don't modify a damaged database
+			return report, fmt.Errorf("database integrity check found %d problems", len(problems))
+		}
 	}
+
+	if j.Input.analyze() {
+		progress.ExecuteTask("Analyzing database", func() {
+			err = j.Optimiser.Analyze(ctx)
+			progress.Increment()
+		})
+		if job.IsCancelled(ctx) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error analyzing database: %w", err)
+		}
+
+		report.Analyzed = true
+	}
+
+	if j.Input.vacuum() {
+		progress.ExecuteTask("Vacuuming database", func() {
+			err = j.Optimiser.Vacuum(ctx)
+			progress.Increment()
+		})
+		if job.IsCancelled(ctx) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error vacuuming database: %w", err)
+		}
+
+		report.Vacuumed = true
+	}
+
+	report.SizeAfter, err = j.Optimiser.Size(ctx)
 	if err != nil {
-		return fmt.Errorf("error vacuuming database: %w", err)
+		return nil, fmt.Errorf("getting database size: %w", err)
 	}
 
-	elapsed := time.Since(start)
-	logger.Infof("Finished optimising database after %s", elapsed)
-	return nil
+	report.Time = time.Now()
+	return report, nil
 }
@@ -0,0 +1,235 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const pausedJobsFilename = "paused_jobs.json"
+
+type PausedJobType string
+
+const (
+	PausedJobTypeScan     PausedJobType = "SCAN"
+	PausedJobTypeGenerate PausedJobType = "GENERATE"
+)
+
+// PausedJob is a scan or generate job that was paused, along with the input
+// required to resume it. The progress of the job is not kept: resuming a job
+// queues it again from the start with the same input. Scan and generate jobs
+// skip work that has already been completed, so the resumed job quickly
+// passes over the files processed before it was paused. Jobs that redo
+// completed work - scans with rescan set, and generate jobs with overwrite
+// set - would repeat all of it, so they cannot be paused.
+type PausedJob struct {
+	ID          int           `json:"id"`
+	Type        PausedJobType `json:"type"`
+	Description string        `json:"description"`
+	PausedAt    time.Time     `json:"paused_at"`
+
+	Scan     *ScanMetadataInput     `json:"scan,omitempty"`
+	Generate *GenerateMetadataInput `json:"generate,omitempty"`
+}
+
+// resumable returns false if resuming the job would repeat work that was
+// completed before it was paused.
+func (j PausedJob) resumable() bool {
+	switch {
+	case j.Scan != nil:
+		return !j.Scan.Rescan
+	case j.Generate != nil:
+		return !j.Generate.Overwrite
+	default:
+		return false
+	}
+}
+
+// addPausableJob queues a job as AddJob does and records its input, so that
+// it may be paused. The input is recorded before the job can start, so the
+// job may be paused as soon as it is queued. The input is not recorded if the
+// job cannot be resumed without repeating completed work.
+func (s *Manager) addPausableJob(ctx context.Context, description string, e job.JobExec, t config.NotificationTaskType, j PausedJob) int {
+	if !j.resumable() {
+		return s.AddJob(ctx, description, e, t)
+	}
+
+	s.pausedJobsMutex.Lock()
+	defer s.pausedJobsMutex.Unlock()
+
+	if s.pausableJobs == nil {
+		s.pausableJobs = make(map[int]PausedJob)
+	}
+
+	// remove jobs that are no longer in the queue
+	for id := range s.pausableJobs {
+		if j := s.JobManager.GetJob(id); j == nil || !isPausableStatus(j.Status) {
+			delete(s.pausableJobs, id)
+		}
+	}
+
+	jobID := s.AddJob(ctx, description, e, t)
+	s.pausableJobs[jobID] = j
+	return jobID
+}
+
+func isPausableStatus(status job.Status) bool {
+	return status == job.StatusReady || status == job.StatusRunning
+}
+
+// IsJobPausable returns true if the provided job may be paused.
+func (s *Manager) IsJobPausable(j job.Job) bool {
+	if !isPausableStatus(j.Status) {
+		return false
+	}
+
+	s.pausedJobsMutex.Lock()
+	defer s.pausedJobsMutex.Unlock()
+
+	_, found := s.pausableJobs[j.ID]
+	return found
+}
+
+// PauseJob stops the job with the provided id and stores its input in the
+// cache directory, so that it can be resumed later, including after a
+// restart. Only scan and generate jobs that do not redo completed work may be
+// paused.
+func (s *Manager) PauseJob(jobID int) error {
+	j := s.JobManager.GetJob(jobID)
+	if j == nil {
+		return fmt.Errorf("job with id %d not found", jobID)
+	}
+
+	if !isPausableStatus(j.Status) {
+		return fmt.Errorf("job with id %d is not running", jobID)
+	}
+
+	s.pausedJobsMutex.Lock()
+	defer s.pausedJobsMutex.Unlock()
+
+	paused, found := s.pausableJobs[jobID]
+	if !found {
+		return fmt.Errorf("job with id %d cannot be paused", jobID)
+	}
+
+	s.JobManager.CancelJob(jobID)
+	delete(s.pausableJobs, jobID)
+
+	pausedJobs := s.loadPausedJobs()
+
+	paused.ID = 1
+	for _, p := range pausedJobs {
+		if p.ID >= paused.ID {
+			paused.ID = p.ID + 1
+		}
+	}
+
+	paused.Description = j.Description
+	paused.PausedAt = time.Now()
+
+	s.pausedJobs = append(pausedJobs, paused)
+	s.savePausedJobs()
+
+	logger.Infof("Paused job %q", j.Description)
+	return nil
+}
+
+// PausedJobs returns the jobs that have been paused and not yet resumed.
+func (s *Manager) PausedJobs() []PausedJob {
+	s.pausedJobsMutex.Lock()
+	defer s.pausedJobsMutex.Unlock()
+
+	return s.loadPausedJobs()
+}
+
+// ResumeJob queues the paused job with the provided id, returning the id of
+// the new job.
+func (s *Manager) ResumeJob(ctx context.Context, id int) (int, error) {
+	s.pausedJobsMutex.Lock()
+
+	pausedJobs := s.loadPausedJobs()
+
+	index := -1
+	for i, p := range pausedJobs {
+		if p.ID == id {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		s.pausedJobsMutex.Unlock()
+		return 0, fmt.Errorf("paused job with id %d not found", id)
+	}
+
+	paused := pausedJobs[index]
+	s.pausedJobs = append(pausedJobs[:index], pausedJobs[index+1:]...)
+	s.savePausedJobs()
+
+	// unlock before queuing, since the job is registered as pausable
+	s.pausedJobsMutex.Unlock()
+
+	logger.Infof("Resuming job %q", paused.Description)
+
+	switch {
+	case paused.Scan != nil:
+		return s.Scan(ctx, *paused.Scan)
+	case paused.Generate != nil:
+		return s.Generate(ctx, *paused.Generate)
+	default:
+		return 0, fmt.Errorf("paused job %d has no input", id)
+	}
+}
+
+func (s *Manager) pausedJobsPath() string {
+	return filepath.Join(s.Config.GetCachePath(), pausedJobsFilename)
+}
+
+// loadPausedJobs returns the paused jobs, reading them from the cache
+// directory if not already loaded. Assumes lock held.
+func (s *Manager) loadPausedJobs() []PausedJob {
+	if s.pausedJobs != nil || s.Config.GetCachePath() == "" {
+		return s.pausedJobs
+	}
+
+	s.pausedJobs = []PausedJob{}
+
+	data, err := os.ReadFile(s.pausedJobsPath())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("error reading paused jobs: %v", err)
+		}
+		return s.pausedJobs
+	}
+
+	if err := json.Unmarshal(data, &s.pausedJobs); err != nil {
+		logger.Warnf("error reading paused jobs: %v", err)
+	}
+
+	return s.pausedJobs
+}
+
+// savePausedJobs writes the paused jobs to the cache directory. Assumes lock
+// held.
+func (s *Manager) savePausedJobs() {
+	if s.Config.GetCachePath() == "" {
+		return
+	}
+
+	data, err := json.Marshal(s.pausedJobs)
+	if err == nil {
+		err = os.WriteFile(s.pausedJobsPath(), data, 0644)
+	}
+
+	if err != nil {
+		logger.Warnf("error writing paused jobs: %v", err)
+	}
+}
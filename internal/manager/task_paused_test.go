@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPausedJobResumable(t *testing.T) {
+	tests := []struct {
+		name string
+		job  PausedJob
+		want bool
+	}{
+		{"scan", PausedJob{Scan: &ScanMetadataInput{}}, true},
+		{"rescan", PausedJob{Scan: &ScanMetadataInput{ScanMetadataOptions: config.ScanMetadataOptions{Rescan: true}}}, false},
+		{"generate", PausedJob{Generate: &GenerateMetadataInput{}}, true},
+		{"generate overwrite", PausedJob{Generate: &GenerateMetadataInput{Overwrite: true}}, false},
+		{"no input", PausedJob{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.job.resumable())
+		})
+	}
+}
+
+func TestPauseJobOnStart(t *testing.T) {
+	s := &Manager{
+		Config:     config.InitializeEmpty(),
+		JobManager: job.NewManager(),
+	}
+	defer s.JobManager.Stop()
+
+	// pause the job as soon as it starts
+	paused := make(chan error, 1)
+	exec := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		for _, j := range s.JobManager.GetQueue() {
+			if j.Status == job.StatusRunning {
+				paused <- s.PauseJob(j.ID)
+				break
+			}
+		}
+
+		<-ctx.Done()
+		return nil
+	})
+
+	input := ScanMetadataInput{}
+	s.addPausableJob(context.Background(), "Scanning...", exec, config.NotificationTaskTypeScan, PausedJob{
+		Type: PausedJobTypeScan,
+		Scan: &input,
+	})
+
+	assert.Nil(t, <-paused)
+
+	pausedJobs := s.PausedJobs()
+	if assert.Len(t, pausedJobs, 1) {
+		assert.Equal(t, PausedJobTypeScan, pausedJobs[0].Type)
+		assert.Equal(t, "Scanning...", pausedJobs[0].Description)
+	}
+
+	// jobs that cannot be resumed are not pausable
+	input.Rescan = true
+	started := make(chan job.Job, 1)
+	exec = job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		for _, j := range s.JobManager.GetQueue() {
+			if j.Status == job.StatusRunning {
+				started <- j
+				break
+			}
+		}
+
+		<-ctx.Done()
+		return nil
+	})
+
+	jobID := s.addPausableJob(context.Background(), "Scanning...", exec, config.NotificationTaskTypeScan, PausedJob{
+		Type: PausedJobTypeScan,
+		Scan: &input,
+	})
+
+	assert.False(t, s.IsJobPausable(<-started))
+	assert.NotNil(t, s.PauseJob(jobID))
+	s.JobManager.CancelJob(jobID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -67,5 +68,8 @@ func (s *Manager) RunPluginTask(
 	if description != nil {
 		displayName = *description
 	}
-	return s.JobManager.Add(ctx, fmt.Sprintf("Running plugin task: %s", displayName), j)
+
+	jobID := s.AddJob(ctx, fmt.Sprintf("Running plugin task: %s", displayName), j, config.NotificationTaskTypePlugin)
+
+	return jobID
 }
@@ -67,7 +67,8 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 		Paths:                  paths,
 		ScanFilters:            []file.PathFilter{newScanFilter(c, repo, minModTime)},
 		ZipFileExtensions:      cfg.GetGalleryExtensions(),
-		ParallelTasks:          cfg.GetParallelTasksWithAutoDetection(),
+		ParallelTasks:          cfg.GetScanParallelTasksWithAutoDetection(),
+		BatchSize:              cfg.GetScanBatchSize(),
 		HandlerRequiredFilters: []file.Filter{newHandlerRequiredFilter(cfg, repo)},
 		Rescan:                 j.input.Rescan,
 	}, progress)
@@ -253,6 +254,7 @@ type scanFilter struct {
 	generatedPath     string
 	videoExcludeRegex []*regexp.Regexp
 	imageExcludeRegex []*regexp.Regexp
+	stashExcludeRegex map[string]stashExcludeRegexps
 	minModTime        time.Time
 }
 
@@ -266,10 +268,23 @@ func newScanFilter(c *config.Config, repo models.Repository, minModTime time.Tim
 		generatedPath:     c.GetGeneratedPath(),
 		videoExcludeRegex: generateRegexps(c.GetExcludes()),
 		imageExcludeRegex: generateRegexps(c.GetImageExcludes()),
+		stashExcludeRegex: generateStashExcludeRegexps(c.GetStashPaths()),
 		minModTime:        minModTime,
 	}
 }
 
+// matchVideoExclude returns true if the path matches the global or stash
+// library video exclusion patterns.
+func (f *scanFilter) matchVideoExclude(path string, s *config.StashConfig) bool {
+	return matchFileRegex(path, f.videoExcludeRegex) || matchFileRegex(path, f.stashExcludeRegex[s.Path].video)
+}
+
+// matchImageExclude returns true if the path matches the global or stash
+// library image exclusion patterns.
+func (f *scanFilter) matchImageExclude(path string, s *config.StashConfig) bool {
+	return matchFileRegex(path, f.imageExcludeRegex) || matchFileRegex(path, f.stashExcludeRegex[s.Path].image)
+}
+
 func (f *scanFilter) Accept(ctx context.Context, path string, info fs.FileInfo) bool {
 	if fsutil.IsPathInDir(f.generatedPath, path) {
 		logger.Warnf("Skipping %q as it overlaps with the generated folder", path)
@@ -314,15 +329,15 @@ func (f *scanFilter) Accept(ctx context.Context, path string, info fs.FileInfo)
 	// shortcut: skip the directory entirely if it matches both exclusion patterns
 	// add a trailing separator so that it correctly matches against patterns like path/.*
 	pathExcludeTest := path + string(filepath.Separator)
-	if (matchFileRegex(pathExcludeTest, f.videoExcludeRegex)) && (s.ExcludeImage || matchFileRegex(pathExcludeTest, f.imageExcludeRegex)) {
+	if (s.ExcludeVideo || f.matchVideoExclude(pathExcludeTest, s)) && (s.ExcludeImage || f.matchImageExclude(pathExcludeTest, s)) {
 		logger.Debugf("Skipping directory %s as it matches video and image exclusion patterns", path)
 		return false
 	}
 
-	if isVideoFile && (s.ExcludeVideo || matchFileRegex(path, f.videoExcludeRegex)) {
+	if isVideoFile && (s.ExcludeVideo || f.matchVideoExclude(path, s)) {
 		logger.Debugf("Skipping %s as it matches video exclusion patterns", path)
 		return false
-	} else if (isImageFile || isZipFile) && (s.ExcludeImage || matchFileRegex(path, f.imageExcludeRegex)) {
+	} else if (isImageFile || isZipFile) && (s.ExcludeImage || f.matchImageExclude(path, s)) {
 		logger.Debugf("Skipping %s as it matches image exclusion patterns", path)
 		return false
 	}
@@ -335,12 +350,17 @@ type scanConfig struct {
 	isGenerateClipPreviews bool
 
 	createGalleriesFromFolders bool
+	imageThumbnailSize         int
 }
 
 func (c *scanConfig) GetCreateGalleriesFromFolders() bool {
 	return c.createGalleriesFromFolders
 }
 
+func (c *scanConfig) GetImageThumbnailSize() int {
+	return c.imageThumbnailSize
+}
+
 func videoFileFilter(ctx context.Context, f models.File) bool {
 	return useAsVideo(f.Base().Path)
 }
@@ -376,6 +396,7 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 					isGenerateThumbnails:       options.ScanGenerateThumbnails,
 					isGenerateClipPreviews:     options.ScanGenerateClipPreviews,
 					createGalleriesFromFolders: c.GetCreateGalleriesFromFolders(),
+					imageThumbnailSize:         c.GetImageThumbnailSize(),
 				},
 				PluginCache: pluginCache,
 				Paths:       instance.Paths,
@@ -411,6 +432,13 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 	}
 }
 
+// Scan phases reported by the scan generators, in addition to the file
+// scanning phases.
+const (
+	scanPhaseThumbnails = "thumbnails"
+	scanPhaseGenerating = "generating"
+)
+
 type imageGenerators struct {
 	input     ScanMetadataInput
 	taskQueue *job.TaskQueue
@@ -440,6 +468,7 @@ func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f model
 		}
 
 		taskThumbnail.Start(ctx)
+		progress.IncrementPhase(scanPhaseThumbnails)
 	}
 
 	// avoid adding a task if the file isn't a video file
@@ -453,6 +482,7 @@ func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f model
 			}
 
 			taskPreview.Start(ctx)
+			progress.IncrementPhase(scanPhaseGenerating)
 			progress.Increment()
 		}
 
@@ -489,11 +519,13 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 		progress.AddTotal(1)
 		spriteFn := func(ctx context.Context) {
 			taskSprite := GenerateSpriteTask{
+				repository:          mgr.Repository,
 				Scene:               *s,
 				Overwrite:           overwrite,
 				fileNamingAlgorithm: g.fileNamingAlgorithm,
 			}
 			taskSprite.Start(ctx)
+			progress.IncrementPhase(scanPhaseGenerating)
 			progress.Increment()
 		}
 
@@ -514,6 +546,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 				fileNamingAlgorithm: g.fileNamingAlgorithm,
 			}
 			taskPhash.Start(ctx)
+			progress.IncrementPhase(scanPhaseGenerating)
 			progress.Increment()
 		}
 
@@ -536,17 +569,21 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 				MarkerPaths:  g.paths.SceneMarkers,
 				ScenePaths:   g.paths.Scene,
 				Overwrite:    overwrite,
+				Throttle:     mgr.GenerateThrottle,
 			}
 
 			taskPreview := GeneratePreviewTask{
+				repository:          mgr.Repository,
 				Scene:               *s,
 				ImagePreview:        t.ScanGenerateImagePreviews,
 				Options:             options,
 				Overwrite:           overwrite,
+				OverwriteImage:      overwrite,
 				fileNamingAlgorithm: g.fileNamingAlgorithm,
 				generator:           generator,
 			}
 			taskPreview.Start(ctx)
+			progress.IncrementPhase(scanPhaseGenerating)
 			progress.Increment()
 		}
 
@@ -566,6 +603,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 				Overwrite:  overwrite,
 			}
 			taskCover.Start(ctx)
+			progress.IncrementPhase(scanPhaseThumbnails)
 			progress.Increment()
 		})
 	}
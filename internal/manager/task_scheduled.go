@@ -0,0 +1,279 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/cron"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ScheduledTaskStatus is a configured scheduled task along with its last and
+// next run times.
+type ScheduledTaskStatus struct {
+	Task    config.ScheduledTask
+	LastRun *time.Time
+	NextRun *time.Time
+}
+
+// ValidateScheduledTasks returns an error if any of the provided scheduled
+// tasks are invalid.
+func ValidateScheduledTasks(tasks []*config.ScheduledTask) error {
+	for _, t := range tasks {
+		if !t.Type.IsValid() {
+			return fmt.Errorf("invalid scheduled task type %q", t.Type)
+		}
+
+		if _, err := cron.Parse(t.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule %q for %s task: %w", t.Schedule, t.Type, err)
+		}
+	}
+
+	return nil
+}
+
+const scheduledTaskRunsFilename = "scheduled_task_runs.json"
+
+// scheduledTaskKey identifies a scheduled task in the stored last run times.
+// Changing the schedule of a task resets its last run time.
+func scheduledTaskKey(t config.ScheduledTask) string {
+	return string(t.Type) + " " + t.Schedule
+}
+
+func (s *Manager) scheduledTaskRunsPath() string {
+	if s.Config.GetConfigFile() == "" {
+		return ""
+	}
+
+	return filepath.Join(s.Config.GetConfigPath(), scheduledTaskRunsFilename)
+}
+
+// loadScheduledTaskRuns returns the stored last run times of the scheduled
+// tasks. Assumes scheduledTaskRunsMutex held.
+func (s *Manager) loadScheduledTaskRuns() map[string]time.Time {
+	ret := make(map[string]time.Time)
+
+	path := s.scheduledTaskRunsPath()
+	if path == "" {
+		return ret
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("error reading scheduled task run times: %v", err)
+		}
+		return ret
+	}
+
+	if err := json.Unmarshal(data, &ret); err != nil {
+		logger.Warnf("error reading scheduled task run times: %v", err)
+	}
+
+	return ret
+}
+
+// saveScheduledTaskRun stores the last run time of the scheduled task, so
+// that it is kept across restarts.
+func (s *Manager) saveScheduledTaskRun(t config.ScheduledTask, at time.Time) {
+	s.scheduledTaskRunsMutex.Lock()
+	defer s.scheduledTaskRunsMutex.Unlock()
+
+	path := s.scheduledTaskRunsPath()
+	if path == "" {
+		return
+	}
+
+	runs := s.loadScheduledTaskRuns()
+	runs[scheduledTaskKey(t)] = at
+
+	data, err := json.Marshal(runs)
+	if err == nil {
+		err = os.WriteFile(path, data, 0644)
+	}
+
+	if err != nil {
+		logger.Warnf("error writing scheduled task run times: %v", err)
+	}
+}
+
+// RefreshScheduler restarts the task scheduler using the current scheduled
+// tasks configuration. Call this when the scheduled tasks change.
+// The last run times of the tasks are stored in the configuration directory,
+// so that runs missed while stash was not running are made up when the
+// scheduler starts.
+func (s *Manager) RefreshScheduler() {
+	s.schedulerMutex.Lock()
+	defer s.schedulerMutex.Unlock()
+
+	s.scheduledTaskRunsMutex.Lock()
+	lastRuns := s.loadScheduledTaskRuns()
+	s.scheduledTaskRunsMutex.Unlock()
+
+	// retain the last run times of unchanged tasks, in case they could not
+	// be stored
+	if s.scheduler != nil {
+		s.scheduler.Stop()
+		for i, e := range s.scheduler.Entries() {
+			key := scheduledTaskKey(*s.scheduledTasks[i])
+			if e.LastRun != nil && e.LastRun.After(lastRuns[key]) {
+				lastRuns[key] = *e.LastRun
+			}
+		}
+	}
+
+	tasks := s.Config.GetScheduledTasks()
+	entries := make([]*cron.Entry, len(tasks))
+	for i, t := range tasks {
+		t := t
+		entries[i] = &cron.Entry{
+			Run: func() {
+				// missed runs are made up at startup, which may be before
+				// the database has been migrated
+				if err := s.Database.Ready(); err != nil {
+					logger.Warnf("Skipping scheduled %s task: %v", t.Type, err)
+					return
+				}
+
+				s.saveScheduledTaskRun(*t, time.Now())
+				s.runScheduledTask(context.Background(), t.Type)
+			},
+		}
+
+		if lastRun, found := lastRuns[scheduledTaskKey(*t)]; found {
+			lastRun := lastRun
+			entries[i].LastRun = &lastRun
+		}
+
+		if !t.Enabled {
+			continue
+		}
+
+		schedule, err := cron.Parse(t.Schedule)
+		if err != nil {
+			logger.Warnf("invalid schedule %q for scheduled %s task: %v", t.Schedule, t.Type, err)
+			continue
+		}
+		entries[i].Schedule = schedule
+	}
+
+	s.scheduledTasks = tasks
+	s.scheduler = cron.NewScheduler(entries)
+	s.scheduler.Start()
+}
+
+// ScheduledTasks returns the configured scheduled tasks and their last and
+// next run times.
+func (s *Manager) ScheduledTasks() []ScheduledTaskStatus {
+	s.schedulerMutex.Lock()
+	defer s.schedulerMutex.Unlock()
+
+	if s.scheduler == nil {
+		return nil
+	}
+
+	var ret []ScheduledTaskStatus
+	for i, e := range s.scheduler.Entries() {
+		ret = append(ret, ScheduledTaskStatus{
+			Task:    *s.scheduledTasks[i],
+			LastRun: e.LastRun,
+			NextRun: e.NextRun,
+		})
+	}
+
+	return ret
+}
+
+func (s *Manager) stopScheduler() {
+	s.schedulerMutex.Lock()
+	defer s.schedulerMutex.Unlock()
+
+	if s.scheduler != nil {
+		s.scheduler.Stop()
+	}
+}
+
+// runScheduledTask queues the job for the provided task type, using the
+// default task settings from the configuration.
+func (s *Manager) runScheduledTask(ctx context.Context, t config.ScheduledTaskType) {
+	logger.Infof("Running scheduled %s task", t)
+
+	var err error
+
+	switch t {
+	case config.ScheduledTaskTypeScan:
+		input := ScanMetadataInput{}
+		if defaults := s.Config.GetDefaultScanSettings(); defaults != nil {
+			input.ScanMetadataOptions = *defaults
+		}
+		_, err = s.Scan(ctx, input)
+	case config.ScheduledTaskTypeGenerate:
+		input := GenerateMetadataInput{}
+		if defaults := s.Config.GetDefaultGenerateSettings(); defaults != nil {
+			input = generateInputFromOptions(*defaults)
+		}
+		_, err = s.Generate(ctx, input)
+	case config.ScheduledTaskTypeClean:
+		s.Clean(ctx, CleanMetadataInput{})
+	case config.ScheduledTaskTypeBackup:
+		j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+			backupPath, _, err := s.BackupDatabase(false)
+			if err != nil {
+				return fmt.Errorf("backing up database: %w", err)
+			}
+
+			logger.Infof("Successfully backed up database to: %s", backupPath)
+			return nil
+		})
+		s.AddJob(ctx, "Backing up database...", j, config.NotificationTaskTypeBackup)
+	case config.ScheduledTaskTypeIdentify:
+		defaults := s.Config.GetDefaultIdentifySettings()
+		if defaults == nil {
+			err = fmt.Errorf("default identify settings not set")
+			break
+		}
+		s.AddJob(ctx, "Identifying...", CreateIdentifyJob(*defaults), config.NotificationTaskTypeIdentify)
+	case config.ScheduledTaskTypeEmptyTrash:
+		_, err = s.EmptyTrash(ctx, EmptyTrashInput{})
+	case config.ScheduledTaskTypePurgeDeletedObjects:
+		_, err = s.PurgeDeletedObjects(ctx, PurgeDeletedObjectsInput{})
+	default:
+		err = fmt.Errorf("unknown task type")
+	}
+
+	if err != nil {
+		logger.Errorf("error running scheduled %s task: %v", t, err)
+	}
+}
+
+func generateInputFromOptions(o models.GenerateMetadataOptions) GenerateMetadataInput {
+	ret := GenerateMetadataInput{
+		Covers:                    o.Covers,
+		Sprites:                   o.Sprites,
+		Previews:                  o.Previews,
+		ImagePreviews:             o.ImagePreviews,
+		Markers:                   o.Markers,
+		MarkerImagePreviews:       o.MarkerImagePreviews,
+		MarkerScreenshots:         o.MarkerScreenshots,
+		Transcodes:                o.Transcodes,
+		Phashes:                   o.Phashes,
+		InteractiveHeatmapsSpeeds: o.InteractiveHeatmapsSpeeds,
+		ImageThumbnails:           o.ImageThumbnails,
+		ClipPreviews:              o.ClipPreviews,
+	}
+
+	if o.PreviewOptions != nil {
+		previewOptions := GeneratePreviewOptionsInput(*o.PreviewOptions)
+		ret.PreviewOptions = &previewOptions
+	}
+
+	return ret
+}
@@ -0,0 +1,40 @@
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledTaskRuns(t *testing.T) {
+	cfg := config.InitializeEmpty()
+	s := &Manager{
+		Config: cfg,
+	}
+
+	scan := config.ScheduledTask{Type: config.ScheduledTaskTypeScan, Schedule: "0 3 * * *"}
+	clean := config.ScheduledTask{Type: config.ScheduledTaskTypeClean, Schedule: "0 4 * * 0"}
+	at := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	// run times are not stored without a config file
+	s.saveScheduledTaskRun(scan, at)
+	assert.Empty(t, s.loadScheduledTaskRuns())
+
+	cfg.SetConfigFile(filepath.Join(t.TempDir(), "config.yml"))
+
+	s.saveScheduledTaskRun(scan, at)
+	s.saveScheduledTaskRun(clean, at.Add(time.Hour))
+
+	runs := s.loadScheduledTaskRuns()
+	assert.Len(t, runs, 2)
+	assert.True(t, at.Equal(runs[scheduledTaskKey(scan)]))
+	assert.True(t, at.Add(time.Hour).Equal(runs[scheduledTaskKey(clean)]))
+
+	// changing the schedule resets the last run time
+	scan.Schedule = "0 5 * * *"
+	_, found := runs[scheduledTaskKey(scan)]
+	assert.False(t, found)
+}
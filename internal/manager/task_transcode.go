@@ -11,7 +11,16 @@ import (
 	"github.com/stashapp/stash/pkg/scene/generate"
 )
 
+// transcodeProbeCodecs maps the codecs of generated transcodes to the codec
+// of files that can be copied into the transcode without re-encoding.
+var transcodeProbeCodecs = map[models.TranscodeCodec]string{
+	models.TranscodeCodecH264: ffmpeg.H264,
+	models.TranscodeCodecVp9:  ffmpeg.Vp9,
+	models.TranscodeCodecAv1:  ffmpeg.Av1,
+}
+
 type GenerateTranscodeTask struct {
+	repository          models.Repository
 	Scene               models.Scene
 	Overwrite           bool
 	fileNamingAlgorithm models.HashAlgorithm
@@ -27,6 +36,10 @@ func (t *GenerateTranscodeTask) GetDescription() string {
 }
 
 func (t *GenerateTranscodeTask) Start(ctx context.Context) {
+	t.StartProgress(ctx, nil)
+}
+
+func (t *GenerateTranscodeTask) StartProgress(ctx context.Context, progress func(percent float64)) {
 	hasTranscode := HasTranscode(&t.Scene, t.fileNamingAlgorithm)
 	if !t.Overwrite && hasTranscode {
 		return
@@ -75,23 +88,34 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 	// if scale is being set, then we can't use stream copy
 	scaleSet := w == 0 && h == 0
 
-	if scaleSet && videoCodec == ffmpeg.H264 { // for non supported h264 files stream copy the video part
+	toneMap := config.GetInstance().GetTranscodeToneMapping() && videoFile.IsHDR()
+	if toneMap {
+		logger.Debugf("[transcode] tone mapping HDR video %s", videoFile.Path)
+	}
+
+	g := *t.g
+	g.Progress = progress
+
+	// stream copy the video part if it is already in the transcode codec
+	if scaleSet && !toneMap && videoCodec == transcodeProbeCodecs[config.GetInstance().GetGeneratedTranscodeCodec()] {
 		if audioCodec == ffmpeg.MissingUnsupported {
-			err = t.g.TranscodeCopyVideo(ctx, videoFile.Path, sceneHash)
+			err = g.TranscodeCopyVideo(ctx, videoFile.Path, sceneHash, videoFile.FileDuration)
 		} else {
-			err = t.g.TranscodeAudio(ctx, videoFile.Path, sceneHash)
+			err = g.TranscodeAudio(ctx, videoFile.Path, sceneHash, videoFile.FileDuration)
 		}
 	} else {
 		options := generate.TranscodeOptions{
-			Width:  w,
-			Height: h,
+			Width:    w,
+			Height:   h,
+			ToneMap:  toneMap,
+			Duration: videoFile.FileDuration,
 		}
 
 		if audioCodec == ffmpeg.MissingUnsupported {
 			// ffmpeg fails if it tries to transcode an unsupported audio codec
-			err = t.g.TranscodeVideo(ctx, videoFile.Path, sceneHash, options)
+			err = g.TranscodeVideo(ctx, videoFile.Path, sceneHash, options)
 		} else {
-			err = t.g.Transcode(ctx, videoFile.Path, sceneHash, options)
+			err = g.Transcode(ctx, videoFile.Path, sceneHash, options)
 		}
 	}
 
@@ -99,6 +123,8 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 		logger.Errorf("[transcode] error generating transcode: %v", err)
 		return
 	}
+
+	recordGeneratedArtifact(ctx, t.repository, t.Scene.ID, models.GeneratedArtifactTypeTranscode, sceneHash)
 }
 
 // return true if transcode is needed
@@ -0,0 +1,257 @@
+// Package cron provides parsing of cron-style schedule expressions and a
+// scheduler to run functions according to them.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears is the number of years to search for the next matching time
+// before giving up. Impossible schedules such as 30th of February would
+// otherwise search forever.
+const maxSearchYears = 5
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+type fieldRange struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteRange = fieldRange{name: "minute", min: 0, max: 59}
+	hourRange   = fieldRange{name: "hour", min: 0, max: 23}
+	domRange    = fieldRange{name: "day of month", min: 1, max: 31}
+	monthRange  = fieldRange{name: "month", min: 1, max: 12, names: monthNames}
+	// 7 is accepted as an alias for Sunday
+	dowRange = fieldRange{name: "day of week", min: 0, max: 7, names: dayNames}
+)
+
+// Schedule is a parsed cron expression. Each field is a bitset of the
+// matching values.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domStar and dowStar are true if the corresponding field was
+	// unrestricted, either because it starts with * or ?, as in cron, or
+	// because it includes every value. If both day fields are restricted, a
+	// time matches if either field matches.
+	domStar bool
+	dowStar bool
+}
+
+// Parse parses a standard five field cron expression, consisting of minute,
+// hour, day of month, month and day of week. Fields may contain values,
+// ranges (1-5), steps (*/15, 0-30/10) and comma-separated lists. Month and day
+// of week names (jan, mon) are accepted, as are the descriptors @yearly,
+// @monthly, @weekly, @daily and @hourly.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if d, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, found %d", ErrInvalidSchedule, len(fields))
+	}
+
+	ret := &Schedule{}
+	var err error
+
+	if ret.minute, err = parseField(fields[0], minuteRange); err != nil {
+		return nil, err
+	}
+	if ret.hour, err = parseField(fields[1], hourRange); err != nil {
+		return nil, err
+	}
+	if ret.dom, err = parseField(fields[2], domRange); err != nil {
+		return nil, err
+	}
+	if ret.month, err = parseField(fields[3], monthRange); err != nil {
+		return nil, err
+	}
+	if ret.dow, err = parseField(fields[4], dowRange); err != nil {
+		return nil, err
+	}
+
+	// fold Sunday as 7 into 0
+	if ret.dow&(1<<7) != 0 {
+		ret.dow = ret.dow&^(1<<7) | 1
+	}
+
+	ret.domStar = isStar(fields[2], ret.dom, domRange)
+	ret.dowStar = isStar(fields[4], ret.dow, fieldRange{min: 0, max: 6})
+
+	return ret, nil
+}
+
+// isStar returns true if the field is unrestricted: it starts with a
+// wildcard, or its values cover the whole range.
+func isStar(field string, bits uint64, r fieldRange) bool {
+	if strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?") {
+		return true
+	}
+
+	for i := r.min; i <= r.max; i++ {
+		if bits&(1<<uint(i)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseField(field string, r fieldRange) (uint64, error) {
+	var ret uint64
+	for _, part := range strings.Split(field, ",") {
+		bits, err := parsePart(part, r)
+		if err != nil {
+			return 0, err
+		}
+		ret |= bits
+	}
+
+	return ret, nil
+}
+
+func parsePart(part string, r fieldRange) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("%w: invalid step %q in %s field", ErrInvalidSchedule, stepPart, r.name)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangePart == "*" || rangePart == "?":
+		start = r.min
+		end = r.max
+	case strings.Contains(rangePart, "-"):
+		lo, hi, _ := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseValue(lo, r); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(hi, r); err != nil {
+			return 0, err
+		}
+		if end < start {
+			return 0, fmt.Errorf("%w: invalid range %q in %s field", ErrInvalidSchedule, rangePart, r.name)
+		}
+	default:
+		var err error
+		if start, err = parseValue(rangePart, r); err != nil {
+			return 0, err
+		}
+		end = start
+		// a step applied to a single value runs to the end of the range
+		if hasStep {
+			end = r.max
+		}
+	}
+
+	var ret uint64
+	for i := start; i <= end; i += step {
+		ret |= 1 << uint(i)
+	}
+
+	return ret, nil
+}
+
+func parseValue(v string, r fieldRange) (int, error) {
+	if n, ok := r.names[strings.ToLower(v)]; ok {
+		return n, nil
+	}
+
+	ret, err := strconv.Atoi(v)
+	if err != nil || ret < r.min || ret > r.max {
+		return 0, fmt.Errorf("%w: invalid value %q in %s field", ErrInvalidSchedule, v, r.name)
+	}
+
+	return ret, nil
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case s.domStar && s.dowStar:
+		return true
+	case s.domStar:
+		return dowMatch
+	case s.dowStar:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// Next returns the first time after t that matches the schedule. Returns the
+// zero time if no matching time could be found.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	loc := t.Location()
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,125 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@never",
+	}
+
+	for _, spec := range tests {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) expected error", spec)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		minute  []int
+		hour    []int
+		dow     []int
+		dowStar bool
+	}{
+		{"0 0 * * *", []int{0}, []int{0}, []int{0, 1, 2, 3, 4, 5, 6}, true},
+		{"0,30 */6 * * *", []int{0, 30}, []int{0, 6, 12, 18}, []int{0, 1, 2, 3, 4, 5, 6}, true},
+		{"5-7 1 * * mon-wed", []int{5, 6, 7}, []int{1}, []int{1, 2, 3}, false},
+		{"0 0 * * sat,7", []int{0}, []int{0}, []int{0, 6}, false},
+		{"0 0 * * 0-7", []int{0}, []int{0}, []int{0, 1, 2, 3, 4, 5, 6}, true},
+		{"0 0 * * */2", []int{0}, []int{0}, []int{0, 2, 4, 6}, true},
+		{"10/20 0 * * *", []int{10, 30, 50}, []int{0}, []int{0, 1, 2, 3, 4, 5, 6}, true},
+	}
+
+	bits := func(values []int) uint64 {
+		var ret uint64
+		for _, v := range values {
+			ret |= 1 << uint(v)
+		}
+		return ret
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.spec)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.spec, err)
+			continue
+		}
+
+		if s.minute != bits(tt.minute) {
+			t.Errorf("Parse(%q) minute = %b, want %v", tt.spec, s.minute, tt.minute)
+		}
+		if s.hour != bits(tt.hour) {
+			t.Errorf("Parse(%q) hour = %b, want %v", tt.spec, s.hour, tt.hour)
+		}
+		if s.dow != bits(tt.dow) {
+			t.Errorf("Parse(%q) dow = %b, want %v", tt.spec, s.dow, tt.dow)
+		}
+		if s.dowStar != tt.dowStar {
+			t.Errorf("Parse(%q) dowStar = %v, want %v", tt.spec, s.dowStar, tt.dowStar)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, 1, 10, 12, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 10, 12, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 10, 12, 45, 0, 0, time.UTC)},
+		{"30 12 * * *", time.Date(2024, 1, 11, 12, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 10, 13, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 4 * * mon-fri", time.Date(2024, 1, 11, 4, 0, 0, 0, time.UTC)},
+		{"0 4 * * sat,sun", time.Date(2024, 1, 13, 4, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		// day of month or day of week
+		{"0 0 1 * mon", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+		// day of week lists and ranges
+		{"0 0 * * mon,wed,fri", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-3", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 1-5/2", time.Date(2024, 1, 12, 12, 0, 0, 0, time.UTC)},
+		{"0 0 20 * thu", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+		// unrestricted day of week fields only match the day of month
+		{"0 0 20 * 0-6", time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 20 * sun-sat", time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 20 * */2", time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1-31 * fri", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.spec)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.spec, err)
+			continue
+		}
+
+		if got := s.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Parse(%q).Next() = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// Entry is a function to be run according to a schedule.
+type Entry struct {
+	Schedule *Schedule
+	Run      func()
+
+	// LastRun is the time the entry was last run. Nil if it has not run.
+	// If a scheduled run was missed since LastRun, for example because the
+	// scheduler was not running, the entry is run as soon as the scheduler
+	// starts.
+	LastRun *time.Time
+	// NextRun is the time the entry is next due to run. Nil if the scheduler
+	// is not running or no matching time could be found.
+	NextRun *time.Time
+}
+
+// Scheduler runs entries at the times matching their schedules.
+type Scheduler struct {
+	entries []*Entry
+
+	mutex sync.Mutex
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewScheduler returns a new Scheduler for the provided entries.
+func NewScheduler(entries []*Entry) *Scheduler {
+	return &Scheduler{
+		entries: entries,
+	}
+}
+
+// Start starts running the scheduled entries in the background.
+func (s *Scheduler) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+
+	now := time.Now()
+	for _, e := range s.entries {
+		e.NextRun = startRun(e, now)
+	}
+
+	s.stop = make(chan struct{})
+	s.wg.Add(1)
+	go s.run(s.stop)
+}
+
+// Stop stops the scheduler. Entries that are already running are not
+// interrupted.
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	if s.stop == nil {
+		s.mutex.Unlock()
+		return
+	}
+
+	close(s.stop)
+	s.stop = nil
+	for _, e := range s.entries {
+		e.NextRun = nil
+	}
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+}
+
+// Entries returns a copy of the scheduler's entries, in the order they were
+// provided.
+func (s *Scheduler) Entries() []Entry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ret := make([]Entry, len(s.entries))
+	for i, e := range s.entries {
+		ret[i] = *e
+	}
+
+	return ret
+}
+
+// startRun returns the time the entry is first due to run when the scheduler
+// starts at now. Returns now if a run was missed since the entry last ran.
+// Only one missed run is made up, regardless of how many were missed.
+func startRun(e *Entry, now time.Time) *time.Time {
+	if e.Schedule != nil && e.LastRun != nil {
+		missed := e.Schedule.Next(*e.LastRun)
+		if !missed.IsZero() && !missed.After(now) {
+			return &now
+		}
+	}
+
+	return nextRun(e.Schedule, now)
+}
+
+func nextRun(schedule *Schedule, now time.Time) *time.Time {
+	if schedule == nil {
+		return nil
+	}
+
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return nil
+	}
+
+	return &next
+}
+
+// earliest returns the earliest next run time of all entries, or nil if no
+// entries are due to run.
+func (s *Scheduler) earliest() *time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret *time.Time
+	for _, e := range s.entries {
+		if e.NextRun != nil && (ret == nil || e.NextRun.Before(*ret)) {
+			ret = e.NextRun
+		}
+	}
+
+	return ret
+}
+
+func (s *Scheduler) run(stop chan struct{}) {
+	defer s.wg.Done()
+
+	for {
+		next := s.earliest()
+		if next == nil {
+			<-stop
+			return
+		}
+
+		timer := time.NewTimer(time.Until(*next))
+
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case now := <-timer.C:
+			s.runDue(now)
+		}
+	}
+}
+
+func (s *Scheduler) runDue(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, e := range s.entries {
+		if e.NextRun == nil || e.NextRun.After(now) {
+			continue
+		}
+
+		lastRun := now
+		e.LastRun = &lastRun
+		e.NextRun = nextRun(e.Schedule, now)
+
+		go e.Run()
+	}
+}
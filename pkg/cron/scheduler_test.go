@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartRun(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 1, 10, 12, 30, 15, 0, time.UTC)
+	daily, _ := Parse("0 3 * * *")
+
+	at := func(t time.Time) *time.Time {
+		return &t
+	}
+
+	tests := []struct {
+		name    string
+		lastRun *time.Time
+		want    time.Time
+	}{
+		{"never run", nil, time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC)},
+		{"ran today", at(time.Date(2024, 1, 10, 3, 0, 0, 0, time.UTC)), time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC)},
+		{"missed today", at(time.Date(2024, 1, 9, 3, 0, 0, 0, time.UTC)), now},
+		{"missed several", at(time.Date(2023, 12, 1, 3, 0, 0, 0, time.UTC)), now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := startRun(&Entry{Schedule: daily, LastRun: tt.lastRun}, now)
+			if got == nil || !got.Equal(tt.want) {
+				t.Errorf("startRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerRunsMissed(t *testing.T) {
+	yearly, _ := Parse("@yearly")
+
+	lastRun := time.Now().AddDate(-2, 0, 0)
+	ran := make(chan struct{}, 1)
+
+	e := &Entry{
+		Schedule: yearly,
+		LastRun:  &lastRun,
+		Run: func() {
+			ran <- struct{}{}
+		},
+	}
+
+	s := NewScheduler([]*Entry{e})
+	s.Start()
+	defer s.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("missed run was not made up")
+	}
+
+	entries := s.Entries()
+	if !entries[0].LastRun.After(lastRun) {
+		t.Errorf("LastRun = %v, want after %v", entries[0].LastRun, lastRun)
+	}
+	if entries[0].NextRun == nil || !entries[0].NextRun.After(time.Now()) {
+		t.Errorf("NextRun = %v, want in the future", entries[0].NextRun)
+	}
+}
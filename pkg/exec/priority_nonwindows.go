@@ -0,0 +1,15 @@
+//go:build linux || darwin || !windows
+// +build linux darwin !windows
+
+package exec
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// SetPriority sets the niceness of a started command. Niceness ranges from
+// -20 (highest priority) to 19 (lowest priority).
+func SetPriority(cmd *exec.Cmd, niceness int) error {
+	return syscall.Setpriority(syscall.PRIO_PROCESS, cmd.Process.Pid, niceness)
+}
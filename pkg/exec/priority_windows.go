@@ -0,0 +1,34 @@
+//go:build windows
+// +build windows
+
+package exec
+
+import (
+	"os/exec"
+
+	"golang.org/x/sys/windows"
+)
+
+// SetPriority sets the priority class of a started command from a niceness
+// value. Windows has no equivalent to niceness, so positive values below 10
+// map to the below normal priority class, and values of 10 or above map to
+// the idle priority class. Values of zero or below are ignored.
+func SetPriority(cmd *exec.Cmd, niceness int) error {
+	var class uint32
+	switch {
+	case niceness >= 10:
+		class = windows.IDLE_PRIORITY_CLASS
+	case niceness > 0:
+		class = windows.BELOW_NORMAL_PRIORITY_CLASS
+	default:
+		return nil
+	}
+
+	h, err := windows.OpenProcess(windows.PROCESS_SET_INFORMATION, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(h)
+
+	return windows.SetPriorityClass(h, class)
+}
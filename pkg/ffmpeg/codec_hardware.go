@@ -11,6 +11,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 var (
@@ -340,42 +341,72 @@ func (f *FFMpeg) hwMaxResFilter(toCodec VideoCodec, vf *models.VideoFile, reqHei
 	return f.hwCodecFilter(videoFilter, toCodec, vf, fullhw)
 }
 
-// Return if a hardware accelerated for HLS is available
-func (f *FFMpeg) hwCodecHLSCompatible() *VideoCodec {
-	for _, element := range f.hwCodecSupport {
-		switch element {
-		case VideoCodecN264,
-			VideoCodecI264,
-			VideoCodecV264,
-			VideoCodecR264,
-			VideoCodecM264: // Note that the Apple encoder sucks at startup, thus HLS quality is crap
-			return &element
-		}
+// hwCodecEncoder returns the hardware encoder family of a codec, or an
+// empty string if the codec is not a hardware codec.
+func hwCodecEncoder(codec VideoCodec) models.HardwareEncoder {
+	switch codec {
+	case VideoCodecN264:
+		return models.HardwareEncoderNvenc
+	case VideoCodecI264, VideoCodecIVP9:
+		return models.HardwareEncoderQsv
+	case VideoCodecV264, VideoCodecVVP9, VideoCodecVVPX:
+		return models.HardwareEncoderVaapi
+	case VideoCodecM264:
+		return models.HardwareEncoderVideotoolbox
+	case VideoCodecR264:
+		return models.HardwareEncoderV4l2m2m
 	}
-	return nil
+
+	return ""
 }
 
-// Return if a hardware accelerated codec for MP4 is available
-func (f *FFMpeg) hwCodecMP4Compatible() *VideoCodec {
-	for _, element := range f.hwCodecSupport {
-		switch element {
-		case VideoCodecN264,
-			VideoCodecI264,
-			VideoCodecM264:
-			return &element
-		}
-	}
-	return nil
+// isHardware returns true if the codec is a hardware codec.
+func (c VideoCodec) isHardware() bool {
+	return hwCodecEncoder(c) != ""
 }
 
-// Return if a hardware accelerated codec for WebM is available
-func (f *FFMpeg) hwCodecWEBMCompatible() *VideoCodec {
+// Return the first supported codec of the provided codecs that uses encoder.
+// Any encoder is used if encoder is auto.
+func (f *FFMpeg) hwCodecCompatible(encoder models.HardwareEncoder, codecs ...VideoCodec) *VideoCodec {
 	for _, element := range f.hwCodecSupport {
-		switch element {
-		case VideoCodecIVP9,
-			VideoCodecVVP9:
-			return &element
+		if encoder != models.HardwareEncoderAuto && hwCodecEncoder(element) != encoder {
+			continue
+		}
+
+		for _, c := range codecs {
+			if element == c {
+				return &element
+			}
 		}
 	}
 	return nil
 }
+
+// hwCodecSupported returns true if the hardware codec was detected at startup.
+func (f *FFMpeg) hwCodecSupported(codec VideoCodec) bool {
+	return sliceutil.Contains(f.hwCodecSupport, codec)
+}
+
+var (
+	// hardware codecs compatible with HLS
+	hwCodecsHLS = []VideoCodec{
+		VideoCodecN264,
+		VideoCodecI264,
+		VideoCodecV264,
+		VideoCodecR264,
+		VideoCodecM264, // Note that the Apple encoder sucks at startup, thus HLS quality is crap
+	}
+
+	// hardware codecs compatible with MP4
+	hwCodecsMP4 = []VideoCodec{
+		VideoCodecN264,
+		VideoCodecI264,
+		VideoCodecM264,
+	}
+
+	// hardware codecs compatible with WebM
+	hwCodecsWEBM = []VideoCodec{
+		VideoCodecIVP9,
+		VideoCodecVVP9,
+	}
+)
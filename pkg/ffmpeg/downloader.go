@@ -4,26 +4,57 @@ import (
 	"runtime"
 )
 
-func GetFFmpegURL() []string {
-	var urls []string
+// Download is an archive containing an ffmpeg and/or ffprobe build.
+type Download struct {
+	// URL is the URL of a specific version of the archive, so that the
+	// archive matches SHA256.
+	URL string
+	// SHA256 is the hex encoded SHA-256 checksum of the archive. It is kept
+	// here rather than fetched from the provider, so that a compromised
+	// provider cannot supply both the archive and its checksum. Archives
+	// without a checksum are not downloaded.
+	SHA256 string
+}
+
+// GetFFmpegDownloads returns the archives to download to obtain ffmpeg and
+// ffprobe builds for the current platform.
+//
+// When changing a URL, the SHA256 of the new archive must be set, after
+// checking it against the checksum published by the provider.
+func GetFFmpegDownloads() []Download {
+	var ret []Download
 	switch runtime.GOOS {
 	case "darwin":
-		urls = []string{"https://evermeet.cx/ffmpeg/getrelease/zip", "https://evermeet.cx/ffmpeg/getrelease/ffprobe/zip"}
+		ret = []Download{
+			{URL: "https://evermeet.cx/ffmpeg/ffmpeg-7.0.2.zip"},
+			{URL: "https://evermeet.cx/ffmpeg/ffprobe-7.0.2.zip"},
+		}
 	case "linux":
 		switch runtime.GOARCH {
 		case "amd64":
-			urls = []string{"https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffmpeg-4.2.1-linux-64.zip", "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffprobe-4.2.1-linux-64.zip"}
+			ret = []Download{
+				{URL: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffmpeg-4.2.1-linux-64.zip"},
+				{URL: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffprobe-4.2.1-linux-64.zip"},
+			}
 		case "arm":
-			urls = []string{"https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffmpeg-4.2.1-linux-armhf-32.zip", "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffprobe-4.2.1-linux-armhf-32.zip"}
+			ret = []Download{
+				{URL: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffmpeg-4.2.1-linux-armhf-32.zip"},
+				{URL: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffprobe-4.2.1-linux-armhf-32.zip"},
+			}
 		case "arm64":
-			urls = []string{"https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffmpeg-4.2.1-linux-arm-64.zip", "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffprobe-4.2.1-linux-arm-64.zip"}
+			ret = []Download{
+				{URL: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffmpeg-4.2.1-linux-arm-64.zip"},
+				{URL: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v4.2.1/ffprobe-4.2.1-linux-arm-64.zip"},
+			}
 		}
 	case "windows":
-		urls = []string{"https://www.gyan.dev/ffmpeg/builds/ffmpeg-release-essentials.zip"}
+		ret = []Download{
+			{URL: "https://www.gyan.dev/ffmpeg/builds/packages/ffmpeg-7.0.2-essentials_build.zip"},
+		}
 	default:
-		urls = []string{""}
+		ret = []Download{{}}
 	}
-	return urls
+	return ret
 }
 
 func getFFMpegFilename() string {
@@ -195,6 +195,15 @@ type FFMpegVersion struct {
 	patch int
 }
 
+// String returns the version in major.minor.patch form, or an empty string
+// if the version was not detected.
+func (v FFMpegVersion) String() string {
+	if v == (FFMpegVersion{}) {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+}
+
 // Gteq returns true if the version is greater than or equal to the other version.
 func (v FFMpegVersion) Gteq(other FFMpegVersion) bool {
 	if v.major > other.major {
@@ -228,11 +237,28 @@ func NewEncoder(ffmpegPath string) *FFMpeg {
 	return ret
 }
 
-// Returns an exec.Cmd that can be used to run ffmpeg using args.
-func (f *FFMpeg) Command(ctx context.Context, args []string) *exec.Cmd {
-	return stashExec.CommandContext(ctx, string(f.ffmpeg), args...)
+// Returns a Process that can be used to run ffmpeg using args. The process
+// is not started until the number of running ffmpeg processes is below the
+// configured maximum.
+func (f *FFMpeg) Command(ctx context.Context, args []string) *Process {
+	return &Process{
+		Cmd:     stashExec.CommandContext(ctx, string(f.ffmpeg), args...),
+		ctx:     ctx,
+		manager: processes,
+	}
 }
 
 func (f *FFMpeg) Path() string {
 	return f.ffmpeg
 }
+
+// Version returns the detected ffmpeg version.
+func (f *FFMpeg) Version() FFMpegVersion {
+	return f.version
+}
+
+// HardwareCodecs returns the hardware codecs found to be working by
+// InitHWSupport.
+func (f *FFMpeg) HardwareCodecs() []VideoCodec {
+	return f.hwCodecSupport
+}
@@ -85,6 +85,9 @@ type VideoFile struct {
 	AudioStream *FFProbeStream
 	VideoStream *FFProbeStream
 
+	// RawJSON is the unparsed ffprobe output
+	RawJSON []byte
+
 	Path      string
 	Title     string
 	Comment   string
@@ -107,10 +110,24 @@ type VideoFile struct {
 	FrameRate    float64
 	Rotation     int64
 	FrameCount   int64
+	// ColorTransfer is the transfer characteristics of the video stream
+	ColorTransfer string
 
 	AudioCodec string
 }
 
+// transfer characteristics of HDR video
+const (
+	colorTransferPQ  = "smpte2084"
+	colorTransferHLG = "arib-std-b67"
+)
+
+// IsHDR returns true if the video stream uses the PQ (HDR10) or HLG transfer
+// characteristics.
+func (v *VideoFile) IsHDR() bool {
+	return v.ColorTransfer == colorTransferPQ || v.ColorTransfer == colorTransferHLG
+}
+
 // TranscodeScale calculates the dimension scaling for a transcode, where maxSize is the maximum size of the longest dimension of the input video.
 // If no scaling is required, then returns 0, 0.
 // Returns -2 for the dimension that will scale to maintain aspect ratio.
@@ -160,7 +177,13 @@ func (f *FFProbe) NewVideoFile(videoPath string) (*VideoFile, error) {
 		return nil, fmt.Errorf("error unmarshalling video data for <%s>: %s", videoPath, err.Error())
 	}
 
-	return parse(videoPath, probeJSON)
+	ret, err := parse(videoPath, probeJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.RawJSON = out
+	return ret, nil
 }
 
 // GetReadFrameCount counts the actual frames of the video file.
@@ -223,6 +246,7 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	if videoStream != nil {
 		result.VideoStream = videoStream
 		result.VideoCodec = videoStream.CodecName
+		result.ColorTransfer = videoStream.ColorTransfer
 		result.FrameCount, _ = strconv.ParseInt(videoStream.NbFrames, 10, 64)
 		if videoStream.NbReadFrames != "" { // if ffprobe counted the frames use that instead
 			fc, _ := strconv.ParseInt(videoStream.NbReadFrames, 10, 64)
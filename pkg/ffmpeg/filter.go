@@ -2,6 +2,7 @@ package ffmpeg
 
 import (
 	"fmt"
+	"strings"
 )
 
 // VideoFilter represents video filter parameters to be passed to ffmpeg.
@@ -93,6 +94,46 @@ func (f VideoFilter) Select(frame int) VideoFilter {
 	return f.Append(fmt.Sprintf("select=eq(n\\,%d)", frame))
 }
 
+// Subtitles returns a VideoFilter burning the text subtitles in path into the
+// video. If streamIndex is not negative, the subtitle stream with that index
+// in path is used. Offset is the start time in seconds of the input, for
+// when the input timestamps are reset after seeking.
+func (f VideoFilter) Subtitles(path string, streamIndex int, offset float64) VideoFilter {
+	filter := "subtitles=filename=" + escapeFilterValue(path)
+	if streamIndex >= 0 {
+		filter += fmt.Sprintf(":si=%d", streamIndex)
+	}
+
+	if offset == 0 {
+		return f.Append(filter)
+	}
+
+	// shift the timestamps to match the subtitles, and back again afterwards
+	f = f.Append(fmt.Sprintf("setpts=PTS+%f/TB", offset))
+	f = f.Append(filter)
+	return f.Append("setpts=PTS-STARTPTS")
+}
+
+// ToneMap returns a VideoFilter converting HDR video to SDR BT.709 video, so
+// that it does not look washed out on SDR displays. The output is 8-bit
+// yuv420p. Requires ffmpeg to be built with libzimg.
+func (f VideoFilter) ToneMap() VideoFilter {
+	f = f.Append("zscale=t=linear:npl=100")
+	f = f.Append("format=gbrpf32le")
+	f = f.Append("zscale=p=bt709")
+	f = f.Append("tonemap=tonemap=hable:desat=0")
+	f = f.Append("zscale=t=bt709:m=bt709:r=tv")
+	return f.Append("format=yuv420p")
+}
+
+// escapeFilterValue escapes s for use as an option value in a filtergraph.
+// Values are escaped once for the filter options and again for the graph.
+func escapeFilterValue(s string) string {
+	optionEscaper := strings.NewReplacer(`\`, `\\`, `'`, `\'`, `:`, `\:`)
+	graphEscaper := strings.NewReplacer(`\`, `\\`, `'`, `\'`, `[`, `\[`, `]`, `\]`, `,`, `\,`, `;`, `\;`)
+	return graphEscaper.Replace(optionEscaper.Replace(s))
+}
+
 // Append returns a VideoFilter appending the given string.
 func (f VideoFilter) Append(s string) VideoFilter {
 	// if filter is empty, then just set
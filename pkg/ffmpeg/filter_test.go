@@ -0,0 +1,87 @@
+package ffmpeg
+
+import "testing"
+
+func TestEscapeFilterValue(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"plain", "/videos/sub.srt", "/videos/sub.srt"},
+		{"quote", "/videos/it's.srt", `/videos/it\\\'s.srt`},
+		{"colon", "/videos/a:b.srt", `/videos/a\\:b.srt`},
+		{"backslash", `/videos/a\b.srt`, `/videos/a\\\\b.srt`},
+		{"comma", "/videos/a,b.srt", `/videos/a\,b.srt`},
+		{"brackets and semicolon", "/videos/[a];b.srt", `/videos/\[a\]\;b.srt`},
+		{"windows path", `C:\videos\sub.srt`, `C\\:\\\\videos\\\\sub.srt`},
+		{"windows path with specials", `C:\my videos\it's, here.srt`, `C\\:\\\\my videos\\\\it\\\'s\, here.srt`},
+		{"unc path", `\\server\share\sub.srt`, `\\\\\\\\server\\\\share\\\\sub.srt`},
+		// example from the ffmpeg filtergraph escaping documentation
+		{
+			"ffmpeg documentation",
+			"this is a 'string': may contain one, or more, special characters",
+			`this is a \\\'string\\\'\\: may contain one\, or more\, special characters`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeFilterValue(tt.s); got != tt.want {
+				t.Errorf("escapeFilterValue(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVideoFilter_Subtitles(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      VideoFilter
+		path        string
+		streamIndex int
+		offset      float64
+		want        VideoFilter
+	}{
+		{
+			"external file",
+			"",
+			"/videos/a:b.srt",
+			-1,
+			0,
+			`subtitles=filename=/videos/a\\:b.srt`,
+		},
+		{
+			"windows path",
+			"",
+			`C:\videos\it's.srt`,
+			-1,
+			0,
+			`subtitles=filename=C\\:\\\\videos\\\\it\\\'s.srt`,
+		},
+		{
+			"embedded stream",
+			"scale=-2:720",
+			"/videos/a,b.mkv",
+			2,
+			0,
+			`scale=-2:720,subtitles=filename=/videos/a\,b.mkv:si=2`,
+		},
+		{
+			"offset",
+			"",
+			"/videos/sub.srt",
+			-1,
+			10,
+			`setpts=PTS+10.000000/TB,subtitles=filename=/videos/sub.srt,setpts=PTS-STARTPTS`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Subtitles(tt.path, tt.streamIndex, tt.offset); got != tt.want {
+				t.Errorf("VideoFilter.Subtitles() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package ffmpeg
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
+	"strings"
 )
 
 // Arger is an interface that can be used to append arguments to an Args slice.
@@ -13,6 +15,23 @@ type Arger interface {
 // Args represents a slice of arguments to be passed to ffmpeg.
 type Args []string
 
+// ValidateExtraArgs returns an error if the user provided arguments in args
+// are invalid. Arguments must not be empty, and options must be separate
+// arguments from their values.
+func ValidateExtraArgs(args []string) error {
+	for _, a := range args {
+		if strings.TrimSpace(a) == "" {
+			return errors.New("arguments must not be empty")
+		}
+
+		if strings.HasPrefix(a, "-") && strings.ContainsAny(a, " \t") {
+			return fmt.Errorf("argument %q contains whitespace: options and values must be separate arguments", a)
+		}
+	}
+
+	return nil
+}
+
 // LogLevel sets the LogLevel to l and returns the result.
 func (a Args) LogLevel(l LogLevel) Args {
 	if l == "" {
@@ -0,0 +1,201 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type ProcessConfig interface {
+	// GetMaxFFMpegProcesses returns the maximum number of ffmpeg processes
+	// that may run at once. Zero is unlimited.
+	GetMaxFFMpegProcesses() int
+}
+
+// processManager limits the number of ffmpeg processes running at once, and
+// keeps track of running processes so that they can be killed on shutdown.
+type processManager struct {
+	config ProcessConfig
+
+	mutex    sync.Mutex
+	running  map[*Process]struct{}
+	released chan struct{}
+	closed   bool
+}
+
+var errProcessManagerClosed = errors.New("ffmpeg is shut down")
+
+// processes is shared by all FFMpeg instances, so that the limit applies
+// across instances when the ffmpeg path is changed.
+var processes = newProcessManager()
+
+// SetProcessConfig sets the configuration used to limit the number of
+// ffmpeg processes running at once.
+func SetProcessConfig(config ProcessConfig) {
+	processes.setConfig(config)
+}
+
+// ShutdownProcesses kills all running ffmpeg processes. Processes started
+// afterwards fail to start.
+func ShutdownProcesses() {
+	processes.shutdown()
+}
+
+func newProcessManager() *processManager {
+	return &processManager{
+		running: make(map[*Process]struct{}),
+	}
+}
+
+func (m *processManager) setConfig(config ProcessConfig) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.config = config
+}
+
+func (m *processManager) maxProcesses() int {
+	if m.config == nil {
+		return 0
+	}
+	return m.config.GetMaxFFMpegProcesses()
+}
+
+// start waits until a process may be started, then starts p. Requests are
+// queued until a running process finishes or ctx is done.
+func (m *processManager) start(ctx context.Context, p *Process) error {
+	for {
+		m.mutex.Lock()
+		if m.closed {
+			m.mutex.Unlock()
+			return errProcessManagerClosed
+		}
+
+		max := m.maxProcesses()
+		if max <= 0 || len(m.running) < max {
+			if err := p.Cmd.Start(); err != nil {
+				m.mutex.Unlock()
+				return err
+			}
+
+			m.running[p] = struct{}{}
+			m.mutex.Unlock()
+			return nil
+		}
+
+		if m.released == nil {
+			m.released = make(chan struct{})
+		}
+		released := m.released
+		m.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// finished removes p from the running processes, allowing a queued process
+// to start.
+func (m *processManager) finished(p *Process) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.running, p)
+	if m.released != nil {
+		close(m.released)
+		m.released = nil
+	}
+}
+
+// shutdown kills all running processes and prevents new processes from
+// starting.
+func (m *processManager) shutdown() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.closed = true
+	if m.released != nil {
+		close(m.released)
+		m.released = nil
+	}
+
+	for p := range m.running {
+		if p.Process == nil {
+			continue
+		}
+
+		logger.Debugf("killing ffmpeg process %d", p.Process.Pid)
+		if err := p.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			logger.Warnf("error killing ffmpeg process %d: %v", p.Process.Pid, err)
+		}
+	}
+}
+
+// Process is an ffmpeg command. Processes are started through the process
+// manager of the FFMpeg instance, which limits the number of processes that
+// may run at once. Wait must be called after a successful Start.
+type Process struct {
+	*exec.Cmd
+
+	ctx      context.Context
+	manager  *processManager
+	waitOnce sync.Once
+	waitErr  error
+}
+
+// Start starts the process, waiting until the number of running processes is
+// below the configured maximum.
+func (p *Process) Start() error {
+	return p.manager.start(p.ctx, p)
+}
+
+// Wait waits for the process to exit, and allows another process to start.
+func (p *Process) Wait() error {
+	p.waitOnce.Do(func() {
+		p.waitErr = p.Cmd.Wait()
+		p.manager.finished(p)
+	})
+	return p.waitErr
+}
+
+// Run starts the process and waits for it to exit.
+func (p *Process) Run() error {
+	if err := p.Start(); err != nil {
+		return err
+	}
+	return p.Wait()
+}
+
+// Output runs the process and returns its standard output. If Stderr is not
+// set, the standard error is returned in the Stderr field of the
+// *exec.ExitError on failure.
+func (p *Process) Output() ([]byte, error) {
+	if p.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+
+	var stdout bytes.Buffer
+	p.Stdout = &stdout
+
+	var stderr *bytes.Buffer
+	if p.Stderr == nil {
+		stderr = &bytes.Buffer{}
+		p.Stderr = stderr
+	}
+
+	err := p.Run()
+
+	var exitErr *exec.ExitError
+	if stderr != nil && errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.Bytes()
+	}
+
+	return stdout.Bytes(), err
+}
@@ -0,0 +1,261 @@
+package ffmpeg
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+type maxProcessesConfig int
+
+func (c maxProcessesConfig) GetMaxFFMpegProcesses() int {
+	return int(c)
+}
+
+// TestHelperProcess is not a real test. It is run as the process started by
+// the other tests, and exits when its standard input is closed, or fails
+// immediately if GO_HELPER_PROCESS_FAIL is set.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	if os.Getenv("GO_HELPER_PROCESS_FAIL") == "1" {
+		os.Exit(1)
+	}
+
+	_, _ = io.Copy(io.Discard, os.Stdin)
+	os.Exit(0)
+}
+
+// helperProcess returns a process that runs until stdin is closed.
+func helperProcess(ctx context.Context, m *processManager) (*Process, io.WriteCloser) {
+	cmd := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+	cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1")
+	stdin, _ := cmd.StdinPipe()
+
+	return &Process{
+		Cmd:     cmd,
+		ctx:     ctx,
+		manager: m,
+	}, stdin
+}
+
+func (m *processManager) runningCount() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return len(m.running)
+}
+
+// startAsync starts p in a goroutine, returning a channel that receives the
+// result.
+func startAsync(p *Process) chan error {
+	ret := make(chan error, 1)
+	go func() {
+		ret <- p.Start()
+	}()
+	return ret
+}
+
+func assertBlocked(t *testing.T, started chan error) {
+	t.Helper()
+
+	select {
+	case err := <-started:
+		t.Fatalf("process started while at the limit: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func assertStarted(t *testing.T, started chan error, wantErr error) {
+	t.Helper()
+
+	select {
+	case err := <-started:
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("Start() error = %v, want %v", err, wantErr)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("process did not start")
+	}
+}
+
+func TestProcessManagerLimit(t *testing.T) {
+	ctx := context.Background()
+	m := newProcessManager()
+	m.setConfig(maxProcessesConfig(2))
+	defer m.shutdown()
+
+	p1, stdin1 := helperProcess(ctx, m)
+	p2, stdin2 := helperProcess(ctx, m)
+	p3, stdin3 := helperProcess(ctx, m)
+
+	if err := p1.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := p2.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// the limit is reached
+	started := startAsync(p3)
+	assertBlocked(t, started)
+
+	if got := m.runningCount(); got != 2 {
+		t.Errorf("running = %d, want 2", got)
+	}
+
+	// finishing a process allows the queued process to start
+	stdin1.Close()
+	if err := p1.Wait(); err != nil {
+		t.Errorf("Wait() error = %v", err)
+	}
+
+	assertStarted(t, started, nil)
+
+	stdin2.Close()
+	stdin3.Close()
+	_ = p2.Wait()
+	_ = p3.Wait()
+
+	if got := m.runningCount(); got != 0 {
+		t.Errorf("running = %d, want 0", got)
+	}
+}
+
+func TestProcessManagerUnlimited(t *testing.T) {
+	ctx := context.Background()
+	m := newProcessManager()
+	defer m.shutdown()
+
+	var stdins []io.WriteCloser
+	var procs []*Process
+	for i := 0; i < 3; i++ {
+		p, stdin := helperProcess(ctx, m)
+		if err := p.Start(); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+		procs = append(procs, p)
+		stdins = append(stdins, stdin)
+	}
+
+	for i, p := range procs {
+		stdins[i].Close()
+		_ = p.Wait()
+	}
+}
+
+func TestProcessManagerReleaseOnError(t *testing.T) {
+	ctx := context.Background()
+	m := newProcessManager()
+	m.setConfig(maxProcessesConfig(1))
+	defer m.shutdown()
+
+	// a process that fails to start does not take a slot
+	failed := &Process{
+		Cmd:     exec.Command("stash-nonexistent-ffmpeg"),
+		ctx:     ctx,
+		manager: m,
+	}
+	if err := failed.Start(); err == nil {
+		t.Fatal("Start() succeeded for a missing executable")
+	}
+
+	if got := m.runningCount(); got != 0 {
+		t.Errorf("running = %d, want 0", got)
+	}
+
+	// a process that exits with an error releases its slot
+	exitErr, _ := helperProcess(ctx, m)
+	exitErr.Env = append(exitErr.Env, "GO_HELPER_PROCESS_FAIL=1")
+	if err := exitErr.Run(); err == nil {
+		t.Fatal("Run() succeeded for a failing process")
+	}
+
+	if got := m.runningCount(); got != 0 {
+		t.Errorf("running = %d, want 0", got)
+	}
+
+	p, stdin := helperProcess(ctx, m)
+	assertStarted(t, startAsync(p), nil)
+	stdin.Close()
+	_ = p.Wait()
+}
+
+func TestProcessManagerCancel(t *testing.T) {
+	m := newProcessManager()
+	m.setConfig(maxProcessesConfig(1))
+	defer m.shutdown()
+
+	p1, stdin1 := helperProcess(context.Background(), m)
+	if err := p1.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// cancelling a queued process stops it waiting without taking a slot
+	ctx, cancel := context.WithCancel(context.Background())
+	p2, _ := helperProcess(ctx, m)
+	started := startAsync(p2)
+	assertBlocked(t, started)
+
+	cancel()
+	assertStarted(t, started, context.Canceled)
+
+	if got := m.runningCount(); got != 1 {
+		t.Errorf("running = %d, want 1", got)
+	}
+
+	// the slot is still released when the running process finishes
+	stdin1.Close()
+	_ = p1.Wait()
+
+	p3, stdin3 := helperProcess(context.Background(), m)
+	assertStarted(t, startAsync(p3), nil)
+	stdin3.Close()
+	_ = p3.Wait()
+}
+
+func TestProcessManagerShutdown(t *testing.T) {
+	m := newProcessManager()
+	m.setConfig(maxProcessesConfig(1))
+
+	ctx := context.Background()
+	p1, stdin1 := helperProcess(ctx, m)
+	defer stdin1.Close()
+
+	if err := p1.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	p2, _ := helperProcess(ctx, m)
+	started := startAsync(p2)
+	assertBlocked(t, started)
+
+	// queued processes fail and running processes are killed
+	m.shutdown()
+	assertStarted(t, started, errProcessManagerClosed)
+
+	waited := make(chan error, 1)
+	go func() {
+		waited <- p1.Wait()
+	}()
+
+	select {
+	case err := <-waited:
+		if err == nil {
+			t.Error("Wait() succeeded for a killed process")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("process was not killed")
+	}
+
+	// processes cannot start after shutdown
+	p3, _ := helperProcess(ctx, m)
+	if err := p3.Start(); !errors.Is(err, errProcessManagerClosed) {
+		t.Errorf("Start() error = %v, want %v", err, errProcessManagerClosed)
+	}
+}
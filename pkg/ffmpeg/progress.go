@@ -0,0 +1,121 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// Progress is the progress of an ffmpeg process, as reported by the
+// -progress option.
+type Progress struct {
+	// Frame is the number of frames written
+	Frame int64
+	// Time is the timestamp of the output written, in seconds
+	Time float64
+}
+
+// Percent returns the progress as a fraction of duration seconds of output,
+// between 0 and 1. Returns -1 if duration is not positive.
+func (p Progress) Percent(duration float64) float64 {
+	if duration <= 0 {
+		return -1
+	}
+
+	ret := p.Time / duration
+	if ret < 0 {
+		return 0
+	} else if ret > 1 {
+		return 1
+	}
+
+	return ret
+}
+
+// ProgressFunc is called with the progress of an ffmpeg process.
+type ProgressFunc func(p Progress)
+
+// WithProgress returns args with the options to write progress to standard
+// error, which may be parsed using NewProgressWriter. The regular statistics
+// output is disabled.
+func WithProgress(args Args) Args {
+	return append(Args{"-progress", "pipe:2", "-nostats"}, args...)
+}
+
+var progressLineRE = regexp.MustCompile(`^([a-z0-9_]+)=(\S*)$`)
+
+type progressWriter struct {
+	w  io.Writer
+	fn ProgressFunc
+
+	buf      []byte
+	progress Progress
+}
+
+// NewProgressWriter returns a writer that parses the progress written to
+// standard error by a process started with WithProgress, calling fn at the
+// end of each progress report. Lines that are not part of a progress report
+// are written to w. Close writes any remaining incomplete line to w.
+func NewProgressWriter(w io.Writer, fn ProgressFunc) io.WriteCloser {
+	return &progressWriter{
+		w:  w,
+		fn: fn,
+	}
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	pw.buf = append(pw.buf, p...)
+
+	for {
+		i := bytes.IndexByte(pw.buf, '\n')
+		if i == -1 {
+			break
+		}
+
+		line := pw.buf[:i+1]
+		if err := pw.writeLine(line); err != nil {
+			return len(p), err
+		}
+		pw.buf = pw.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+func (pw *progressWriter) Close() error {
+	if len(pw.buf) == 0 {
+		return nil
+	}
+
+	err := pw.writeLine(pw.buf)
+	pw.buf = nil
+	return err
+}
+
+func (pw *progressWriter) writeLine(line []byte) error {
+	match := progressLineRE.FindSubmatch(bytes.TrimRight(line, "\r\n"))
+	if match == nil {
+		_, err := pw.w.Write(line)
+		return err
+	}
+
+	value := string(match[2])
+	switch string(match[1]) {
+	case "frame":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			pw.progress.Frame = v
+		}
+	case "out_time_us":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			pw.progress.Time = float64(v) / 1000000
+		}
+	case "progress":
+		// progress is the last key of each report
+		if pw.fn != nil {
+			pw.fn(pw.progress)
+		}
+	}
+
+	return nil
+}
@@ -2,13 +2,16 @@ package ffmpeg
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 const (
@@ -27,12 +30,20 @@ type StreamManager struct {
 
 	config      StreamManagerConfig
 	lockManager *fsutil.ReadLockManager
+	monitor     TranscodeMonitor
 
 	context    context.Context
 	cancelFunc context.CancelFunc
 
 	runningStreams map[string]*runningStream
-	streamsMutex   sync.Mutex
+	// cachedStreams maps the directories of stopped streams that are kept
+	// on disk to the time they were last accessed
+	cachedStreams map[string]time.Time
+	streamsMutex  sync.Mutex
+
+	// hdrFiles caches whether probed files are HDR, keyed by hdrFileKey
+	hdrFiles      map[string]bool
+	hdrFilesMutex sync.Mutex
 }
 
 type StreamManagerConfig interface {
@@ -40,9 +51,229 @@ type StreamManagerConfig interface {
 	GetLiveTranscodeInputArgs() []string
 	GetLiveTranscodeOutputArgs() []string
 	GetTranscodeHardwareAcceleration() bool
+	GetTranscodeHardwareEncoder() models.HardwareEncoder
+	// GetLiveTranscodeH264Encoders returns the ordered list of encoders
+	// to try when live transcoding to H.264. Empty uses the default.
+	GetLiveTranscodeH264Encoders() []string
+	// GetLiveTranscodeVP9Encoders returns the ordered list of encoders
+	// to try when live transcoding to VP9. Empty uses the default.
+	GetLiveTranscodeVP9Encoders() []string
+	// GetLiveTranscodePreset returns the libx264 preset used when live
+	// transcoding.
+	GetLiveTranscodePreset() models.PreviewPreset
+	// GetLiveTranscodeCRF returns the CRF used when live transcoding with
+	// software encoders. Zero uses the default for the codec.
+	GetLiveTranscodeCRF() int
+	// GetLiveTranscodeMaxBitrate returns the maximum video bitrate of live
+	// transcodes in kbit/s. Zero is unlimited.
+	GetLiveTranscodeMaxBitrate() int
+	// GetLiveTranscodeCacheSize returns the maximum size in MB of segments
+	// kept on disk after a segmented stream stops. Zero disables caching.
+	GetLiveTranscodeCacheSize() int
+	// GetTranscodeToneMapping returns true if HDR video should be tone
+	// mapped to SDR when transcoding.
+	GetTranscodeToneMapping() bool
+}
+
+// TranscodeMonitor is notified of running live transcodes.
+type TranscodeMonitor interface {
+	// TranscodeStarted is called when a live transcode starts. Calling
+	// cancel stops the transcode. The returned TranscodeProgress receives
+	// the progress of the transcode.
+	TranscodeStarted(description string, cancel func()) TranscodeProgress
+}
+
+// TranscodeProgress receives the progress of a live transcode.
+type TranscodeProgress interface {
+	// SetPercent sets the progress of the transcode, from 0 to 1.
+	SetPercent(percent float64)
+	// Done is called when the transcode process exits.
+	Done()
+}
+
+type nopTranscodeProgress struct{}
+
+func (nopTranscodeProgress) SetPercent(percent float64) {}
+func (nopTranscodeProgress) Done()                      {}
+
+// transcodeStarted notifies the monitor, if set, that a live transcode has
+// started.
+func (sm *StreamManager) transcodeStarted(description string, cancel func()) TranscodeProgress {
+	if sm.monitor == nil {
+		return nopTranscodeProgress{}
+	}
+
+	return sm.monitor.TranscodeStarted(description, cancel)
+}
+
+// Subtitle is a text subtitle track to burn into a live transcode.
+type Subtitle struct {
+	// ID identifies the track in stream URLs and cache directory names.
+	ID string
+	// Path is the file containing the subtitles. This is the video file for
+	// embedded subtitles.
+	Path string
+	// StreamIndex is the index of the subtitle stream in the file, or -1 for
+	// external subtitle files.
+	StreamIndex int
+}
+
+// videoFilter returns videoFilter with the subtitles burned in beforehand, so
+// that they are drawn before frames are uploaded for hardware encoding.
+func (s *Subtitle) videoFilter(videoFilter VideoFilter, offset float64) VideoFilter {
+	if s == nil {
+		return videoFilter
+	}
+
+	ret := VideoFilter("").Subtitles(s.Path, s.StreamIndex, offset)
+	if videoFilter != "" {
+		ret = ret.Append(string(videoFilter))
+	}
+
+	return ret
+}
+
+// toneMapFilter returns videoFilter with HDR to SDR tone mapping applied
+// beforehand if toneMap is true.
+func toneMapFilter(videoFilter VideoFilter, toneMap bool) VideoFilter {
+	if !toneMap {
+		return videoFilter
+	}
+
+	ret := VideoFilter("").ToneMap()
+	if videoFilter != "" {
+		ret = ret.Append(string(videoFilter))
+	}
+
+	return ret
+}
+
+func hdrFileKey(vf *models.VideoFile) string {
+	return fmt.Sprintf("%s_%d", vf.Path, vf.ModTime.UnixNano())
+}
+
+// toneMap returns true if tone mapping is enabled and vf is HDR video. The
+// colour characteristics are not stored in the database, so the file is
+// probed the first time it is transcoded.
+func (sm *StreamManager) toneMap(vf *models.VideoFile) bool {
+	if !sm.config.GetTranscodeToneMapping() {
+		return false
+	}
+
+	key := hdrFileKey(vf)
+
+	sm.hdrFilesMutex.Lock()
+	hdr, found := sm.hdrFiles[key]
+	sm.hdrFilesMutex.Unlock()
+
+	if found {
+		return hdr
+	}
+
+	probed, err := sm.ffprobe.NewVideoFile(vf.Path)
+	if err != nil {
+		logger.Warnf("[transcode] error probing %s for HDR: %v", vf.Path, err)
+		return false
+	}
+
+	hdr = probed.IsHDR()
+	if hdr {
+		logger.Debugf("[transcode] tone mapping HDR video %s", vf.Path)
+	}
+
+	sm.hdrFilesMutex.Lock()
+	sm.hdrFiles[key] = hdr
+	sm.hdrFilesMutex.Unlock()
+
+	return hdr
+}
+
+// qualityArgs returns the configured quality options for codec. These must
+// be added after the codec options so that they take precedence.
+func (sm *StreamManager) qualityArgs(codec VideoCodec) (args Args) {
+	if codec == "" || codec == VideoCodecCopy {
+		return
+	}
+
+	if codec == VideoCodecLibX264 {
+		args = append(args, "-preset", sm.config.GetLiveTranscodePreset().String())
+	}
+
+	if crf := sm.config.GetLiveTranscodeCRF(); crf > 0 && (codec == VideoCodecLibX264 || codec == VideoCodecVP9 || codec == VideoCodecSVTAV1) {
+		args = append(args, "-crf", strconv.Itoa(crf))
+	}
+
+	if maxBitrate := sm.config.GetLiveTranscodeMaxBitrate(); maxBitrate > 0 {
+		if codec == VideoCodecVP9 {
+			// libvpx uses the bitrate as the maximum in constrained quality mode
+			args = append(args, "-b:v", fmt.Sprintf("%dk", maxBitrate))
+		} else {
+			args = append(args,
+				"-maxrate", fmt.Sprintf("%dk", maxBitrate),
+				"-bufsize", fmt.Sprintf("%dk", maxBitrate*2),
+			)
+		}
+	}
+
+	return
+}
+
+// codecChain returns the codecs to try in order when encoding with the
+// software codec or a hardware equivalent. compatible is the list of
+// hardware codecs supported by the stream type.
+//
+// If no encoders are configured for the codec, the chain is the hardware
+// codec selected by the hardware encoder setting, followed by the software
+// codec. Otherwise it is the configured encoders that are available, with
+// the software codec appended if not present. Hardware codecs are only
+// included if hardware acceleration is enabled.
+func (sm *StreamManager) codecChain(software VideoCodec, compatible []VideoCodec) []VideoCodec {
+	var configured []string
+	switch software {
+	case VideoCodecLibX264:
+		configured = sm.config.GetLiveTranscodeH264Encoders()
+	case VideoCodecVP9:
+		configured = sm.config.GetLiveTranscodeVP9Encoders()
+	}
+
+	hwEnabled := sm.config.GetTranscodeHardwareAcceleration()
+
+	var ret []VideoCodec
+	if len(configured) == 0 {
+		if hwEnabled {
+			if hwcodec := sm.encoder.hwCodecCompatible(sm.config.GetTranscodeHardwareEncoder(), compatible...); hwcodec != nil {
+				ret = append(ret, *hwcodec)
+			}
+		}
+		return append(ret, software)
+	}
+
+	for _, c := range configured {
+		codec := VideoCodec(c)
+		// skip encoders that are not supported or not compatible
+		if codec != software && !(hwEnabled && sliceutil.Contains(compatible, codec) && sm.encoder.hwCodecSupported(codec)) {
+			continue
+		}
+		ret = sliceutil.AppendUnique(ret, codec)
+	}
+
+	return sliceutil.AppendUnique(ret, software)
+}
+
+// selectCodec returns the first codec in the codec chain that is not in failed.
+func (sm *StreamManager) selectCodec(software VideoCodec, compatible []VideoCodec, failed []VideoCodec) VideoCodec {
+	for _, codec := range sm.codecChain(software, compatible) {
+		if !sliceutil.Contains(failed, codec) {
+			return codec
+		}
+	}
+
+	return software
 }
 
-func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe FFProbe, config StreamManagerConfig, lockManager *fsutil.ReadLockManager) *StreamManager {
+// NewStreamManager returns a new StreamManager. monitor is notified of live
+// transcodes, and may be nil.
+func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe FFProbe, config StreamManagerConfig, lockManager *fsutil.ReadLockManager, monitor TranscodeMonitor) *StreamManager {
 	if cacheDir == "" {
 		logger.Warn("cache directory is not set. Live HLS/DASH transcoding will be disabled")
 	}
@@ -55,9 +286,12 @@ func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe FFProbe, config
 		ffprobe:        ffprobe,
 		config:         config,
 		lockManager:    lockManager,
+		monitor:        monitor,
 		context:        ctx,
 		cancelFunc:     cancel,
 		runningStreams: make(map[string]*runningStream),
+		cachedStreams:  make(map[string]time.Time),
+		hdrFiles:       make(map[string]bool),
 	}
 
 	go func() {
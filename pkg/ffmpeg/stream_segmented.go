@@ -6,20 +6,24 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
 	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/hash/md5"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/utils"
 
 	"github.com/zencoder/go-dash/v3/mpd"
@@ -48,6 +52,7 @@ const (
 	maxIdleTime = 30 * time.Second
 
 	resolutionParamKey = "resolution"
+	subtitleParamKey   = "subtitle"
 	// TODO - setting the apikey in here isn't ideal
 	apiKeyParamKey = "apikey"
 )
@@ -55,7 +60,7 @@ const (
 type StreamType struct {
 	Name          string
 	SegmentType   *SegmentType
-	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string)
+	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, subtitle *Subtitle)
 	Args          func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) Args
 }
 
@@ -95,6 +100,49 @@ var (
 			return
 		},
 	}
+	StreamTypeHLSFMP4 = &StreamType{
+		Name:          "hls-fmp4",
+		SegmentType:   SegmentTypeFMP4,
+		ServeManifest: serveHLSFMP4Manifest,
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+			// only generate the actual init segment (init.m4s)
+			// when generating the first segment
+			init := ".init.m4s"
+			if segment == 0 {
+				init = "init.m4s"
+			}
+
+			args = CodecInit(codec)
+			args = append(args,
+				"-flags", "+cgop",
+				"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentLength),
+			)
+			args = args.VideoFilter(videoFilter)
+			if videoOnly {
+				args = append(args, "-an")
+			} else {
+				args = append(args,
+					"-c:a", "aac",
+					"-ac", "2",
+				)
+			}
+			args = append(args,
+				"-sn",
+				"-copyts",
+				"-avoid_negative_ts", "disabled",
+				"-f", "hls",
+				"-start_number", fmt.Sprint(segment),
+				"-hls_time", fmt.Sprint(segmentLength),
+				"-hls_flags", "split_by_time",
+				"-hls_segment_type", "fmp4",
+				"-hls_fmp4_init_filename", init,
+				"-hls_playlist_type", "vod",
+				"-hls_segment_filename", filepath.Join(outputDir, ".%d.m4s"),
+				filepath.Join(outputDir, "manifest.m3u8"),
+			)
+			return
+		},
+	}
 	StreamTypeHLSCopy = &StreamType{
 		Name:          "hls-copy",
 		SegmentType:   SegmentTypeTS,
@@ -182,6 +230,73 @@ var (
 			return
 		},
 	}
+	StreamTypeDASHMP4Video = &StreamType{
+		Name:          "dash-mp4-v",
+		SegmentType:   SegmentTypeMP4Video,
+		ServeManifest: serveDASHMP4Manifest,
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+			// only generate the actual init segment (init_v.m4s)
+			// when generating the first segment
+			init := ".init_v.m4s"
+			if segment == 0 {
+				init = "init_v.m4s"
+			}
+
+			args = CodecInit(codec)
+			args = append(args,
+				"-flags", "+cgop",
+				"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentLength),
+			)
+			args = args.VideoFilter(videoFilter)
+			args = append(args,
+				"-copyts",
+				"-avoid_negative_ts", "disabled",
+				"-map", "0:v:0",
+				"-f", "hls",
+				"-start_number", fmt.Sprint(segment),
+				"-hls_time", fmt.Sprint(segmentLength),
+				"-hls_flags", "split_by_time",
+				"-hls_segment_type", "fmp4",
+				"-hls_fmp4_init_filename", init,
+				"-hls_playlist_type", "vod",
+				"-hls_segment_filename", filepath.Join(outputDir, ".%d_v.m4s"),
+				filepath.Join(outputDir, "manifest.m3u8"),
+			)
+			return
+		},
+	}
+	StreamTypeDASHMP4Audio = &StreamType{
+		Name:          "dash-mp4-a",
+		SegmentType:   SegmentTypeMP4Audio,
+		ServeManifest: serveDASHMP4Manifest,
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+			// only generate the actual init segment (init_a.m4s)
+			// when generating the first segment
+			init := ".init_a.m4s"
+			if segment == 0 {
+				init = "init_a.m4s"
+			}
+			args = append(args,
+				"-c:a", "aac",
+				"-b:a", "128000",
+				"-ar", "48000",
+				"-ac", "2",
+				"-copyts",
+				"-avoid_negative_ts", "disabled",
+				"-map", "0:a:0",
+				"-f", "hls",
+				"-start_number", fmt.Sprint(segment),
+				"-hls_time", fmt.Sprint(segmentLength),
+				"-hls_flags", "split_by_time",
+				"-hls_segment_type", "fmp4",
+				"-hls_fmp4_init_filename", init,
+				"-hls_playlist_type", "vod",
+				"-hls_segment_filename", filepath.Join(outputDir, ".%d_a.m4s"),
+				filepath.Join(outputDir, "manifest.m3u8"),
+			)
+			return
+		},
+	}
 )
 
 type SegmentType struct {
@@ -206,6 +321,28 @@ var (
 			return segment, err
 		},
 	}
+	SegmentTypeFMP4 = &SegmentType{
+		Format:   "%d.m4s",
+		MimeType: MimeMp4Video,
+		MakeFilename: func(segment int) string {
+			if segment == -1 {
+				return "init.m4s"
+			} else {
+				return fmt.Sprintf("%d.m4s", segment)
+			}
+		},
+		ParseSegment: func(str string) (int, error) {
+			if str == "init" {
+				return -1, nil
+			} else {
+				segment, err := strconv.Atoi(str)
+				if err != nil || segment < 0 {
+					err = ErrInvalidSegment
+				}
+				return segment, err
+			}
+		},
+	}
 	SegmentTypeWEBMVideo = &SegmentType{
 		Format:   "%d_v.webm",
 		MimeType: MimeWebmVideo,
@@ -250,6 +387,50 @@ var (
 			}
 		},
 	}
+	SegmentTypeMP4Video = &SegmentType{
+		Format:   "%d_v.m4s",
+		MimeType: MimeMp4Video,
+		MakeFilename: func(segment int) string {
+			if segment == -1 {
+				return "init_v.m4s"
+			} else {
+				return fmt.Sprintf("%d_v.m4s", segment)
+			}
+		},
+		ParseSegment: func(str string) (int, error) {
+			if str == "init" {
+				return -1, nil
+			} else {
+				segment, err := strconv.Atoi(str)
+				if err != nil || segment < 0 {
+					err = ErrInvalidSegment
+				}
+				return segment, err
+			}
+		},
+	}
+	SegmentTypeMP4Audio = &SegmentType{
+		Format:   "%d_a.m4s",
+		MimeType: MimeMp4Audio,
+		MakeFilename: func(segment int) string {
+			if segment == -1 {
+				return "init_a.m4s"
+			} else {
+				return fmt.Sprintf("%d_a.m4s", segment)
+			}
+		},
+		ParseSegment: func(str string) (int, error) {
+			if str == "init" {
+				return -1, nil
+			} else {
+				segment, err := strconv.Atoi(str)
+				if err != nil || segment < 0 {
+					err = ErrInvalidSegment
+				}
+				return segment, err
+			}
+		},
+	}
 )
 
 var ErrInvalidSegment = errors.New("invalid segment")
@@ -260,10 +441,12 @@ type StreamOptions struct {
 	Resolution string
 	Hash       string
 	Segment    string
+	// Subtitle is burned into the video if set
+	Subtitle *Subtitle
 }
 
 type transcodeProcess struct {
-	cmd         *exec.Cmd
+	cmd         *Process
 	context     context.Context
 	cancel      context.CancelFunc
 	cancelled   bool
@@ -287,38 +470,49 @@ type runningStream struct {
 	streamType       *StreamType
 	vf               *models.VideoFile
 	maxTranscodeSize int
+	subtitle         *Subtitle
+	toneMap          bool
 	outputDir        string
 
 	waitingSegments []*waitingSegment
 	tp              *transcodeProcess
 	lastAccessed    time.Time
 	lastSegment     int
+
+	// failedCodecs are the hardware codecs that have failed to encode the file
+	failedCodecs []VideoCodec
 }
 
 func (t StreamType) String() string {
 	return t.Name
 }
 
-func (t StreamType) FileDir(hash string, maxTranscodeSize int) string {
-	if maxTranscodeSize == 0 {
-		return fmt.Sprintf("%s_%s", hash, t)
-	} else {
-		return fmt.Sprintf("%s_%s_%d", hash, t, maxTranscodeSize)
+// FileDir returns the name of the directory of the segments of a stream.
+// encodeKey identifies the encoder and quality settings of the stream, and
+// is empty if the stream is copied.
+func (t StreamType) FileDir(hash string, maxTranscodeSize int, subtitle *Subtitle, toneMap bool, encodeKey string) string {
+	ret := fmt.Sprintf("%s_%s", hash, t)
+	if maxTranscodeSize != 0 {
+		ret += fmt.Sprintf("_%d", maxTranscodeSize)
+	}
+	if encodeKey != "" {
+		ret += "_" + encodeKey
 	}
+	if subtitle != nil {
+		ret += "_sub" + subtitle.ID
+	}
+	if toneMap {
+		ret += "_sdr"
+	}
+	return ret
 }
 
-func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
+func HLSGetCodec(sm *StreamManager, name string, failedCodecs []VideoCodec) (codec VideoCodec) {
 	switch name {
-	case "hls":
-		codec = VideoCodecLibX264
-		if hwcodec := sm.encoder.hwCodecHLSCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
-			codec = *hwcodec
-		}
+	case "hls", "hls-fmp4", "dash-mp4-v":
+		codec = sm.selectCodec(VideoCodecLibX264, hwCodecsHLS, failedCodecs)
 	case "dash-v":
-		codec = VideoCodecVP9
-		if hwcodec := sm.encoder.hwCodecWEBMCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
-			codec = *hwcodec
-		}
+		codec = sm.selectCodec(VideoCodecVP9, hwCodecsWEBM, failedCodecs)
 	case "hls-copy":
 		codec = VideoCodecCopy
 	}
@@ -326,16 +520,31 @@ func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
 	return codec
 }
 
-func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
+// encodeKey returns a key identifying the encoder and quality settings used
+// for streams of type t, so that cached segments are not reused after these
+// settings are changed. Returns an empty string for copied streams.
+func (sm *StreamManager) encodeKey(t *StreamType) string {
+	codec := HLSGetCodec(sm, t.Name, nil)
+	if codec == "" || codec == VideoCodecCopy {
+		return ""
+	}
+
+	key := string(codec) + " " + strings.Join(sm.qualityArgs(codec), " ")
+	return md5.FromString(key)[:8]
+}
+
+func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) (Args, VideoCodec) {
 	extraInputArgs := sm.config.GetLiveTranscodeInputArgs()
 	extraOutputArgs := sm.config.GetLiveTranscodeOutputArgs()
 
 	args := Args{"-hide_banner"}
 	args = args.LogLevel(LogLevelError)
 
-	codec := HLSGetCodec(sm, s.streamType.Name)
+	codec := HLSGetCodec(sm, s.streamType.Name, s.failedCodecs)
 
-	fullhw := sm.config.GetTranscodeHardwareAcceleration() && sm.encoder.hwCanFullHWTranscode(sm.context, codec, s.vf, s.maxTranscodeSize)
+	// subtitles and tone mapping cannot be applied when the whole transcode
+	// is done in hardware
+	fullhw := codec.isHardware() && s.subtitle == nil && !s.toneMap && sm.encoder.hwCanFullHWTranscode(sm.context, codec, s.vf, s.maxTranscodeSize)
 	args = sm.encoder.hwDeviceInit(args, codec, fullhw)
 	args = append(args, extraInputArgs...)
 
@@ -348,12 +557,23 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 	videoOnly := ProbeAudioCodec(s.vf.AudioCodec) == MissingUnsupported
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, s.vf, s.maxTranscodeSize, fullhw)
+	if codec != VideoCodecCopy {
+		// timestamps are copied from the input, so no offset is needed
+		videoFilter = s.subtitle.videoFilter(videoFilter, 0)
+		videoFilter = toneMapFilter(videoFilter, s.toneMap)
+	}
+
+	streamArgs := s.streamType.Args(codec, segment, videoFilter, videoOnly, s.outputDir)
 
-	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.outputDir)...)
+	// quality options must precede the output file, which is the last argument
+	last := len(streamArgs) - 1
+	args = append(args, streamArgs[:last]...)
+	args = append(args, sm.qualityArgs(codec)...)
+	args = append(args, streamArgs[last])
 
 	args = append(args, extraOutputArgs...)
 
-	return args
+	return args, codec
 }
 
 // checkSegments renames temp segments that have been completely generated.
@@ -412,7 +632,18 @@ func segmentExists(path string) bool {
 
 // serveHLSManifest serves a generated HLS playlist. The URLs for the segments
 // are of the form {r.URL}/%d.ts{?urlQuery} where %d is the segment index.
-func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string) {
+func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, subtitle *Subtitle) {
+	serveHLSPlaylist(sm, w, r, vf, resolution, subtitle, SegmentTypeTS)
+}
+
+// serveHLSFMP4Manifest serves a generated HLS playlist using fragmented MP4
+// segments. The URLs for the segments are of the form {r.URL}/%d.m4s{?urlQuery}
+// where %d is the segment index, and the init segment is {r.URL}/init.m4s{?urlQuery}.
+func serveHLSFMP4Manifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, subtitle *Subtitle) {
+	serveHLSPlaylist(sm, w, r, vf, resolution, subtitle, SegmentTypeFMP4)
+}
+
+func serveHLSPlaylist(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, subtitle *Subtitle, segmentType *SegmentType) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
 		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
@@ -437,6 +668,10 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 		urlQuery.Set(resolutionParamKey, resolution)
 	}
 
+	if subtitle != nil {
+		urlQuery.Set(subtitleParamKey, subtitle.ID)
+	}
+
 	// TODO - this needs to be handled outside of this package
 	if apikey != "" {
 		urlQuery.Set(apiKeyParamKey, apikey)
@@ -451,11 +686,20 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 
 	fmt.Fprint(&buf, "#EXTM3U\n")
 
-	fmt.Fprint(&buf, "#EXT-X-VERSION:3\n")
+	// fragmented MP4 segments require version 7
+	if segmentType == SegmentTypeFMP4 {
+		fmt.Fprint(&buf, "#EXT-X-VERSION:7\n")
+	} else {
+		fmt.Fprint(&buf, "#EXT-X-VERSION:3\n")
+	}
 	fmt.Fprint(&buf, "#EXT-X-MEDIA-SEQUENCE:0\n")
 	fmt.Fprintf(&buf, "#EXT-X-TARGETDURATION:%d\n", segmentLength)
 	fmt.Fprint(&buf, "#EXT-X-PLAYLIST-TYPE:VOD\n")
 
+	if segmentType == SegmentTypeFMP4 {
+		fmt.Fprintf(&buf, "#EXT-X-MAP:URI=\"%s/%s%s\"\n", baseURL, segmentType.MakeFilename(-1), urlQueryString)
+	}
+
 	leftover := probeResult.FileDuration
 	segment := 0
 
@@ -466,7 +710,7 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 		}
 
 		fmt.Fprintf(&buf, "#EXTINF:%f,\n", thisLength)
-		fmt.Fprintf(&buf, "%s/%d.ts%s\n", baseURL, segment, urlQueryString)
+		fmt.Fprintf(&buf, "%s/%s%s\n", baseURL, segmentType.MakeFilename(segment), urlQueryString)
 
 		leftover -= thisLength
 		segment++
@@ -478,8 +722,121 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 	utils.ServeStaticContent(w, r, buf.Bytes())
 }
 
-// serveDASHManifest serves a generated DASH manifest.
-func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string) {
+// hlsRendition is a resolution included in HLS master playlists, with the
+// estimated bandwidth of its stream in kbit/s.
+type hlsRendition struct {
+	resolution models.StreamingResolutionEnum
+	bandwidth  int
+}
+
+// hlsRenditions are the renditions included in HLS master playlists below
+// the resolution of the file, from highest to lowest.
+var hlsRenditions = []hlsRendition{
+	{models.StreamingResolutionEnumFourK, 16000},
+	{models.StreamingResolutionEnumFullHd, 6000},
+	{models.StreamingResolutionEnumStandardHd, 3000},
+	{models.StreamingResolutionEnumStandard, 1200},
+	{models.StreamingResolutionEnumLow, 400},
+}
+
+// scaledSize returns the size of vf when its smaller dimension is scaled
+// down to maxSize.
+func scaledSize(vf *models.VideoFile, maxSize int) (int, int) {
+	w, h := vf.Width, vf.Height
+	if maxSize == 0 || w == 0 || h == 0 || min(w, h) <= maxSize {
+		return w, h
+	}
+
+	even := func(v int) int {
+		return v - v%2
+	}
+
+	if w > h {
+		return even(w * maxSize / h), maxSize
+	}
+	return maxSize, even(h * maxSize / w)
+}
+
+// ServeHLSMasterPlaylist serves an HLS master playlist listing a rendition of
+// the file for each streaming resolution up to the maximum streaming
+// transcode size and the resolution of the file. Players switch between the
+// renditions depending on the available bandwidth. Each rendition is the HLS
+// stream of manifestName with the resolution parameter, and is transcoded on
+// demand.
+func (sm *StreamManager) ServeHLSMasterPlaylist(w http.ResponseWriter, r *http.Request, vf *models.VideoFile, manifestName string, subtitle *Subtitle) {
+	if sm.cacheDir == "" {
+		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
+		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
+		return
+	}
+
+	maxStreamingSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
+	maxBitrate := sm.config.GetLiveTranscodeMaxBitrate()
+	fileSize := min(vf.Width, vf.Height)
+
+	apikey := r.URL.Query().Get(apiKeyParamKey)
+
+	var buf bytes.Buffer
+	fmt.Fprint(&buf, "#EXTM3U\n")
+
+	writeRendition := func(resolution models.StreamingResolutionEnum, bandwidth int) {
+		if maxBitrate > 0 && bandwidth > maxBitrate {
+			bandwidth = maxBitrate
+		}
+
+		urlQuery := url.Values{}
+		urlQuery.Set(resolutionParamKey, resolution.String())
+		if subtitle != nil {
+			urlQuery.Set(subtitleParamKey, subtitle.ID)
+		}
+		// TODO - this needs to be handled outside of this package
+		if apikey != "" {
+			urlQuery.Set(apiKeyParamKey, apikey)
+		}
+
+		fmt.Fprintf(&buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d", bandwidth*1000)
+		width, height := scaledSize(vf, resolution.GetMaxResolution())
+		if width > 0 && height > 0 {
+			fmt.Fprintf(&buf, ",RESOLUTION=%dx%d", width, height)
+		}
+		fmt.Fprintf(&buf, "\n%s?%s\n", manifestName, urlQuery.Encode())
+	}
+
+	// include the original resolution if it is within the maximum size, or
+	// if the size of the file is unknown
+	if maxStreamingSize == 0 || fileSize == 0 || fileSize <= maxStreamingSize {
+		bandwidth := int(vf.BitRate / 1000)
+		if bandwidth <= 0 {
+			bandwidth = hlsRenditions[0].bandwidth
+		}
+		writeRendition(models.StreamingResolutionEnumOriginal, bandwidth)
+	}
+
+	for _, rendition := range hlsRenditions {
+		size := rendition.resolution.GetMaxResolution()
+		if size >= fileSize || (maxStreamingSize != 0 && size > maxStreamingSize) {
+			continue
+		}
+
+		writeRendition(rendition.resolution, rendition.bandwidth)
+	}
+
+	w.Header().Set("Content-Type", MimeHLS)
+	utils.ServeStaticContent(w, r, buf.Bytes())
+}
+
+// serveDASHManifest serves a generated DASH manifest using WebM segments.
+func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, subtitle *Subtitle) {
+	serveDASHPlaylist(sm, w, r, vf, resolution, subtitle, false)
+}
+
+// serveDASHMP4Manifest serves a generated DASH manifest using fragmented MP4
+// segments, with H.264 video and AAC audio.
+func serveDASHMP4Manifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, subtitle *Subtitle) {
+	serveDASHPlaylist(sm, w, r, vf, resolution, subtitle, true)
+}
+
+func serveDASHPlaylist(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, subtitle *Subtitle, mp4 bool) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with DASH because cache dir is unset")
 		http.Error(w, "cannot live transcode files with DASH because cache dir is unset", http.StatusServiceUnavailable)
@@ -538,6 +895,9 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 		maxTranscodeSize = models.StreamingResolutionEnum(resolution).GetMaxResolution()
 		urlQuery.Set(resolutionParamKey, resolution)
 	}
+	if subtitle != nil {
+		urlQuery.Set(subtitleParamKey, subtitle.ID)
+	}
 	if maxTranscodeSize != 0 {
 		videoSize := videoHeight
 		if videoWidth < videoSize {
@@ -563,15 +923,28 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	baseUrl.RawQuery = ""
 	m.BaseURL = baseUrl.String()
 
-	video, _ := m.AddNewAdaptationSetVideo(MimeWebmVideo, "progressive", true, 1)
+	if mp4 {
+		video, _ := m.AddNewAdaptationSetVideo(MimeMp4Video, "progressive", true, 1)
+
+		_, _ = video.SetNewSegmentTemplate(2, "init_v.m4s"+urlQueryString, "$Number$_v.m4s"+urlQueryString, 0, 1)
+		_, _ = video.AddNewRepresentationVideo(200000, "avc1.640028", "0", framerate, int64(videoWidth), int64(videoHeight))
 
-	_, _ = video.SetNewSegmentTemplate(2, "init_v.webm"+urlQueryString, "$Number$_v.webm"+urlQueryString, 0, 1)
-	_, _ = video.AddNewRepresentationVideo(200000, "vp09.00.40.08", "0", framerate, int64(videoWidth), int64(videoHeight))
+		if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
+			audio, _ := m.AddNewAdaptationSetAudio(MimeMp4Audio, true, 1, "und")
+			_, _ = audio.SetNewSegmentTemplate(2, "init_a.m4s"+urlQueryString, "$Number$_a.m4s"+urlQueryString, 0, 1)
+			_, _ = audio.AddNewRepresentationAudio(48000, 128000, "mp4a.40.2", "1")
+		}
+	} else {
+		video, _ := m.AddNewAdaptationSetVideo(MimeWebmVideo, "progressive", true, 1)
 
-	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
-		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, "und")
-		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQueryString, "$Number$_a.webm"+urlQueryString, 0, 1)
-		_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", "1")
+		_, _ = video.SetNewSegmentTemplate(2, "init_v.webm"+urlQueryString, "$Number$_v.webm"+urlQueryString, 0, 1)
+		_, _ = video.AddNewRepresentationVideo(200000, "vp09.00.40.08", "0", framerate, int64(videoWidth), int64(videoHeight))
+
+		if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
+			audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, "und")
+			_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQueryString, "$Number$_a.webm"+urlQueryString, 0, 1)
+			_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", "1")
+		}
 	}
 
 	var buf bytes.Buffer
@@ -581,8 +954,8 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	utils.ServeStaticContent(w, r, buf.Bytes())
 }
 
-func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string) {
-	streamType.ServeManifest(sm, w, r, vf, resolution)
+func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string, subtitle *Subtitle) {
+	streamType.ServeManifest(sm, w, r, vf, resolution, subtitle)
 }
 
 func (sm *StreamManager) serveWaitingSegment(w http.ResponseWriter, r *http.Request, segment *waitingSegment) {
@@ -627,7 +1000,10 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 		maxTranscodeSize = models.StreamingResolutionEnum(options.Resolution).GetMaxResolution()
 	}
 
-	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize)
+	// copied streams cannot be tone mapped
+	toneMap := options.StreamType != StreamTypeHLSCopy && sm.toneMap(options.VideoFile)
+
+	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize, options.Subtitle, toneMap, sm.encodeKey(options.StreamType))
 	outputDir := filepath.Join(sm.cacheDir, dir)
 
 	name := streamType.SegmentType.MakeFilename(segment)
@@ -642,12 +1018,17 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 			streamType:       options.StreamType,
 			vf:               options.VideoFile,
 			maxTranscodeSize: maxTranscodeSize,
+			subtitle:         options.Subtitle,
+			toneMap:          toneMap,
 			outputDir:        outputDir,
 
 			// initialize to cap 10 to avoid reallocations
 			waitingSegments: make([]*waitingSegment, 0, 10),
 		}
 		sm.runningStreams[dir] = stream
+
+		// existing segments are reused by the new stream
+		delete(sm.cachedStreams, dir)
 	}
 
 	now := time.Now()
@@ -688,8 +1069,8 @@ func (sm *StreamManager) startTranscode(stream *runningStream, segment int, done
 
 	lockCtx := sm.lockManager.ReadLock(sm.context, stream.vf.Path)
 
-	args := stream.makeStreamArgs(sm, segment)
-	cmd := sm.encoder.Command(lockCtx, args)
+	args, codec := stream.makeStreamArgs(sm, segment)
+	cmd := sm.encoder.Command(lockCtx, WithProgress(args))
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
@@ -720,8 +1101,28 @@ func (sm *StreamManager) startTranscode(stream *runningStream, segment int, done
 	}
 	stream.tp = tp
 
+	description := fmt.Sprintf("Live transcoding %s to %s", stream.vf.Path, stream.streamType)
+	progress := sm.transcodeStarted(description, func() {
+		sm.streamsMutex.Lock()
+		defer sm.streamsMutex.Unlock()
+
+		if stream.tp == tp {
+			sm.stopTranscode(stream)
+		}
+	})
+
 	go func() {
-		errStr, _ := io.ReadAll(stderr)
+		defer progress.Done()
+
+		// timestamps are copied from the input, so the output time is the
+		// time in the file
+		var errBuf bytes.Buffer
+		pw := NewProgressWriter(&errBuf, func(p Progress) {
+			progress.SetPercent(p.Percent(stream.vf.Duration))
+		})
+		_, _ = io.Copy(pw, stderr)
+		_ = pw.Close()
+		errStr := errBuf.Bytes()
 		outStr, _ := io.ReadAll(stdout)
 
 		errCmd := cmd.Wait()
@@ -758,6 +1159,13 @@ func (sm *StreamManager) startTranscode(stream *runningStream, segment int, done
 		// clear remaining segments after ffmpeg exit
 		tp.checkSegments()
 
+		// hardware encoders may fail on some files, so use the next
+		// encoder for the stream if no segments were produced
+		if err != nil && codec.isHardware() && !segmentExists(filepath.Join(tp.outputDir, fmt.Sprintf(tp.segmentType.Format, segment))) {
+			logger.Warnf("[transcode] encoder %s failed for %s, falling back to the next encoder", codec, stream.vf.Path)
+			stream.failedCodecs = sliceutil.AppendUnique(stream.failedCodecs, codec)
+		}
+
 		if stream.tp == tp {
 			stream.tp = nil
 		}
@@ -781,13 +1189,19 @@ func (sm *StreamManager) stopTranscode(stream *runningStream) {
 
 func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time) {
 	if len(stream.waitingSegments) == 0 && stream.lastAccessed.Add(maxIdleTime).Before(now) {
-		// Stream expired. Cancel the transcode process and delete the files
-		logger.Debugf("[transcode] stream for %s not accessed recently. Cancelling transcode and removing files", stream.dir)
-
+		// Stream expired. Cancel the transcode process and cache or delete the files
 		sm.stopTranscode(stream)
-		sm.removeTranscodeFiles(stream)
-
 		delete(sm.runningStreams, stream.dir)
+
+		if sm.config.GetLiveTranscodeCacheSize() > 0 {
+			logger.Debugf("[transcode] stream for %s not accessed recently. Cancelling transcode and caching files", stream.dir)
+			sm.cachedStreams[stream.dir] = stream.lastAccessed
+		} else {
+			logger.Debugf("[transcode] stream for %s not accessed recently. Cancelling transcode and removing files", stream.dir)
+			sm.removeTranscodeFiles(stream)
+		}
+
+		sm.evictCachedStreams()
 		return
 	}
 
@@ -877,6 +1291,66 @@ func (sm *StreamManager) removeTranscodeFiles(stream *runningStream) {
 	}
 }
 
+// evictCachedStreams removes the least recently accessed cached streams until
+// the total size of the cached streams is within the configured cache size.
+// assume lock is held
+func (sm *StreamManager) evictCachedStreams() {
+	if len(sm.cachedStreams) == 0 {
+		return
+	}
+
+	maxSize := int64(sm.config.GetLiveTranscodeCacheSize()) * 1024 * 1024
+
+	dirs := make([]string, 0, len(sm.cachedStreams))
+	sizes := make(map[string]int64, len(sm.cachedStreams))
+	var total int64
+	for dir := range sm.cachedStreams {
+		size := dirSize(filepath.Join(sm.cacheDir, dir))
+		dirs = append(dirs, dir)
+		sizes[dir] = size
+		total += size
+	}
+
+	sort.Slice(dirs, func(i, j int) bool {
+		return sm.cachedStreams[dirs[i]].Before(sm.cachedStreams[dirs[j]])
+	})
+
+	for _, dir := range dirs {
+		if total <= maxSize {
+			break
+		}
+
+		logger.Debugf("[transcode] removing cached stream %s", dir)
+		sm.removeCachedStream(dir)
+		total -= sizes[dir]
+	}
+}
+
+// assume lock is held
+func (sm *StreamManager) removeCachedStream(dir string) {
+	path := filepath.Join(sm.cacheDir, dir)
+	if err := os.RemoveAll(path); err != nil {
+		logger.Warnf("[transcode] error removing segment directory %s: %v", path, err)
+	}
+	delete(sm.cachedStreams, dir)
+}
+
+// dirSize returns the total size of the files in the directory.
+func dirSize(path string) int64 {
+	var ret int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil {
+			ret += info.Size()
+		}
+		return nil
+	})
+	return ret
+}
+
 // stopAndRemoveAll stops all current streams and removes all cache files
 func (sm *StreamManager) stopAndRemoveAll() {
 	sm.streamsMutex.Lock()
@@ -892,6 +1366,10 @@ func (sm *StreamManager) stopAndRemoveAll() {
 		sm.removeTranscodeFiles(stream)
 	}
 
+	for dir := range sm.cachedStreams {
+		sm.removeCachedStream(dir)
+	}
+
 	// ensure nothing else can use the map
 	sm.runningStreams = nil
 }
@@ -0,0 +1,409 @@
+package ffmpeg
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+type testStreamConfig struct {
+	StreamManagerConfig
+
+	hwAccel      bool
+	hwEncoder    models.HardwareEncoder
+	h264Encoders []string
+	vp9Encoders  []string
+	preset       models.PreviewPreset
+	crf          int
+	maxBitrate   int
+	cacheSize    int
+}
+
+func (c testStreamConfig) GetLiveTranscodeCacheSize() int {
+	return c.cacheSize
+}
+
+func (c testStreamConfig) GetTranscodeHardwareAcceleration() bool {
+	return c.hwAccel
+}
+
+func (c testStreamConfig) GetTranscodeHardwareEncoder() models.HardwareEncoder {
+	return c.hwEncoder
+}
+
+func (c testStreamConfig) GetLiveTranscodeH264Encoders() []string {
+	return c.h264Encoders
+}
+
+func (c testStreamConfig) GetLiveTranscodeVP9Encoders() []string {
+	return c.vp9Encoders
+}
+
+func (c testStreamConfig) GetLiveTranscodePreset() models.PreviewPreset {
+	return c.preset
+}
+
+func (c testStreamConfig) GetLiveTranscodeCRF() int {
+	return c.crf
+}
+
+func (c testStreamConfig) GetLiveTranscodeMaxBitrate() int {
+	return c.maxBitrate
+}
+
+func TestStreamManager_selectCodec(t *testing.T) {
+	// codecs detected at startup
+	supported := []VideoCodec{VideoCodecV264, VideoCodecN264, VideoCodecVVP9}
+
+	tests := []struct {
+		name       string
+		config     testStreamConfig
+		software   VideoCodec
+		compatible []VideoCodec
+		failed     []VideoCodec
+		wantChain  []VideoCodec
+		want       VideoCodec
+	}{
+		{
+			"hardware acceleration disabled",
+			testStreamConfig{hwEncoder: models.HardwareEncoderAuto},
+			VideoCodecLibX264, hwCodecsHLS, nil,
+			[]VideoCodec{VideoCodecLibX264},
+			VideoCodecLibX264,
+		},
+		{
+			"auto uses first supported compatible codec",
+			testStreamConfig{hwAccel: true, hwEncoder: models.HardwareEncoderAuto},
+			VideoCodecLibX264, hwCodecsHLS, nil,
+			[]VideoCodec{VideoCodecV264, VideoCodecLibX264},
+			VideoCodecV264,
+		},
+		{
+			"auto skips incompatible codecs",
+			testStreamConfig{hwAccel: true, hwEncoder: models.HardwareEncoderAuto},
+			VideoCodecLibX264, hwCodecsMP4, nil,
+			[]VideoCodec{VideoCodecN264, VideoCodecLibX264},
+			VideoCodecN264,
+		},
+		{
+			"selected hardware encoder",
+			testStreamConfig{hwAccel: true, hwEncoder: models.HardwareEncoderNvenc},
+			VideoCodecLibX264, hwCodecsHLS, nil,
+			[]VideoCodec{VideoCodecN264, VideoCodecLibX264},
+			VideoCodecN264,
+		},
+		{
+			"selected hardware encoder not supported",
+			testStreamConfig{hwAccel: true, hwEncoder: models.HardwareEncoderQsv},
+			VideoCodecLibX264, hwCodecsHLS, nil,
+			[]VideoCodec{VideoCodecLibX264},
+			VideoCodecLibX264,
+		},
+		{
+			"hardware encoder failed falls back to software",
+			testStreamConfig{hwAccel: true, hwEncoder: models.HardwareEncoderAuto},
+			VideoCodecLibX264, hwCodecsHLS, []VideoCodec{VideoCodecV264},
+			[]VideoCodec{VideoCodecV264, VideoCodecLibX264},
+			VideoCodecLibX264,
+		},
+		{
+			"vp9 hardware encoder",
+			testStreamConfig{hwAccel: true, hwEncoder: models.HardwareEncoderAuto},
+			VideoCodecVP9, hwCodecsWEBM, nil,
+			[]VideoCodec{VideoCodecVVP9, VideoCodecVP9},
+			VideoCodecVVP9,
+		},
+		{
+			"configured chain",
+			testStreamConfig{hwAccel: true, h264Encoders: []string{"h264_nvenc", "h264_vaapi"}},
+			VideoCodecLibX264, hwCodecsHLS, nil,
+			[]VideoCodec{VideoCodecN264, VideoCodecV264, VideoCodecLibX264},
+			VideoCodecN264,
+		},
+		{
+			"configured chain falls through failed encoders",
+			testStreamConfig{hwAccel: true, h264Encoders: []string{"h264_nvenc", "h264_vaapi"}},
+			VideoCodecLibX264, hwCodecsHLS, []VideoCodec{VideoCodecN264},
+			[]VideoCodec{VideoCodecN264, VideoCodecV264, VideoCodecLibX264},
+			VideoCodecV264,
+		},
+		{
+			"configured chain all failed",
+			testStreamConfig{hwAccel: true, h264Encoders: []string{"h264_nvenc", "h264_vaapi"}},
+			VideoCodecLibX264, hwCodecsHLS, []VideoCodec{VideoCodecN264, VideoCodecV264},
+			[]VideoCodec{VideoCodecN264, VideoCodecV264, VideoCodecLibX264},
+			VideoCodecLibX264,
+		},
+		{
+			"configured chain skips unsupported and incompatible encoders",
+			testStreamConfig{hwAccel: true, h264Encoders: []string{"h264_qsv", "h264_vaapi", "vp9_vaapi", "h264_vaapi"}},
+			VideoCodecLibX264, hwCodecsMP4, nil,
+			[]VideoCodec{VideoCodecLibX264},
+			VideoCodecLibX264,
+		},
+		{
+			"configured chain with software encoder first",
+			testStreamConfig{hwAccel: true, h264Encoders: []string{"libx264", "h264_nvenc"}},
+			VideoCodecLibX264, hwCodecsHLS, nil,
+			[]VideoCodec{VideoCodecLibX264, VideoCodecN264},
+			VideoCodecLibX264,
+		},
+		{
+			"configured chain ignores hardware encoders when disabled",
+			testStreamConfig{h264Encoders: []string{"h264_nvenc"}},
+			VideoCodecLibX264, hwCodecsHLS, nil,
+			[]VideoCodec{VideoCodecLibX264},
+			VideoCodecLibX264,
+		},
+		{
+			"configured vp9 chain",
+			testStreamConfig{hwAccel: true, vp9Encoders: []string{"vp9_vaapi"}, h264Encoders: []string{"h264_nvenc"}},
+			VideoCodecVP9, hwCodecsWEBM, nil,
+			[]VideoCodec{VideoCodecVVP9, VideoCodecVP9},
+			VideoCodecVVP9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := &StreamManager{
+				encoder: &FFMpeg{hwCodecSupport: supported},
+				config:  tt.config,
+			}
+
+			if got := sm.codecChain(tt.software, tt.compatible); !reflect.DeepEqual(got, tt.wantChain) {
+				t.Errorf("StreamManager.codecChain() = %v, want %v", got, tt.wantChain)
+			}
+			if got := sm.selectCodec(tt.software, tt.compatible, tt.failed); got != tt.want {
+				t.Errorf("StreamManager.selectCodec() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamManager_qualityArgs(t *testing.T) {
+	config := testStreamConfig{
+		preset:     models.PreviewPresetVeryfast,
+		crf:        23,
+		maxBitrate: 4000,
+	}
+
+	tests := []struct {
+		name   string
+		config testStreamConfig
+		codec  VideoCodec
+		want   Args
+	}{
+		{"copy", config, VideoCodecCopy, nil},
+		{"empty", config, "", nil},
+		{
+			"libx264",
+			config,
+			VideoCodecLibX264,
+			Args{"-preset", "veryfast", "-crf", "23", "-maxrate", "4000k", "-bufsize", "8000k"},
+		},
+		{
+			"libx264 defaults",
+			testStreamConfig{preset: models.PreviewPresetUltrafast},
+			VideoCodecLibX264,
+			Args{"-preset", "ultrafast"},
+		},
+		{
+			"vp9",
+			config,
+			VideoCodecVP9,
+			Args{"-crf", "23", "-b:v", "4000k"},
+		},
+		{
+			"vp9 defaults",
+			testStreamConfig{},
+			VideoCodecVP9,
+			nil,
+		},
+		{
+			"svtav1",
+			config,
+			VideoCodecSVTAV1,
+			Args{"-crf", "23", "-maxrate", "4000k", "-bufsize", "8000k"},
+		},
+		{
+			"hardware h264",
+			config,
+			VideoCodecN264,
+			Args{"-maxrate", "4000k", "-bufsize", "8000k"},
+		},
+		{
+			"hardware vp9",
+			config,
+			VideoCodecVVP9,
+			Args{"-maxrate", "4000k", "-bufsize", "8000k"},
+		},
+		{
+			"hardware without max bitrate",
+			testStreamConfig{crf: 23},
+			VideoCodecV264,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := &StreamManager{config: tt.config}
+			if got := sm.qualityArgs(tt.codec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StreamManager.qualityArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamManager_encodeKey(t *testing.T) {
+	sm := &StreamManager{
+		encoder: &FFMpeg{hwCodecSupport: []VideoCodec{VideoCodecN264}},
+		config: testStreamConfig{
+			hwEncoder: models.HardwareEncoderAuto,
+			preset:    models.PreviewPresetVeryfast,
+			crf:       23,
+		},
+	}
+
+	key := func(t *StreamType, config testStreamConfig) string {
+		sm.config = config
+		return sm.encodeKey(t)
+	}
+
+	base := sm.config.(testStreamConfig)
+	hls := key(StreamTypeHLS, base)
+
+	if got := key(StreamTypeHLSCopy, base); got != "" {
+		t.Errorf("encodeKey(hls-copy) = %q, want empty", got)
+	}
+	if got := key(StreamTypeHLS, base); got != hls {
+		t.Errorf("encodeKey(hls) = %q, want %q", got, hls)
+	}
+
+	changed := map[string]testStreamConfig{}
+
+	c := base
+	c.preset = models.PreviewPresetSlow
+	changed["preset"] = c
+
+	c = base
+	c.crf = 28
+	changed["crf"] = c
+
+	c = base
+	c.maxBitrate = 4000
+	changed["max bitrate"] = c
+
+	c = base
+	c.hwAccel = true
+	changed["hardware encoder"] = c
+
+	c = base
+	c.h264Encoders = []string{"h264_nvenc"}
+	c.hwAccel = true
+	changed["configured encoder"] = c
+
+	for name, config := range changed {
+		if got := key(StreamTypeHLS, config); got == hls {
+			t.Errorf("encodeKey(hls) with changed %s = %q, want different key", name, got)
+		}
+	}
+
+	if got := key(StreamTypeDASHVideo, base); got == hls {
+		t.Errorf("encodeKey(dash-v) = %q, want different key to hls", got)
+	}
+}
+
+func TestStreamType_FileDir(t *testing.T) {
+	tests := []struct {
+		name             string
+		maxTranscodeSize int
+		subtitle         *Subtitle
+		toneMap          bool
+		encodeKey        string
+		want             string
+	}{
+		{"original", 0, nil, false, "", "hash_hls"},
+		{"resolution", 720, nil, false, "", "hash_hls_720"},
+		{"encode key", 720, nil, false, "0123abcd", "hash_hls_720_0123abcd"},
+		{"all", 720, &Subtitle{ID: "1"}, true, "0123abcd", "hash_hls_720_0123abcd_sub1_sdr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StreamTypeHLS.FileDir("hash", tt.maxTranscodeSize, tt.subtitle, tt.toneMap, tt.encodeKey); got != tt.want {
+				t.Errorf("StreamType.FileDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamManager_evictCachedStreams(t *testing.T) {
+	const segmentSize = 600 * 1024
+
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		cacheSize int
+		want      []string
+	}{
+		{"within size", 2, []string{"old", "middle", "new", "empty"}},
+		{"over size", 1, []string{"new", "empty"}},
+		{"no cache", 0, []string{"empty"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			sm := &StreamManager{
+				cacheDir: cacheDir,
+				config:   testStreamConfig{cacheSize: tt.cacheSize},
+				cachedStreams: map[string]time.Time{
+					"old":    now.Add(-3 * time.Minute),
+					"middle": now.Add(-2 * time.Minute),
+					"new":    now.Add(-time.Minute),
+					// streams without segments take no space, so are
+					// kept once the other streams are removed
+					"empty": now,
+				},
+			}
+
+			for _, dir := range []string{"old", "middle", "new"} {
+				fn := filepath.Join(cacheDir, dir, "0.ts")
+				if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(fn, make([]byte, segmentSize), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sm.evictCachedStreams()
+
+			for _, dir := range []string{"old", "middle", "new", "empty"} {
+				_, cached := sm.cachedStreams[dir]
+				want := sliceutil.Contains(tt.want, dir)
+
+				if cached != want {
+					t.Errorf("stream %s cached = %v, want %v", dir, cached, want)
+				}
+
+				if dir == "empty" {
+					continue
+				}
+
+				_, err := os.Stat(filepath.Join(cacheDir, dir))
+				if exists := err == nil; exists != want {
+					t.Errorf("stream %s directory exists = %v, want %v", dir, exists, want)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package ffmpeg
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os/exec"
@@ -14,8 +17,11 @@ import (
 )
 
 type StreamFormat struct {
-	MimeType string
-	Args     func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) Args
+	MimeType  string
+	Container Container
+	// Codec is the software codec used when the video cannot be copied.
+	Codec VideoCodec
+	Args  func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool, copyAudio bool) Args
 }
 
 func CodecInit(codec VideoCodec) (args Args) {
@@ -39,6 +45,12 @@ func CodecInit(codec VideoCodec) (args Args) {
 			"-crf", "30",
 			"-b:v", "0",
 		)
+	case VideoCodecSVTAV1:
+		args = append(args,
+			"-pix_fmt", "yuv420p",
+			"-preset", "10",
+			"-crf", "35",
+		)
 	// HW Codecs
 	case VideoCodecN264:
 		args = append(args,
@@ -83,13 +95,17 @@ func CodecInit(codec VideoCodec) (args Args) {
 
 var (
 	StreamTypeMP4 = StreamFormat{
-		MimeType: MimeMp4Video,
-		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
+		MimeType:  MimeMp4Video,
+		Container: Mp4,
+		Codec:     VideoCodecLibX264,
+		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool, copyAudio bool) (args Args) {
 			args = CodecInit(codec)
 			args = append(args, "-movflags", "frag_keyframe+empty_moov")
 			args = args.VideoFilter(videoFilter)
 			if videoOnly {
 				args = args.SkipAudio()
+			} else if copyAudio {
+				args = args.AudioCodec(AudioCodecCopy)
 			} else {
 				args = append(args, "-ac", "2")
 			}
@@ -98,25 +114,31 @@ var (
 		},
 	}
 	StreamTypeWEBM = StreamFormat{
-		MimeType: MimeWebmVideo,
-		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
-			args = CodecInit(codec)
-			args = args.VideoFilter(videoFilter)
-			if videoOnly {
-				args = args.SkipAudio()
-			} else {
-				args = append(args, "-ac", "2")
-			}
-			args = args.Format(FormatWebm)
-			return
-		},
+		MimeType:  MimeWebmVideo,
+		Container: Webm,
+		Codec:     VideoCodecVP9,
+		Args:      webmArgs,
+	}
+	StreamTypeWEBMAV1 = StreamFormat{
+		MimeType:  MimeWebmVideo,
+		Container: Webm,
+		Codec:     VideoCodecSVTAV1,
+		Args:      webmArgs,
 	}
 	StreamTypeMKV = StreamFormat{
-		MimeType: MimeMkvVideo,
-		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
+		MimeType:  MimeMkvVideo,
+		Container: Matroska,
+		Codec:     VideoCodecLibX264,
+		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool, copyAudio bool) (args Args) {
 			args = CodecInit(codec)
+			// the video is only encoded when burning in subtitles
+			if codec != VideoCodecCopy {
+				args = args.VideoFilter(videoFilter)
+			}
 			if videoOnly {
 				args = args.SkipAudio()
+			} else if copyAudio {
+				args = args.AudioCodec(AudioCodecCopy)
 			} else {
 				args = args.AudioCodec(AudioCodecLibOpus)
 				args = append(args,
@@ -131,11 +153,33 @@ var (
 	}
 )
 
+func webmArgs(codec VideoCodec, videoFilter VideoFilter, videoOnly bool, copyAudio bool) (args Args) {
+	args = CodecInit(codec)
+	args = args.VideoFilter(videoFilter)
+	if videoOnly {
+		args = args.SkipAudio()
+	} else if copyAudio {
+		args = args.AudioCodec(AudioCodecCopy)
+	} else {
+		args = append(args, "-ac", "2")
+	}
+	args = args.Format(FormatWebm)
+	return
+}
+
 type TranscodeOptions struct {
 	StreamType StreamFormat
 	VideoFile  *models.VideoFile
 	Resolution string
 	StartTime  float64
+	// Subtitle is burned into the video if set
+	Subtitle *Subtitle
+
+	// toneMap is true if HDR video is tone mapped to SDR
+	toneMap bool
+
+	// failedCodecs are the hardware codecs that have failed to encode the file
+	failedCodecs []VideoCodec
 }
 
 func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int) (codec VideoCodec) {
@@ -149,31 +193,43 @@ func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int)
 		}
 	}
 
+	// burning in subtitles and tone mapping require the video to be encoded
+	canCopy := !needsResize && o.Subtitle == nil && !o.toneMap
+
 	switch o.StreamType.MimeType {
 	case MimeMp4Video:
-		if !needsResize && o.VideoFile.VideoCodec == H264 {
+		if canCopy && o.VideoFile.VideoCodec == H264 {
 			return VideoCodecCopy
 		}
-		codec = VideoCodecLibX264
-		if hwcodec := sm.encoder.hwCodecMP4Compatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
-			codec = *hwcodec
-		}
+		codec = sm.selectCodec(VideoCodecLibX264, hwCodecsMP4, o.failedCodecs)
 	case MimeWebmVideo:
-		if !needsResize && (o.VideoFile.VideoCodec == Vp8 || o.VideoFile.VideoCodec == Vp9) {
-			return VideoCodecCopy
+		if o.StreamType.Codec == VideoCodecSVTAV1 {
+			if canCopy && o.VideoFile.VideoCodec == Av1 {
+				return VideoCodecCopy
+			}
+			return sm.selectCodec(VideoCodecSVTAV1, nil, o.failedCodecs)
 		}
-		codec = VideoCodecVP9
-		if hwcodec := sm.encoder.hwCodecWEBMCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
-			codec = *hwcodec
+		if canCopy && (o.VideoFile.VideoCodec == Vp8 || o.VideoFile.VideoCodec == Vp9) {
+			return VideoCodecCopy
 		}
+		codec = sm.selectCodec(VideoCodecVP9, hwCodecsWEBM, o.failedCodecs)
 	case MimeMkvVideo:
+		if o.Subtitle != nil || o.toneMap {
+			return sm.selectCodec(VideoCodecLibX264, hwCodecsMP4, o.failedCodecs)
+		}
 		codec = VideoCodecCopy
 	}
 
 	return codec
 }
 
-func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
+// FileCopyAudio returns true if the audio of the file is supported by the
+// output container, so it can be copied instead of transcoded.
+func (o TranscodeOptions) FileCopyAudio() bool {
+	return IsValidAudioForContainer(ProbeAudioCodec(o.VideoFile.AudioCodec), o.StreamType.Container)
+}
+
+func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) (Args, VideoCodec) {
 	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
 	if o.Resolution != "" {
 		maxTranscodeSize = models.StreamingResolutionEnum(o.Resolution).GetMaxResolution()
@@ -186,7 +242,9 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 
 	codec := o.FileGetCodec(sm, maxTranscodeSize)
 
-	fullhw := sm.config.GetTranscodeHardwareAcceleration() && sm.encoder.hwCanFullHWTranscode(sm.context, codec, o.VideoFile, maxTranscodeSize)
+	// subtitles and tone mapping are applied to decoded frames, so cannot
+	// be used when the whole transcode is done in hardware
+	fullhw := codec.isHardware() && o.Subtitle == nil && !o.toneMap && sm.encoder.hwCanFullHWTranscode(sm.context, codec, o.VideoFile, maxTranscodeSize)
 	args = sm.encoder.hwDeviceInit(args, codec, fullhw)
 	args = append(args, extraInputArgs...)
 
@@ -197,16 +255,27 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 	args = args.Input(o.VideoFile.Path)
 
 	videoOnly := ProbeAudioCodec(o.VideoFile.AudioCodec) == MissingUnsupported
+	copyAudio := !videoOnly && o.FileCopyAudio()
+
+	switch {
+	case codec == VideoCodecCopy && copyAudio:
+		logger.Debugf("[transcode] remuxing %s without re-encoding", o.VideoFile.Path)
+	case codec == VideoCodecCopy:
+		logger.Debugf("[transcode] transcoding audio only for %s", o.VideoFile.Path)
+	}
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, o.VideoFile, maxTranscodeSize, fullhw)
+	videoFilter = o.Subtitle.videoFilter(videoFilter, o.StartTime)
+	videoFilter = toneMapFilter(videoFilter, o.toneMap)
 
-	args = append(args, o.StreamType.Args(codec, videoFilter, videoOnly)...)
+	args = append(args, o.StreamType.Args(codec, videoFilter, videoOnly, copyAudio)...)
+	args = append(args, sm.qualityArgs(codec)...)
 
 	args = append(args, extraOutputArgs...)
 
 	args = args.Output("pipe:")
 
-	return args
+	return args, codec
 }
 
 func (sm *StreamManager) ServeTranscode(w http.ResponseWriter, r *http.Request, options TranscodeOptions) {
@@ -217,6 +286,8 @@ func (sm *StreamManager) ServeTranscode(w http.ResponseWriter, r *http.Request,
 	// due to ERR_INCOMPLETE_CHUNKED_ENCODING
 	// We trust that the request context will be closed, so we don't need to call Cancel on the returned context here.
 
+	options.toneMap = sm.toneMap(options.VideoFile)
+
 	handler, err := sm.getTranscodeStream(lockCtx, options)
 
 	if err != nil {
@@ -232,8 +303,8 @@ func (sm *StreamManager) ServeTranscode(w http.ResponseWriter, r *http.Request,
 }
 
 func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options TranscodeOptions) (http.HandlerFunc, error) {
-	args := options.makeStreamArgs(sm)
-	cmd := sm.encoder.Command(ctx, args)
+	args, codec := options.makeStreamArgs(sm)
+	cmd := sm.encoder.Command(ctx, WithProgress(args))
 
 	stdout, err := cmd.StdoutPipe()
 	if nil != err {
@@ -250,11 +321,24 @@ func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options Tra
 	if err = cmd.Start(); err != nil {
 		return nil, err
 	}
-	ctx.AttachCommand(cmd)
+	ctx.AttachCommand(cmd.Cmd)
+
+	description := fmt.Sprintf("Live transcoding %s to %s", options.VideoFile.Path, options.StreamType.Container)
+	progress := sm.transcodeStarted(description, ctx.Cancel)
 
 	// stderr must be consumed or the process deadlocks
 	go func() {
-		errStr, _ := io.ReadAll(stderr)
+		defer progress.Done()
+
+		// the output starts at the seek time
+		var errBuf bytes.Buffer
+		pw := NewProgressWriter(&errBuf, func(p Progress) {
+			p.Time += options.StartTime
+			progress.SetPercent(p.Percent(options.VideoFile.Duration))
+		})
+		_, _ = io.Copy(pw, stderr)
+		_ = pw.Close()
+		errStr := errBuf.Bytes()
 
 		errCmd := cmd.Wait()
 
@@ -274,6 +358,18 @@ func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options Tra
 		}
 	}()
 
+	out := bufio.NewReader(stdout)
+
+	// hardware encoders may fail on some files, so wait for output before
+	// serving the stream, and retry using the next encoder if there is none
+	if codec.isHardware() {
+		if _, err := out.Peek(1); err != nil && ctx.Err() == nil {
+			logger.Warnf("[transcode] encoder %s failed for %s, falling back to the next encoder", codec, options.VideoFile.Path)
+			options.failedCodecs = append(options.failedCodecs, codec)
+			return sm.getTranscodeStream(ctx, options)
+		}
+	}
+
 	mimeType := options.StreamType.MimeType
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", "no-store")
@@ -282,7 +378,7 @@ func (sm *StreamManager) getTranscodeStream(ctx *fsutil.LockContext, options Tra
 
 		// process killing should be handled by command context
 
-		_, err := io.Copy(w, stdout)
+		_, err := io.Copy(w, out)
 		if err != nil && !errors.Is(err, syscall.EPIPE) && !errors.Is(err, syscall.ECONNRESET) {
 			logger.Errorf("[transcode] error serving transcoded video file: %v", err)
 		}
@@ -15,6 +15,11 @@ type ScreenshotOptions struct {
 	Verbosity ffmpeg.LogLevel
 
 	UseSelectFilter bool
+
+	// arguments added before the input argument
+	ExtraInputArgs []string
+	// arguments added before the output argument
+	ExtraOutputArgs []string
 }
 
 func (o *ScreenshotOptions) setDefaults() {
@@ -56,6 +61,7 @@ func ScreenshotTime(input string, t float64, options ScreenshotOptions) ffmpeg.A
 	var args ffmpeg.Args
 	args = args.LogLevel(options.Verbosity)
 	args = args.Overwrite()
+	args = append(args, options.ExtraInputArgs...)
 	args = args.Seek(t)
 
 	args = args.Input(input)
@@ -72,6 +78,7 @@ func ScreenshotTime(input string, t float64, options ScreenshotOptions) ffmpeg.A
 		args = args.VideoFilter(vf)
 	}
 
+	args = append(args, options.ExtraOutputArgs...)
 	args = args.AppendArgs(options.OutputType)
 	args = args.Output(options.OutputPath)
 
@@ -86,6 +93,7 @@ func ScreenshotFrame(input string, frame int, options ScreenshotOptions) ffmpeg.
 	var args ffmpeg.Args
 	args = args.LogLevel(options.Verbosity)
 	args = args.Overwrite()
+	args = append(args, options.ExtraInputArgs...)
 
 	args = args.Input(input)
 	args = args.VideoFrames(1)
@@ -102,6 +110,7 @@ func ScreenshotFrame(input string, frame int, options ScreenshotOptions) ffmpeg.
 
 	args = args.VideoFilter(vf)
 
+	args = append(args, options.ExtraOutputArgs...)
 	args = args.AppendArgs(options.OutputType)
 	args = args.Output(options.OutputPath)
 
@@ -48,6 +48,10 @@ type FFProbeStream struct {
 	CodecType          string `json:"codec_type"`
 	CodedHeight        int    `json:"coded_height,omitempty"`
 	CodedWidth         int    `json:"coded_width,omitempty"`
+	ColorPrimaries     string `json:"color_primaries,omitempty"`
+	ColorRange         string `json:"color_range,omitempty"`
+	ColorSpace         string `json:"color_space,omitempty"`
+	ColorTransfer      string `json:"color_transfer,omitempty"`
 	DisplayAspectRatio string `json:"display_aspect_ratio,omitempty"`
 	Disposition        struct {
 		AttachedPic     int `json:"attached_pic"`
@@ -40,23 +40,54 @@ type CleanOptions struct {
 	PathFilter PathFilter
 }
 
-// Clean starts the clean process.
-func (s *Cleaner) Clean(ctx context.Context, options CleanOptions, progress *job.Progress) {
+// CleanReason describes why a file or folder was marked for cleaning.
+type CleanReason string
+
+const (
+	// CleanReasonMissing indicates that the file or folder no longer exists.
+	CleanReasonMissing CleanReason = "MISSING"
+	// CleanReasonExcluded indicates that the file or folder is excluded by
+	// the path filter.
+	CleanReasonExcluded CleanReason = "EXCLUDED"
+	// CleanReasonZipFileCleaned indicates that the file or folder is
+	// contained in a zip file that was marked for cleaning.
+	CleanReasonZipFileCleaned CleanReason = "ZIP_FILE_CLEANED"
+)
+
+// CleanedItem is a file or folder that was marked for cleaning.
+// Only one of FileID and FolderID is set.
+type CleanedItem struct {
+	FileID   models.FileID
+	FolderID models.FolderID
+	Path     string
+	Reason   CleanReason
+}
+
+// Clean starts the clean process. Returns the files and folders that were
+// marked for cleaning. If options.DryRun is true, these are not deleted.
+//
+// If an error occurs, no items are returned, since the files and folders
+// marked so far are incomplete, and are not deleted. If the context is
+// cancelled, the items marked before cancellation are returned.
+func (s *Cleaner) Clean(ctx context.Context, options CleanOptions, progress *job.Progress) ([]CleanedItem, error) {
 	j := &cleanJob{
 		Cleaner:  s,
 		progress: progress,
 		options:  options,
 	}
 
-	if err := j.execute(ctx); err != nil {
-		logger.Errorf("error cleaning files: %v", err)
-		return
+	toDelete := newDeleteSet()
+	if err := j.execute(ctx, &toDelete); err != nil {
+		return nil, err
 	}
+
+	return toDelete.items(), nil
 }
 
 type fileOrFolder struct {
 	fileID   models.FileID
 	folderID models.FolderID
+	reason   CleanReason
 }
 
 type deleteSet struct {
@@ -73,9 +104,9 @@ func newDeleteSet() deleteSet {
 	}
 }
 
-func (s *deleteSet) add(id models.FileID, path string) {
+func (s *deleteSet) add(id models.FileID, path string, reason CleanReason) {
 	if _, ok := s.fileIDSet[id]; !ok {
-		s.orderedList = append(s.orderedList, fileOrFolder{fileID: id})
+		s.orderedList = append(s.orderedList, fileOrFolder{fileID: id, reason: reason})
 		s.fileIDSet[id] = path
 	}
 }
@@ -85,9 +116,9 @@ func (s *deleteSet) has(id models.FileID) bool {
 	return ok
 }
 
-func (s *deleteSet) addFolder(id models.FolderID, path string) {
+func (s *deleteSet) addFolder(id models.FolderID, path string, reason CleanReason) {
 	if _, ok := s.folderIDSet[id]; !ok {
-		s.orderedList = append(s.orderedList, fileOrFolder{folderID: id})
+		s.orderedList = append(s.orderedList, fileOrFolder{folderID: id, reason: reason})
 		s.folderIDSet[id] = path
 	}
 }
@@ -101,10 +132,27 @@ func (s *deleteSet) len() int {
 	return len(s.orderedList)
 }
 
-func (j *cleanJob) execute(ctx context.Context) error {
-	progress := j.progress
+func (s *deleteSet) items() []CleanedItem {
+	ret := make([]CleanedItem, len(s.orderedList))
+	for i, ff := range s.orderedList {
+		ret[i] = CleanedItem{
+			FileID:   ff.fileID,
+			FolderID: ff.folderID,
+			Reason:   ff.reason,
+		}
 
-	toDelete := newDeleteSet()
+		if ff.fileID != 0 {
+			ret[i].Path = s.fileIDSet[ff.fileID]
+		} else {
+			ret[i].Path = s.folderIDSet[ff.folderID]
+		}
+	}
+
+	return ret
+}
+
+func (j *cleanJob) execute(ctx context.Context, toDelete *deleteSet) error {
+	progress := j.progress
 
 	var (
 		fileCount   int
@@ -132,11 +180,11 @@ func (j *cleanJob) execute(ctx context.Context) error {
 	progress.AddTotal(fileCount + folderCount)
 	progress.Definite()
 
-	if err := j.assessFiles(ctx, &toDelete); err != nil {
+	if err := j.assessFiles(ctx, toDelete); err != nil {
 		return err
 	}
 
-	if err := j.assessFolders(ctx, &toDelete); err != nil {
+	if err := j.assessFolders(ctx, toDelete); err != nil {
 		return err
 	}
 
@@ -195,8 +243,8 @@ func (j *cleanJob) assessFiles(ctx context.Context, toDelete *deleteSet) error {
 				}
 
 				progress.ExecuteTask(fmt.Sprintf("Assessing file %s for clean", path), func() {
-					if j.shouldClean(ctx, f) {
-						err = j.flagFileForDelete(ctx, toDelete, f)
+					if clean, reason := j.shouldClean(ctx, f); clean {
+						err = j.flagFileForDelete(ctx, toDelete, f, reason)
 					} else {
 						// increment progress, no further processing
 						progress.Increment()
@@ -223,7 +271,7 @@ func (j *cleanJob) assessFiles(ctx context.Context, toDelete *deleteSet) error {
 }
 
 // flagFolderForDelete adds folders to the toDelete set, with the leaf folders added first
-func (j *cleanJob) flagFileForDelete(ctx context.Context, toDelete *deleteSet, f models.File) error {
+func (j *cleanJob) flagFileForDelete(ctx context.Context, toDelete *deleteSet, f models.File, reason CleanReason) error {
 	r := j.Repository
 	// add contained files first
 	containedFiles, err := r.File.FindByZipFileID(ctx, f.Base().ID)
@@ -233,7 +281,7 @@ func (j *cleanJob) flagFileForDelete(ctx context.Context, toDelete *deleteSet, f
 
 	for _, cf := range containedFiles {
 		logger.Infof("Marking contained file %q to clean", cf.Base().Path)
-		toDelete.add(cf.Base().ID, cf.Base().Path)
+		toDelete.add(cf.Base().ID, cf.Base().Path, CleanReasonZipFileCleaned)
 	}
 
 	// add contained folders as well
@@ -244,10 +292,10 @@ func (j *cleanJob) flagFileForDelete(ctx context.Context, toDelete *deleteSet, f
 
 	for _, cf := range containedFolders {
 		logger.Infof("Marking contained folder %q to clean", cf.Path)
-		toDelete.addFolder(cf.ID, cf.Path)
+		toDelete.addFolder(cf.ID, cf.Path, CleanReasonZipFileCleaned)
 	}
 
-	toDelete.add(f.Base().ID, f.Base().Path)
+	toDelete.add(f.Base().ID, f.Base().Path, reason)
 
 	return nil
 }
@@ -281,8 +329,8 @@ func (j *cleanJob) assessFolders(ctx context.Context, toDelete *deleteSet) error
 
 				err = nil
 				progress.ExecuteTask(fmt.Sprintf("Assessing folder %s for clean", path), func() {
-					if j.shouldCleanFolder(ctx, f) {
-						if err = j.flagFolderForDelete(ctx, toDelete, f); err != nil {
+					if clean, reason := j.shouldCleanFolder(ctx, f); clean {
+						if err = j.flagFolderForDelete(ctx, toDelete, f, reason); err != nil {
 							return
 						}
 					} else {
@@ -310,10 +358,10 @@ func (j *cleanJob) assessFolders(ctx context.Context, toDelete *deleteSet) error
 	return nil
 }
 
-func (j *cleanJob) flagFolderForDelete(ctx context.Context, toDelete *deleteSet, folder *models.Folder) error {
+func (j *cleanJob) flagFolderForDelete(ctx context.Context, toDelete *deleteSet, folder *models.Folder, reason CleanReason) error {
 	// it is possible that child folders may be included while parent folders are not
 	// so we need to check child folders separately
-	toDelete.addFolder(folder.ID, folder.Path)
+	toDelete.addFolder(folder.ID, folder.Path, reason)
 
 	return nil
 }
@@ -329,42 +377,42 @@ func isNotFound(err error) bool {
 			errors.As(err, &pathErr))
 }
 
-func (j *cleanJob) shouldClean(ctx context.Context, f models.File) bool {
+func (j *cleanJob) shouldClean(ctx context.Context, f models.File) (bool, CleanReason) {
 	path := f.Base().Path
 
 	info, err := f.Base().Info(j.FS)
 	if err != nil && !isNotFound(err) {
 		logger.Errorf("error getting file info for %q, not cleaning: %v", path, err)
-		return false
+		return false, ""
 	}
 
 	if info == nil {
 		// info is nil - file not exist
 		logger.Infof("File not found. Marking to clean: \"%s\"", path)
-		return true
+		return true, CleanReasonMissing
 	}
 
 	// run through path filter, if returns false then the file should be cleaned
 	filter := j.options.PathFilter
 
 	// don't log anything - assume filter will have logged the reason
-	return !filter.Accept(ctx, path, info)
+	return !filter.Accept(ctx, path, info), CleanReasonExcluded
 }
 
-func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) bool {
+func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) (bool, CleanReason) {
 	path := f.Path
 
 	info, err := f.Info(j.FS)
 
 	if err != nil && !isNotFound(err) {
 		logger.Errorf("error getting folder info for %q, not cleaning: %v", path, err)
-		return false
+		return false, ""
 	}
 
 	if info == nil {
 		// info is nil - file not exist
 		logger.Infof("Folder not found. Marking to clean: \"%s\"", path)
-		return true
+		return true, CleanReasonMissing
 	}
 
 	// #3261 - handle symlinks
@@ -373,13 +421,13 @@ func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) bool
 		if err != nil {
 			// don't bail out if symlink is invalid
 			logger.Infof("Invalid symlink. Marking to clean: \"%s\"", path)
-			return true
+			return true, CleanReasonMissing
 		}
 
 		info, err = j.FS.Lstat(finalPath)
 		if err != nil && !isNotFound(err) {
 			logger.Errorf("error getting file info for %q (-> %s), not cleaning: %v", path, finalPath, err)
-			return false
+			return false, ""
 		}
 	}
 
@@ -387,7 +435,7 @@ func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *models.Folder) bool
 	filter := j.options.PathFilter
 
 	// don't log anything - assume filter will have logged the reason
-	return !filter.Accept(ctx, path, info)
+	return !filter.Accept(ctx, path, info), CleanReasonExcluded
 }
 
 func (j *cleanJob) deleteFile(ctx context.Context, fileID models.FileID, fn string) {
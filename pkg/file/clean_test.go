@@ -0,0 +1,30 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCleanerCleanError(t *testing.T) {
+	db := mocks.NewDatabase()
+	db.File.On("CountAllInPaths", mock.Anything, []string{"/stash"}).Return(0, errors.New("count failed")).Once()
+
+	c := &Cleaner{
+		Repository: NewRepository(db.Repository()),
+	}
+
+	// no partial results are returned on error
+	cleaned, err := c.Clean(context.Background(), CleanOptions{
+		Paths:  []string{"/stash"},
+		DryRun: true,
+	}, nil)
+
+	assert.NotNil(t, err)
+	assert.Nil(t, cleaned)
+	db.AssertExpectations(t)
+}
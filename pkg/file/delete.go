@@ -63,9 +63,15 @@ func newRenamerRemoverImpl() renamerRemoverImpl {
 // be restored to their original state with the Abort method. If the
 // transaction is committed, the marked files are then deleted from the
 // filesystem using the Complete method.
+//
+// If TrashPath is set, files marked using the MediaFiles method are moved to
+// the trash directory when marked instead of being renamed, and are moved
+// back by Rollback.
 type Deleter struct {
 	RenamerRemover RenamerRemover
+	TrashPath      string
 	files          []string
+	trashed        []TrashedFile
 	dirs           []string
 }
 
@@ -112,6 +118,38 @@ func (d *Deleter) Files(paths []string) error {
 	return nil
 }
 
+// MediaFiles designates library files to be deleted. Behaves the same as
+// Files if TrashPath is not set. Otherwise the files are moved to the trash
+// immediately, and an error is returned if a file could not be moved, such
+// as when the trash is on a different filesystem. Generated files should be
+// marked using Files instead.
+func (d *Deleter) MediaFiles(paths []string) error {
+	if d.TrashPath == "" {
+		return d.Files(paths)
+	}
+
+	trash := Trash{Path: d.TrashPath}
+	for _, p := range paths {
+		// fail silently if the file does not exist
+		if _, err := d.RenamerRemover.Stat(p); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				logger.Warnf("File %q does not exist and therefore cannot be deleted. Ignoring.", p)
+				continue
+			}
+
+			return fmt.Errorf("check file %q exists: %w", p, err)
+		}
+
+		f, err := trash.Move(p, p)
+		if err != nil {
+			return fmt.Errorf("moving file %q to trash: %w", p, err)
+		}
+		d.trashed = append(d.trashed, *f)
+	}
+
+	return nil
+}
+
 // Dirs designates directories to be deleted. Each directory marked will be renamed to add
 // a `.delete` suffix. An error is returned if a directory could not be renamed.
 // Note that if an error is returned, then some directories may be left renamed.
@@ -142,19 +180,28 @@ func (d *Deleter) Dirs(paths []string) error {
 // original names and clears the marked list. Any errors encountered are
 // logged. All files will be attempted regardless of any errors occurred.
 func (d *Deleter) Rollback() {
-	for _, f := range append(d.files, d.dirs...) {
+	toRestore := append(d.files, d.dirs...)
+	for _, f := range toRestore {
 		if err := d.renameForRestore(f); err != nil {
 			logger.Warnf("Error restoring %q: %v", f, err)
 		}
 	}
 
+	trash := Trash{Path: d.TrashPath}
+	for _, f := range d.trashed {
+		if _, err := trash.Restore(f.Name); err != nil {
+			logger.Warnf("Error restoring %q from trash: %v", f.OriginalPath, err)
+		}
+	}
+
 	d.files = nil
+	d.trashed = nil
 	d.dirs = nil
 }
 
 // Commit deletes all files marked for deletion and clears the marked list.
-// Any errors encountered are logged. All files will be attempted, regardless
-// of the errors encountered.
+// Files moved to the trash are left there. Any errors encountered are logged.
+// All files will be attempted, regardless of the errors encountered.
 func (d *Deleter) Commit() {
 	for _, f := range d.files {
 		if err := d.RenamerRemover.Remove(f + deleteFileSuffix); err != nil {
@@ -169,6 +216,7 @@ func (d *Deleter) Commit() {
 	}
 
 	d.files = nil
+	d.trashed = nil
 	d.dirs = nil
 }
 
@@ -187,7 +235,7 @@ func Destroy(ctx context.Context, destroyer models.FileDestroyer, f models.File,
 
 	// don't delete files in zip files
 	if deleteFile && f.Base().ZipFileID == nil {
-		if err := fileDeleter.Files([]string{f.Base().Path}); err != nil {
+		if err := fileDeleter.MediaFiles([]string{f.Base().Path}); err != nil {
 			return err
 		}
 	}
@@ -230,7 +278,7 @@ func (d *ZipDestroyer) DestroyZip(ctx context.Context, f models.File, fileDelete
 	}
 
 	if deleteFile {
-		if err := fileDeleter.Files([]string{f.Base().Path}); err != nil {
+		if err := fileDeleter.MediaFiles([]string{f.Base().Path}); err != nil {
 			return err
 		}
 	}
@@ -104,6 +104,24 @@ func (m *Mover) Move(ctx context.Context, f models.File, folder *models.Folder,
 	return m.moveFile(oldPath, newPath)
 }
 
+// MoveSidecarFile moves a file that is not tracked in the database, such as
+// a caption file, alongside a moved file. It does nothing if the file does
+// not exist. The move is reverted if the transaction is rolled back.
+func (m *Mover) MoveSidecarFile(oldPath, newPath string) error {
+	if _, err := m.Renamer.Stat(oldPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("getting info for %s: %w", oldPath, err)
+	}
+
+	if _, err := m.Renamer.Stat(newPath); !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("file %s already exists", newPath)
+	}
+
+	return m.moveFile(oldPath, newPath)
+}
+
 func (m *Mover) CreateFolderHierarchy(path string) error {
 	info, err := m.Renamer.Stat(path)
 	if err != nil {
@@ -96,9 +96,16 @@ type ProgressReporter interface {
 	AddTotal(total int)
 	Increment()
 	Definite()
+	IncrementPhase(phase string)
 	ExecuteTask(description string, fn func())
 }
 
+// Scan phases reported to the ProgressReporter.
+const (
+	ProgressPhaseHashing = "hashing"
+	ProgressPhaseProbing = "probing"
+)
+
 type scanJob struct {
 	*Scanner
 
@@ -117,6 +124,10 @@ type scanJob struct {
 	count          int
 
 	txnRetryer txn.Retryer
+
+	// batch is used to create new files in batched transactions.
+	// nil if batching is disabled.
+	batch *txnBatch
 }
 
 // ScanOptions provides options for scanning files.
@@ -135,6 +146,10 @@ type ScanOptions struct {
 
 	ParallelTasks int
 
+	// BatchSize is the number of new files to create in each transaction.
+	// Values less than 2 create each file in its own transaction.
+	BatchSize int
+
 	// When true files in path will be rescanned even if they haven't changed
 	Rescan bool
 }
@@ -152,6 +167,13 @@ func (s *Scanner) Scan(ctx context.Context, handlers []Handler, options ScanOpti
 		},
 	}
 
+	if options.BatchSize > 1 {
+		job.batch = &txnBatch{
+			size:    options.BatchSize,
+			withTxn: job.withTxn,
+		}
+	}
+
 	job.execute(ctx)
 }
 
@@ -365,6 +387,10 @@ func (s *scanJob) processQueue(ctx context.Context) error {
 
 	wg := sizedwaitgroup.New(parallelTasks)
 
+	// create any batched files that have already been processed, even if
+	// the scan was cancelled
+	defer s.flushBatch(utils.ValueOnlyContext{Context: ctx})
+
 	if err := func() error {
 		defer wg.Wait()
 
@@ -386,6 +412,9 @@ func (s *scanJob) processQueue(ctx context.Context) error {
 		return err
 	}
 
+	// create batched files before processing the retry list
+	s.flushBatch(ctx)
+
 	s.retrying = true
 
 	if err := func() error {
@@ -412,6 +441,16 @@ func (s *scanJob) processQueue(ctx context.Context) error {
 	return nil
 }
 
+func (s *scanJob) flushBatch(ctx context.Context) {
+	if s.batch == nil {
+		return
+	}
+
+	if err := s.batch.flush(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		logger.Errorf("error creating batched files: %v", err)
+	}
+}
+
 func (s *scanJob) incrementProgress(f scanFile) {
 	// don't increment for files inside zip files since these aren't
 	// counted during the initial walking
@@ -751,7 +790,7 @@ func (s *scanJob) onNewFile(ctx context.Context, f scanFile) (models.File, error
 	}
 
 	// if not renamed, queue file for creation
-	if err := s.withTxn(ctx, func(ctx context.Context) error {
+	create := func(ctx context.Context) error {
 		if err := s.Repository.File.Create(ctx, file); err != nil {
 			return fmt.Errorf("creating file %q: %w", path, err)
 		}
@@ -761,7 +800,19 @@ func (s *scanJob) onNewFile(ctx context.Context, f scanFile) (models.File, error
 		}
 
 		return nil
-	}); err != nil {
+	}
+
+	// zip files must be created immediately so that their contents can
+	// reference them
+	if s.batch != nil && !s.isZipFile(f.info.Name()) {
+		if err := s.batch.add(ctx, path, create); err != nil {
+			return nil, err
+		}
+
+		return file, nil
+	}
+
+	if err := s.withTxn(ctx, create); err != nil {
 		return nil, err
 	}
 
@@ -777,6 +828,8 @@ func (s *scanJob) fireDecorators(ctx context.Context, fs models.FS, f models.Fil
 		}
 	}
 
+	s.ProgressReports.IncrementPhase(ProgressPhaseProbing)
+
 	return f, nil
 }
 
@@ -805,26 +858,11 @@ func (s *scanJob) calculateFingerprints(fs models.FS, f *models.BaseFile, path s
 		return nil, fmt.Errorf("calculating fingerprint for file %q: %w", path, err)
 	}
 
-	return fp, nil
-}
-
-func appendFileUnique(v []models.File, toAdd []models.File) []models.File {
-	for _, f := range toAdd {
-		found := false
-		id := f.Base().ID
-		for _, vv := range v {
-			if vv.Base().ID == id {
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			v = append(v, f)
-		}
+	if !useExisting {
+		s.ProgressReports.IncrementPhase(ProgressPhaseHashing)
 	}
 
-	return v
+	return fp, nil
 }
 
 func (s *scanJob) getFileFS(f *models.BaseFile) (models.FS, error) {
@@ -842,15 +880,9 @@ func (s *scanJob) getFileFS(f *models.BaseFile) (models.FS, error) {
 }
 
 func (s *scanJob) handleRename(ctx context.Context, f models.File, fp []models.Fingerprint) (models.File, error) {
-	var others []models.File
-
-	for _, tfp := range fp {
-		thisOthers, err := s.Repository.File.FindByFingerprint(ctx, tfp)
-		if err != nil {
-			return nil, fmt.Errorf("getting files by fingerprint %v: %w", tfp, err)
-		}
-
-		others = appendFileUnique(others, thisOthers)
+	others, err := s.Repository.File.FindByFingerprints(ctx, fp)
+	if err != nil {
+		return nil, fmt.Errorf("getting files by fingerprints %v: %w", fp, err)
 	}
 
 	var missing []models.File
@@ -897,10 +929,10 @@ func (s *scanJob) handleRename(ctx context.Context, f models.File, fp []models.F
 
 	// assume does not exist, update existing file
 	// it's possible that there may be multiple missing files.
-	// just use the first one to rename.
+	// prefer one with the same basename, otherwise use the first one to rename.
 	// #4775 - using the new file instance means that any changes made to the existing
 	// file will be lost. Update the existing file instead.
-	other := missing[0]
+	other := selectRenamedFile(f, missing)
 	updated := other.Clone()
 	updatedBase := updated.Base()
 
@@ -938,6 +970,20 @@ func (s *scanJob) handleRename(ctx context.Context, f models.File, fp []models.F
 	return updated, nil
 }
 
+// selectRenamedFile returns the missing file that f was most likely renamed
+// from. Files with the same basename as f are preferred, otherwise the first
+// missing file is returned.
+func selectRenamedFile(f models.File, missing []models.File) models.File {
+	basename := f.Base().Basename
+	for _, other := range missing {
+		if other.Base().Basename == basename {
+			return other
+		}
+	}
+
+	return missing[0]
+}
+
 func (s *scanJob) isHandlerRequired(ctx context.Context, f models.File) bool {
 	accept := len(s.options.HandlerRequiredFilters) == 0
 	for _, filter := range s.options.HandlerRequiredFilters {
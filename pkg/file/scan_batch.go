@@ -0,0 +1,92 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type batchOp struct {
+	path string
+	fn   func(ctx context.Context) error
+}
+
+// txnBatch collects database operations and executes them together in a
+// single transaction once the batch is full. This reduces the number of
+// transactions committed during a scan, which otherwise dominates the scan
+// time on slow disks.
+//
+// Operations are executed in the order they were added. Operations must not
+// depend on the results of other operations being available outside of the
+// batch transaction.
+type txnBatch struct {
+	size    int
+	withTxn func(ctx context.Context, fn func(ctx context.Context) error) error
+
+	mutex   sync.Mutex
+	pending []batchOp
+}
+
+// add queues fn to be executed in a batched transaction. If the batch is
+// full, then the batch is executed in the calling goroutine.
+// Returns an error only if the batch consists of the single operation and
+// it failed.
+func (b *txnBatch) add(ctx context.Context, path string, fn func(ctx context.Context) error) error {
+	b.mutex.Lock()
+	b.pending = append(b.pending, batchOp{path: path, fn: fn})
+
+	if len(b.pending) < b.size {
+		b.mutex.Unlock()
+		return nil
+	}
+
+	ops := b.pending
+	b.pending = nil
+	b.mutex.Unlock()
+
+	return b.execute(ctx, ops)
+}
+
+// flush executes any pending operations.
+func (b *txnBatch) flush(ctx context.Context) error {
+	b.mutex.Lock()
+	ops := b.pending
+	b.pending = nil
+	b.mutex.Unlock()
+
+	return b.execute(ctx, ops)
+}
+
+func (b *txnBatch) execute(ctx context.Context, ops []batchOp) error {
+	if len(ops) == 0 {
+		return nil
+	}
+
+	err := b.withTxn(ctx, func(ctx context.Context) error {
+		for _, op := range ops {
+			if err := op.fn(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err == nil || len(ops) == 1 {
+		return err
+	}
+
+	// the whole transaction was rolled back - execute each operation in its
+	// own transaction so that only the failing operations are lost
+	logger.Debugf("batched transaction of %d operations failed, retrying individually: %v", len(ops), err)
+
+	for _, op := range ops {
+		if err := b.withTxn(ctx, op.fn); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Errorf("error processing %q: %v", op.path, err)
+		}
+	}
+
+	return nil
+}
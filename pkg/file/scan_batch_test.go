@@ -0,0 +1,90 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTxn records the operations committed by each transaction.
+type fakeTxn struct {
+	txns      int
+	committed []string
+}
+
+func (f *fakeTxn) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.txns++
+
+	var ops []string
+	ctx = context.WithValue(ctx, fakeTxnKey{}, &ops)
+	if err := fn(ctx); err != nil {
+		// rollback
+		return err
+	}
+
+	f.committed = append(f.committed, ops...)
+	return nil
+}
+
+type fakeTxnKey struct{}
+
+func recordOp(name string, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err != nil {
+			return err
+		}
+
+		ops := ctx.Value(fakeTxnKey{}).(*[]string)
+		*ops = append(*ops, name)
+		return nil
+	}
+}
+
+func TestTxnBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("batches operations", func(t *testing.T) {
+		ft := &fakeTxn{}
+		b := &txnBatch{size: 2, withTxn: ft.withTxn}
+
+		assert.Nil(t, b.add(ctx, "a", recordOp("a", nil)))
+		assert.Equal(t, 0, ft.txns, "operations should not execute until the batch is full")
+
+		assert.Nil(t, b.add(ctx, "b", recordOp("b", nil)))
+		assert.Nil(t, b.add(ctx, "c", recordOp("c", nil)))
+		assert.Equal(t, 1, ft.txns)
+		assert.Equal(t, []string{"a", "b"}, ft.committed)
+
+		assert.Nil(t, b.flush(ctx))
+		assert.Equal(t, 2, ft.txns)
+		assert.Equal(t, []string{"a", "b", "c"}, ft.committed)
+
+		assert.Nil(t, b.flush(ctx))
+		assert.Equal(t, 2, ft.txns, "flushing an empty batch should not start a transaction")
+	})
+
+	t.Run("failed operation", func(t *testing.T) {
+		ft := &fakeTxn{}
+		b := &txnBatch{size: 3, withTxn: ft.withTxn}
+
+		assert.Nil(t, b.add(ctx, "a", recordOp("a", nil)))
+		assert.Nil(t, b.add(ctx, "b", recordOp("b", errors.New("failed"))))
+		assert.Nil(t, b.add(ctx, "c", recordOp("c", nil)))
+
+		// one failed batch, then one transaction per operation
+		assert.Equal(t, 4, ft.txns)
+		assert.Equal(t, []string{"a", "c"}, ft.committed)
+	})
+
+	t.Run("failed single operation", func(t *testing.T) {
+		ft := &fakeTxn{}
+		b := &txnBatch{size: 2, withTxn: ft.withTxn}
+
+		errFailed := errors.New("failed")
+		assert.Nil(t, b.add(ctx, "a", recordOp("a", errFailed)))
+		assert.ErrorIs(t, b.flush(ctx), errFailed)
+		assert.Empty(t, ft.committed)
+	})
+}
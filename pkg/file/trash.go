@@ -0,0 +1,287 @@
+package file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	trashFilesDir     = "files"
+	trashInfoDir      = "info"
+	trashInfoSuffix   = ".trashinfo"
+	trashInfoHeader   = "[Trash Info]"
+	trashInfoPath     = "Path="
+	trashInfoDate     = "DeletionDate="
+	trashInfoDateTime = "2006-01-02T15:04:05"
+)
+
+// ErrTrashedFileNotFound is returned when a file is not in the trash.
+var ErrTrashedFileNotFound = errors.New("file not found in trash")
+
+// TrashedFile is a file that has been moved to the trash.
+type TrashedFile struct {
+	// Name of the file in the trash directory
+	Name string
+	// OriginalPath is the path the file was deleted from
+	OriginalPath string
+	DeletedAt    time.Time
+}
+
+// Trash moves deleted files to a trash directory instead of removing them,
+// so that they can be restored later. The layout of the directory follows the
+// freedesktop.org trash specification: files are stored in the files
+// subdirectory, with the original path and deletion time of each file stored
+// in a matching .trashinfo file in the info subdirectory.
+type Trash struct {
+	Path string
+}
+
+func (t Trash) filesPath() string {
+	return filepath.Join(t.Path, trashFilesDir)
+}
+
+func (t Trash) infoPath(name string) string {
+	return filepath.Join(t.Path, trashInfoDir, name+trashInfoSuffix)
+}
+
+// ValidateTrashPath returns an error if the trash directory is inside one of
+// the library paths, or contains one of them. Trashed files would otherwise
+// be added to the library again by the next scan.
+func ValidateTrashPath(trashPath string, libraryPaths []string) error {
+	if trashPath == "" {
+		return nil
+	}
+
+	trashPath, err := filepath.Abs(trashPath)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range libraryPaths {
+		libraryPath, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+
+		if fsutil.IsPathInDir(libraryPath, trashPath) || fsutil.IsPathInDir(trashPath, libraryPath) {
+			return fmt.Errorf("trash directory %q must not overlap library path %q", trashPath, p)
+		}
+	}
+
+	return nil
+}
+
+// Move moves the file at src to the trash, recording originalPath as its
+// original location.
+//
+// The file is renamed rather than copied, so the trash directory must be on
+// the same filesystem as the file. This keeps moving a file to the trash fast
+// enough to be done while a transaction is open, and an error is returned
+// immediately if the file would have to be copied.
+func (t Trash) Move(src string, originalPath string) (*TrashedFile, error) {
+	if err := fsutil.EnsureDirAll(t.filesPath()); err != nil {
+		return nil, fmt.Errorf("creating trash directory: %w", err)
+	}
+	if err := fsutil.EnsureDirAll(filepath.Join(t.Path, trashInfoDir)); err != nil {
+		return nil, fmt.Errorf("creating trash directory: %w", err)
+	}
+
+	ret := &TrashedFile{
+		OriginalPath: originalPath,
+		DeletedAt:    time.Now(),
+	}
+
+	// reserve a unique name by exclusively creating the info file
+	base := filepath.Base(originalPath)
+	ext := filepath.Ext(base)
+	for i := 1; ; i++ {
+		ret.Name = base
+		if i > 1 {
+			ret.Name = strings.TrimSuffix(base, ext) + "." + strconv.Itoa(i) + ext
+		}
+
+		f, err := os.OpenFile(t.infoPath(ret.Name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("writing trash info: %w", err)
+		}
+
+		_, err = f.WriteString(ret.info())
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(t.infoPath(ret.Name))
+			return nil, fmt.Errorf("writing trash info: %w", err)
+		}
+
+		break
+	}
+
+	if err := os.Rename(src, filepath.Join(t.filesPath(), ret.Name)); err != nil {
+		_ = os.Remove(t.infoPath(ret.Name))
+		return nil, fmt.Errorf("the trash directory must be on the same filesystem as the file: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (f TrashedFile) info() string {
+	u := url.URL{Path: filepath.ToSlash(f.OriginalPath)}
+	return trashInfoHeader + "\n" +
+		trashInfoPath + u.EscapedPath() + "\n" +
+		trashInfoDate + f.DeletedAt.Format(trashInfoDateTime) + "\n"
+}
+
+func (t Trash) readInfo(name string) (*TrashedFile, error) {
+	f, err := os.Open(t.infoPath(name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ret := &TrashedFile{
+		Name: name,
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, trashInfoPath):
+			p, err := url.PathUnescape(strings.TrimPrefix(line, trashInfoPath))
+			if err != nil {
+				return nil, fmt.Errorf("invalid path in %s: %w", name+trashInfoSuffix, err)
+			}
+			ret.OriginalPath = filepath.FromSlash(p)
+		case strings.HasPrefix(line, trashInfoDate):
+			ret.DeletedAt, err = time.ParseInLocation(trashInfoDateTime, strings.TrimPrefix(line, trashInfoDate), time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("invalid deletion date in %s: %w", name+trashInfoSuffix, err)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if ret.OriginalPath == "" {
+		return nil, fmt.Errorf("missing path in %s", name+trashInfoSuffix)
+	}
+
+	return ret, nil
+}
+
+// List returns the files in the trash, most recently deleted first.
+func (t Trash) List() ([]TrashedFile, error) {
+	entries, err := os.ReadDir(filepath.Join(t.Path, trashInfoDir))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ret []TrashedFile
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), trashInfoSuffix)
+		if e.IsDir() || !ok {
+			continue
+		}
+
+		f, err := t.readInfo(name)
+		if err != nil {
+			logger.Warnf("Error reading trash info for %q: %v", name, err)
+			continue
+		}
+
+		ret = append(ret, *f)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].DeletedAt.After(ret[j].DeletedAt)
+	})
+
+	return ret, nil
+}
+
+// Restore moves the trashed file with the provided name back to its original
+// path, returning the restored path. An error is returned if a file already
+// exists at the original path.
+func (t Trash) Restore(name string) (string, error) {
+	// prevent paths outside of the trash directory
+	if name != filepath.Base(name) {
+		return "", fmt.Errorf("invalid trashed file name %q", name)
+	}
+
+	f, err := t.readInfo(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("%w: %s", ErrTrashedFileNotFound, name)
+		}
+		return "", err
+	}
+
+	if exists, _ := fsutil.FileExists(f.OriginalPath); exists {
+		return "", fmt.Errorf("file already exists at %q", f.OriginalPath)
+	}
+
+	if err := fsutil.EnsureDirAll(filepath.Dir(f.OriginalPath)); err != nil {
+		return "", fmt.Errorf("creating directory: %w", err)
+	}
+
+	if err := fsutil.SafeMove(filepath.Join(t.filesPath(), name), f.OriginalPath); err != nil {
+		return "", err
+	}
+
+	if err := os.Remove(t.infoPath(name)); err != nil {
+		logger.Warnf("Error removing trash info for %q: %v", name, err)
+	}
+
+	return f.OriginalPath, nil
+}
+
+// Empty permanently deletes trashed files that were deleted before the
+// provided time, returning the number of files deleted. If before is zero,
+// all trashed files are deleted.
+func (t Trash) Empty(before time.Time) (int, error) {
+	files, err := t.List()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, f := range files {
+		if !before.IsZero() && !f.DeletedAt.Before(before) {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(t.filesPath(), f.Name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Warnf("Error deleting trashed file %q: %v", f.Name, err)
+			continue
+		}
+
+		if err := os.Remove(t.infoPath(f.Name)); err != nil {
+			logger.Warnf("Error removing trash info for %q: %v", f.Name, err)
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
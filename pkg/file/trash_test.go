@@ -0,0 +1,142 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrash(t *testing.T) {
+	tmpDir := t.TempDir()
+	libraryDir := filepath.Join(tmpDir, "library")
+	trash := Trash{Path: filepath.Join(tmpDir, "trash")}
+
+	if err := os.MkdirAll(libraryDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(libraryDir, "video name.mp4")
+	writeFile := func() {
+		if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// trash the same path twice to ensure names do not collide
+	writeFile()
+	first, err := trash.Move(path, path)
+	if !assert.Nil(t, err) {
+		return
+	}
+	writeFile()
+	second, err := trash.Move(path, path)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "video name.mp4", first.Name)
+	assert.Equal(t, "video name.2.mp4", second.Name)
+	assert.NoFileExists(t, path)
+
+	files, err := trash.List()
+	if assert.Nil(t, err) && assert.Len(t, files, 2) {
+		for _, f := range files {
+			assert.Equal(t, path, f.OriginalPath)
+		}
+	}
+
+	restored, err := trash.Restore(first.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, path, restored)
+	assert.FileExists(t, path)
+
+	// cannot restore over an existing file
+	_, err = trash.Restore(second.Name)
+	assert.NotNil(t, err)
+
+	_, err = trash.Restore(first.Name)
+	assert.ErrorIs(t, err, ErrTrashedFileNotFound)
+
+	_, err = trash.Restore(filepath.Join("..", "library", "video name.mp4"))
+	assert.NotNil(t, err)
+
+	// nothing was deleted before an hour ago
+	deleted, err := trash.Empty(time.Now().Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, deleted)
+
+	deleted, err = trash.Empty(time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, deleted)
+
+	files, err = trash.List()
+	assert.Nil(t, err)
+	assert.Empty(t, files)
+}
+
+func TestValidateTrashPath(t *testing.T) {
+	library := filepath.Join("stash", "library")
+	libraries := []string{library, filepath.Join("stash", "other")}
+
+	tests := []struct {
+		name      string
+		trashPath string
+		wantErr   bool
+	}{
+		{"not set", "", false},
+		{"outside library", filepath.Join("stash", "trash"), false},
+		{"library prefix", filepath.Join("stash", "library-trash"), false},
+		{"inside library", filepath.Join(library, ".trash"), true},
+		{"same as library", library, true},
+		{"contains library", "stash", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTrashPath(tt.trashPath, libraries)
+			assert.Equal(t, tt.wantErr, err != nil, "ValidateTrashPath(%q) = %v", tt.trashPath, err)
+		})
+	}
+}
+
+func TestDeleterTrash(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "video.mp4")
+	trash := Trash{Path: filepath.Join(tmpDir, "trash")}
+
+	newDeleter := func() *Deleter {
+		d := NewDeleter()
+		d.TrashPath = trash.Path
+		return d
+	}
+
+	if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// files are moved to the trash when marked, and restored on rollback
+	d := newDeleter()
+	assert.Nil(t, d.MediaFiles([]string{path}))
+	assert.NoFileExists(t, path)
+	assert.FileExists(t, filepath.Join(trash.Path, trashFilesDir, "video.mp4"))
+
+	d.Rollback()
+	assert.FileExists(t, path)
+	files, err := trash.List()
+	assert.Nil(t, err)
+	assert.Empty(t, files)
+
+	// files are left in the trash on commit
+	d = newDeleter()
+	assert.Nil(t, d.MediaFiles([]string{path, filepath.Join(tmpDir, "missing.mp4")}))
+	d.Commit()
+	assert.NoFileExists(t, path)
+
+	files, err = trash.List()
+	if assert.Nil(t, err) && assert.Len(t, files, 1) {
+		assert.Equal(t, path, files[0].OriginalPath)
+	}
+}
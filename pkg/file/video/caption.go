@@ -1,6 +1,7 @@
 package video
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -9,13 +10,14 @@ import (
 	"strings"
 
 	"github.com/asticode/go-astisub"
+	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
 	"golang.org/x/text/language"
 )
 
-var CaptionExts = []string{"vtt", "srt"} // in a case where vtt and srt files are both provided prioritize vtt file due to native support
+var CaptionExts = []string{"vtt", "srt", "ass", "ssa"} // in a case where vtt and srt files are both provided prioritize vtt file due to native support
 
 // to be used for captions without a language code in the filename
 // ISO 639-1 uses 2 or 3 a-z chars for codes so 00 is a safe non valid choise
@@ -48,11 +50,24 @@ func IsValidLanguage(lang string) bool {
 	return err == nil
 }
 
+// normalizeCaptionType returns the caption type in the lowercase form that is
+// stored for captions.
+func normalizeCaptionType(captionType string) string {
+	return strings.ToLower(captionType)
+}
+
+// CaptionTypeMatches returns true if the caption types are the same. Caption
+// types are compared case-insensitively, since the type of captions found by
+// earlier scans may not be lowercase.
+func CaptionTypeMatches(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
 // IsLangInCaptions returns true if lang is present
 // in the captions
 func IsLangInCaptions(lang string, ext string, captions []*models.VideoCaption) bool {
 	for _, caption := range captions {
-		if lang == caption.LanguageCode && ext == caption.CaptionType {
+		if lang == caption.LanguageCode && CaptionTypeMatches(ext, caption.CaptionType) {
 			return true
 		}
 	}
@@ -120,7 +135,7 @@ func AssociateCaptions(ctx context.Context, captionPath string, txnMgr txn.Manag
 			captions, er := w.GetCaptions(ctx, fileID)
 			if er == nil {
 				fileExt := filepath.Ext(captionPath)
-				ext := fileExt[1:]
+				ext := normalizeCaptionType(fileExt[1:])
 				if !IsLangInCaptions(captionLang, ext, captions) { // only update captions if language code is not present
 					newCaption := &models.VideoCaption{
 						LanguageCode: captionLang,
@@ -141,6 +156,62 @@ func AssociateCaptions(ctx context.Context, captionPath string, txnMgr txn.Manag
 	}
 }
 
+// AssociateCaptionFiles associates the caption files alongside the video file
+// that are named after it, with an optional language code, that are not
+// already associated. This handles caption files found before the video file
+// was added. Assumes it is being called within a transaction.
+func AssociateCaptionFiles(ctx context.Context, f *models.VideoFile, w CaptionUpdater) error {
+	dir := filepath.Dir(f.Path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading directory %s: %w", dir, err)
+	}
+
+	prefix := strings.TrimSuffix(f.Path, filepath.Ext(f.Path)) + "."
+
+	var found []*models.VideoCaption
+	for _, e := range entries {
+		captionPath := filepath.Join(dir, e.Name())
+		if e.IsDir() || !fsutil.MatchExtension(captionPath, CaptionExts) || getCaptionPrefix(captionPath) != prefix {
+			continue
+		}
+
+		found = append(found, &models.VideoCaption{
+			LanguageCode: getCaptionsLangFromPath(captionPath),
+			Filename:     e.Name(),
+			CaptionType:  normalizeCaptionType(filepath.Ext(captionPath)[1:]),
+		})
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	captions, err := w.GetCaptions(ctx, f.ID)
+	if err != nil {
+		return fmt.Errorf("getting captions for file %s: %w", f.Path, err)
+	}
+
+	changed := false
+	for _, c := range found {
+		if !IsLangInCaptions(c.LanguageCode, c.CaptionType, captions) {
+			captions = append(captions, c)
+			changed = true
+			logger.Debugf("Matched caption %s to file %s", c.Filename, f.Path)
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := w.UpdateCaptions(ctx, f.ID, captions); err != nil {
+		return fmt.Errorf("updating captions for file %s: %w", f.Path, err)
+	}
+
+	return nil
+}
+
 // CleanCaptions removes non existent/accessible language codes from captions
 func CleanCaptions(ctx context.Context, f *models.VideoFile, txnMgr txn.Manager, w CaptionUpdater) error {
 	captions, err := w.GetCaptions(ctx, f.ID)
@@ -189,3 +260,107 @@ func CleanCaptions(ctx context.Context, f *models.VideoFile, txnMgr txn.Manager,
 
 	return nil
 }
+
+// ParseSubs parses caption data of the provided caption type.
+func ParseSubs(data []byte, captionType string) (*astisub.Subtitles, error) {
+	switch normalizeCaptionType(captionType) {
+	case "vtt":
+		return astisub.ReadFromWebVTT(bytes.NewReader(data))
+	case "srt":
+		return astisub.ReadFromSRT(bytes.NewReader(data))
+	case "ass", "ssa":
+		return astisub.ReadFromSSA(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("unsupported caption type: %s", captionType)
+	}
+}
+
+// AddCaption writes the caption data alongside the video file and associates
+// it with the file. An existing caption with the same language and type is
+// overwritten. Assumes it is being called within a transaction.
+func AddCaption(ctx context.Context, f *models.VideoFile, lang string, captionType string, data []byte, w CaptionUpdater) (*models.VideoCaption, error) {
+	captionType = normalizeCaptionType(captionType)
+
+	if lang == "" {
+		lang = LangUnknown
+	} else if lang != LangUnknown && !IsValidLanguage(lang) {
+		return nil, fmt.Errorf("invalid language code: %s", lang)
+	}
+
+	if _, err := ParseSubs(data, captionType); err != nil {
+		return nil, fmt.Errorf("parsing caption data: %w", err)
+	}
+
+	captions, err := w.GetCaptions(ctx, f.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting captions for file %s: %w", f.Path, err)
+	}
+
+	var newCaption *models.VideoCaption
+	for _, caption := range captions {
+		if caption.LanguageCode == lang && CaptionTypeMatches(caption.CaptionType, captionType) {
+			// overwrite the existing caption file, which may differ in case
+			newCaption = caption
+			break
+		}
+	}
+
+	if newCaption == nil {
+		newCaption = &models.VideoCaption{
+			LanguageCode: lang,
+			Filename:     filepath.Base(GetCaptionPath(f.Path, lang, captionType)),
+			CaptionType:  captionType,
+		}
+
+		captions = append(captions, newCaption)
+		if err := w.UpdateCaptions(ctx, f.ID, captions); err != nil {
+			return nil, fmt.Errorf("updating captions for file %s: %w", f.Path, err)
+		}
+	}
+
+	captionPath := newCaption.Path(f.Path)
+
+	if err := os.WriteFile(captionPath, data, 0644); err != nil {
+		return nil, fmt.Errorf("writing caption file %s: %w", captionPath, err)
+	}
+
+	logger.Infof("Added caption %s for %s", newCaption.Filename, f.Path)
+
+	return newCaption, nil
+}
+
+// RemoveCaption removes the caption with the provided language and type from
+// the file, returning the path of the caption file so that it can be deleted
+// by the caller. Assumes it is being called within a transaction.
+func RemoveCaption(ctx context.Context, f *models.VideoFile, lang string, captionType string, w CaptionUpdater) (string, error) {
+	captionType = normalizeCaptionType(captionType)
+
+	if lang == "" {
+		lang = LangUnknown
+	}
+
+	captions, err := w.GetCaptions(ctx, f.ID)
+	if err != nil {
+		return "", fmt.Errorf("getting captions for file %s: %w", f.Path, err)
+	}
+
+	var removed *models.VideoCaption
+	var newCaptions []*models.VideoCaption
+	for _, caption := range captions {
+		if caption.LanguageCode == lang && CaptionTypeMatches(caption.CaptionType, captionType) {
+			removed = caption
+			continue
+		}
+		newCaptions = append(newCaptions, caption)
+	}
+
+	if removed == nil {
+		return "", fmt.Errorf("caption %s.%s not found for file %s", lang, captionType, f.Path)
+	}
+
+	if err := w.UpdateCaptions(ctx, f.ID, newCaptions); err != nil {
+		return "", fmt.Errorf("updating captions for file %s: %w", f.Path, err)
+	}
+
+	return removed.Path(f.Path), nil
+}
@@ -1,8 +1,12 @@
 package video
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,3 +55,137 @@ func TestGetCaptionsLangFromPath(t *testing.T) {
 		assert.Equal(t, l.expectedLang, getCaptionsLangFromPath(l.captionPath))
 	}
 }
+
+func TestParseSubs(t *testing.T) {
+	const srt = "1\n00:00:01,000 --> 00:00:02,000\nHello\n"
+	const vtt = "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHello\n"
+	const ass = "[Script Info]\nScriptType: v4.00+\n\n[Events]\nFormat: Layer, Start, End, Style, Text\nDialogue: 0,0:00:01.00,0:00:02.00,Default,Hello\n"
+
+	tests := []struct {
+		name        string
+		data        string
+		captionType string
+		wantErr     bool
+	}{
+		{"srt", srt, "srt", false},
+		{"vtt", vtt, "vtt", false},
+		{"ass", ass, "ass", false},
+		{"uppercase", srt, "SRT", false},
+		{"unsupported", srt, "txt", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs, err := ParseSubs([]byte(tt.data), tt.captionType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, subs.Items, 1)
+		})
+	}
+}
+
+type testCaptionUpdater struct {
+	captions []*models.VideoCaption
+}
+
+func (u *testCaptionUpdater) GetCaptions(ctx context.Context, fileID models.FileID) ([]*models.VideoCaption, error) {
+	return u.captions, nil
+}
+
+func (u *testCaptionUpdater) UpdateCaptions(ctx context.Context, fileID models.FileID, captions []*models.VideoCaption) error {
+	u.captions = captions
+	return nil
+}
+
+func TestAssociateCaptionFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, fn := range []string{"video.mp4", "video.srt", "video.en.ass", "video.de.SRT", "video.fr.vtt", "video.part2.srt", "other.srt"} {
+		if err := os.WriteFile(filepath.Join(dir, fn), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f := &models.VideoFile{
+		BaseFile: &models.BaseFile{
+			Path: filepath.Join(dir, "video.mp4"),
+		},
+	}
+
+	existing := &models.VideoCaption{LanguageCode: "fr", Filename: "video.fr.vtt", CaptionType: "vtt"}
+	u := &testCaptionUpdater{captions: []*models.VideoCaption{existing}}
+
+	if err := AssociateCaptionFiles(context.Background(), f, u); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.ElementsMatch(t, []*models.VideoCaption{
+		existing,
+		{LanguageCode: LangUnknown, Filename: "video.srt", CaptionType: "srt"},
+		{LanguageCode: "en", Filename: "video.en.ass", CaptionType: "ass"},
+		// caption types are stored in lowercase
+		{LanguageCode: "de", Filename: "video.de.SRT", CaptionType: "srt"},
+	}, u.captions)
+}
+
+func TestAddRemoveCaption(t *testing.T) {
+	const srt = "1\n00:00:01,000 --> 00:00:02,000\nHello\n"
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	f := &models.VideoFile{
+		BaseFile: &models.BaseFile{
+			Path: filepath.Join(dir, "video.mp4"),
+		},
+	}
+
+	// a caption found by an earlier scan with an uppercase type
+	existing := &models.VideoCaption{LanguageCode: "de", Filename: "video.de.SRT", CaptionType: "SRT"}
+	u := &testCaptionUpdater{captions: []*models.VideoCaption{existing}}
+
+	added, err := AddCaption(ctx, f, "en", "SRT", []byte(srt), u)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, &models.VideoCaption{LanguageCode: "en", Filename: "video.en.srt", CaptionType: "srt"}, added)
+	assert.FileExists(t, filepath.Join(dir, "video.en.srt"))
+	assert.Equal(t, []*models.VideoCaption{existing, added}, u.captions)
+
+	// the existing caption is overwritten when added with a different case
+	overwritten, err := AddCaption(ctx, f, "de", "srt", []byte(srt), u)
+	assert.NoError(t, err)
+	assert.Equal(t, existing, overwritten)
+	assert.FileExists(t, filepath.Join(dir, "video.de.SRT"))
+	assert.Len(t, u.captions, 2)
+
+	tests := []struct {
+		name        string
+		lang        string
+		captionType string
+		wantPath    string
+		wantErr     bool
+	}{
+		{"uppercase type", "en", "SRT", filepath.Join(dir, "video.en.srt"), false},
+		{"stored uppercase type", "de", "srt", filepath.Join(dir, "video.de.SRT"), false},
+		{"not found", "fr", "srt", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RemoveCaption(ctx, f, tt.lang, tt.captionType, u)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPath, got)
+		})
+	}
+
+	assert.Empty(t, u.captions)
+}
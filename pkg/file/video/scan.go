@@ -54,6 +54,7 @@ func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (
 		FrameRate:   videoFile.FrameRate,
 		BitRate:     videoFile.Bitrate,
 		Interactive: interactive,
+		ProbeData:   videoFile.RawJSON,
 	}, nil
 }
 
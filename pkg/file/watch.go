@@ -0,0 +1,259 @@
+package file
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// WatchChanges contains the directories affected by file system changes.
+type WatchChanges struct {
+	// Changed contains directories in which files were added or modified.
+	Changed []string
+	// Removed contains directories from which files were removed or renamed.
+	Removed []string
+}
+
+// Watcher recursively watches directories for changes. Changes are batched
+// and reported once no further changes have occurred within the debounce
+// duration.
+type Watcher struct {
+	Debounce time.Duration
+	OnChange func(changes WatchChanges)
+
+	watcher *fsnotify.Watcher
+	paths   []string
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	mutex   sync.Mutex
+	changed map[string]struct{}
+	removed map[string]struct{}
+	timer   *time.Timer
+	// flushSeq identifies the most recently scheduled flush. Flushes with an
+	// earlier sequence number were superseded or stopped and do nothing.
+	flushSeq uint64
+}
+
+// NewWatcher returns a new Watcher which calls onChange with the affected
+// directories once no changes have occurred for the debounce duration.
+func NewWatcher(debounce time.Duration, onChange func(changes WatchChanges)) *Watcher {
+	return &Watcher{
+		Debounce: debounce,
+		OnChange: onChange,
+		changed:  make(map[string]struct{}),
+		removed:  make(map[string]struct{}),
+	}
+}
+
+// Start starts watching the provided paths and all of their subdirectories.
+func (w *Watcher) Start(paths []string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	w.watcher = watcher
+	w.paths = append([]string{}, paths...)
+	w.done = make(chan struct{})
+
+	for _, p := range paths {
+		w.addRecursive(p)
+	}
+
+	w.wg.Add(1)
+	go w.run()
+
+	return nil
+}
+
+// Stop stops watching for changes. Pending changes are discarded. OnChange
+// is not called after Stop returns. Stop must not be called from OnChange.
+func (w *Watcher) Stop() {
+	if w.watcher == nil {
+		return
+	}
+
+	close(w.done)
+	w.watcher.Close()
+
+	w.mutex.Lock()
+	w.stopTimer()
+	// discard a flush that is already running but has not yet taken the lock
+	w.flushSeq++
+	w.changed = make(map[string]struct{})
+	w.removed = make(map[string]struct{})
+	w.mutex.Unlock()
+
+	// wait for the event loop and any running flush
+	w.wg.Wait()
+
+	w.watcher = nil
+	w.paths = nil
+}
+
+// stopTimer stops the pending flush timer, if any. Must be called with the
+// mutex held.
+func (w *Watcher) stopTimer() {
+	if w.timer == nil {
+		return
+	}
+
+	if w.timer.Stop() {
+		// the flush will not run
+		w.wg.Done()
+	}
+	w.timer = nil
+}
+
+// Paths returns the paths being watched, excluding subdirectories.
+func (w *Watcher) Paths() []string {
+	return w.paths
+}
+
+func (w *Watcher) addRecursive(path string) {
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			logger.Warnf("error walking %s for watching: %v", p, err)
+			return nil
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if err := w.watcher.Add(p); err != nil {
+			logger.Warnf("error watching %s: %v", p, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("error watching %s: %v", path, err)
+	}
+}
+
+func (w *Watcher) run() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(event)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Warnf("file watcher error: %v", err)
+		}
+	}
+}
+
+func (w *Watcher) handleEvent(event fsnotify.Event) {
+	dir := filepath.Dir(event.Name)
+
+	switch {
+	case event.Has(fsnotify.Create):
+		// watch new directories
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			w.addRecursive(event.Name)
+		}
+		w.record(dir, false)
+	case event.Has(fsnotify.Write):
+		w.record(dir, false)
+	case event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+		w.record(dir, true)
+	}
+}
+
+func (w *Watcher) record(dir string, removed bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	select {
+	case <-w.done:
+		// stopped while handling the event
+		return
+	default:
+	}
+
+	if removed {
+		w.removed[dir] = struct{}{}
+	} else {
+		w.changed[dir] = struct{}{}
+	}
+
+	w.stopTimer()
+	w.flushSeq++
+	seq := w.flushSeq
+	w.wg.Add(1)
+	w.timer = time.AfterFunc(w.Debounce, func() {
+		w.flush(seq)
+	})
+}
+
+func (w *Watcher) flush(seq uint64) {
+	defer w.wg.Done()
+
+	w.mutex.Lock()
+	if seq != w.flushSeq {
+		w.mutex.Unlock()
+		return
+	}
+
+	changes := WatchChanges{
+		Changed: topLevelPaths(w.changed),
+		Removed: topLevelPaths(w.removed),
+	}
+	w.changed = make(map[string]struct{})
+	w.removed = make(map[string]struct{})
+	w.timer = nil
+	w.mutex.Unlock()
+
+	if w.OnChange != nil {
+		w.OnChange(changes)
+	}
+}
+
+// topLevelPaths returns the sorted paths, excluding any path that is a
+// subdirectory of another path in the set.
+func topLevelPaths(paths map[string]struct{}) []string {
+	var sorted []string
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	var ret []string
+	for _, p := range sorted {
+		if !containsParentPath(ret, p) {
+			ret = append(ret, p)
+		}
+	}
+
+	return ret
+}
+
+func containsParentPath(parents []string, path string) bool {
+	for _, parent := range parents {
+		prefix := strings.TrimSuffix(parent, string(filepath.Separator)) + string(filepath.Separator)
+		if path == parent || strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
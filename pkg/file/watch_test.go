@@ -0,0 +1,166 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testWatchDebounce = 50 * time.Millisecond
+
+func startTestWatcher(t *testing.T, dir string, onChange func(changes WatchChanges)) *Watcher {
+	t.Helper()
+
+	w := NewWatcher(testWatchDebounce, onChange)
+	if err := w.Start([]string{dir}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(w.Stop)
+
+	return w
+}
+
+func waitForChanges(t *testing.T, changes <-chan WatchChanges) WatchChanges {
+	t.Helper()
+
+	select {
+	case c := <-changes:
+		return c
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for changes")
+		return WatchChanges{}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	subDir := filepath.Join(dir, "sub")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := make(chan WatchChanges, 10)
+	startTestWatcher(t, dir, func(c WatchChanges) {
+		changes <- c
+	})
+
+	// changes in existing subdirectories are reported
+	fn := filepath.Join(subDir, "file.mp4")
+	if err := os.WriteFile(fn, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := waitForChanges(t, changes)
+	assert.Equal(t, []string{subDir}, c.Changed)
+	assert.Empty(t, c.Removed)
+
+	// removed files are reported separately
+	if err := os.Remove(fn); err != nil {
+		t.Fatal(err)
+	}
+
+	c = waitForChanges(t, changes)
+	assert.Empty(t, c.Changed)
+	assert.Equal(t, []string{subDir}, c.Removed)
+
+	// new directories are watched
+	newDir := filepath.Join(dir, "new")
+	if err := os.Mkdir(newDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c = waitForChanges(t, changes)
+	assert.Equal(t, []string{dir}, c.Changed)
+
+	if err := os.WriteFile(filepath.Join(newDir, "file.mp4"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c = waitForChanges(t, changes)
+	assert.Equal(t, []string{newDir}, c.Changed)
+}
+
+func TestWatcherStop(t *testing.T) {
+	dir := t.TempDir()
+
+	var called atomic.Bool
+	w := startTestWatcher(t, dir, func(c WatchChanges) {
+		called.Store(true)
+	})
+
+	if err := os.WriteFile(filepath.Join(dir, "file.mp4"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the event to be recorded
+	assert.Eventually(t, func() bool {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		return w.timer != nil
+	}, 5*time.Second, time.Millisecond)
+
+	w.Stop()
+
+	// pending changes are discarded
+	time.Sleep(4 * testWatchDebounce)
+	assert.False(t, called.Load())
+
+	// stopping again does nothing
+	w.Stop()
+}
+
+func TestWatcherStopDuringFlush(t *testing.T) {
+	dir := t.TempDir()
+
+	var called atomic.Bool
+	w := startTestWatcher(t, dir, func(c WatchChanges) {
+		called.Store(true)
+	})
+
+	w.record(dir, false)
+
+	// let the timer fire while the flush is blocked
+	w.mutex.Lock()
+	time.Sleep(4 * testWatchDebounce)
+
+	stopped := make(chan struct{})
+	go func() {
+		w.Stop()
+		close(stopped)
+	}()
+
+	// Stop waits for the flush, which only proceeds once the lock is released
+	time.Sleep(testWatchDebounce)
+	w.mutex.Unlock()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Stop")
+	}
+
+	// if the flush took the lock before Stop, it completed before Stop
+	// returned. Either way, no changes are reported after Stop returns.
+	calledAtStop := called.Load()
+	time.Sleep(4 * testWatchDebounce)
+	assert.Equal(t, calledAtStop, called.Load())
+}
+
+func TestTopLevelPaths(t *testing.T) {
+	paths := map[string]struct{}{
+		filepath.Join("a", "b"):      {},
+		"a":                          {},
+		filepath.Join("ab", "c"):     {},
+		filepath.Join("d", "e", "f"): {},
+	}
+
+	assert.Equal(t, []string{
+		"a",
+		filepath.Join("ab", "c"),
+		filepath.Join("d", "e", "f"),
+	}, topLevelPaths(paths))
+}
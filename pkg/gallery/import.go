@@ -26,6 +26,8 @@ type Importer struct {
 	FolderFinder        models.FolderFinder
 	Input               jsonschema.Gallery
 	MissingRefBehaviour models.ImportMissingRefEnum
+	// RestoreID is the id to create the gallery with, if set
+	RestoreID int
 
 	ID      int
 	gallery models.Gallery
@@ -351,18 +353,21 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 	for _, f := range i.gallery.Files.List() {
 		fileIDs = append(fileIDs, f.Base().ID)
 	}
+	i.gallery.ID = i.RestoreID
 	err := i.ReaderWriter.Create(ctx, &i.gallery, fileIDs)
 	if err != nil {
 		return nil, fmt.Errorf("error creating gallery: %v", err)
 	}
 
 	id := i.gallery.ID
+	i.ID = id
 	return &id, nil
 }
 
 func (i *Importer) Update(ctx context.Context, id int) error {
 	gallery := i.gallery
 	gallery.ID = id
+	i.ID = id
 	err := i.ReaderWriter.Update(ctx, &gallery)
 	if err != nil {
 		return fmt.Errorf("error updating existing gallery: %v", err)
@@ -15,12 +15,20 @@ type FileDeleter struct {
 	*file.Deleter
 
 	Paths *paths.Paths
+	// ThumbnailSize is the size of generated thumbnails. Defaults to
+	// models.DefaultGthumbWidth if zero.
+	ThumbnailSize int
 }
 
 // MarkGeneratedFiles marks for deletion the generated files for the provided image.
 func (d *FileDeleter) MarkGeneratedFiles(image *models.Image) error {
 	var files []string
-	thumbPath := d.Paths.Generated.GetThumbnailPath(image.Checksum, models.DefaultGthumbWidth)
+	thumbSize := d.ThumbnailSize
+	if thumbSize <= 0 {
+		thumbSize = models.DefaultGthumbWidth
+	}
+
+	thumbPath := d.Paths.Generated.GetThumbnailPath(image.Checksum, thumbSize)
 	exists, _ := fsutil.FileExists(thumbPath)
 	if exists {
 		files = append(files, thumbPath)
@@ -0,0 +1,54 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// register decoders for the image formats supported by the pure Go
+	// thumbnailer
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp"
+)
+
+const goImageQuality = 70
+
+// goImageThumbnail returns a JPEG thumbnail of the image in data, resized so
+// that its largest dimension is no larger than maxSize. It is used when vips
+// is not available, and supports JPEG, PNG, GIF and WebP images.
+func goImageThumbnail(data []byte, maxSize int) ([]byte, error) {
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() > maxSize || bounds.Dy() > maxSize {
+		img = imaging.Fit(img, maxSize, maxSize, imaging.Lanczos)
+	}
+
+	// flatten any transparency onto a white background
+	if !isOpaque(img) {
+		bg := imaging.New(img.Bounds().Dx(), img.Bounds().Dy(), color.White)
+		img = imaging.Overlay(bg, img, image.Point{}, 1)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: goImageQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	return false
+}
@@ -41,6 +41,7 @@ type GalleryFinderCreator interface {
 
 type ScanConfig interface {
 	GetCreateGalleriesFromFolders() bool
+	GetImageThumbnailSize() int
 }
 
 type ScanGenerator interface {
@@ -150,7 +151,7 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 
 		if oldHash != "" && newHash != "" && oldHash != newHash {
 			// remove cache dir of gallery
-			_ = os.Remove(h.Paths.Generated.GetThumbnailPath(oldHash, models.DefaultGthumbWidth))
+			_ = os.Remove(h.Paths.Generated.GetThumbnailPath(oldHash, h.ScanConfig.GetImageThumbnailSize()))
 		}
 	}
 
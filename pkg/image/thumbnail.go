@@ -14,6 +14,7 @@ import (
 	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -67,6 +68,8 @@ func NewThumbnailEncoder(ffmpegEncoder *ffmpeg.FFMpeg, ffProbe ffmpeg.FFProbe, c
 
 // GetThumbnail returns the thumbnail image of the provided image resized to
 // the provided max size. It resizes based on the largest X/Y direction.
+// Images are resized using vips if available, falling back to resizing in Go
+// and then to ffmpeg.
 // It returns nil and an error if an error occurs reading, decoding or encoding
 // the image, or if the image is not suitable for thumbnails.
 func (e *ThumbnailEncoder) GetThumbnail(f models.File, maxSize int) ([]byte, error) {
@@ -105,10 +108,21 @@ func (e *ThumbnailEncoder) GetThumbnail(f models.File, maxSize int) ([]byte, err
 
 	// vips has issues loading files from stdin on Windows
 	if e.vips != nil && runtime.GOOS != "windows" {
-		return e.vips.ImageThumbnail(buf, maxSize)
-	} else {
-		return e.ffmpegImageThumbnail(buf, maxSize)
+		ret, err := e.vips.ImageThumbnail(bytes.NewBuffer(data), maxSize)
+		if err == nil {
+			return ret, nil
+		}
+
+		logger.Debugf("vips failed to generate thumbnail for %s, using fallback: %v", f.Base().Path, err)
 	}
+
+	// fall back to resizing in Go, then to ffmpeg for formats that Go
+	// cannot decode
+	if ret, err := goImageThumbnail(data, maxSize); err == nil {
+		return ret, nil
+	}
+
+	return e.ffmpegImageThumbnail(bytes.NewBuffer(data), maxSize)
 }
 
 // GetPreview returns the preview clip of the provided image clip resized to
@@ -28,6 +28,17 @@ func MakeJobExec(fn JobExecFn) JobExec {
 	}
 }
 
+type contextKey int
+
+const jobIDKey contextKey = iota
+
+// GetJobID returns the ID of the job executing with the provided context. The
+// second return value is false if the context is not from a job.
+func GetJobID(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(jobIDKey).(int)
+	return id, ok
+}
+
 // Status is the status of a Job
 type Status string
 
@@ -51,33 +62,65 @@ type Job struct {
 	ID     int
 	Status Status
 	// details of the current operations of the job
-	Details     []string
-	Description string
+	Details []string
+	// DetailsProgress is the progress of each of the current operations
+	// in Details, from 0 to 1, or ProgressIndefinite if not known.
+	DetailsProgress []float64
+	Description     string
 	// Progress in terms of 0 - 1.
-	Progress  float64
+	Progress float64
+	// Processed and Total are the number of work units completed and the
+	// total number of work units. Total is 0 if the progress is indefinite.
+	Processed int
+	Total     int
+	// Phases is the number of work units completed in each named phase
+	// of the job, in the order the phases were first reported.
+	Phases    []Phase
 	StartTime *time.Time
 	EndTime   *time.Time
 	AddTime   time.Time
 	Error     *string
+	// Result is the outcome reported by the job, such as the location of a
+	// file it generated. Nil if the job has not reported a result.
+	Result *string
 
 	outerCtx   context.Context
 	exec       JobExec
 	cancelFunc context.CancelFunc
 }
 
+// Phase is the progress of a named phase of a job.
+type Phase struct {
+	Name      string
+	Processed int
+}
+
 // TimeElapsed returns the total time elapsed for the job.
 // If the EndTime is set, then it uses this to calculate the elapsed time, otherwise it uses time.Now.
 func (j *Job) TimeElapsed() time.Duration {
 	var end time.Time
 	if j.EndTime != nil {
-		end = time.Now()
-	} else {
 		end = *j.EndTime
+	} else {
+		end = time.Now()
 	}
 
 	return end.Sub(*j.StartTime)
 }
 
+// TimeRemaining returns the estimated time remaining for a running job,
+// extrapolated from the elapsed time and the current progress. Returns nil
+// if the job is not running or the progress is not known.
+func (j *Job) TimeRemaining() *time.Duration {
+	if j.Status != StatusRunning || j.StartTime == nil || j.Progress <= 0 || j.Progress >= 1 {
+		return nil
+	}
+
+	elapsed := j.TimeElapsed()
+	ret := time.Duration(float64(elapsed) * (1 - j.Progress) / j.Progress)
+	return &ret
+}
+
 func (j *Job) cancel() {
 	if j.Status == StatusReady {
 		j.Status = StatusCancelled
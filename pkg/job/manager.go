@@ -25,9 +25,18 @@ type Manager struct {
 	lastID int
 
 	subscriptions       []*ManagerSubscription
+	hooks               []Hook
 	updateThrottleLimit time.Duration
 }
 
+// Hook is notified when jobs start and finish executing. The methods are
+// called from the goroutine executing the job, so that the job does not
+// start or finish until they return.
+type Hook interface {
+	JobStarted(j Job)
+	JobFinished(j Job)
+}
+
 // NewManager initialises and returns a new Manager.
 func NewManager() *Manager {
 	ret := &Manager{
@@ -49,6 +58,14 @@ func (m *Manager) Stop() {
 	close(m.stop)
 }
 
+// AddHook adds a hook that is notified when jobs start and finish executing.
+func (m *Manager) AddHook(h Hook) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.hooks = append(m.hooks, h)
+}
+
 // Add queues a job.
 func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
 	m.mutex.Lock()
@@ -78,7 +95,7 @@ func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
 }
 
 // Start adds a job and starts it immediately, concurrently with any other
-// jobs.
+// jobs. The job is removed from the queue when it finishes.
 func (m *Manager) Start(ctx context.Context, description string, e JobExec) int {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -95,8 +112,17 @@ func (m *Manager) Start(ctx context.Context, description string, e JobExec) int
 	}
 
 	m.queue = append(m.queue, &j)
+	m.notifyNewJob(&j)
+
+	done := m.dispatch(ctx, &j)
+
+	go func() {
+		<-done
 
-	m.dispatch(ctx, &j)
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		m.removeJob(&j)
+	}()
 
 	return j.ID
 }
@@ -182,6 +208,8 @@ func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
 	ctx, cancelFunc := context.WithCancel(utils.ValueOnlyContext{Context: ctx})
 	j.cancelFunc = cancelFunc
 
+	ctx = context.WithValue(ctx, jobIDKey, j.ID)
+
 	done = make(chan struct{})
 	go m.executeJob(ctx, j, done)
 
@@ -192,6 +220,7 @@ func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
 
 func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
 	defer close(done)
+	defer m.notifyHooks(j, Hook.JobFinished)
 	defer m.onJobFinish(j)
 	defer func() {
 		if p := recover(); p != nil {
@@ -205,6 +234,8 @@ func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
 		}
 	}()
 
+	m.notifyHooks(j, Hook.JobStarted)
+
 	progress := m.newProgress(j)
 	if err := j.exec.Execute(ctx, progress); err != nil {
 		logger.Errorf("task failed due to error: %v", err)
@@ -212,6 +243,17 @@ func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
 	}
 }
 
+func (m *Manager) notifyHooks(j *Job, fn func(h Hook, j Job)) {
+	m.mutex.Lock()
+	hooks := m.hooks
+	jj := *j
+	m.mutex.Unlock()
+
+	for _, h := range hooks {
+		fn(h, jj)
+	}
+}
+
 func (m *Manager) onJobFinish(job *Job) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -234,6 +276,7 @@ func (m *Manager) removeJob(job *Job) {
 
 	// clear any subtasks
 	job.Details = nil
+	job.DetailsProgress = nil
 
 	m.queue = append(m.queue[:index], m.queue[index+1:]...)
 
@@ -284,6 +327,31 @@ func (m *Manager) CancelJob(id int) {
 	}
 }
 
+// PrioritiseJob moves the job with the provided id ahead of all other jobs
+// that have not yet started, so that it is the next job to be executed.
+// Returns false if no job exists with the provided id or if the job has
+// already started.
+func (m *Manager) PrioritiseJob(id int) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	index, j := m.getJob(m.queue, id)
+	if j == nil || j.Status != StatusReady {
+		return false
+	}
+
+	// find the first job that has not yet started
+	first := 0
+	for first < index && m.queue[first].Status != StatusReady {
+		first++
+	}
+
+	copy(m.queue[first+1:index+1], m.queue[first:index])
+	m.queue[first] = j
+
+	return true
+}
+
 // CancelAll cancels all of the jobs in the queue. This is the same as
 // calling CancelJob on all jobs in the queue.
 func (m *Manager) CancelAll() {
@@ -392,12 +460,34 @@ func (u *updater) notifyUpdate() {
 	u.updateTimer = nil
 }
 
-func (u *updater) updateProgress(progress float64, details []string) {
+type progressUpdate struct {
+	percent   float64
+	processed int
+	total     int
+	phases    []Phase
+	details   []string
+
+	detailsProgress []float64
+}
+
+func (u *updater) setResult(result string) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	u.job.Result = &result
+	u.notifyUpdate()
+}
+
+func (u *updater) updateProgress(update progressUpdate) {
 	u.m.mutex.Lock()
 	defer u.m.mutex.Unlock()
 
-	u.job.Progress = progress
-	u.job.Details = details
+	u.job.Progress = update.percent
+	u.job.Processed = update.processed
+	u.job.Total = update.total
+	u.job.Phases = update.phases
+	u.job.Details = update.details
+	u.job.DetailsProgress = update.detailsProgress
 
 	if time.Since(u.lastUpdate) < u.m.updateThrottleLimit {
 		if u.updateTimer == nil {
@@ -126,6 +126,46 @@ func TestAdd(t *testing.T) {
 	assert.NotNil(j2.StartTime)
 }
 
+func TestStart(t *testing.T) {
+	m := NewManager()
+
+	// queue a job that runs until finished
+	queued := newTestExec(make(chan struct{}))
+	queuedID := m.Add(context.Background(), "queued", queued)
+
+	exec := newTestExec(make(chan struct{}))
+	jobID := m.Start(context.Background(), "started", exec)
+
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	// expect the job to start alongside the queued job
+	select {
+	case <-exec.started:
+		// ok
+	default:
+		t.Error("exec was not started")
+	}
+
+	assert.Equal(StatusRunning, m.GetJob(queuedID).Status)
+	assert.Equal(StatusRunning, m.GetJob(jobID).Status)
+
+	close(exec.finish)
+
+	time.Sleep(sleepTime)
+
+	// expect the job to be removed from the queue
+	for _, j := range m.GetQueue() {
+		assert.NotEqual(jobID, j.ID)
+	}
+
+	j := m.GetJob(jobID)
+	assert.Equal(StatusFinished, j.Status)
+
+	close(queued.finish)
+}
+
 func TestCancel(t *testing.T) {
 	m := NewManager()
 
@@ -343,3 +383,88 @@ func TestSubscribe(t *testing.T) {
 
 	cancel()
 }
+
+func TestPrioritiseJob(t *testing.T) {
+	m := NewManager()
+
+	// add a running job and three queued jobs
+	exec1 := newTestExec(make(chan struct{}))
+	jobID := m.Add(context.Background(), "test job", exec1)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	job2ID := m.Add(context.Background(), "job 2", newTestExec(make(chan struct{})))
+	job3ID := m.Add(context.Background(), "job 3", newTestExec(make(chan struct{})))
+	exec4 := newTestExec(make(chan struct{}))
+	job4ID := m.Add(context.Background(), "job 4", exec4)
+
+	assert := assert.New(t)
+
+	// running jobs cannot be prioritised
+	assert.False(m.PrioritiseJob(jobID))
+	assert.False(m.PrioritiseJob(999))
+
+	assert.True(m.PrioritiseJob(job4ID))
+
+	var ids []int
+	for _, j := range m.GetQueue() {
+		ids = append(ids, j.ID)
+	}
+
+	assert.Equal([]int{jobID, job4ID, job2ID, job3ID}, ids)
+
+	// allow first job to finish
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the prioritised job to have started
+	select {
+	case <-exec4.started:
+		// ok
+	default:
+		t.Error("exec was not started")
+	}
+}
+
+type testHook struct {
+	started  chan Job
+	finished chan Job
+}
+
+func (h *testHook) JobStarted(j Job) {
+	h.started <- j
+}
+
+func (h *testHook) JobFinished(j Job) {
+	h.finished <- j
+}
+
+func TestHook(t *testing.T) {
+	m := NewManager()
+
+	hook := &testHook{
+		started:  make(chan Job, 1),
+		finished: make(chan Job, 1),
+	}
+	m.AddHook(hook)
+
+	finish := make(chan struct{})
+	exec := newTestExec(finish)
+	jobID := m.Add(context.Background(), "test job", exec)
+
+	assert := assert.New(t)
+
+	started := <-hook.started
+	assert.Equal(jobID, started.ID)
+	assert.Equal(StatusRunning, started.Status)
+
+	close(finish)
+
+	finished := <-hook.finished
+	assert.Equal(jobID, finished.ID)
+	assert.Equal(StatusFinished, finished.Status)
+	assert.NotNil(finished.EndTime)
+}
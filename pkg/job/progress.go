@@ -14,6 +14,7 @@ type Progress struct {
 	total        int
 	percent      float64
 	currentTasks []*task
+	phases       []Phase
 
 	mutex   sync.Mutex
 	updater *updater
@@ -21,15 +22,34 @@ type Progress struct {
 
 type task struct {
 	description string
+	percent     float64
 }
 
 func (p *Progress) updated() {
 	var details []string
+	var detailsProgress []float64
 	for _, t := range p.currentTasks {
 		details = append(details, t.description)
+		detailsProgress = append(detailsProgress, t.percent)
 	}
 
-	p.updater.updateProgress(p.percent, details)
+	total := p.total
+	if !p.defined {
+		total = 0
+	}
+
+	phases := make([]Phase, len(p.phases))
+	copy(phases, p.phases)
+
+	p.updater.updateProgress(progressUpdate{
+		percent:   p.percent,
+		processed: p.processed,
+		total:     total,
+		phases:    phases,
+		details:   details,
+
+		detailsProgress: detailsProgress,
+	})
 }
 
 // Indefinite sets the progress to an indefinite amount.
@@ -142,6 +162,25 @@ func (p *Progress) AddProcessed(v int) {
 	p.calculatePercent()
 }
 
+// IncrementPhase increments the number of work units completed in the named
+// phase of the job. This is used to report a breakdown of the work done by
+// the job, and does not affect the progress percentage.
+func (p *Progress) IncrementPhase(phase string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for i := range p.phases {
+		if p.phases[i].Name == phase {
+			p.phases[i].Processed++
+			p.updated()
+			return
+		}
+	}
+
+	p.phases = append(p.phases, Phase{Name: phase, Processed: 1})
+	p.updated()
+}
+
 func (p *Progress) addTask(t *task) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -163,14 +202,46 @@ func (p *Progress) removeTask(t *task) {
 	}
 }
 
+func (p *Progress) setTaskPercent(t *task, percent float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if percent < 0 {
+		percent = 0
+	} else if percent > 1 {
+		percent = 1
+	}
+
+	t.percent = percent
+	p.updated()
+}
+
+// SetResult sets the Result of the job.
+func (p *Progress) SetResult(result string) {
+	p.updater.setResult(result)
+}
+
 // ExecuteTask executes a task as part of a job. The description is used to
 // populate the Details slice in the parent Job.
 func (p *Progress) ExecuteTask(description string, fn func()) {
+	p.ExecuteTaskProgress(description, func(func(float64)) {
+		fn()
+	})
+}
+
+// ExecuteTaskProgress executes a task as ExecuteTask, passing fn a function
+// to report the progress of the task, from 0 to 1. The progress is used to
+// populate the DetailsProgress slice in the parent Job, and does not affect
+// the progress of the job.
+func (p *Progress) ExecuteTaskProgress(description string, fn func(setPercent func(percent float64))) {
 	t := &task{
 		description: description,
+		percent:     ProgressIndefinite,
 	}
 
 	p.addTask(t)
 	defer p.removeTask(t)
-	fn()
+	fn(func(percent float64) {
+		p.setTaskPercent(t, percent)
+	})
 }
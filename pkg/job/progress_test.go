@@ -148,3 +148,109 @@ func TestExecuteTask(t *testing.T) {
 	assert.Len(j.Details, 0)
 	m.mutex.Unlock()
 }
+
+func TestExecuteTaskProgress(t *testing.T) {
+	m := NewManager()
+	j := &Job{}
+
+	p := createProgress(m, j)
+
+	c := make(chan struct{})
+	setPercentChan := make(chan func(float64), 1)
+
+	go p.ExecuteTaskProgress("taskDescription", func(setPercent func(float64)) {
+		setPercentChan <- setPercent
+		<-c
+	})
+
+	setPercent := <-setPercentChan
+
+	assert := assert.New(t)
+
+	m.mutex.Lock()
+	// progress is not known until set
+	assert.Equal([]float64{ProgressIndefinite}, j.DetailsProgress)
+	m.mutex.Unlock()
+
+	setPercent(0.5)
+
+	m.mutex.Lock()
+	assert.Equal([]float64{0.5}, j.DetailsProgress)
+	// job progress is unchanged
+	assert.Equal(10, j.Processed)
+	m.mutex.Unlock()
+
+	// percent is constrained between 0 and 1
+	setPercent(2)
+
+	m.mutex.Lock()
+	assert.Equal([]float64{1}, j.DetailsProgress)
+	m.mutex.Unlock()
+
+	close(c)
+
+	time.Sleep(sleepTime)
+
+	m.mutex.Lock()
+	assert.Len(j.DetailsProgress, 0)
+	m.mutex.Unlock()
+}
+
+func TestProgressIncrementPhase(t *testing.T) {
+	m := NewManager()
+	j := &Job{}
+
+	p := createProgress(m, j)
+
+	p.IncrementPhase("hashing")
+	p.IncrementPhase("probing")
+	p.IncrementPhase("hashing")
+
+	assert := assert.New(t)
+
+	assert.Equal([]Phase{
+		{Name: "hashing", Processed: 2},
+		{Name: "probing", Processed: 1},
+	}, j.Phases)
+	assert.Equal(10, j.Processed)
+	assert.Equal(100, j.Total)
+}
+
+func TestProgressSetResult(t *testing.T) {
+	m := NewManager()
+	j := &Job{}
+
+	p := createProgress(m, j)
+
+	assert := assert.New(t)
+	assert.Nil(j.Result)
+
+	p.SetResult("result")
+
+	if assert.NotNil(j.Result) {
+		assert.Equal("result", *j.Result)
+	}
+}
+
+func TestJobTimeRemaining(t *testing.T) {
+	start := time.Now().Add(-10 * time.Second)
+	j := &Job{
+		Status:    StatusRunning,
+		StartTime: &start,
+		Progress:  0.5,
+	}
+
+	assert := assert.New(t)
+
+	remaining := j.TimeRemaining()
+	if assert.NotNil(remaining) {
+		assert.InDelta(10*time.Second, *remaining, float64(time.Second))
+	}
+
+	j.Progress = ProgressIndefinite
+	assert.Nil(j.TimeRemaining())
+
+	j.Progress = 0.5
+	j.Status = StatusFinished
+	assert.Nil(j.TimeRemaining())
+}
@@ -10,6 +10,10 @@ const singleFirstCharacterRegex = `^[\p{L}][.\-_ ]`
 
 // Cache is used to cache queries that should not change across an autotag process.
 type Cache struct {
+	// PerformerAliases enables matching of performer aliases in addition to
+	// performer names. Alias matching is disabled if the cache is nil.
+	PerformerAliases bool
+
 	singleCharPerformers []*models.Performer
 	singleCharStudios    []*models.Studio
 	singleCharTags       []*models.Tag
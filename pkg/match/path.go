@@ -127,7 +127,8 @@ func regexpMatchesPath(r *regexp.Regexp, path string) int {
 }
 
 func getPerformers(ctx context.Context, words []string, performerReader models.PerformerAutoTagQueryer, cache *Cache) ([]*models.Performer, error) {
-	performers, err := performerReader.QueryForAutoTag(ctx, words)
+	includeAliases := cache != nil && cache.PerformerAliases
+	performers, err := performerReader.QueryForAutoTag(ctx, words, includeAliases)
 	if err != nil {
 		return nil, err
 	}
@@ -155,20 +156,20 @@ func PathToPerformers(ctx context.Context, path string, reader models.PerformerA
 			matches = true
 		}
 
-		// TODO - disabled alias matching until we can get finer
-		// control over the matching
-		// if !matches {
-		// 	if err := p.LoadAliases(ctx, reader); err != nil {
-		// 		return nil, err
-		// 	}
-
-		// 	for _, alias := range p.Aliases.List() {
-		// 		if nameMatchesPath(alias, path) != -1 {
-		// 			matches = true
-		// 			break
-		// 		}
-		// 	}
-		// }
+		// alias matching is opt-in, since aliases are more likely to
+		// produce false positives
+		if !matches && cache != nil && cache.PerformerAliases {
+			if err := p.LoadAliases(ctx, reader); err != nil {
+				return nil, err
+			}
+
+			for _, alias := range p.Aliases.List() {
+				if nameMatchesPath(alias, path) != -1 {
+					matches = true
+					break
+				}
+			}
+		}
 
 		if matches {
 			ret = append(ret, p)
@@ -0,0 +1,18 @@
+package models
+
+import "context"
+
+type AuditEntryReader interface {
+	// Query returns the entries matching the filter, most recent first, and
+	// the total number of matching entries.
+	Query(ctx context.Context, filter *AuditEntryFilterType, findFilter *FindFilterType) ([]*AuditEntry, int, error)
+}
+
+type AuditEntryWriter interface {
+	Create(ctx context.Context, obj *AuditEntry) error
+}
+
+type AuditEntryReaderWriter interface {
+	AuditEntryReader
+	AuditEntryWriter
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type DeletedObjectReader interface {
+	Find(ctx context.Context, id int) (*DeletedObject, error)
+	FindMany(ctx context.Context, ids []int) ([]*DeletedObject, error)
+	// All returns all deleted objects, most recently deleted first.
+	All(ctx context.Context) ([]*DeletedObject, error)
+}
+
+type DeletedObjectWriter interface {
+	Create(ctx context.Context, obj *DeletedObject) error
+	Destroy(ctx context.Context, id int) error
+	// DestroyBefore destroys objects deleted before the provided time,
+	// returning the number of objects destroyed.
+	DestroyBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+type DeletedObjectReaderWriter interface {
+	DeletedObjectReader
+	DeletedObjectWriter
+}
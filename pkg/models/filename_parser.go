@@ -5,6 +5,7 @@ type SceneParserInput struct {
 	WhitespaceCharacters *string  `json:"whitespaceCharacters"`
 	CapitalizeTitle      *bool    `json:"capitalizeTitle"`
 	IgnoreOrganized      *bool    `json:"ignoreOrganized"`
+	Regex                *bool    `json:"regex"`
 }
 
 type SceneParserResult struct {
@@ -33,6 +33,10 @@ type GeneratePreviewOptions struct {
 	PreviewExcludeEnd *string `json:"previewExcludeEnd"`
 	// Preset when generating preview
 	PreviewPreset *PreviewPreset `json:"previewPreset"`
+	// Width of the preview, in pixels
+	PreviewWidth *int `json:"previewWidth"`
+	// Include audio stream in the preview
+	PreviewAudio *bool `json:"previewAudio"`
 }
 
 type PreviewPreset string
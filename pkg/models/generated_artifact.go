@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// GeneratedArtifactType is a type of generated file that is tracked per scene.
+type GeneratedArtifactType string
+
+const (
+	GeneratedArtifactTypeSprite             GeneratedArtifactType = "SPRITE"
+	GeneratedArtifactTypePreview            GeneratedArtifactType = "PREVIEW"
+	GeneratedArtifactTypeImagePreview       GeneratedArtifactType = "IMAGE_PREVIEW"
+	GeneratedArtifactTypeTranscode          GeneratedArtifactType = "TRANSCODE"
+	GeneratedArtifactTypeInteractiveHeatmap GeneratedArtifactType = "INTERACTIVE_HEATMAP"
+)
+
+var AllGeneratedArtifactType = []GeneratedArtifactType{
+	GeneratedArtifactTypeSprite,
+	GeneratedArtifactTypePreview,
+	GeneratedArtifactTypeImagePreview,
+	GeneratedArtifactTypeTranscode,
+	GeneratedArtifactTypeInteractiveHeatmap,
+}
+
+func (e GeneratedArtifactType) IsValid() bool {
+	switch e {
+	case GeneratedArtifactTypeSprite, GeneratedArtifactTypePreview, GeneratedArtifactTypeImagePreview, GeneratedArtifactTypeTranscode, GeneratedArtifactTypeInteractiveHeatmap:
+		return true
+	}
+	return false
+}
+
+func (e GeneratedArtifactType) String() string {
+	return string(e)
+}
+
+func (e *GeneratedArtifactType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GeneratedArtifactType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GeneratedArtifactType", str)
+	}
+	return nil
+}
+
+func (e GeneratedArtifactType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// GeneratedArtifact records that a generated file was successfully created
+// for a scene. Hash is the scene hash used to name the file, so a record is
+// only valid while the scene hash is unchanged.
+type GeneratedArtifact struct {
+	Type        GeneratedArtifactType `json:"type"`
+	Hash        string                `json:"hash"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// FindGeneratedArtifact returns the artifact of type t generated using hash,
+// or nil if it is not present.
+func FindGeneratedArtifact(artifacts []GeneratedArtifact, t GeneratedArtifactType, hash string) *GeneratedArtifact {
+	if hash == "" {
+		return nil
+	}
+
+	for i := range artifacts {
+		if artifacts[i].Type == t && artifacts[i].Hash == hash {
+			return &artifacts[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// HardwareEncoder is a family of hardware video encoders supported by ffmpeg.
+type HardwareEncoder string
+
+const (
+	// Use the first supported hardware encoder
+	HardwareEncoderAuto HardwareEncoder = "AUTO"
+	// NVIDIA NVENC
+	HardwareEncoderNvenc HardwareEncoder = "NVENC"
+	// Intel Quick Sync Video
+	HardwareEncoderQsv HardwareEncoder = "QSV"
+	// Video Acceleration API
+	HardwareEncoderVaapi HardwareEncoder = "VAAPI"
+	// Apple VideoToolbox
+	HardwareEncoderVideotoolbox HardwareEncoder = "VIDEOTOOLBOX"
+	// Video4Linux2 memory-to-memory
+	HardwareEncoderV4l2m2m HardwareEncoder = "V4L2M2M"
+)
+
+var AllHardwareEncoder = []HardwareEncoder{
+	HardwareEncoderAuto,
+	HardwareEncoderNvenc,
+	HardwareEncoderQsv,
+	HardwareEncoderVaapi,
+	HardwareEncoderVideotoolbox,
+	HardwareEncoderV4l2m2m,
+}
+
+func (e HardwareEncoder) IsValid() bool {
+	switch e {
+	case HardwareEncoderAuto, HardwareEncoderNvenc, HardwareEncoderQsv, HardwareEncoderVaapi, HardwareEncoderVideotoolbox, HardwareEncoderV4l2m2m:
+		return true
+	}
+	return false
+}
+
+func (e HardwareEncoder) String() string {
+	return string(e)
+}
+
+func (e *HardwareEncoder) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HardwareEncoder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HardwareEncoder", str)
+	}
+	return nil
+}
+
+func (e HardwareEncoder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,45 @@
+package jsonschema
+
+import (
+	"fmt"
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stashapp/stash/pkg/models/json"
+)
+
+// ManifestVersion is the version of the export directory structure and
+// object formats. It should be incremented when a change is made that older
+// versions cannot import.
+const ManifestVersion = 1
+
+// Manifest describes an exported metadata directory.
+type Manifest struct {
+	Version       int           `json:"version"`
+	StashVersion  string        `json:"stash_version,omitempty"`
+	SchemaVersion uint          `json:"schema_version,omitempty"`
+	CreatedAt     json.JSONTime `json:"created_at,omitempty"`
+}
+
+func LoadManifestFile(filePath string) (*Manifest, error) {
+	var manifest Manifest
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	jsonParser := json.NewDecoder(file)
+	err = jsonParser.Decode(&manifest)
+	if err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func SaveManifestFile(filePath string, manifest *Manifest) error {
+	if manifest == nil {
+		return fmt.Errorf("manifest must not be nil")
+	}
+	return marshalToFile(filePath, manifest)
+}
@@ -49,6 +49,8 @@ type Performer struct {
 	PenisLength   float64            `json:"penis_length,omitempty"`
 	Circumcised   string             `json:"circumcised,omitempty"`
 	CareerLength  string             `json:"career_length,omitempty"`
+	CareerStart   int                `json:"career_start,omitempty"`
+	CareerEnd     int                `json:"career_end,omitempty"`
 	Tattoos       string             `json:"tattoos,omitempty"`
 	Piercings     string             `json:"piercings,omitempty"`
 	Aliases       StringOrStringList `json:"aliases,omitempty"`
@@ -33,6 +33,14 @@ type SceneFile struct {
 	Bitrate    int           `json:"bitrate"`
 }
 
+// SceneFileHashes contains the hashes of a scene file. These are used to
+// locate the file on import if it is not found at its exported path.
+type SceneFileHashes struct {
+	Path     string `json:"path"`
+	Checksum string `json:"checksum,omitempty"`
+	Oshash   string `json:"oshash,omitempty"`
+}
+
 type SceneGroup struct {
 	GroupName  string `json:"movieName,omitempty"`
 	SceneIndex int    `json:"scene_index,omitempty"`
@@ -54,17 +62,18 @@ type Scene struct {
 	// deprecated - for import only
 	OCounter int `json:"o_counter,omitempty"`
 
-	Details    string        `json:"details,omitempty"`
-	Director   string        `json:"director,omitempty"`
-	Galleries  []GalleryRef  `json:"galleries,omitempty"`
-	Performers []string      `json:"performers,omitempty"`
-	Groups     []SceneGroup  `json:"movies,omitempty"`
-	Tags       []string      `json:"tags,omitempty"`
-	Markers    []SceneMarker `json:"markers,omitempty"`
-	Files      []string      `json:"files,omitempty"`
-	Cover      string        `json:"cover,omitempty"`
-	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+	Details    string            `json:"details,omitempty"`
+	Director   string            `json:"director,omitempty"`
+	Galleries  []GalleryRef      `json:"galleries,omitempty"`
+	Performers []string          `json:"performers,omitempty"`
+	Groups     []SceneGroup      `json:"movies,omitempty"`
+	Tags       []string          `json:"tags,omitempty"`
+	Markers    []SceneMarker     `json:"markers,omitempty"`
+	Files      []string          `json:"files,omitempty"`
+	FileHashes []SceneFileHashes `json:"file_hashes,omitempty"`
+	Cover      string            `json:"cover,omitempty"`
+	CreatedAt  json.JSONTime     `json:"created_at,omitempty"`
+	UpdatedAt  json.JSONTime     `json:"updated_at,omitempty"`
 
 	// deprecated - for import only
 	LastPlayedAt json.JSONTime `json:"last_played_at,omitempty"`
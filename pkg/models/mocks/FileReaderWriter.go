@@ -222,6 +222,29 @@ func (_m *FileReaderWriter) FindByFingerprint(ctx context.Context, fp models.Fin
 	return r0, r1
 }
 
+// FindByFingerprints provides a mock function with given fields: ctx, fp
+func (_m *FileReaderWriter) FindByFingerprints(ctx context.Context, fp []models.Fingerprint) ([]models.File, error) {
+	ret := _m.Called(ctx, fp)
+
+	var r0 []models.File
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Fingerprint) []models.File); ok {
+		r0 = rf(ctx, fp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.File)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []models.Fingerprint) error); ok {
+		r1 = rf(ctx, fp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByPath provides a mock function with given fields: ctx, path
 func (_m *FileReaderWriter) FindByPath(ctx context.Context, path string) (models.File, error) {
 	ret := _m.Called(ctx, path)
@@ -291,6 +314,29 @@ func (_m *FileReaderWriter) GetCaptions(ctx context.Context, fileID models.FileI
 	return r0, r1
 }
 
+// GetProbeData provides a mock function with given fields: ctx, fileID
+func (_m *FileReaderWriter) GetProbeData(ctx context.Context, fileID models.FileID) ([]byte, error) {
+	ret := _m.Called(ctx, fileID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, models.FileID) []byte); ok {
+		r0 = rf(ctx, fileID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.FileID) error); ok {
+		r1 = rf(ctx, fileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsPrimary provides a mock function with given fields: ctx, fileID
 func (_m *FileReaderWriter) IsPrimary(ctx context.Context, fileID models.FileID) (bool, error) {
 	ret := _m.Called(ctx, fileID)
@@ -303,6 +303,29 @@ func (_m *GalleryReaderWriter) FindByImageID(ctx context.Context, imageID int) (
 	return r0, r1
 }
 
+// FindByParentFolderID provides a mock function with given fields: ctx, parentFolderID
+func (_m *GalleryReaderWriter) FindByParentFolderID(ctx context.Context, parentFolderID models.FolderID) ([]*models.Gallery, error) {
+	ret := _m.Called(ctx, parentFolderID)
+
+	var r0 []*models.Gallery
+	if rf, ok := ret.Get(0).(func(context.Context, models.FolderID) []*models.Gallery); ok {
+		r0 = rf(ctx, parentFolderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Gallery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.FolderID) error); ok {
+		r1 = rf(ctx, parentFolderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByPath provides a mock function with given fields: ctx, path
 func (_m *GalleryReaderWriter) FindByPath(ctx context.Context, path string) ([]*models.Gallery, error) {
 	ret := _m.Called(ctx, path)
@@ -478,13 +478,13 @@ func (_m *PerformerReaderWriter) QueryCount(ctx context.Context, performerFilter
 	return r0, r1
 }
 
-// QueryForAutoTag provides a mock function with given fields: ctx, words
-func (_m *PerformerReaderWriter) QueryForAutoTag(ctx context.Context, words []string) ([]*models.Performer, error) {
-	ret := _m.Called(ctx, words)
+// QueryForAutoTag provides a mock function with given fields: ctx, words, includeAliases
+func (_m *PerformerReaderWriter) QueryForAutoTag(ctx context.Context, words []string, includeAliases bool) ([]*models.Performer, error) {
+	ret := _m.Called(ctx, words, includeAliases)
 
 	var r0 []*models.Performer
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*models.Performer); ok {
-		r0 = rf(ctx, words)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, bool) []*models.Performer); ok {
+		r0 = rf(ctx, words, includeAliases)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Performer)
@@ -492,8 +492,8 @@ func (_m *PerformerReaderWriter) QueryForAutoTag(ctx context.Context, words []st
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, words)
+	if rf, ok := ret.Get(1).(func(context.Context, []string, bool) error); ok {
+		r1 = rf(ctx, words, includeAliases)
 	} else {
 		r1 = ret.Error(1)
 	}
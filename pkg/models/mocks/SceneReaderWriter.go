@@ -127,6 +127,20 @@ func (_m *SceneReaderWriter) AssignFiles(ctx context.Context, sceneID int, fileI
 	return r0
 }
 
+// ClearGeneratedArtifacts provides a mock function with given fields: ctx, sceneID, types
+func (_m *SceneReaderWriter) ClearGeneratedArtifacts(ctx context.Context, sceneID int, types []models.GeneratedArtifactType) error {
+	ret := _m.Called(ctx, sceneID, types)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []models.GeneratedArtifactType) error); ok {
+		r0 = rf(ctx, sceneID, types)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Count provides a mock function with given fields: ctx
 func (_m *SceneReaderWriter) Count(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
@@ -358,6 +372,29 @@ func (_m *SceneReaderWriter) CountViews(ctx context.Context, id int) (int, error
 	return r0, r1
 }
 
+// CountViewsByDay provides a mock function with given fields: ctx, start, end
+func (_m *SceneReaderWriter) CountViewsByDay(ctx context.Context, start *time.Time, end *time.Time) ([]*models.SceneViewDay, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []*models.SceneViewDay
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time) []*models.SceneViewDay); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneViewDay)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, newScene, fileIDs
 func (_m *SceneReaderWriter) Create(ctx context.Context, newScene *models.Scene, fileIDs []models.FileID) error {
 	ret := _m.Called(ctx, newScene, fileIDs)
@@ -635,6 +672,29 @@ func (_m *SceneReaderWriter) FindByOSHash(ctx context.Context, oshash string) ([
 	return r0, r1
 }
 
+// FindByParentFolderID provides a mock function with given fields: ctx, parentFolderID
+func (_m *SceneReaderWriter) FindByParentFolderID(ctx context.Context, parentFolderID models.FolderID) ([]*models.Scene, error) {
+	ret := _m.Called(ctx, parentFolderID)
+
+	var r0 []*models.Scene
+	if rf, ok := ret.Get(0).(func(context.Context, models.FolderID) []*models.Scene); ok {
+		r0 = rf(ctx, parentFolderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Scene)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.FolderID) error); ok {
+		r1 = rf(ctx, parentFolderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByPath provides a mock function with given fields: ctx, path
 func (_m *SceneReaderWriter) FindByPath(ctx context.Context, path string) ([]*models.Scene, error) {
 	ret := _m.Called(ctx, path)
@@ -840,6 +900,29 @@ func (_m *SceneReaderWriter) GetGalleryIDs(ctx context.Context, relatedID int) (
 	return r0, r1
 }
 
+// GetGeneratedArtifacts provides a mock function with given fields: ctx, sceneID
+func (_m *SceneReaderWriter) GetGeneratedArtifacts(ctx context.Context, sceneID int) ([]models.GeneratedArtifact, error) {
+	ret := _m.Called(ctx, sceneID)
+
+	var r0 []models.GeneratedArtifact
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.GeneratedArtifact); ok {
+		r0 = rf(ctx, sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.GeneratedArtifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGroups provides a mock function with given fields: ctx, id
 func (_m *SceneReaderWriter) GetGroups(ctx context.Context, id int) ([]models.GroupsScenes, error) {
 	ret := _m.Called(ctx, id)
@@ -1160,6 +1243,52 @@ func (_m *SceneReaderWriter) GetViewDates(ctx context.Context, relatedID int) ([
 	return r0, r1
 }
 
+// GetViews provides a mock function with given fields: ctx, start, end
+func (_m *SceneReaderWriter) GetViews(ctx context.Context, start *time.Time, end *time.Time) ([]*models.SceneView, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []*models.SceneView
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, *time.Time) []*models.SceneView); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneView)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetViewsOnThisDay provides a mock function with given fields: ctx, date
+func (_m *SceneReaderWriter) GetViewsOnThisDay(ctx context.Context, date time.Time) ([]*models.SceneView, error) {
+	ret := _m.Called(ctx, date)
+
+	var r0 []*models.SceneView
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.SceneView); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneView)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasCover provides a mock function with given fields: ctx, sceneID
 func (_m *SceneReaderWriter) HasCover(ctx context.Context, sceneID int) (bool, error) {
 	ret := _m.Called(ctx, sceneID)
@@ -1309,6 +1438,20 @@ func (_m *SceneReaderWriter) SaveActivity(ctx context.Context, sceneID int, resu
 	return r0, r1
 }
 
+// SetGeneratedArtifact provides a mock function with given fields: ctx, sceneID, artifact
+func (_m *SceneReaderWriter) SetGeneratedArtifact(ctx context.Context, sceneID int, artifact models.GeneratedArtifact) error {
+	ret := _m.Called(ctx, sceneID, artifact)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.GeneratedArtifact) error); ok {
+		r0 = rf(ctx, sceneID, artifact)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Size provides a mock function with given fields: ctx
 func (_m *SceneReaderWriter) Size(ctx context.Context) (float64, error) {
 	ret := _m.Called(ctx)
@@ -450,6 +450,29 @@ func (_m *TagReaderWriter) FindByStudioID(ctx context.Context, studioID int) ([]
 	return r0, r1
 }
 
+// FindCoOccurring provides a mock function with given fields: ctx, tagIDs, limit
+func (_m *TagReaderWriter) FindCoOccurring(ctx context.Context, tagIDs []int, limit int) ([]*models.TagCoOccurrence, error) {
+	ret := _m.Called(ctx, tagIDs, limit)
+
+	var r0 []*models.TagCoOccurrence
+	if rf, ok := ret.Get(0).(func(context.Context, []int, int) []*models.TagCoOccurrence); ok {
+		r0 = rf(ctx, tagIDs, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TagCoOccurrence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int, int) error); ok {
+		r1 = rf(ctx, tagIDs, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindMany provides a mock function with given fields: ctx, ids
 func (_m *TagReaderWriter) FindMany(ctx context.Context, ids []int) ([]*models.Tag, error) {
 	ret := _m.Called(ctx, ids)
@@ -21,6 +21,8 @@ type Database struct {
 	Studio         *StudioReaderWriter
 	Tag            *TagReaderWriter
 	SavedFilter    *SavedFilterReaderWriter
+	DeletedObject  *DeletedObjectReaderWriter
+	AuditEntry     *AuditEntryReaderWriter
 }
 
 func (*Database) Begin(ctx context.Context, exclusive bool) (context.Context, error) {
@@ -70,6 +72,8 @@ func NewDatabase() *Database {
 		Studio:         &StudioReaderWriter{},
 		Tag:            &TagReaderWriter{},
 		SavedFilter:    &SavedFilterReaderWriter{},
+		DeletedObject:  &DeletedObjectReaderWriter{},
+		AuditEntry:     &AuditEntryReaderWriter{},
 	}
 }
 
@@ -86,6 +90,8 @@ func (db *Database) AssertExpectations(t mock.TestingT) {
 	db.Studio.AssertExpectations(t)
 	db.Tag.AssertExpectations(t)
 	db.SavedFilter.AssertExpectations(t)
+	db.DeletedObject.AssertExpectations(t)
+	db.AuditEntry.AssertExpectations(t)
 }
 
 func (db *Database) Repository() models.Repository {
@@ -103,5 +109,7 @@ func (db *Database) Repository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    db.SavedFilter,
+		DeletedObject:  db.DeletedObject,
+		AuditEntry:     db.AuditEntry,
 	}
 }
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type AuditSource string
+
+const (
+	AuditSourceUI     AuditSource = "UI"
+	AuditSourceAPIKey AuditSource = "API_KEY"
+	AuditSourcePlugin AuditSource = "PLUGIN"
+	AuditSourceTask   AuditSource = "TASK"
+)
+
+var AllAuditSource = []AuditSource{
+	AuditSourceUI,
+	AuditSourceAPIKey,
+	AuditSourcePlugin,
+	AuditSourceTask,
+}
+
+func (e AuditSource) IsValid() bool {
+	switch e {
+	case AuditSourceUI, AuditSourceAPIKey, AuditSourcePlugin, AuditSourceTask:
+		return true
+	}
+	return false
+}
+
+func (e AuditSource) String() string {
+	return string(e)
+}
+
+func (e *AuditSource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditSource", str)
+	}
+	return nil
+}
+
+func (e AuditSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// AuditEntry records a change made to an object.
+type AuditEntry struct {
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	// ObjectType is the type of the changed object, such as Scene or Performer
+	ObjectType string `json:"object_type"`
+	ObjectID   int    `json:"object_id"`
+	// Action is one of Create, Update, Destroy or Merge
+	Action string      `json:"action"`
+	Source AuditSource `json:"source"`
+	// UserID is the user that made the change, if authentication is enabled
+	UserID string `json:"user_id"`
+	// PluginID is the plugin that made the change, if it was made by a
+	// plugin hook
+	PluginID string `json:"plugin_id"`
+	// Fields are the input fields that were changed. Empty if not known.
+	Fields []string `json:"fields"`
+	// Input is the input of the change, encoded as JSON
+	Input string `json:"input"`
+}
+
+type AuditEntryFilterType struct {
+	ObjectType *string      `json:"object_type"`
+	ObjectID   *string      `json:"object_id"`
+	Action     *string      `json:"action"`
+	Source     *AuditSource `json:"source"`
+	PluginID   *string      `json:"plugin_id"`
+	// Filter to entries created at or after this time
+	CreatedAfter *time.Time `json:"created_after"`
+	// Filter to entries created before this time
+	CreatedBefore *time.Time `json:"created_before"`
+}
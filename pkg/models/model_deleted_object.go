@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type DeletedObjectType string
+
+const (
+	DeletedObjectTypeScene     DeletedObjectType = "SCENE"
+	DeletedObjectTypeGallery   DeletedObjectType = "GALLERY"
+	DeletedObjectTypePerformer DeletedObjectType = "PERFORMER"
+)
+
+var AllDeletedObjectType = []DeletedObjectType{
+	DeletedObjectTypeScene,
+	DeletedObjectTypeGallery,
+	DeletedObjectTypePerformer,
+}
+
+func (e DeletedObjectType) IsValid() bool {
+	switch e {
+	case DeletedObjectTypeScene, DeletedObjectTypeGallery, DeletedObjectTypePerformer:
+		return true
+	}
+	return false
+}
+
+func (e DeletedObjectType) String() string {
+	return string(e)
+}
+
+func (e *DeletedObjectType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DeletedObjectType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DeletedObjectType", str)
+	}
+	return nil
+}
+
+func (e DeletedObjectType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// DeletedObject is a scene, gallery or performer that has been deleted, kept
+// so that it can be restored. Data contains the object in the JSON format
+// used by the metadata export.
+type DeletedObject struct {
+	ID         int               `json:"id"`
+	ObjectType DeletedObjectType `json:"object_type"`
+	// ObjectID is the id of the object before it was deleted
+	ObjectID  int       `json:"object_id"`
+	Name      string    `json:"name"`
+	Data      []byte    `json:"data"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
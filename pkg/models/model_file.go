@@ -277,6 +277,11 @@ type VideoFile struct {
 
 	Interactive      bool `json:"interactive"`
 	InteractiveSpeed *int `json:"interactive_speed"`
+
+	// ProbeData is the raw ffprobe output for the file. It is set when the
+	// file is probed, and is not loaded with the file. If nil, the stored
+	// output is left unchanged when the file is saved.
+	ProbeData []byte `json:"-"`
 }
 
 func (f VideoFile) GetWidth() int {
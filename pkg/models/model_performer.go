@@ -20,6 +20,8 @@ type Performer struct {
 	PenisLength    *float64        `json:"penis_length"`
 	Circumcised    *CircumisedEnum `json:"circumcised"`
 	CareerLength   string          `json:"career_length"`
+	CareerStart    *int            `json:"career_start"`
+	CareerEnd      *int            `json:"career_end"`
 	Tattoos        string          `json:"tattoos"`
 	Piercings      string          `json:"piercings"`
 	Favorite       bool            `json:"favorite"`
@@ -64,6 +66,8 @@ type PerformerPartial struct {
 	PenisLength    OptionalFloat64
 	Circumcised    OptionalString
 	CareerLength   OptionalString
+	CareerStart    OptionalInt
+	CareerEnd      OptionalInt
 	Tattoos        OptionalString
 	Piercings      OptionalString
 	Favorite       OptionalBool
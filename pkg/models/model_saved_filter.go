@@ -1,9 +1,14 @@
 package models
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type FilterMode string
@@ -68,4 +73,190 @@ type SavedFilter struct {
 	FindFilter   *FindFilterType        `json:"find_filter"`
 	ObjectFilter map[string]interface{} `json:"object_filter"`
 	UIOptions    map[string]interface{} `json:"ui_options"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// labelledIDs returns the ids of a list of labelled items as stored by the
+// UI. Returns false if v is not a list of labelled items.
+func labelledIDs(v interface{}) ([]interface{}, bool) {
+	items, ok := v.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, false
+	}
+
+	ids := make([]interface{}, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		ids[i] = m["id"]
+	}
+
+	return ids, true
+}
+
+// normalizeCriterion converts a criterion as stored by the UI into the
+// structure of the GraphQL filter inputs. Multi criteria are stored as
+// labelled items, which are reduced to their ids. Criteria with more than one
+// value store the values in an object, which are moved to the criterion.
+func normalizeCriterion(c map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(c))
+	for k, v := range c {
+		ret[k] = v
+	}
+
+	switch value := c["value"].(type) {
+	case []interface{}:
+		if ids, ok := labelledIDs(value); ok {
+			ret["value"] = ids
+		}
+	case map[string]interface{}:
+		delete(ret, "value")
+		for k, v := range value {
+			switch k {
+			case "items":
+				ids, _ := labelledIDs(v)
+				if ids == nil {
+					ids = []interface{}{}
+				}
+				ret["value"] = ids
+			case "excluded":
+				ids, _ := labelledIDs(v)
+				ret["excludes"] = ids
+			case "stashID":
+				ret["stash_id"] = v
+			default:
+				// value, value2, depth, distance and endpoint
+				ret[k] = v
+			}
+		}
+	}
+
+	return ret
+}
+
+// scalarFilterFields returns the json names and kinds of the fields of the
+// filter type that are pointers to scalar values rather than criterion
+// inputs.
+func scalarFilterFields(t reflect.Type) map[string]reflect.Kind {
+	ret := make(map[string]reflect.Kind)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || field.Type.Kind() != reflect.Ptr {
+			continue
+		}
+
+		switch kind := field.Type.Elem().Kind(); kind {
+		case reflect.Bool, reflect.String:
+			ret[name] = kind
+		}
+	}
+
+	return ret
+}
+
+// scalarCriterionValue returns the value of a criterion for a scalar filter
+// field. Boolean fields are stored by the UI as "true" or "false".
+func scalarCriterionValue(c map[string]interface{}, kind reflect.Kind) interface{} {
+	v := c["value"]
+	if s, ok := v.(string); ok && kind == reflect.Bool {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+
+	return v
+}
+
+// savedResolutions are the resolution values stored by the UI.
+var savedResolutions = map[string]ResolutionEnum{
+	"144p":  ResolutionEnumVeryLow,
+	"240p":  ResolutionEnumLow,
+	"360p":  ResolutionEnumR360p,
+	"480p":  ResolutionEnumStandard,
+	"540p":  ResolutionEnumWebHd,
+	"720p":  ResolutionEnumStandardHd,
+	"1080p": ResolutionEnumFullHd,
+	"1440p": ResolutionEnumQuadHd,
+	"1920p": ResolutionEnumVrHd,
+	"4k":    ResolutionEnumFourK,
+	"5k":    ResolutionEnumFiveK,
+	"6k":    ResolutionEnumSixK,
+	"7k":    ResolutionEnumSevenK,
+	"8k":    ResolutionEnumEightK,
+	"Huge":  ResolutionEnumHuge,
+}
+
+// SceneFilter returns the scene filter represented by the saved filter's
+// object filter. The object filter may be stored in the form used by the UI
+// or in the form of the GraphQL filter input.
+//
+// Returns an error if the saved filter is not a scene filter, or if it
+// contains criteria that are not supported by the scene filter input or have
+// invalid values, rather than ignoring them.
+func (f SavedFilter) SceneFilter() (*SceneFilterType, error) {
+	if f.Mode != FilterModeScenes {
+		return nil, fmt.Errorf("saved filter %d is not a scene filter", f.ID)
+	}
+
+	scalars := scalarFilterFields(reflect.TypeOf(SceneFilterType{}))
+
+	normalized := make(map[string]interface{}, len(f.ObjectFilter))
+	for k, v := range f.ObjectFilter {
+		if c, ok := v.(map[string]interface{}); ok {
+			switch {
+			case scalars[k] != reflect.Invalid:
+				// scalar fields such as booleans are stored as criteria
+				v = scalarCriterionValue(c, scalars[k])
+			case k == "duplicated" && c["value"] != nil:
+				// stored as a boolean criterion
+				v = map[string]interface{}{
+					"duplicated": scalarCriterionValue(c, reflect.Bool),
+				}
+			case k == "orientation":
+				// the orientation input has no modifier
+				c = normalizeCriterion(c)
+				delete(c, "modifier")
+				v = c
+			default:
+				v = normalizeCriterion(c)
+			}
+		}
+		normalized[k] = v
+	}
+
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret SceneFilterType
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&ret); err != nil {
+		return nil, fmt.Errorf("converting saved filter %d: %w", f.ID, err)
+	}
+
+	if ret.Resolution != nil && !ret.Resolution.Value.IsValid() {
+		v, found := savedResolutions[string(ret.Resolution.Value)]
+		if !found {
+			return nil, fmt.Errorf("converting saved filter %d: invalid resolution %q", f.ID, ret.Resolution.Value)
+		}
+		ret.Resolution.Value = v
+	}
+	if ret.Orientation != nil {
+		for i, o := range ret.Orientation.Value {
+			// stored by the UI as the display name
+			v := OrientationEnum(strings.ToUpper(string(o)))
+			if !v.IsValid() {
+				return nil, fmt.Errorf("converting saved filter %d: invalid orientation %q", f.ID, o)
+			}
+			ret.Orientation.Value[i] = v
+		}
+	}
+
+	return &ret, nil
 }
@@ -0,0 +1,286 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedFilter_SceneFilter(t *testing.T) {
+	depth := 1
+	value2 := 100
+
+	f := SavedFilter{
+		Mode: FilterModeScenes,
+		ObjectFilter: map[string]interface{}{
+			"tags": map[string]interface{}{
+				"modifier": "INCLUDES",
+				"value": map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"id": "1", "label": "tag 1"},
+					},
+					"excluded": []interface{}{
+						map[string]interface{}{"id": "2", "label": "tag 2"},
+					},
+					"depth": 1,
+				},
+			},
+			"rating100": map[string]interface{}{
+				"modifier": "BETWEEN",
+				"value": map[string]interface{}{
+					"value":  50,
+					"value2": 100,
+				},
+			},
+			"organized": map[string]interface{}{
+				"modifier": "EQUALS",
+				"value":    true,
+			},
+		},
+	}
+
+	got, err := f.SceneFilter()
+	assert.NoError(t, err)
+
+	organized := true
+	assert.Equal(t, &SceneFilterType{
+		Tags: &HierarchicalMultiCriterionInput{
+			Value:    []string{"1"},
+			Excludes: []string{"2"},
+			Depth:    &depth,
+			Modifier: CriterionModifierIncludes,
+		},
+		Rating100: &IntCriterionInput{
+			Value:    50,
+			Value2:   &value2,
+			Modifier: CriterionModifierBetween,
+		},
+		Organized: &organized,
+	}, got)
+
+	f.Mode = FilterModePerformers
+	_, err = f.SceneFilter()
+	assert.Error(t, err)
+}
+
+func TestSavedFilter_SceneFilterRoundTrip(t *testing.T) {
+	depth := 2
+	value2 := 100
+	distance := 4
+	endpoint := "https://stashdb.org/graphql"
+	duplicated := true
+	organized := false
+	title := "title"
+
+	// filters saved in the GraphQL input form are converted unchanged
+	want := &SceneFilterType{
+		Title: &StringCriterionInput{
+			Value:    title,
+			Modifier: CriterionModifierIncludes,
+		},
+		Tags: &HierarchicalMultiCriterionInput{
+			Value:    []string{"1", "2"},
+			Excludes: []string{"3"},
+			Depth:    &depth,
+			Modifier: CriterionModifierIncludesAll,
+		},
+		Rating100: &IntCriterionInput{
+			Value:    50,
+			Value2:   &value2,
+			Modifier: CriterionModifierBetween,
+		},
+		PhashDistance: &PhashDistanceCriterionInput{
+			Value:    "abcdef",
+			Distance: &distance,
+			Modifier: CriterionModifierEquals,
+		},
+		Duplicated: &PHashDuplicationCriterionInput{
+			Duplicated: &duplicated,
+		},
+		StashIDEndpoint: &StashIDCriterionInput{
+			Endpoint: &endpoint,
+			Modifier: CriterionModifierNotNull,
+		},
+		Resolution: &ResolutionCriterionInput{
+			Value:    ResolutionEnumStandardHd,
+			Modifier: CriterionModifierGreaterThan,
+		},
+		Orientation: &OrientationCriterionInput{
+			Value: []OrientationEnum{OrientationLandscape},
+		},
+		Organized: &organized,
+	}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var objectFilter map[string]interface{}
+	if err := json.Unmarshal(data, &objectFilter); err != nil {
+		t.Fatal(err)
+	}
+
+	f := SavedFilter{
+		Mode:         FilterModeScenes,
+		ObjectFilter: objectFilter,
+	}
+
+	got, err := f.SceneFilter()
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestSavedFilter_SceneFilterUIForm(t *testing.T) {
+	distance := 4
+	endpoint := "https://stashdb.org/graphql"
+	stashID := "1234"
+	trueValue := true
+
+	tests := []struct {
+		name      string
+		key       string
+		criterion map[string]interface{}
+		want      *SceneFilterType
+	}{
+		{
+			"boolean string",
+			"organized",
+			map[string]interface{}{"modifier": "EQUALS", "value": "true"},
+			&SceneFilterType{Organized: &trueValue},
+		},
+		{
+			"labelled multi",
+			"performers",
+			map[string]interface{}{
+				"modifier": "INCLUDES",
+				"value": []interface{}{
+					map[string]interface{}{"id": "1", "label": "performer 1"},
+					map[string]interface{}{"id": "2", "label": "performer 2"},
+				},
+			},
+			&SceneFilterType{Performers: &MultiCriterionInput{
+				Value:    []string{"1", "2"},
+				Modifier: CriterionModifierIncludes,
+			}},
+		},
+		{
+			"phash distance",
+			"phash_distance",
+			map[string]interface{}{
+				"modifier": "EQUALS",
+				"value":    map[string]interface{}{"value": "abcdef", "distance": 4},
+			},
+			&SceneFilterType{PhashDistance: &PhashDistanceCriterionInput{
+				Value:    "abcdef",
+				Distance: &distance,
+				Modifier: CriterionModifierEquals,
+			}},
+		},
+		{
+			"stash id endpoint",
+			"stash_id_endpoint",
+			map[string]interface{}{
+				"modifier": "EQUALS",
+				"value":    map[string]interface{}{"endpoint": endpoint, "stashID": stashID},
+			},
+			&SceneFilterType{StashIDEndpoint: &StashIDCriterionInput{
+				Endpoint: &endpoint,
+				StashID:  &stashID,
+				Modifier: CriterionModifierEquals,
+			}},
+		},
+		{
+			"duplicated",
+			"duplicated",
+			map[string]interface{}{"modifier": "EQUALS", "value": "true"},
+			&SceneFilterType{Duplicated: &PHashDuplicationCriterionInput{
+				Duplicated: &trueValue,
+			}},
+		},
+		{
+			"resolution",
+			"resolution",
+			map[string]interface{}{"modifier": "EQUALS", "value": "720p"},
+			&SceneFilterType{Resolution: &ResolutionCriterionInput{
+				Value:    ResolutionEnumStandardHd,
+				Modifier: CriterionModifierEquals,
+			}},
+		},
+		{
+			"orientation",
+			"orientation",
+			map[string]interface{}{"modifier": "INCLUDES", "value": []interface{}{"Landscape", "Square"}},
+			&SceneFilterType{Orientation: &OrientationCriterionInput{
+				Value: []OrientationEnum{OrientationLandscape, OrientationSquare},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := SavedFilter{
+				Mode:         FilterModeScenes,
+				ObjectFilter: map[string]interface{}{tt.key: tt.criterion},
+			}
+
+			got, err := f.SceneFilter()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSavedFilter_SceneFilterUnsupported(t *testing.T) {
+	tests := []struct {
+		name         string
+		objectFilter map[string]interface{}
+	}{
+		{
+			"unknown criterion",
+			map[string]interface{}{
+				"unknown": map[string]interface{}{"modifier": "EQUALS", "value": "x"},
+			},
+		},
+		{
+			"unknown nested value",
+			map[string]interface{}{
+				"rating100": map[string]interface{}{
+					"modifier": "EQUALS",
+					"value":    map[string]interface{}{"value": 1, "unknown": 2},
+				},
+			},
+		},
+		{
+			"invalid resolution",
+			map[string]interface{}{
+				"resolution": map[string]interface{}{"modifier": "EQUALS", "value": "100p"},
+			},
+		},
+		{
+			"invalid orientation",
+			map[string]interface{}{
+				"orientation": map[string]interface{}{"value": []interface{}{"Diagonal"}},
+			},
+		},
+		{
+			"wrong type",
+			map[string]interface{}{
+				"rating100": map[string]interface{}{"modifier": "EQUALS", "value": "high"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := SavedFilter{
+				Mode:         FilterModeScenes,
+				ObjectFilter: tt.objectFilter,
+			}
+
+			_, err := f.SceneFilter()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -272,3 +272,17 @@ type VideoCaption struct {
 func (c VideoCaption) Path(filePath string) string {
 	return filepath.Join(filepath.Dir(filePath), c.Filename)
 }
+
+// SceneView is a single play of a scene.
+type SceneView struct {
+	SceneID  int       `json:"scene_id"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+// SceneViewDay is the number of scene plays on a day.
+type SceneViewDay struct {
+	// Date is the day in YYYY-MM-DD format, in local time
+	Date       string `json:"date"`
+	PlayCount  int    `json:"play_count"`
+	SceneCount int    `json:"scene_count"`
+}
@@ -12,7 +12,8 @@ type ScrapedStudio struct {
 	// Set if studio matched
 	StoredID     *string        `json:"stored_id"`
 	Name         string         `json:"name"`
-	URL          *string        `json:"url"`
+	URLs         []string       `json:"urls"`
+	URL          *string        `json:"url"` // deprecated
 	Parent       *ScrapedStudio `json:"parent"`
 	Image        *string        `json:"image"`
 	Images       []string       `json:"images"`
@@ -35,8 +36,13 @@ func (s *ScrapedStudio) ToStudio(endpoint string, excluded map[string]bool) *Stu
 		})
 	}
 
-	if s.URL != nil && !excluded["url"] {
-		ret.URL = *s.URL
+	// if URLs are provided, only use those
+	if len(s.URLs) > 0 {
+		if !excluded["urls"] {
+			ret.URLs = NewRelatedStrings(s.URLs)
+		}
+	} else if s.URL != nil && !excluded["url"] {
+		ret.URLs = NewRelatedStrings([]string{*s.URL})
 	}
 
 	if s.Parent != nil && s.Parent.StoredID != nil && !excluded["parent"] && !excluded["parent_studio"] {
@@ -70,8 +76,19 @@ func (s *ScrapedStudio) ToPartial(id string, endpoint string, excluded map[strin
 		ret.Name = NewOptionalString(s.Name)
 	}
 
-	if s.URL != nil && !excluded["url"] {
-		ret.URL = NewOptionalString(*s.URL)
+	// if URLs are provided, only use those
+	if len(s.URLs) > 0 {
+		if !excluded["urls"] {
+			ret.URLs = &UpdateStrings{
+				Values: s.URLs,
+				Mode:   RelationshipUpdateModeSet,
+			}
+		}
+	} else if s.URL != nil && !excluded["url"] {
+		ret.URLs = &UpdateStrings{
+			Values: []string{*s.URL},
+			Mode:   RelationshipUpdateModeSet,
+		}
 	}
 
 	if s.Parent != nil && !excluded["parent"] {
@@ -119,6 +136,8 @@ type ScrapedPerformer struct {
 	PenisLength    *string       `json:"penis_length"`
 	Circumcised    *string       `json:"circumcised"`
 	CareerLength   *string       `json:"career_length"`
+	CareerStart    *string       `json:"career_start"`
+	CareerEnd      *string       `json:"career_end"`
 	Tattoos        *string       `json:"tattoos"`
 	Piercings      *string       `json:"piercings"`
 	Aliases        *string       `json:"aliases"`
@@ -157,6 +176,18 @@ func (p *ScrapedPerformer) ToPerformer(endpoint string, excluded map[string]bool
 	if p.CareerLength != nil && !excluded["career_length"] {
 		ret.CareerLength = *p.CareerLength
 	}
+	if p.CareerStart != nil && !excluded["career_start"] {
+		y, err := strconv.Atoi(*p.CareerStart)
+		if err == nil {
+			ret.CareerStart = &y
+		}
+	}
+	if p.CareerEnd != nil && !excluded["career_end"] {
+		y, err := strconv.Atoi(*p.CareerEnd)
+		if err == nil {
+			ret.CareerEnd = &y
+		}
+	}
 	if p.Country != nil && !excluded["country"] {
 		ret.Country = *p.Country
 	}
@@ -292,6 +323,18 @@ func (p *ScrapedPerformer) ToPartial(endpoint string, excluded map[string]bool,
 	if p.CareerLength != nil && !excluded["career_length"] {
 		ret.CareerLength = NewOptionalString(*p.CareerLength)
 	}
+	if p.CareerStart != nil && !excluded["career_start"] {
+		y, err := strconv.Atoi(*p.CareerStart)
+		if err == nil {
+			ret.CareerStart = NewOptionalInt(y)
+		}
+	}
+	if p.CareerEnd != nil && !excluded["career_end"] {
+		y, err := strconv.Atoi(*p.CareerEnd)
+		if err == nil {
+			ret.CareerEnd = NewOptionalInt(y)
+		}
+	}
 	if p.Country != nil && !excluded["country"] {
 		ret.Country = NewOptionalString(*p.Country)
 	}
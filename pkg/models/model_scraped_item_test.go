@@ -31,7 +31,7 @@ func Test_scrapedToStudioInput(t *testing.T) {
 			endpoint,
 			&Studio{
 				Name: name,
-				URL:  url,
+				URLs: NewRelatedStrings([]string{url}),
 				StashIDs: NewRelatedStashIDs([]StashID{
 					{
 						Endpoint: endpoint,
@@ -99,7 +99,7 @@ func Test_scrapedToPerformerInput(t *testing.T) {
 	remoteSiteID := "remoteSiteID"
 
 	var stringValues []string
-	for i := 0; i < 20; i++ {
+	for i := 0; i < 22; i++ {
 		stringValues = append(stringValues, strconv.Itoa(i))
 	}
 
@@ -158,6 +158,8 @@ func Test_scrapedToPerformerInput(t *testing.T) {
 				Measurements:   nextVal(),
 				FakeTits:       nextVal(),
 				CareerLength:   nextVal(),
+				CareerStart:    nextVal(),
+				CareerEnd:      nextVal(),
 				Tattoos:        nextVal(),
 				Piercings:      nextVal(),
 				Aliases:        nextVal(),
@@ -183,6 +185,8 @@ func Test_scrapedToPerformerInput(t *testing.T) {
 				Measurements:   *nextVal(),
 				FakeTits:       *nextVal(),
 				CareerLength:   *nextVal(),
+				CareerStart:    nextIntVal(),
+				CareerEnd:      nextIntVal(),
 				Tattoos:        *nextVal(),
 				Piercings:      *nextVal(),
 				Aliases:        NewRelatedStrings([]string{*nextVal()}),
@@ -310,9 +314,12 @@ func TestScrapedStudio_ToPartial(t *testing.T) {
 			fullStudio,
 			stdArgs,
 			StudioPartial{
-				ID:       id,
-				Name:     NewOptionalString(name),
-				URL:      NewOptionalString(url),
+				ID:   id,
+				Name: NewOptionalString(name),
+				URLs: &UpdateStrings{
+					Values: []string{url},
+					Mode:   RelationshipUpdateModeSet,
+				},
 				ParentID: NewOptionalInt(parentStoredID),
 				StashIDs: &UpdateStashIDs{
 					StashIDs: append(existingStashIDs, StashID{
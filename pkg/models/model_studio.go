@@ -8,7 +8,6 @@ import (
 type Studio struct {
 	ID        int       `json:"id"`
 	Name      string    `json:"name"`
-	URL       string    `json:"url"`
 	ParentID  *int      `json:"parent_id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -19,6 +18,7 @@ type Studio struct {
 	IgnoreAutoTag bool   `json:"ignore_auto_tag"`
 
 	Aliases  RelatedStrings  `json:"aliases"`
+	URLs     RelatedStrings  `json:"urls"`
 	TagIDs   RelatedIDs      `json:"tag_ids"`
 	StashIDs RelatedStashIDs `json:"stash_ids"`
 }
@@ -35,7 +35,6 @@ func NewStudio() Studio {
 type StudioPartial struct {
 	ID       int
 	Name     OptionalString
-	URL      OptionalString
 	ParentID OptionalInt
 	// Rating expressed in 1-100 scale
 	Rating        OptionalInt
@@ -46,6 +45,7 @@ type StudioPartial struct {
 	IgnoreAutoTag OptionalBool
 
 	Aliases  *UpdateStrings
+	URLs     *UpdateStrings
 	TagIDs   *UpdateIDs
 	StashIDs *UpdateStashIDs
 }
@@ -63,6 +63,12 @@ func (s *Studio) LoadAliases(ctx context.Context, l AliasLoader) error {
 	})
 }
 
+func (s *Studio) LoadURLs(ctx context.Context, l URLLoader) error {
+	return s.URLs.load(func() ([]string, error) {
+		return l.GetURLs(ctx, s.ID)
+	})
+}
+
 func (s *Studio) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	return s.TagIDs.load(func() ([]int, error) {
 		return l.GetTagIDs(ctx, s.ID)
@@ -80,6 +86,10 @@ func (s *Studio) LoadRelationships(ctx context.Context, l PerformerReader) error
 		return err
 	}
 
+	if err := s.LoadURLs(ctx, l); err != nil {
+		return err
+	}
+
 	if err := s.LoadTagIDs(ctx, l); err != nil {
 		return err
 	}
@@ -45,6 +45,13 @@ func (s *Tag) LoadChildIDs(ctx context.Context, l TagRelationLoader) error {
 	})
 }
 
+// TagCoOccurrence is a tag and the number of scenes on which it appears
+// alongside a set of other tags.
+type TagCoOccurrence struct {
+	TagID int `json:"tag_id"`
+	Count int `json:"count"`
+}
+
 type TagPartial struct {
 	Name          OptionalString
 	Description   OptionalString
@@ -10,6 +10,7 @@ import (
 type JSONPaths struct {
 	Metadata string
 
+	Manifest    string
 	ScrapedFile string
 
 	Performers string
@@ -25,6 +26,7 @@ type JSONPaths struct {
 func newJSONPaths(baseDir string) *JSONPaths {
 	jp := JSONPaths{}
 	jp.Metadata = baseDir
+	jp.Manifest = filepath.Join(baseDir, "manifest.json")
 	jp.ScrapedFile = filepath.Join(baseDir, "scraped.json")
 	jp.Performers = filepath.Join(baseDir, "performers")
 	jp.Scenes = filepath.Join(baseDir, "scenes")
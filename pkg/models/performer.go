@@ -138,6 +138,10 @@ type PerformerFilterType struct {
 	Circumcised *CircumcisionCriterionInput `json:"circumcised"`
 	// Filter by career length
 	CareerLength *StringCriterionInput `json:"career_length"`
+	// Filter by career start year
+	CareerStart *IntCriterionInput `json:"career_start"`
+	// Filter by career end year
+	CareerEnd *IntCriterionInput `json:"career_end"`
 	// Filter by tattoos
 	Tattoos *StringCriterionInput `json:"tattoos"`
 	// Filter by piercings
@@ -217,6 +221,8 @@ type PerformerCreateInput struct {
 	PenisLength    *float64        `json:"penis_length"`
 	Circumcised    *CircumisedEnum `json:"circumcised"`
 	CareerLength   *string         `json:"career_length"`
+	CareerStart    *int            `json:"career_start"`
+	CareerEnd      *int            `json:"career_end"`
 	Tattoos        *string         `json:"tattoos"`
 	Piercings      *string         `json:"piercings"`
 	Aliases        *string         `json:"aliases"`
@@ -254,6 +260,8 @@ type PerformerUpdateInput struct {
 	PenisLength    *float64        `json:"penis_length"`
 	Circumcised    *CircumisedEnum `json:"circumcised"`
 	CareerLength   *string         `json:"career_length"`
+	CareerStart    *int            `json:"career_start"`
+	CareerEnd      *int            `json:"career_end"`
 	Tattoos        *string         `json:"tattoos"`
 	Piercings      *string         `json:"piercings"`
 	Aliases        *string         `json:"aliases"`
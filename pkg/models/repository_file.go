@@ -17,6 +17,7 @@ type FileFinder interface {
 	FindAllInPaths(ctx context.Context, p []string, limit, offset int) ([]File, error)
 	FindByPath(ctx context.Context, path string) (File, error)
 	FindByFingerprint(ctx context.Context, fp Fingerprint) ([]File, error)
+	FindByFingerprints(ctx context.Context, fp []Fingerprint) ([]File, error)
 	FindByZipFileID(ctx context.Context, zipFileID FileID) ([]File, error)
 	FindByFileInfo(ctx context.Context, info fs.FileInfo, size int64) ([]File, error)
 }
@@ -69,6 +70,7 @@ type FileReader interface {
 	FileCounter
 
 	GetCaptions(ctx context.Context, fileID FileID) ([]*VideoCaption, error)
+	GetProbeData(ctx context.Context, fileID FileID) ([]byte, error)
 	IsPrimary(ctx context.Context, fileID FileID) (bool, error)
 }
 
@@ -18,6 +18,7 @@ type GalleryFinder interface {
 	FindByPath(ctx context.Context, path string) ([]*Gallery, error)
 	FindByFileID(ctx context.Context, fileID FileID) ([]*Gallery, error)
 	FindByFolderID(ctx context.Context, folderID FolderID) ([]*Gallery, error)
+	FindByParentFolderID(ctx context.Context, parentFolderID FolderID) ([]*Gallery, error)
 	FindBySceneID(ctx context.Context, sceneID int) ([]*Gallery, error)
 	FindByImageID(ctx context.Context, imageID int) ([]*Gallery, error)
 	FindUserGalleryByTitle(ctx context.Context, title string) ([]*Gallery, error)
@@ -36,6 +37,7 @@ type GalleryCounter interface {
 }
 
 // GalleryCreator provides methods to create galleries.
+// If the new gallery's ID is set, the gallery is created with that ID.
 type GalleryCreator interface {
 	Create(ctx context.Context, newGallery *Gallery, fileIDs []FileID) error
 }
@@ -31,8 +31,9 @@ type PerformerAutoTagQueryer interface {
 	AliasLoader
 
 	// TODO - this interface is temporary until the filter schema can fully
-	// support the query needed
-	QueryForAutoTag(ctx context.Context, words []string) ([]*Performer, error)
+	// support the query needed. Performers with aliases matching the words
+	// are only included if includeAliases is true.
+	QueryForAutoTag(ctx context.Context, words []string, includeAliases bool) ([]*Performer, error)
 }
 
 // PerformerCounter provides methods to count performers.
@@ -42,6 +43,7 @@ type PerformerCounter interface {
 }
 
 // PerformerCreator provides methods to create performers.
+// If the new performer's ID is set, the performer is created with that ID.
 type PerformerCreator interface {
 	Create(ctx context.Context, newPerformer *Performer) error
 }
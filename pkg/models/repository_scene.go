@@ -21,6 +21,7 @@ type SceneFinder interface {
 	FindByPath(ctx context.Context, path string) ([]*Scene, error)
 	FindByFileID(ctx context.Context, fileID FileID) ([]*Scene, error)
 	FindByPrimaryFileID(ctx context.Context, fileID FileID) ([]*Scene, error)
+	FindByParentFolderID(ctx context.Context, parentFolderID FolderID) ([]*Scene, error)
 	FindByPerformerID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindByGalleryID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindByGroupID(ctx context.Context, groupID int) ([]*Scene, error)
@@ -47,6 +48,7 @@ type SceneCounter interface {
 }
 
 // SceneCreator provides methods to create scenes.
+// If the new scene's ID is set, the scene is created with that ID.
 type SceneCreator interface {
 	Create(ctx context.Context, newScene *Scene, fileIDs []FileID) error
 }
@@ -76,6 +78,15 @@ type ViewDateReader interface {
 	GetViewDates(ctx context.Context, relatedID int) ([]time.Time, error)
 	GetManyViewDates(ctx context.Context, ids []int) ([][]time.Time, error)
 	GetManyLastViewed(ctx context.Context, ids []int) ([]*time.Time, error)
+	// GetViews returns the scene plays between start and end, most recent
+	// first. Nil values are not bounded.
+	GetViews(ctx context.Context, start *time.Time, end *time.Time) ([]*SceneView, error)
+	// GetViewsOnThisDay returns the scene plays on the same month and day as
+	// date in earlier years, most recent first.
+	GetViewsOnThisDay(ctx context.Context, date time.Time) ([]*SceneView, error)
+	// CountViewsByDay returns the number of scene plays on each day between
+	// start and end, most recent first. Days without plays are omitted.
+	CountViewsByDay(ctx context.Context, start *time.Time, end *time.Time) ([]*SceneViewDay, error)
 }
 
 type ODateReader interface {
@@ -86,6 +97,23 @@ type ODateReader interface {
 	GetManyODates(ctx context.Context, ids []int) ([][]time.Time, error)
 }
 
+// GeneratedArtifactReader provides methods to get the generated files
+// recorded for a scene.
+type GeneratedArtifactReader interface {
+	GetGeneratedArtifacts(ctx context.Context, sceneID int) ([]GeneratedArtifact, error)
+}
+
+// GeneratedArtifactWriter provides methods to record the generated files
+// of a scene.
+type GeneratedArtifactWriter interface {
+	// SetGeneratedArtifact records the artifact, replacing any existing
+	// record of the same type.
+	SetGeneratedArtifact(ctx context.Context, sceneID int, artifact GeneratedArtifact) error
+	// ClearGeneratedArtifacts removes the records of the provided types.
+	// All records are removed if types is empty.
+	ClearGeneratedArtifacts(ctx context.Context, sceneID int, types []GeneratedArtifactType) error
+}
+
 // SceneReader provides all methods to read scenes.
 type SceneReader interface {
 	SceneFinder
@@ -95,6 +123,7 @@ type SceneReader interface {
 	URLLoader
 	ViewDateReader
 	ODateReader
+	GeneratedArtifactReader
 	FileIDLoader
 	GalleryIDLoader
 	PerformerIDLoader
@@ -136,6 +165,7 @@ type SceneWriter interface {
 
 	OHistoryWriter
 	ViewHistoryWriter
+	GeneratedArtifactWriter
 	SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error)
 }
 
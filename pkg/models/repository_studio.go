@@ -77,6 +77,7 @@ type StudioReader interface {
 	AliasLoader
 	StashIDLoader
 	TagIDLoader
+	URLLoader
 
 	All(ctx context.Context) ([]*Studio, error)
 	GetImage(ctx context.Context, studioID int) ([]byte, error)
@@ -88,6 +89,8 @@ type StudioWriter interface {
 	StudioCreator
 	StudioUpdater
 	StudioDestroyer
+
+	Merge(ctx context.Context, source []int, destination int) error
 }
 
 // StudioReaderWriter provides all studio methods.
@@ -25,6 +25,7 @@ type TagFinder interface {
 	FindByStudioID(ctx context.Context, studioID int) ([]*Tag, error)
 	FindByName(ctx context.Context, name string, nocase bool) (*Tag, error)
 	FindByNames(ctx context.Context, names []string, nocase bool) ([]*Tag, error)
+	FindCoOccurring(ctx context.Context, tagIDs []int, limit int) ([]*TagCoOccurrence, error)
 }
 
 // TagQueryer provides methods to query tags.
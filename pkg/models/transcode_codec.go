@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// TranscodeCodec is the video codec used when generating transcodes.
+type TranscodeCodec string
+
+const (
+	// H.264, supported by all browsers
+	TranscodeCodecH264 TranscodeCodec = "H264"
+	// VP9, smaller than H.264 at the same quality
+	TranscodeCodecVp9 TranscodeCodec = "VP9"
+	// AV1, smaller than VP9 at the same quality, but slower to encode
+	TranscodeCodecAv1 TranscodeCodec = "AV1"
+)
+
+var AllTranscodeCodec = []TranscodeCodec{
+	TranscodeCodecH264,
+	TranscodeCodecVp9,
+	TranscodeCodecAv1,
+}
+
+func (e TranscodeCodec) IsValid() bool {
+	switch e {
+	case TranscodeCodecH264, TranscodeCodecVp9, TranscodeCodecAv1:
+		return true
+	}
+	return false
+}
+
+func (e TranscodeCodec) String() string {
+	return string(e)
+}
+
+func (e *TranscodeCodec) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TranscodeCodec(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TranscodeCodec", str)
+	}
+	return nil
+}
+
+func (e TranscodeCodec) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
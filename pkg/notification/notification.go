@@ -0,0 +1,208 @@
+// Package notification provides notifiers that send messages to external
+// services, such as webhooks, Gotify, ntfy and email.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const httpTimeout = 30 * time.Second
+
+// Message is a notification to be sent.
+type Message struct {
+	Title string
+	Text  string
+	// Failed is true if the message reports a failure. Notifiers may use this
+	// to set the priority of the message.
+	Failed bool
+}
+
+// Notifier sends messages to an external service.
+type Notifier interface {
+	Notify(ctx context.Context, m Message) error
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: httpTimeout,
+	}
+}
+
+func post(ctx context.Context, url string, contentType string, body []byte, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("http %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// Webhook posts messages as JSON to a URL. The body is an object with title,
+// message and failed fields.
+type Webhook struct {
+	URL string
+}
+
+func (n Webhook) Notify(ctx context.Context, m Message) error {
+	body, err := json.Marshal(struct {
+		Title   string `json:"title"`
+		Message string `json:"message"`
+		Failed  bool   `json:"failed"`
+	}{
+		Title:   m.Title,
+		Message: m.Text,
+		Failed:  m.Failed,
+	})
+	if err != nil {
+		return err
+	}
+
+	return post(ctx, n.URL, "application/json", body, nil)
+}
+
+const (
+	gotifyPriority       = 5
+	gotifyFailedPriority = 8
+)
+
+// Gotify sends messages to a Gotify server using an application token.
+type Gotify struct {
+	// URL of the Gotify server
+	URL   string
+	Token string
+}
+
+func (n Gotify) Notify(ctx context.Context, m Message) error {
+	priority := gotifyPriority
+	if m.Failed {
+		priority = gotifyFailedPriority
+	}
+
+	body, err := json.Marshal(struct {
+		Title    string `json:"title"`
+		Message  string `json:"message"`
+		Priority int    `json:"priority"`
+	}{
+		Title:    m.Title,
+		Message:  m.Text,
+		Priority: priority,
+	})
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("X-Gotify-Key", n.Token)
+
+	return post(ctx, strings.TrimSuffix(n.URL, "/")+"/message", "application/json", body, header)
+}
+
+// Ntfy publishes messages to an ntfy topic.
+type Ntfy struct {
+	// URL of the topic, including the server. eg https://ntfy.sh/mytopic
+	URL string
+	// Access token. Optional.
+	Token string
+}
+
+func (n Ntfy) Notify(ctx context.Context, m Message) error {
+	header := http.Header{}
+	header.Set("Title", m.Title)
+	if m.Failed {
+		header.Set("Priority", "high")
+		header.Set("Tags", "warning")
+	}
+	if n.Token != "" {
+		header.Set("Authorization", "Bearer "+n.Token)
+	}
+
+	return post(ctx, n.URL, "text/plain; charset=utf-8", []byte(m.Text), header)
+}
+
+// SMTPSettings are the settings used to connect to an SMTP server.
+type SMTPSettings struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender address
+	From string
+}
+
+// Email sends messages by email.
+type Email struct {
+	SMTP SMTPSettings
+	To   []string
+}
+
+func (n Email) Notify(ctx context.Context, m Message) error {
+	if n.SMTP.Host == "" {
+		return fmt.Errorf("smtp host not set")
+	}
+	if len(n.To) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	port := n.SMTP.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(n.SMTP.Host, strconv.Itoa(port))
+
+	var auth smtp.Auth
+	if n.SMTP.Username != "" {
+		auth = smtp.PlainAuth("", n.SMTP.Username, n.SMTP.Password, n.SMTP.Host)
+	}
+
+	from := n.SMTP.From
+	if from == "" {
+		from = n.SMTP.Username
+	}
+
+	// smtp.SendMail does not support contexts
+	return smtp.SendMail(addr, auth, from, n.To, emailBody(from, n.To, m))
+}
+
+func emailBody(from string, to []string, m Message) []byte {
+	// strip newlines from headers
+	subject := strings.Join(strings.Fields(m.Title), " ")
+
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	b.WriteString("Subject: " + subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Text, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return []byte(b.String())
+}
@@ -0,0 +1,104 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type request struct {
+	path   string
+	header http.Header
+	body   string
+}
+
+func newTestServer(t *testing.T, status int) (*httptest.Server, *request) {
+	ret := &request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ret.path = r.URL.Path
+		ret.header = r.Header
+		ret.body = string(body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, ret
+}
+
+var testMessage = Message{
+	Title:  "Task Failed",
+	Text:   "Task \"Scanning\" failed.",
+	Failed: true,
+}
+
+func TestWebhook(t *testing.T) {
+	server, got := newTestServer(t, http.StatusOK)
+
+	err := Webhook{URL: server.URL + "/hook"}.Notify(context.Background(), testMessage)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "/hook", got.path)
+
+	var body map[string]interface{}
+	if assert.Nil(t, json.Unmarshal([]byte(got.body), &body)) {
+		assert.Equal(t, testMessage.Title, body["title"])
+		assert.Equal(t, testMessage.Text, body["message"])
+		assert.Equal(t, true, body["failed"])
+	}
+}
+
+func TestGotify(t *testing.T) {
+	server, got := newTestServer(t, http.StatusOK)
+
+	err := Gotify{URL: server.URL + "/", Token: "token"}.Notify(context.Background(), testMessage)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "/message", got.path)
+	assert.Equal(t, "token", got.header.Get("X-Gotify-Key"))
+	assert.Contains(t, got.body, `"priority":8`)
+}
+
+func TestNtfy(t *testing.T) {
+	server, got := newTestServer(t, http.StatusOK)
+
+	err := Ntfy{URL: server.URL + "/topic", Token: "token"}.Notify(context.Background(), testMessage)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "/topic", got.path)
+	assert.Equal(t, testMessage.Title, got.header.Get("Title"))
+	assert.Equal(t, "Bearer token", got.header.Get("Authorization"))
+	assert.Equal(t, testMessage.Text, got.body)
+}
+
+func TestNotifyError(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusUnauthorized)
+
+	err := Webhook{URL: server.URL}.Notify(context.Background(), testMessage)
+	assert.NotNil(t, err)
+}
+
+func TestEmailBody(t *testing.T) {
+	m := Message{
+		Title: "Multi\nline title",
+		Text:  "line one\nline two",
+	}
+
+	body := string(emailBody("stash@example.com", []string{"a@example.com", "b@example.com"}, m))
+
+	assert.Contains(t, body, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, body, "Subject: Multi line title\r\n")
+	assert.True(t, strings.HasSuffix(body, "\r\n\r\nline one\r\nline two\r\n"))
+}
@@ -66,6 +66,14 @@ func ToJSON(ctx context.Context, reader ImageAliasStashIDGetter, performer *mode
 		newPerformerJSON.Weight = *performer.Weight
 	}
 
+	if performer.CareerStart != nil {
+		newPerformerJSON.CareerStart = *performer.CareerStart
+	}
+
+	if performer.CareerEnd != nil {
+		newPerformerJSON.CareerEnd = *performer.CareerEnd
+	}
+
 	if performer.PenisLength != nil {
 		newPerformerJSON.PenisLength = *performer.PenisLength
 	}
@@ -47,6 +47,8 @@ var (
 	rating          = 5
 	height          = 123
 	weight          = 60
+	careerStart     = 2005
+	careerEnd       = 2015
 	penisLength     = 1.23
 	circumcisedEnum = models.CircumisedEnumCut
 	circumcised     = circumcisedEnum.String()
@@ -100,6 +102,8 @@ func createFullPerformer(id int, name string) *models.Performer {
 		DeathDate:      &deathDate,
 		HairColor:      hairColor,
 		Weight:         &weight,
+		CareerStart:    &careerStart,
+		CareerEnd:      &careerEnd,
 		IgnoreAutoTag:  autoTagIgnored,
 		TagIDs:         models.NewRelatedIDs([]int{}),
 		StashIDs:       models.NewRelatedStashIDs(stashIDs),
@@ -150,6 +154,8 @@ func createFullJSONPerformer(name string, image string) *jsonschema.Performer {
 		DeathDate:     deathDate.String(),
 		HairColor:     hairColor,
 		Weight:        weight,
+		CareerStart:   careerStart,
+		CareerEnd:     careerEnd,
 		StashIDs:      stashIDs,
 		IgnoreAutoTag: autoTagIgnored,
 	}
@@ -23,6 +23,8 @@ type Importer struct {
 	TagWriter           models.TagFinderCreator
 	Input               jsonschema.Performer
 	MissingRefBehaviour models.ImportMissingRefEnum
+	// RestoreID is the id to create the performer with, if set
+	RestoreID int
 
 	ID        int
 	performer models.Performer
@@ -164,18 +166,21 @@ func (i *Importer) FindExistingID(ctx context.Context) (*int, error) {
 }
 
 func (i *Importer) Create(ctx context.Context) (*int, error) {
+	i.performer.ID = i.RestoreID
 	err := i.ReaderWriter.Create(ctx, &i.performer)
 	if err != nil {
 		return nil, fmt.Errorf("error creating performer: %v", err)
 	}
 
 	id := i.performer.ID
+	i.ID = id
 	return &id, nil
 }
 
 func (i *Importer) Update(ctx context.Context, id int) error {
 	performer := i.performer
 	performer.ID = id
+	i.ID = id
 	err := i.ReaderWriter.Update(ctx, &performer)
 	if err != nil {
 		return fmt.Errorf("error updating existing performer: %v", err)
@@ -257,6 +262,14 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 		newPerformer.Weight = &performerJSON.Weight
 	}
 
+	if performerJSON.CareerStart != 0 {
+		newPerformer.CareerStart = &performerJSON.CareerStart
+	}
+
+	if performerJSON.CareerEnd != 0 {
+		newPerformer.CareerEnd = &performerJSON.CareerEnd
+	}
+
 	if performerJSON.PenisLength != 0 {
 		newPerformer.PenisLength = &performerJSON.PenisLength
 	}
@@ -42,6 +42,7 @@ const (
 
 	StudioCreatePost  TriggerEnum = "Studio.Create.Post"
 	StudioUpdatePost  TriggerEnum = "Studio.Update.Post"
+	StudioMergePost   TriggerEnum = "Studio.Merge.Post"
 	StudioDestroyPost TriggerEnum = "Studio.Destroy.Post"
 
 	TagCreatePost  TriggerEnum = "Tag.Create.Post"
@@ -81,6 +82,7 @@ var AllHookTriggerEnum = []TriggerEnum{
 
 	StudioCreatePost,
 	StudioUpdatePost,
+	StudioMergePost,
 	StudioDestroyPost,
 
 	TagCreatePost,
@@ -122,6 +124,7 @@ func (e TriggerEnum) IsValid() bool {
 
 		StudioCreatePost,
 		StudioUpdatePost,
+		StudioMergePost,
 		StudioDestroyPost,
 
 		TagCreatePost,
@@ -98,12 +98,17 @@ type ServerConfig interface {
 	GetPythonPath() string
 }
 
+// PostHookListener is called whenever a post hook is triggered, regardless
+// of whether any plugins handle the hook.
+type PostHookListener func(ctx context.Context, hookContext common.HookContext)
+
 // Cache stores plugin details.
 type Cache struct {
-	config       ServerConfig
-	plugins      []Config
-	sessionStore *session.Store
-	gqlHandler   http.Handler
+	config           ServerConfig
+	plugins          []Config
+	sessionStore     *session.Store
+	gqlHandler       http.Handler
+	postHookListener PostHookListener
 }
 
 // NewCache returns a new Cache.
@@ -127,6 +132,10 @@ func (c *Cache) RegisterSessionStore(sessionStore *session.Store) {
 	c.sessionStore = sessionStore
 }
 
+func (c *Cache) RegisterPostHookListener(listener PostHookListener) {
+	c.postHookListener = listener
+}
+
 // ReloadPlugins clears the plugin cache and loads from the plugin path.
 // If a plugin cannot be loaded, an error is logged and the plugin is skipped.
 func (c *Cache) ReloadPlugins() {
@@ -358,12 +367,25 @@ func waitForTask(ctx context.Context, task Task) error {
 }
 
 func (c Cache) ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
-	if err := c.executePostHooks(ctx, hookType, common.HookContext{
+	// hooks may call back into the server, so they must wait until the
+	// shared transaction has completed
+	if txn.InSharedTxn(ctx) {
+		c.RegisterPostHooks(ctx, id, hookType, input, inputFields)
+		return
+	}
+
+	hookContext := common.HookContext{
 		ID:          id,
 		Type:        hookType.String(),
 		Input:       input,
 		InputFields: inputFields,
-	}); err != nil {
+	}
+
+	if c.postHookListener != nil {
+		c.postHookListener(ctx, hookContext)
+	}
+
+	if err := c.executePostHooks(ctx, hookType, hookContext); err != nil {
 		logger.Errorf("error executing post hooks: %s", err.Error())
 	}
 }
@@ -168,7 +168,7 @@ func (s *Service) deleteFiles(ctx context.Context, scene *models.Scene, fileDele
 			funscriptPath := video.GetFunscriptPath(f.Path)
 			funscriptExists, _ := fsutil.FileExists(funscriptPath)
 			if funscriptExists {
-				if err := fileDeleter.Files([]string{funscriptPath}); err != nil {
+				if err := fileDeleter.MediaFiles([]string{funscriptPath}); err != nil {
 					return err
 				}
 			}
@@ -51,7 +51,17 @@ func ToBasicJSON(ctx context.Context, reader ExportGetter, scene *models.Scene)
 	newSceneJSON.Organized = scene.Organized
 
 	for _, f := range scene.Files.List() {
-		newSceneJSON.Files = append(newSceneJSON.Files, f.Base().Path)
+		base := f.Base()
+		newSceneJSON.Files = append(newSceneJSON.Files, base.Path)
+
+		hashes := jsonschema.SceneFileHashes{
+			Path:     base.Path,
+			Checksum: base.Fingerprints.GetString(models.FingerprintTypeMD5),
+			Oshash:   base.Fingerprints.GetString(models.FingerprintTypeOshash),
+		}
+		if hashes.Checksum != "" || hashes.Oshash != "" {
+			newSceneJSON.FileHashes = append(newSceneJSON.FileHashes, hashes)
+		}
 	}
 
 	cover, err := reader.GetCover(ctx, scene.ID)
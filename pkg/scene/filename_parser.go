@@ -197,6 +197,42 @@ func newParseMapper(pattern string, ignoreFields []string) (*parseMapper, error)
 	return ret, nil
 }
 
+// newRegexParseMapper returns a parseMapper for a regular expression pattern.
+// Fields are captured using named groups matching the field names.
+func newRegexParseMapper(pattern string) (*parseMapper, error) {
+	initParserFields()
+
+	regex, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var invalid []string
+
+	// one entry per capture group, so that fields line up with the submatches
+	var fields []string
+	for _, name := range regex.SubexpNames()[1:] {
+		if name != "" {
+			parserField, found := validFields[name]
+			if !found || !parserField.isCaptured {
+				invalid = append(invalid, name)
+			}
+		}
+
+		fields = append(fields, name)
+	}
+
+	if len(invalid) > 0 {
+		return nil, errors.New("Invalid fields: " + strings.Join(invalid, ", "))
+	}
+
+	return &parseMapper{
+		fields:      fields,
+		regexString: pattern,
+		regex:       regex,
+	}, nil
+}
+
 type sceneHolder struct {
 	scene      *models.Scene
 	result     *models.Scene
@@ -471,7 +507,13 @@ func NewFilenameParserRepository(repo models.Repository) FilenameParserRepositor
 
 func (p *FilenameParser) Parse(ctx context.Context) ([]*models.SceneParserResult, int, error) {
 	// perform the query to find the scenes
-	mapper, err := newParseMapper(p.Pattern, p.ParserInput.IgnoreWords)
+	var mapper *parseMapper
+	var err error
+	if p.ParserInput.Regex != nil && *p.ParserInput.Regex {
+		mapper, err = newRegexParseMapper(p.Pattern)
+	} else {
+		mapper, err = newParseMapper(p.Pattern, p.ParserInput.IgnoreWords)
+	}
 
 	if err != nil {
 		return nil, 0, err
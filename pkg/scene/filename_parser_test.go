@@ -0,0 +1,46 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexParseMapper(t *testing.T) {
+	const pattern = `^(?P<studio>[^-]+) - (?P<yyyy>\d{4})\.(?P<mm>\d{2})\.(?P<dd>\d{2}) - (?P<title>.+?)(?:\.(mp4|mkv))?$`
+
+	m, err := newRegexParseMapper(pattern)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	s := &models.Scene{
+		Path: "/videos/Studio - 2021.03.04 - Some Title.mp4",
+	}
+
+	h := m.parse(s)
+	if !assert.NotNil(t, h) {
+		return
+	}
+
+	assert.Equal(t, "Studio", h.studio)
+	assert.Equal(t, "Some Title", h.result.Title)
+	assert.Equal(t, "2021-03-04", h.result.Date.String())
+
+	assert.Nil(t, m.parse(&models.Scene{Path: "/videos/unmatched.mp4"}))
+}
+
+func TestRegexParseMapperInvalid(t *testing.T) {
+	tests := []string{
+		`(?P<invalid>.*)`,
+		// ext is not a captured field
+		`(?P<title>.*)\.(?P<ext>.*)`,
+		`(?P<title>.*`,
+	}
+
+	for _, pattern := range tests {
+		_, err := newRegexParseMapper(pattern)
+		assert.NotNil(t, err, pattern)
+	}
+}
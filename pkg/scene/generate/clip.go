@@ -0,0 +1,91 @@
+package generate
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type ClipOptions struct {
+	// Start is the start of the clip in seconds.
+	Start float64
+	// Duration is the length of the clip in seconds.
+	Duration float64
+	// Reencode re-encodes the clip, so that it starts exactly at Start.
+	// Otherwise the streams are copied, and the clip starts at the keyframe
+	// before Start.
+	Reencode bool
+}
+
+// Clip writes the part of input described by options to output, which is a
+// path without an extension, and returns the path of the written file.
+//
+// Copied clips use the container of the input file. If copying fails, or
+// Reencode is set, the clip is encoded using the generated transcode codec
+// and AAC audio, and is written as an MP4 file.
+func (g Generator) Clip(ctx context.Context, input string, output string, options ClipOptions) (string, error) {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	if ext := filepath.Ext(input); !options.Reencode && ext != "" {
+		ret := output + ext
+		err := g.generateFile(lockCtx, g.ScenePaths, "*"+ext, ret, g.clipCopy(input, options))
+		if err == nil {
+			return ret, nil
+		}
+
+		if lockCtx.Err() != nil {
+			return "", err
+		}
+
+		logger.Warnf("[clip] copying clip of %s failed, re-encoding: %v", input, err)
+	}
+
+	ret := output + ".mp4"
+	if err := g.generateFile(lockCtx, g.ScenePaths, mp4Pattern, ret, g.clipReencode(input, options)); err != nil {
+		return "", err
+	}
+
+	return ret, nil
+}
+
+func (g Generator) clipCopy(input string, options ClipOptions) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
+			OutputPath: tmpFn,
+			StartTime:  options.Start,
+			Duration:   options.Duration,
+			VideoCodec: ffmpeg.VideoCodecCopy,
+			// shift timestamps so that the clip starts at zero
+			VideoArgs:  ffmpeg.Args{"-avoid_negative_ts", "make_zero"},
+			AudioCodec: ffmpeg.AudioCodecCopy,
+		})
+
+		return g.generate(lockCtx, args)
+	}
+}
+
+func (g Generator) clipReencode(input string, options ClipOptions) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		videoCodec, videoArgs := g.transcodeVideoCodec()
+		inputArgs, outputArgs := g.transcodeArgs()
+
+		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
+			OutputPath: tmpFn,
+			StartTime:  options.Start,
+			Duration:   options.Duration,
+			VideoCodec: videoCodec,
+			VideoArgs:  videoArgs,
+			AudioCodec: ffmpeg.AudioCodecAAC,
+
+			ExtraInputArgs:  inputArgs,
+			ExtraOutputArgs: outputArgs,
+		})
+
+		return g.generate(lockCtx, args)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 const (
@@ -45,8 +47,20 @@ type ScenePaths interface {
 }
 
 type FFMpegConfig interface {
+	// GetTranscodeInputArgs and GetTranscodeOutputArgs return the arguments
+	// added to all generate operations.
 	GetTranscodeInputArgs() []string
 	GetTranscodeOutputArgs() []string
+	GetPreviewInputArgs() []string
+	GetPreviewOutputArgs() []string
+	GetScreenshotInputArgs() []string
+	GetScreenshotOutputArgs() []string
+	GetGeneratedTranscodeInputArgs() []string
+	GetGeneratedTranscodeOutputArgs() []string
+	GetGeneratedTranscodeCodec() models.TranscodeCodec
+	// GetGeneratedTranscodeCRF returns the CRF of generated transcodes. Zero
+	// uses the default for the codec.
+	GetGeneratedTranscodeCRF() int
 }
 
 type Generator struct {
@@ -56,6 +70,41 @@ type Generator struct {
 	MarkerPaths  MarkerPaths
 	ScenePaths   ScenePaths
 	Overwrite    bool
+	// Throttle limits the resources used by ffmpeg. May be nil.
+	Throttle *Throttle
+	// Progress is called with the progress of the current operation, from
+	// 0 to 1, for operations that report progress. May be nil.
+	Progress func(percent float64)
+}
+
+// reportProgress calls the Progress function if set.
+func (g Generator) reportProgress(percent float64) {
+	if g.Progress != nil {
+		g.Progress(percent)
+	}
+}
+
+// extraArgs returns the extra ffmpeg input and output arguments for an
+// operation: the arguments for all operations followed by opInput and
+// opOutput.
+func (g Generator) extraArgs(opInput, opOutput []string) (input []string, output []string) {
+	input = append(input, g.FFMpegConfig.GetTranscodeInputArgs()...)
+	input = append(input, opInput...)
+	output = append(output, g.FFMpegConfig.GetTranscodeOutputArgs()...)
+	output = append(output, opOutput...)
+	return
+}
+
+func (g Generator) previewArgs() (input []string, output []string) {
+	return g.extraArgs(g.FFMpegConfig.GetPreviewInputArgs(), g.FFMpegConfig.GetPreviewOutputArgs())
+}
+
+func (g Generator) screenshotArgs() (input []string, output []string) {
+	return g.extraArgs(g.FFMpegConfig.GetScreenshotInputArgs(), g.FFMpegConfig.GetScreenshotOutputArgs())
+}
+
+func (g Generator) transcodeArgs() (input []string, output []string) {
+	return g.extraArgs(g.FFMpegConfig.GetGeneratedTranscodeInputArgs(), g.FFMpegConfig.GetGeneratedTranscodeOutputArgs())
 }
 
 type generateFn func(lockCtx *fsutil.LockContext, tmpFn string) error
@@ -127,18 +176,50 @@ func (g Generator) generateBytes(lockCtx *fsutil.LockContext, p Paths, pattern s
 // Returns an error if the command fails. If the command fails, the return
 // value will be of type *exec.ExitError.
 func (g Generator) generate(ctx *fsutil.LockContext, args []string) error {
+	return g.generateProgress(ctx, args, 0, nil)
+}
+
+// generateProgress runs ffmpeg as generate, calling progress with the
+// fraction of duration seconds of output written. Progress is not reported
+// if progress is nil or duration is not positive.
+func (g Generator) generateProgress(ctx *fsutil.LockContext, args []string, duration float64, progress func(percent float64)) error {
+	if err := g.Throttle.acquire(ctx); err != nil {
+		return err
+	}
+	defer g.Throttle.release()
+
+	reportProgress := progress != nil && duration > 0
+	if reportProgress {
+		args = ffmpeg.WithProgress(args)
+	}
+
+	args = g.Throttle.args(args)
 	cmd := g.Encoder.Command(ctx, args)
 
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
+	var progressWriter io.WriteCloser
+	if reportProgress {
+		progressWriter = ffmpeg.NewProgressWriter(&stderr, func(p ffmpeg.Progress) {
+			progress(p.Percent(duration))
+		})
+		cmd.Stderr = progressWriter
+	}
+
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("error starting command: %w", err)
 	}
 
-	ctx.AttachCommand(cmd)
+	ctx.AttachCommand(cmd.Cmd)
+	g.Throttle.started(cmd.Cmd)
 
-	if err := cmd.Wait(); err != nil {
+	err := cmd.Wait()
+	if progressWriter != nil {
+		_ = progressWriter.Close()
+	}
+
+	if err != nil {
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
 			exitErr.Stderr = stderr.Bytes()
@@ -152,6 +233,12 @@ func (g Generator) generate(ctx *fsutil.LockContext, args []string) error {
 
 // GenerateOutput runs ffmpeg with the given args and returns it standard output.
 func (g Generator) generateOutput(lockCtx *fsutil.LockContext, args []string) ([]byte, error) {
+	if err := g.Throttle.acquire(lockCtx); err != nil {
+		return nil, err
+	}
+	defer g.Throttle.release()
+
+	args = g.Throttle.args(args)
 	cmd := g.Encoder.Command(lockCtx, args)
 
 	var stdout bytes.Buffer
@@ -164,7 +251,8 @@ func (g Generator) generateOutput(lockCtx *fsutil.LockContext, args []string) ([
 		return nil, fmt.Errorf("error starting command: %w", err)
 	}
 
-	lockCtx.AttachCommand(cmd)
+	lockCtx.AttachCommand(cmd.Cmd)
+	g.Throttle.started(cmd.Cmd)
 
 	if err := cmd.Wait(); err != nil {
 		var exitErr *exec.ExitError
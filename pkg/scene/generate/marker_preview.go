@@ -68,12 +68,17 @@ func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions)
 			"-strict", "-2",
 		)
 
+		inputArgs, outputArgs := g.previewArgs()
+
 		trimOptions := transcoder.TranscodeOptions{
 			Duration:   markerPreviewDuration,
 			StartTime:  float64(options.Seconds),
 			OutputPath: tmpFn,
 			VideoCodec: ffmpeg.VideoCodecLibX264,
 			VideoArgs:  videoArgs,
+
+			ExtraInputArgs:  inputArgs,
+			ExtraOutputArgs: outputArgs,
 		}
 
 		if options.Audio {
@@ -129,12 +134,17 @@ func (g Generator) sceneMarkerWebp(input string, options sceneMarkerOptions) gen
 			"-threads", "4",
 		)
 
+		inputArgs, outputArgs := g.previewArgs()
+
 		trimOptions := transcoder.TranscodeOptions{
 			Duration:   markerImageDuration,
 			StartTime:  float64(options.Seconds),
 			OutputPath: tmpFn,
 			VideoCodec: ffmpeg.VideoCodecLibWebP,
 			VideoArgs:  videoArgs,
+
+			ExtraInputArgs:  inputArgs,
+			ExtraOutputArgs: outputArgs,
 		}
 
 		args := transcoder.Transcode(input, trimOptions)
@@ -173,11 +183,16 @@ type SceneMarkerScreenshotOptions struct {
 
 func (g Generator) sceneMarkerScreenshot(input string, options SceneMarkerScreenshotOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		inputArgs, outputArgs := g.screenshotArgs()
+
 		ssOptions := transcoder.ScreenshotOptions{
 			OutputPath: tmpFn,
 			OutputType: transcoder.ScreenshotOutputTypeImage2,
 			Quality:    markerScreenshotQuality,
 			Width:      options.Width,
+
+			ExtraInputArgs:  inputArgs,
+			ExtraOutputArgs: outputArgs,
 		}
 
 		args := transcoder.ScreenshotTime(input, float64(options.Seconds), ssOptions)
@@ -32,6 +32,9 @@ type PreviewOptions struct {
 
 	Preset string
 
+	// Width of the preview video. Defaults to 640 if not set.
+	Width int
+
 	Audio bool
 }
 
@@ -127,6 +130,7 @@ func (g *Generator) previewVideo(input string, videoDuration float64, options Pr
 				StartTime:  time,
 				Duration:   segmentDuration,
 				OutputPath: chunkFile.Name(),
+				Width:      options.Width,
 				Audio:      options.Audio,
 				Preset:     options.Preset,
 			}
@@ -134,6 +138,8 @@ func (g *Generator) previewVideo(input string, videoDuration float64, options Pr
 			if err := g.previewVideoChunk(lockCtx, input, chunkOptions, fallback, useVsync2); err != nil {
 				return err
 			}
+
+			g.reportProgress(float64(i+1) / float64(options.Segments))
 		}
 
 		// generate concat file based on generated video chunks
@@ -156,6 +162,7 @@ func (g *Generator) previewVideoSingle(input string, videoDuration float64, opti
 			StartTime:  0,
 			Duration:   videoDuration,
 			OutputPath: tmpFn,
+			Width:      options.Width,
 			Audio:      options.Audio,
 			Preset:     options.Preset,
 		}
@@ -168,13 +175,21 @@ type previewChunkOptions struct {
 	StartTime  float64
 	Duration   float64
 	OutputPath string
+	Width      int
 	Audio      bool
 	Preset     string
 }
 
 func (g Generator) previewVideoChunk(lockCtx *fsutil.LockContext, fn string, options previewChunkOptions, fallback bool, useVsync2 bool) error {
+	width := options.Width
+	if width <= 0 {
+		width = scenePreviewWidth
+	}
+	// yuv420p requires even dimensions
+	width -= width % 2
+
 	var videoFilter ffmpeg.VideoFilter
-	videoFilter = videoFilter.ScaleWidth(scenePreviewWidth)
+	videoFilter = videoFilter.ScaleWidth(width)
 
 	var videoArgs ffmpeg.Args
 	videoArgs = videoArgs.VideoFilter(videoFilter)
@@ -193,6 +208,8 @@ func (g Generator) previewVideoChunk(lockCtx *fsutil.LockContext, fn string, opt
 		videoArgs = append(videoArgs, "-vsync", "2")
 	}
 
+	inputArgs, outputArgs := g.previewArgs()
+
 	trimOptions := transcoder.TranscodeOptions{
 		OutputPath: options.OutputPath,
 		StartTime:  options.StartTime,
@@ -204,8 +221,8 @@ func (g Generator) previewVideoChunk(lockCtx *fsutil.LockContext, fn string, opt
 		VideoCodec: ffmpeg.VideoCodecLibX264,
 		VideoArgs:  videoArgs,
 
-		ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
-		ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
+		ExtraInputArgs:  inputArgs,
+		ExtraOutputArgs: outputArgs,
 	}
 
 	if options.Audio {
@@ -301,14 +318,16 @@ func (g Generator) previewVideoToImage(input string) generateFn {
 			"-threads", "4",
 		)
 
+		inputArgs, outputArgs := g.previewArgs()
+
 		encodeOptions := transcoder.TranscodeOptions{
 			OutputPath: tmpFn,
 
 			VideoCodec: ffmpeg.VideoCodecLibWebP,
 			VideoArgs:  videoArgs,
 
-			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
-			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
+			ExtraInputArgs:  inputArgs,
+			ExtraOutputArgs: outputArgs,
 		}
 
 		args := transcoder.Transcode(input, encodeOptions)
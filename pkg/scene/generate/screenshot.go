@@ -44,6 +44,19 @@ func (g Generator) Screenshot(ctx context.Context, input string, videoWidth int,
 	return ret, nil
 }
 
+// Frame returns a JPEG image of the frame at the provided time. The image is
+// scaled to width if it is not zero.
+func (g Generator) Frame(ctx context.Context, input string, at float64, width int) ([]byte, error) {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	return g.generateBytes(lockCtx, g.ScenePaths, jpgPattern, g.screenshot(input, screenshotOptions{
+		Time:    at,
+		Width:   width,
+		Quality: screenshotQuality,
+	}))
+}
+
 type screenshotOptions struct {
 	Time    float64
 	Width   int
@@ -52,11 +65,16 @@ type screenshotOptions struct {
 
 func (g Generator) screenshot(input string, options screenshotOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		inputArgs, outputArgs := g.screenshotArgs()
+
 		ssOptions := transcoder.ScreenshotOptions{
 			OutputPath: tmpFn,
 			OutputType: transcoder.ScreenshotOutputTypeImage2,
 			Quality:    options.Quality,
 			Width:      options.Width,
+
+			ExtraInputArgs:  inputArgs,
+			ExtraOutputArgs: outputArgs,
 		}
 
 		args := transcoder.ScreenshotTime(input, options.Time, ssOptions)
@@ -30,10 +30,15 @@ func (g Generator) SpriteScreenshot(ctx context.Context, input string, seconds f
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
+	inputArgs, outputArgs := g.screenshotArgs()
+
 	ssOptions := transcoder.ScreenshotOptions{
 		OutputPath: "-",
 		OutputType: transcoder.ScreenshotOutputTypeBMP,
 		Width:      spriteScreenshotWidth,
+
+		ExtraInputArgs:  inputArgs,
+		ExtraOutputArgs: outputArgs,
 	}
 
 	args := transcoder.ScreenshotTime(input, seconds, ssOptions)
@@ -45,10 +50,15 @@ func (g Generator) SpriteScreenshotSlow(ctx context.Context, input string, frame
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
+	inputArgs, outputArgs := g.screenshotArgs()
+
 	ssOptions := transcoder.ScreenshotOptions{
 		OutputPath: "-",
 		OutputType: transcoder.ScreenshotOutputTypeBMP,
 		Width:      spriteScreenshotWidth,
+
+		ExtraInputArgs:  inputArgs,
+		ExtraOutputArgs: outputArgs,
 	}
 
 	args := transcoder.ScreenshotFrame(input, frame, ssOptions)
@@ -0,0 +1,115 @@
+package generate
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"sync"
+
+	stashExec "github.com/stashapp/stash/pkg/exec"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type ThrottleConfig interface {
+	// GetGenerateFFMpegThreads returns the number of threads passed to ffmpeg.
+	// Zero uses the ffmpeg default.
+	GetGenerateFFMpegThreads() int
+	// GetGenerateFFMpegNiceness returns the niceness of ffmpeg processes.
+	// Zero leaves the priority unchanged.
+	GetGenerateFFMpegNiceness() int
+	// GetGenerateMaxFFMpegProcesses returns the maximum number of ffmpeg
+	// processes that may run at once. Zero is unlimited.
+	GetGenerateMaxFFMpegProcesses() int
+}
+
+// Throttle limits the resources used by the ffmpeg processes run by
+// generators. A single Throttle should be shared by all generators so that
+// the process limit applies across tasks. A nil Throttle does not limit
+// anything.
+type Throttle struct {
+	Config ThrottleConfig
+
+	mutex    sync.Mutex
+	running  int
+	released chan struct{}
+}
+
+// acquire waits until another ffmpeg process may be started.
+func (t *Throttle) acquire(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	for {
+		t.mutex.Lock()
+		max := t.Config.GetGenerateMaxFFMpegProcesses()
+		if max <= 0 || t.running < max {
+			t.running++
+			t.mutex.Unlock()
+			return nil
+		}
+
+		if t.released == nil {
+			t.released = make(chan struct{})
+		}
+		released := t.released
+		t.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// release must be called when a process started after acquire has finished.
+func (t *Throttle) release() {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.running--
+	if t.released != nil {
+		close(t.released)
+		t.released = nil
+	}
+}
+
+// args returns args with the thread limit applied to the output.
+func (t *Throttle) args(args []string) []string {
+	if t == nil || len(args) == 0 {
+		return args
+	}
+
+	threads := t.Config.GetGenerateFFMpegThreads()
+	if threads <= 0 {
+		return args
+	}
+
+	// output options must precede the output file, which is the last argument
+	last := len(args) - 1
+	ret := make([]string, 0, len(args)+2)
+	ret = append(ret, args[:last]...)
+	ret = append(ret, "-threads", strconv.Itoa(threads))
+	return append(ret, args[last])
+}
+
+// started applies the configured priority to a started command.
+func (t *Throttle) started(cmd *exec.Cmd) {
+	if t == nil {
+		return
+	}
+
+	niceness := t.Config.GetGenerateFFMpegNiceness()
+	if niceness == 0 {
+		return
+	}
+
+	if err := stashExec.SetPriority(cmd, niceness); err != nil {
+		logger.Warnf("error setting ffmpeg priority: %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package generate
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testThrottleConfig struct {
+	threads      int
+	niceness     int
+	maxProcesses int
+}
+
+func (c testThrottleConfig) GetGenerateFFMpegThreads() int {
+	return c.threads
+}
+
+func (c testThrottleConfig) GetGenerateFFMpegNiceness() int {
+	return c.niceness
+}
+
+func (c testThrottleConfig) GetGenerateMaxFFMpegProcesses() int {
+	return c.maxProcesses
+}
+
+// runThrottled runs n processes of the given duration through the throttle
+// and returns the maximum number that ran at once.
+func runThrottled(t *testing.T, throttle *Throttle, n int, d time.Duration) int {
+	t.Helper()
+
+	var (
+		wg         sync.WaitGroup
+		mutex      sync.Mutex
+		running    int
+		maxRunning int
+	)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := throttle.acquire(context.Background()); err != nil {
+				t.Errorf("acquire: %v", err)
+				return
+			}
+			defer throttle.release()
+
+			mutex.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mutex.Unlock()
+
+			time.Sleep(d)
+
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return maxRunning
+}
+
+func TestThrottle_acquire(t *testing.T) {
+	const (
+		processes = 8
+		duration  = 20 * time.Millisecond
+	)
+
+	tests := []struct {
+		name         string
+		maxProcesses int
+		want         int
+	}{
+		{"limited", 2, 2},
+		{"single", 1, 1},
+		{"unlimited", 0, processes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttle := &Throttle{Config: testThrottleConfig{maxProcesses: tt.maxProcesses}}
+
+			got := runThrottled(t, throttle, processes, duration)
+			assert.Equal(t, tt.want, got)
+			assert.Zero(t, throttle.running)
+		})
+	}
+}
+
+func TestThrottle_acquireNil(t *testing.T) {
+	var throttle *Throttle
+	assert.Nil(t, throttle.acquire(context.Background()))
+	throttle.release()
+}
+
+func TestThrottle_acquireCancel(t *testing.T) {
+	throttle := &Throttle{Config: testThrottleConfig{maxProcesses: 1}}
+
+	if err := throttle.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+	go func() {
+		done <- throttle.acquire(ctx)
+	}()
+
+	// the second process waits for the first
+	select {
+	case err := <-done:
+		t.Fatalf("acquire returned before release: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("acquire did not return after cancel")
+	}
+
+	// the cancelled process is not counted
+	throttle.release()
+	assert.Zero(t, throttle.running)
+
+	// released processes may be started again
+	if err := throttle.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	throttle.release()
+}
+
+func TestThrottle_acquireAfterRelease(t *testing.T) {
+	throttle := &Throttle{Config: testThrottleConfig{maxProcesses: 1}}
+
+	if err := throttle.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- throttle.acquire(context.Background())
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	throttle.release()
+
+	select {
+	case err := <-done:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("acquire did not return after release")
+	}
+
+	throttle.release()
+	assert.Zero(t, throttle.running)
+}
+
+func TestThrottle_args(t *testing.T) {
+	args := []string{"-i", "input.mp4", "-c:v", "libx264", "output.mp4"}
+
+	tests := []struct {
+		name     string
+		throttle *Throttle
+		args     []string
+		want     []string
+	}{
+		{"nil", nil, args, args},
+		{"default threads", &Throttle{Config: testThrottleConfig{}}, args, args},
+		{
+			"threads",
+			&Throttle{Config: testThrottleConfig{threads: 2}},
+			args,
+			[]string{"-i", "input.mp4", "-c:v", "libx264", "-threads", "2", "output.mp4"},
+		},
+		{"empty", &Throttle{Config: testThrottleConfig{threads: 2}}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.throttle.args(tt.args))
+		})
+	}
+
+	// the original args are not modified
+	assert.Equal(t, []string{"-i", "input.mp4", "-c:v", "libx264", "output.mp4"}, args)
+}
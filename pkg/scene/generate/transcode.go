@@ -2,16 +2,34 @@ package generate
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 type TranscodeOptions struct {
 	Width  int
 	Height int
+	// ToneMap converts HDR video to SDR
+	ToneMap bool
+	// Duration of the video in seconds, used to report progress
+	Duration float64
+}
+
+// videoFilter returns the video filter for options.
+func (o TranscodeOptions) videoFilter() ffmpeg.VideoFilter {
+	var videoFilter ffmpeg.VideoFilter
+	if o.ToneMap {
+		videoFilter = videoFilter.ToneMap()
+	}
+	if o.Width != 0 && o.Height != 0 {
+		videoFilter = videoFilter.ScaleDimensions(o.Width, o.Height)
+	}
+	return videoFilter
 }
 
 func (g Generator) Transcode(ctx context.Context, input string, hash string, options TranscodeOptions) error {
@@ -32,19 +50,21 @@ func (g Generator) TranscodeVideo(ctx context.Context, input string, hash string
 }
 
 // TranscodeAudio will copy the video stream as is, and transcode audio.
-func (g Generator) TranscodeAudio(ctx context.Context, input string, hash string) error {
+// Duration is the duration of the video in seconds, used to report progress.
+func (g Generator) TranscodeAudio(ctx context.Context, input string, hash string, duration float64) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
-	return g.makeTranscode(lockCtx, hash, g.transcodeAudio(input))
+	return g.makeTranscode(lockCtx, hash, g.transcodeAudio(input, duration))
 }
 
 // TranscodeCopyVideo will copy the video stream as is, and drop the audio stream.
-func (g Generator) TranscodeCopyVideo(ctx context.Context, input string, hash string) error {
+// Duration is the duration of the video in seconds, used to report progress.
+func (g Generator) TranscodeCopyVideo(ctx context.Context, input string, hash string, duration float64) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
-	return g.makeTranscode(lockCtx, hash, g.transcodeCopyVideo(input))
+	return g.makeTranscode(lockCtx, hash, g.transcodeCopyVideo(input, duration))
 }
 
 func (g Generator) makeTranscode(lockCtx *fsutil.LockContext, hash string, generateFn generateFn) error {
@@ -64,72 +84,103 @@ func (g Generator) makeTranscode(lockCtx *fsutil.LockContext, hash string, gener
 	return nil
 }
 
+// transcodeVideoCodec returns the configured video codec of generated
+// transcodes and its encoding arguments.
+func (g Generator) transcodeVideoCodec() (ffmpeg.VideoCodec, ffmpeg.Args) {
+	crf := g.FFMpegConfig.GetGeneratedTranscodeCRF()
+
+	switch g.FFMpegConfig.GetGeneratedTranscodeCodec() {
+	case models.TranscodeCodecVp9:
+		if crf == 0 {
+			crf = 31
+		}
+		return ffmpeg.VideoCodecVP9, ffmpeg.Args{
+			"-pix_fmt", "yuv420p",
+			"-deadline", "good",
+			"-cpu-used", "4",
+			"-row-mt", "1",
+			"-crf", strconv.Itoa(crf),
+			"-b:v", "0",
+		}
+	case models.TranscodeCodecAv1:
+		if crf == 0 {
+			crf = 32
+		}
+		return ffmpeg.VideoCodecSVTAV1, ffmpeg.Args{
+			"-pix_fmt", "yuv420p",
+			"-preset", "8",
+			"-crf", strconv.Itoa(crf),
+		}
+	default:
+		if crf == 0 {
+			crf = 23
+		}
+		return ffmpeg.VideoCodecLibX264, ffmpeg.Args{
+			"-pix_fmt", "yuv420p",
+			"-profile:v", "high",
+			"-level", "4.2",
+			"-preset", "superfast",
+			"-crf", strconv.Itoa(crf),
+		}
+	}
+}
+
 func (g Generator) transcode(input string, options TranscodeOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
 		var videoArgs ffmpeg.Args
-		if options.Width != 0 && options.Height != 0 {
-			var videoFilter ffmpeg.VideoFilter
-			videoFilter = videoFilter.ScaleDimensions(options.Width, options.Height)
+		if videoFilter := options.videoFilter(); videoFilter != "" {
 			videoArgs = videoArgs.VideoFilter(videoFilter)
 		}
 
-		videoArgs = append(videoArgs,
-			"-pix_fmt", "yuv420p",
-			"-profile:v", "high",
-			"-level", "4.2",
-			"-preset", "superfast",
-			"-crf", "23",
-		)
+		videoCodec, codecArgs := g.transcodeVideoCodec()
+		videoArgs = append(videoArgs, codecArgs...)
+
+		inputArgs, outputArgs := g.transcodeArgs()
 
 		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
 			OutputPath: tmpFn,
-			VideoCodec: ffmpeg.VideoCodecLibX264,
+			VideoCodec: videoCodec,
 			VideoArgs:  videoArgs,
 			AudioCodec: ffmpeg.AudioCodecAAC,
 
-			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
-			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
+			ExtraInputArgs:  inputArgs,
+			ExtraOutputArgs: outputArgs,
 		})
 
-		return g.generate(lockCtx, args)
+		return g.generateProgress(lockCtx, args, options.Duration, g.Progress)
 	}
 }
 
 func (g Generator) transcodeVideo(input string, options TranscodeOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
 		var videoArgs ffmpeg.Args
-		if options.Width != 0 && options.Height != 0 {
-			var videoFilter ffmpeg.VideoFilter
-			videoFilter = videoFilter.ScaleDimensions(options.Width, options.Height)
+		if videoFilter := options.videoFilter(); videoFilter != "" {
 			videoArgs = videoArgs.VideoFilter(videoFilter)
 		}
 
-		videoArgs = append(videoArgs,
-			"-pix_fmt", "yuv420p",
-			"-profile:v", "high",
-			"-level", "4.2",
-			"-preset", "superfast",
-			"-crf", "23",
-		)
+		videoCodec, codecArgs := g.transcodeVideoCodec()
+		videoArgs = append(videoArgs, codecArgs...)
 
 		var audioArgs ffmpeg.Args
 		audioArgs = audioArgs.SkipAudio()
 
+		inputArgs, outputArgs := g.transcodeArgs()
+
 		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
 			OutputPath: tmpFn,
-			VideoCodec: ffmpeg.VideoCodecLibX264,
+			VideoCodec: videoCodec,
 			VideoArgs:  videoArgs,
 			AudioArgs:  audioArgs,
 
-			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
-			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
+			ExtraInputArgs:  inputArgs,
+			ExtraOutputArgs: outputArgs,
 		})
 
-		return g.generate(lockCtx, args)
+		return g.generateProgress(lockCtx, args, options.Duration, g.Progress)
 	}
 }
 
-func (g Generator) transcodeAudio(input string) generateFn {
+func (g Generator) transcodeAudio(input string, duration float64) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
 		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
 			OutputPath: tmpFn,
@@ -137,11 +188,11 @@ func (g Generator) transcodeAudio(input string) generateFn {
 			AudioCodec: ffmpeg.AudioCodecAAC,
 		})
 
-		return g.generate(lockCtx, args)
+		return g.generateProgress(lockCtx, args, duration, g.Progress)
 	}
 }
 
-func (g Generator) transcodeCopyVideo(input string) generateFn {
+func (g Generator) transcodeCopyVideo(input string, duration float64) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
 
 		var audioArgs ffmpeg.Args
@@ -153,6 +204,6 @@ func (g Generator) transcodeCopyVideo(input string) generateFn {
 			AudioArgs:  audioArgs,
 		})
 
-		return g.generate(lockCtx, args)
+		return g.generateProgress(lockCtx, args, duration, g.Progress)
 	}
 }
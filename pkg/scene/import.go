@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
 	"github.com/stashapp/stash/pkg/models/jsonschema"
@@ -31,6 +32,8 @@ type Importer struct {
 	Input               jsonschema.Scene
 	MissingRefBehaviour models.ImportMissingRefEnum
 	FileNamingAlgorithm models.HashAlgorithm
+	// RestoreID is the id to create the scene with, if set
+	RestoreID int
 
 	ID             int
 	scene          models.Scene
@@ -168,11 +171,25 @@ func (i *Importer) populateFiles(ctx context.Context) error {
 			return fmt.Errorf("error finding file: %w", err)
 		}
 
+		if f == nil {
+			// the file may have been moved since the export, so try to
+			// locate it by its hashes
+			f, err = i.findFileByHashes(ctx, path)
+			if err != nil {
+				return fmt.Errorf("error finding file by hash: %w", err)
+			}
+		}
+
 		if f == nil {
 			return fmt.Errorf("scene file '%s' not found", path)
-		} else {
-			files = append(files, f.(*models.VideoFile))
 		}
+
+		vf, ok := f.(*models.VideoFile)
+		if !ok {
+			return fmt.Errorf("file '%s' is not a video file", f.Base().Path)
+		}
+
+		files = append(files, vf)
 	}
 
 	i.scene.Files = models.NewRelatedVideoFiles(files)
@@ -180,6 +197,41 @@ func (i *Importer) populateFiles(ctx context.Context) error {
 	return nil
 }
 
+// findFileByHashes returns the first video file matching the exported
+// checksum or oshash of the file with the provided path. Returns nil if no
+// hashes were exported for the file or no matching file was found.
+func (i *Importer) findFileByHashes(ctx context.Context, path string) (models.File, error) {
+	for _, h := range i.Input.FileHashes {
+		if h.Path != path {
+			continue
+		}
+
+		var fps []models.Fingerprint
+		if h.Checksum != "" {
+			fps = append(fps, models.Fingerprint{Type: models.FingerprintTypeMD5, Fingerprint: h.Checksum})
+		}
+		if h.Oshash != "" {
+			fps = append(fps, models.Fingerprint{Type: models.FingerprintTypeOshash, Fingerprint: h.Oshash})
+		}
+
+		for _, fp := range fps {
+			found, err := i.FileFinder.FindByFingerprint(ctx, fp)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, f := range found {
+				if _, ok := f.(*models.VideoFile); ok {
+					logger.Infof("[scenes] scene file '%s' not found, using '%s' with matching %s", path, f.Base().Path, fp.Type)
+					return f, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
 func (i *Importer) populateStudio(ctx context.Context) error {
 	if i.Input.Studio != "" {
 		studio, err := i.StudioWriter.FindByName(ctx, i.Input.Studio, false)
@@ -485,6 +537,7 @@ func (i *Importer) Create(ctx context.Context) (*int, error) {
 	for _, f := range i.scene.Files.List() {
 		fileIDs = append(fileIDs, f.Base().ID)
 	}
+	i.scene.ID = i.RestoreID
 	if err := i.ReaderWriter.Create(ctx, &i.scene, fileIDs); err != nil {
 		return nil, fmt.Errorf("error creating scene: %v", err)
 	}
@@ -401,3 +401,58 @@ func TestImporterPreImportWithMissingTagCreateErr(t *testing.T) {
 
 	db.AssertExpectations(t)
 }
+
+func TestImporterPreImportWithMovedFile(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	const (
+		oldPath     = "old/path.mp4"
+		missingPath = "missing/path.mp4"
+		checksum    = "checksum"
+		oshash      = "oshash"
+	)
+
+	movedFile := &models.VideoFile{
+		BaseFile: &models.BaseFile{
+			ID:   1,
+			Path: "new/path.mp4",
+		},
+	}
+
+	i := Importer{
+		FileFinder: db.File,
+		Input: jsonschema.Scene{
+			Files: []string{oldPath},
+			FileHashes: []jsonschema.SceneFileHashes{
+				{
+					Path:     oldPath,
+					Checksum: checksum,
+					Oshash:   oshash,
+				},
+			},
+		},
+	}
+
+	db.File.On("FindByPath", testCtx, oldPath).Return(nil, nil).Once()
+	db.File.On("FindByFingerprint", testCtx, models.Fingerprint{
+		Type:        models.FingerprintTypeMD5,
+		Fingerprint: checksum,
+	}).Return(nil, nil).Once()
+	db.File.On("FindByFingerprint", testCtx, models.Fingerprint{
+		Type:        models.FingerprintTypeOshash,
+		Fingerprint: oshash,
+	}).Return([]models.File{movedFile}, nil).Once()
+
+	err := i.PreImport(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.VideoFile{movedFile}, i.scene.Files.List())
+
+	// no hashes for the file
+	i.Input.Files = []string{missingPath}
+	db.File.On("FindByPath", testCtx, missingPath).Return(nil, nil).Once()
+
+	err = i.PreImport(testCtx)
+	assert.NotNil(t, err)
+
+	db.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateHash(t *testing.T) {
+	const (
+		oldHash = "oldhash"
+		newHash = "newhash"
+	)
+
+	p := paths.NewPaths(t.TempDir(), "")
+	scenePaths := p.Scene
+
+	files := []func(string) string{
+		scenePaths.GetVideoPreviewPath,
+		scenePaths.GetWebpPreviewPath,
+		scenePaths.GetTranscodePath,
+		scenePaths.GetSpriteImageFilePath,
+		scenePaths.GetInteractiveHeatmapPath,
+		scenePaths.GetLegacyScreenshotPath,
+	}
+
+	for _, fn := range files {
+		writeTestFile(t, fn(oldHash), "")
+	}
+
+	oldSprite := filepath.Base(scenePaths.GetSpriteImageFilePath(oldHash))
+	writeTestFile(t, scenePaths.GetSpriteVttFilePath(oldHash), oldSprite+"#xywh=0,0,160,90")
+	writeTestFile(t, p.SceneMarkers.GetVideoPreviewPath(oldHash, 10), "")
+
+	MigrateHash(&p, oldHash, newHash)
+
+	for _, fn := range files {
+		assert.NoFileExists(t, fn(oldHash))
+		assert.FileExists(t, fn(newHash))
+	}
+
+	assert.FileExists(t, p.SceneMarkers.GetVideoPreviewPath(newHash, 10))
+
+	vtt, err := os.ReadFile(scenePaths.GetSpriteVttFilePath(newHash))
+	if assert.Nil(t, err) {
+		newSprite := filepath.Base(scenePaths.GetSpriteImageFilePath(newHash))
+		assert.Equal(t, newSprite+"#xywh=0,0,160,90", string(vtt))
+	}
+}
+
+func writeTestFile(t *testing.T, path string, contents string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,477 @@
+package scene
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+const nfoExt = ".nfo"
+
+var ErrNotNFO = errors.New("not a movie or episode NFO file")
+
+// NFO is the scene metadata read from a Kodi or Plex movie or episode NFO
+// file.
+type NFO struct {
+	XMLName   xml.Name
+	Title     string     `xml:"title,omitempty"`
+	Plot      string     `xml:"plot,omitempty"`
+	Premiered string     `xml:"premiered,omitempty"`
+	Aired     string     `xml:"aired,omitempty"`
+	Director  string     `xml:"director,omitempty"`
+	Studios   []string   `xml:"studio,omitempty"`
+	Genres    []string   `xml:"genre,omitempty"`
+	Tags      []string   `xml:"tag,omitempty"`
+	Actors    []NFOActor `xml:"actor,omitempty"`
+	// UserRating is the rating in the range 1 to 10. Only written on export.
+	UserRating int `xml:"userrating,omitempty"`
+	// Thumbs are the relative paths of the poster and fanart images. Only
+	// written on export.
+	Thumbs []NFOThumb `xml:"thumb,omitempty"`
+}
+
+type NFOActor struct {
+	Name string `xml:"name"`
+}
+
+type NFOThumb struct {
+	Aspect string `xml:"aspect,attr"`
+	Path   string `xml:",chardata"`
+}
+
+// ParseNFO reads a movie or episode NFO file. Returns ErrNotNFO if the
+// root element is not movie or episodedetails.
+func ParseNFO(r io.Reader) (*NFO, error) {
+	var ret NFO
+	if err := xml.NewDecoder(r).Decode(&ret); err != nil {
+		return nil, fmt.Errorf("parsing NFO: %w", err)
+	}
+
+	switch ret.XMLName.Local {
+	case "movie", "episodedetails":
+		return &ret, nil
+	default:
+		return nil, ErrNotNFO
+	}
+}
+
+// NFOPath returns the path of the NFO file with the same basename as the
+// video file at path.
+func NFOPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + nfoExt
+}
+
+// NFOPaths returns the paths of the NFO files that may be used for the video
+// file at path, in order of preference. Kodi and Plex use an NFO file with
+// the same basename as the video, or movie.nfo in the same folder. movie.nfo
+// describes the whole folder, so it is only included if onlyVideo is true,
+// indicating that the video is the only video file in its folder.
+func NFOPaths(path string, onlyVideo bool) []string {
+	ret := []string{NFOPath(path)}
+	if onlyVideo {
+		ret = append(ret, filepath.Join(filepath.Dir(path), "movie"+nfoExt))
+	}
+	return ret
+}
+
+// onlyVideoInFolder returns true if the video file at path is the only file
+// in its folder with one of the provided video extensions.
+func onlyVideoInFolder(path string, videoExtensions []string) (bool, error) {
+	const batchSize = 100
+
+	d, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return false, err
+	}
+	defer d.Close()
+
+	videos := 0
+	for {
+		entries, err := d.ReadDir(batchSize)
+		for _, e := range entries {
+			if !e.IsDir() && fsutil.MatchExtension(e.Name(), videoExtensions) {
+				videos++
+				if videos > 1 {
+					return false, nil
+				}
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// NFOImagePath returns the path of the poster or fanart image for the video
+// file at path, using the Kodi naming convention. kind is either "poster" or
+// "fanart".
+func NFOImagePath(path string, kind string, ext string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "-" + kind + ext
+}
+
+// FindNFO returns the first NFO file found for the video file at path.
+// videoExtensions is used to determine if the video is the only video in its
+// folder, in which case movie.nfo may be used. Returns nil if no NFO file is
+// found.
+func FindNFO(path string, videoExtensions []string) (*NFO, error) {
+	onlyVideo, err := onlyVideoInFolder(path, videoExtensions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range NFOPaths(path, onlyVideo) {
+		f, err := os.Open(p)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		nfo, err := ParseNFO(f)
+		f.Close()
+
+		if errors.Is(err, ErrNotNFO) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+
+		return nfo, nil
+	}
+
+	return nil, nil
+}
+
+// date returns the premiered date, falling back to the aired date for
+// episodes. Returns nil if neither is a valid date.
+func (n *NFO) date() *models.Date {
+	for _, s := range []string{n.Premiered, n.Aired} {
+		if d, err := models.ParseDate(strings.TrimSpace(s)); err == nil {
+			return &d
+		}
+	}
+
+	return nil
+}
+
+// tagNames returns the genres and tags of the NFO, without duplicates.
+func (n *NFO) tagNames() []string {
+	return cleanNFONames(append(append([]string{}, n.Genres...), n.Tags...))
+}
+
+func (n *NFO) actorNames() []string {
+	var ret []string
+	for _, a := range n.Actors {
+		ret = append(ret, a.Name)
+	}
+	return cleanNFONames(ret)
+}
+
+func cleanNFONames(names []string) []string {
+	var ret []string
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n != "" {
+			ret = append(ret, n)
+		}
+	}
+	return stringslice.UniqueFold(ret)
+}
+
+// WriteNFO writes nfo as an XML document to w.
+func WriteNFO(w io.Writer, nfo *NFO) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(nfo); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ToNFO returns the movie NFO for the provided scene.
+func ToNFO(ctx context.Context, studioReader models.StudioGetter, performerReader models.PerformerFinder, tagReader TagFinder, s *models.Scene) (*NFO, error) {
+	ret := &NFO{
+		XMLName:  xml.Name{Local: "movie"},
+		Title:    s.GetTitle(),
+		Plot:     s.Details,
+		Director: s.Director,
+	}
+
+	if s.Date != nil {
+		ret.Premiered = s.Date.String()
+	}
+
+	if s.Rating != nil {
+		// convert from 1-100 to 1-10
+		ret.UserRating = (*s.Rating + 5) / 10
+		if ret.UserRating < 1 {
+			ret.UserRating = 1
+		}
+	}
+
+	studioName, err := GetStudioName(ctx, studioReader, s)
+	if err != nil {
+		return nil, fmt.Errorf("getting studio: %w", err)
+	}
+	if studioName != "" {
+		ret.Studios = []string{studioName}
+	}
+
+	performers, err := performerReader.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting performers: %w", err)
+	}
+	for _, p := range performers {
+		ret.Actors = append(ret.Actors, NFOActor{Name: p.Name})
+	}
+
+	ret.Tags, err = GetTagNames(ctx, tagReader, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+type NFOUpdaterReaderWriter interface {
+	models.SceneUpdater
+	models.PerformerIDLoader
+	models.TagIDLoader
+}
+
+// NFOUpdater sets scene metadata from NFO files.
+type NFOUpdater struct {
+	ReaderWriter    NFOUpdaterReaderWriter
+	StudioWriter    models.StudioFinderCreator
+	PerformerWriter models.PerformerFinderCreator
+	TagWriter       models.TagFinderCreator
+
+	// Overwrite replaces existing title, details, director, date and studio
+	// values. Otherwise, only empty values are set. Performers and tags are
+	// always added to the existing values.
+	Overwrite bool
+	// MissingRefBehaviour determines how studios, performers and tags that
+	// do not exist are handled.
+	MissingRefBehaviour models.ImportMissingRefEnum
+}
+
+// Update sets the metadata of s from nfo. Returns true if the scene was
+// changed.
+func (u *NFOUpdater) Update(ctx context.Context, s *models.Scene, nfo *NFO) (bool, error) {
+	partial := models.NewScenePartial()
+	changed := false
+
+	setString := func(existing string, v string) models.OptionalString {
+		v = strings.TrimSpace(v)
+		if v == "" || v == existing || (existing != "" && !u.Overwrite) {
+			return models.OptionalString{}
+		}
+
+		changed = true
+		return models.NewOptionalString(v)
+	}
+
+	partial.Title = setString(s.Title, nfo.Title)
+	partial.Details = setString(s.Details, nfo.Plot)
+	partial.Director = setString(s.Director, nfo.Director)
+
+	if d := nfo.date(); d != nil && (s.Date == nil || (u.Overwrite && !s.Date.Equal(d.Time))) {
+		partial.Date = models.NewOptionalDate(*d)
+		changed = true
+	}
+
+	if studios := cleanNFONames(nfo.Studios); len(studios) > 0 && (s.StudioID == nil || u.Overwrite) {
+		studioID, err := u.findOrCreateStudio(ctx, studios[0])
+		if err != nil {
+			return false, err
+		}
+
+		if studioID != nil && (s.StudioID == nil || *s.StudioID != *studioID) {
+			partial.StudioID = models.NewOptionalInt(*studioID)
+			changed = true
+		}
+	}
+
+	if err := s.LoadPerformerIDs(ctx, u.ReaderWriter); err != nil {
+		return false, err
+	}
+
+	performerIDs, err := u.performerIDs(ctx, nfo.actorNames())
+	if err != nil {
+		return false, err
+	}
+
+	if newIDs := sliceutil.Exclude(performerIDs, s.PerformerIDs.List()); len(newIDs) > 0 {
+		partial.PerformerIDs = &models.UpdateIDs{
+			IDs:  newIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+		changed = true
+	}
+
+	if err := s.LoadTagIDs(ctx, u.ReaderWriter); err != nil {
+		return false, err
+	}
+
+	tagIDs, err := u.tagIDs(ctx, nfo.tagNames())
+	if err != nil {
+		return false, err
+	}
+
+	if newIDs := sliceutil.Exclude(tagIDs, s.TagIDs.List()); len(newIDs) > 0 {
+		partial.TagIDs = &models.UpdateIDs{
+			IDs:  newIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	if _, err := u.ReaderWriter.UpdatePartial(ctx, s.ID, partial); err != nil {
+		return false, fmt.Errorf("updating scene: %w", err)
+	}
+
+	return true, nil
+}
+
+func (u *NFOUpdater) missingRefBehaviour() models.ImportMissingRefEnum {
+	if u.MissingRefBehaviour == "" {
+		return models.ImportMissingRefEnumIgnore
+	}
+	return u.MissingRefBehaviour
+}
+
+func (u *NFOUpdater) findOrCreateStudio(ctx context.Context, name string) (*int, error) {
+	studio, err := u.StudioWriter.FindByName(ctx, name, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding studio by name: %w", err)
+	}
+
+	if studio != nil {
+		return &studio.ID, nil
+	}
+
+	switch u.missingRefBehaviour() {
+	case models.ImportMissingRefEnumFail:
+		return nil, fmt.Errorf("studio '%s' not found", name)
+	case models.ImportMissingRefEnumCreate:
+		newStudio := models.NewStudio()
+		newStudio.Name = name
+
+		if err := u.StudioWriter.Create(ctx, &newStudio); err != nil {
+			return nil, fmt.Errorf("creating studio: %w", err)
+		}
+
+		return &newStudio.ID, nil
+	}
+
+	return nil, nil
+}
+
+func (u *NFOUpdater) performerIDs(ctx context.Context, names []string) ([]int, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	performers, err := u.PerformerWriter.FindByNames(ctx, names, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding performers by name: %w", err)
+	}
+
+	existing := make(map[string]int)
+	for _, p := range performers {
+		existing[strings.ToLower(p.Name)] = p.ID
+	}
+
+	return u.resolveNames(names, existing, "performers", func(name string) (int, error) {
+		newPerformer := models.NewPerformer()
+		newPerformer.Name = name
+
+		err := u.PerformerWriter.Create(ctx, &newPerformer)
+		return newPerformer.ID, err
+	})
+}
+
+func (u *NFOUpdater) tagIDs(ctx context.Context, names []string) ([]int, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tags, err := u.TagWriter.FindByNames(ctx, names, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding tags by name: %w", err)
+	}
+
+	existing := make(map[string]int)
+	for _, t := range tags {
+		existing[strings.ToLower(t.Name)] = t.ID
+	}
+
+	return u.resolveNames(names, existing, "tags", func(name string) (int, error) {
+		newTag := models.NewTag()
+		newTag.Name = name
+
+		err := u.TagWriter.Create(ctx, &newTag)
+		return newTag.ID, err
+	})
+}
+
+// resolveNames returns the IDs of the objects with the provided names,
+// handling missing objects according to the missing reference behaviour.
+// existing maps lowercase names to IDs.
+func (u *NFOUpdater) resolveNames(names []string, existing map[string]int, kind string, create func(name string) (int, error)) ([]int, error) {
+	var ret []int
+	var missing []string
+	for _, name := range names {
+		if id, found := existing[strings.ToLower(name)]; found {
+			ret = append(ret, id)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return ret, nil
+	}
+
+	switch u.missingRefBehaviour() {
+	case models.ImportMissingRefEnumFail:
+		return nil, fmt.Errorf("%s [%s] not found", kind, strings.Join(missing, ", "))
+	case models.ImportMissingRefEnumCreate:
+		for _, name := range missing {
+			id, err := create(name)
+			if err != nil {
+				return nil, fmt.Errorf("creating %s: %w", kind, err)
+			}
+
+			ret = append(ret, id)
+		}
+	}
+
+	return ret, nil
+}
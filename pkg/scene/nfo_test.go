@@ -0,0 +1,271 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testNFO = `<?xml version="1.0" encoding="UTF-8" standalone="yes" ?>
+<movie>
+  <title>Movie Title</title>
+  <plot>Movie plot.</plot>
+  <premiered>2021-03-04</premiered>
+  <director>Director Name</director>
+  <studio>Studio Name</studio>
+  <genre>Drama</genre>
+  <genre>drama</genre>
+  <tag>Favourite</tag>
+  <actor>
+    <name>Actor One</name>
+    <role>Role</role>
+  </actor>
+  <actor>
+    <name> Actor Two </name>
+  </actor>
+</movie>
+`
+
+func TestParseNFO(t *testing.T) {
+	nfo, err := ParseNFO(strings.NewReader(testNFO))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "Movie Title", nfo.Title)
+	assert.Equal(t, "Movie plot.", nfo.Plot)
+	assert.Equal(t, "Director Name", nfo.Director)
+	assert.Equal(t, []string{"Studio Name"}, nfo.Studios)
+	assert.Equal(t, []string{"Drama", "Favourite"}, nfo.tagNames())
+	assert.Equal(t, []string{"Actor One", "Actor Two"}, nfo.actorNames())
+
+	if d := nfo.date(); assert.NotNil(t, d) {
+		assert.Equal(t, "2021-03-04", d.String())
+	}
+
+	episode := `<episodedetails><title>Episode</title><aired>2020-01-02</aired></episodedetails>`
+	nfo, err = ParseNFO(strings.NewReader(episode))
+	if assert.Nil(t, err) {
+		assert.Equal(t, "Episode", nfo.Title)
+		if d := nfo.date(); assert.NotNil(t, d) {
+			assert.Equal(t, "2020-01-02", d.String())
+		}
+	}
+
+	_, err = ParseNFO(strings.NewReader(`<tvshow><title>Show</title></tvshow>`))
+	assert.ErrorIs(t, err, ErrNotNFO)
+
+	_, err = ParseNFO(strings.NewReader(`not xml`))
+	assert.NotNil(t, err)
+}
+
+func TestNFOPaths(t *testing.T) {
+	path := filepath.Join("videos", "movie name.mp4")
+	assert.Equal(t, []string{
+		filepath.Join("videos", "movie name.nfo"),
+		filepath.Join("videos", "movie.nfo"),
+	}, NFOPaths(path, true))
+	assert.Equal(t, []string{
+		filepath.Join("videos", "movie name.nfo"),
+	}, NFOPaths(path, false))
+}
+
+func TestFindNFO(t *testing.T) {
+	videoExtensions := []string{"mp4", "mkv"}
+
+	writeFile := func(path string, data string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	nfo := func(title string) string {
+		return "<movie><title>" + title + "</title></movie>"
+	}
+
+	// movie.nfo is used for the only video in a folder
+	single := t.TempDir()
+	writeFile(filepath.Join(single, "movie.nfo"), nfo("folder"))
+	writeFile(filepath.Join(single, "video.mp4"), "")
+	writeFile(filepath.Join(single, "poster.jpg"), "")
+
+	got, err := FindNFO(filepath.Join(single, "video.mp4"), videoExtensions)
+	if assert.Nil(t, err) && assert.NotNil(t, got) {
+		assert.Equal(t, "folder", got.Title)
+	}
+
+	// movie.nfo is not used when the folder contains multiple videos
+	multiple := t.TempDir()
+	writeFile(filepath.Join(multiple, "movie.nfo"), nfo("folder"))
+	writeFile(filepath.Join(multiple, "first.mp4"), "")
+	writeFile(filepath.Join(multiple, "second.MKV"), "")
+	writeFile(filepath.Join(multiple, "second.nfo"), nfo("second"))
+
+	got, err = FindNFO(filepath.Join(multiple, "first.mp4"), videoExtensions)
+	assert.Nil(t, err)
+	assert.Nil(t, got)
+
+	// the video's own NFO file is still used
+	got, err = FindNFO(filepath.Join(multiple, "second.MKV"), videoExtensions)
+	if assert.Nil(t, err) && assert.NotNil(t, got) {
+		assert.Equal(t, "second", got.Title)
+	}
+}
+
+func TestNFOUpdater_Update(t *testing.T) {
+	const (
+		sceneID           = 1
+		existingStudioID  = 2
+		existingActorID   = 3
+		createdActorID    = 4
+		existingTagID     = 5
+		createdTagID      = 6
+		alreadySetActorID = 7
+	)
+
+	nfo, err := ParseNFO(strings.NewReader(testNFO))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := mocks.NewDatabase()
+
+	db.Studio.On("FindByName", testCtx, "Studio Name", true).Return(&models.Studio{
+		ID: existingStudioID,
+	}, nil)
+	db.Performer.On("FindByNames", testCtx, []string{"Actor One", "Actor Two"}, true).Return([]*models.Performer{
+		{ID: existingActorID, Name: "actor one"},
+	}, nil)
+	db.Performer.On("Create", testCtx, mock.MatchedBy(func(p *models.Performer) bool {
+		return p.Name == "Actor Two"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Performer).ID = createdActorID
+	}).Return(nil)
+	db.Tag.On("FindByNames", testCtx, []string{"Drama", "Favourite"}, true).Return([]*models.Tag{
+		{ID: existingTagID, Name: "Drama"},
+	}, nil)
+	db.Tag.On("Create", testCtx, mock.MatchedBy(func(t *models.Tag) bool {
+		return t.Name == "Favourite"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Tag).ID = createdTagID
+	}).Return(nil)
+
+	db.Scene.On("UpdatePartial", testCtx, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+		// title is already set, so is not overwritten
+		return !p.Title.Set &&
+			p.Details.Value == "Movie plot." &&
+			p.Director.Value == "Director Name" &&
+			p.Date.Value.String() == "2021-03-04" &&
+			p.StudioID.Value == existingStudioID &&
+			assert.ElementsMatch(t, []int{existingActorID, createdActorID}, p.PerformerIDs.IDs) &&
+			p.PerformerIDs.Mode == models.RelationshipUpdateModeAdd &&
+			assert.ElementsMatch(t, []int{existingTagID, createdTagID}, p.TagIDs.IDs)
+	})).Return(nil, nil).Once()
+
+	u := NFOUpdater{
+		ReaderWriter:        db.Scene,
+		StudioWriter:        db.Studio,
+		PerformerWriter:     db.Performer,
+		TagWriter:           db.Tag,
+		MissingRefBehaviour: models.ImportMissingRefEnumCreate,
+	}
+
+	s := &models.Scene{
+		ID:           sceneID,
+		Title:        "Existing Title",
+		PerformerIDs: models.NewRelatedIDs([]int{alreadySetActorID}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+	}
+
+	changed, err := u.Update(testCtx, s, nfo)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+
+	// nothing to change
+	s = &models.Scene{
+		ID:           sceneID,
+		Title:        "Movie Title",
+		Details:      "Movie plot.",
+		Director:     "Director Name",
+		Date:         nfo.date(),
+		StudioID:     &[]int{existingStudioID}[0],
+		PerformerIDs: models.NewRelatedIDs([]int{existingActorID, createdActorID}),
+		TagIDs:       models.NewRelatedIDs([]int{existingTagID, createdTagID}),
+	}
+
+	changed, err = u.Update(testCtx, s, nfo)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+
+	db.AssertExpectations(t)
+}
+
+func TestToNFO(t *testing.T) {
+	const (
+		sceneID  = 1
+		studioID = 2
+		rating   = 75
+	)
+
+	date, _ := models.ParseDate("2021-03-04")
+	studio := studioID
+	r := rating
+
+	s := &models.Scene{
+		ID:       sceneID,
+		Title:    "Movie Title",
+		Details:  "Movie plot.",
+		Director: "Director Name",
+		Date:     &date,
+		Rating:   &r,
+		StudioID: &studio,
+	}
+
+	db := mocks.NewDatabase()
+	db.Studio.On("Find", testCtx, studioID).Return(&models.Studio{Name: "Studio Name"}, nil)
+	db.Performer.On("FindBySceneID", testCtx, sceneID).Return([]*models.Performer{
+		{Name: "Actor One"},
+	}, nil)
+	db.Tag.On("FindBySceneID", testCtx, sceneID).Return([]*models.Tag{
+		{Name: "Drama"},
+	}, nil)
+
+	nfo, err := ToNFO(testCtx, db.Studio, db.Performer, db.Tag, s)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	nfo.Thumbs = []NFOThumb{{Aspect: "poster", Path: "movie-poster.jpg"}}
+
+	var buf strings.Builder
+	if !assert.Nil(t, WriteNFO(&buf, nfo)) {
+		return
+	}
+
+	assert.Contains(t, buf.String(), `<thumb aspect="poster">movie-poster.jpg</thumb>`)
+	assert.Contains(t, buf.String(), `<userrating>8</userrating>`)
+
+	// written NFO files can be read back
+	got, err := ParseNFO(strings.NewReader(buf.String()))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "movie", got.XMLName.Local)
+	assert.Equal(t, "Movie Title", got.Title)
+	assert.Equal(t, "Movie plot.", got.Plot)
+	assert.Equal(t, "Director Name", got.Director)
+	assert.Equal(t, "2021-03-04", got.Premiered)
+	assert.Equal(t, []string{"Studio Name"}, got.Studios)
+	assert.Equal(t, []string{"Actor One"}, got.actorNames())
+	assert.Equal(t, []string{"Drama"}, got.tagNames())
+
+	db.AssertExpectations(t)
+}
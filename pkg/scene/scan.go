@@ -79,6 +79,11 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 		}
 	}
 
+	// caption files may have been scanned before the video file was added
+	if err := video.AssociateCaptionFiles(ctx, videoFile, h.CaptionUpdater); err != nil {
+		logger.Warnf("error associating caption files for %s: %v", videoFile.Path, err)
+	}
+
 	// try to match the file to a scene
 	existing, err := h.CreatorUpdater.FindByFileID(ctx, f.Base().ID)
 	if err != nil {
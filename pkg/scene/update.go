@@ -121,8 +121,50 @@ func (s *Service) AssignFile(ctx context.Context, sceneID int, fileID models.Fil
 	}
 
 	if isPrimary {
-		return errors.New("cannot reassign primary file")
+		if err := s.promoteOtherFile(ctx, sceneID, fileID); err != nil {
+			return err
+		}
 	}
 
 	return s.Repository.AssignFiles(ctx, sceneID, []models.FileID{fileID})
 }
+
+// promoteOtherFile sets another file as the primary file of the scenes that
+// have fileID as their primary file, so that fileID can be reassigned to the
+// scene with sceneID. Returns an error if fileID is the only file of a scene.
+func (s *Service) promoteOtherFile(ctx context.Context, sceneID int, fileID models.FileID) error {
+	scenes, err := s.Repository.FindByPrimaryFileID(ctx, fileID)
+	if err != nil {
+		return err
+	}
+
+	for _, scn := range scenes {
+		if scn.ID == sceneID {
+			return errors.New("file is already the primary file of the scene")
+		}
+
+		if err := scn.LoadFiles(ctx, s.Repository); err != nil {
+			return err
+		}
+
+		var newPrimary *models.FileID
+		for _, f := range scn.Files.List() {
+			if f.ID != fileID {
+				newPrimary = &f.ID
+				break
+			}
+		}
+
+		if newPrimary == nil {
+			return errors.New("cannot reassign the only file of a scene")
+		}
+
+		if _, err := s.Repository.UpdatePartial(ctx, scn.ID, models.ScenePartial{
+			PrimaryFileID: newPrimary,
+		}); err != nil {
+			return fmt.Errorf("setting primary file of scene %d: %w", scn.ID, err)
+		}
+	}
+
+	return nil
+}
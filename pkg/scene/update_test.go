@@ -316,3 +316,98 @@ func TestUpdateSet_UpdateInput(t *testing.T) {
 		})
 	}
 }
+
+func TestService_AssignFile(t *testing.T) {
+	const (
+		sceneID = iota + 1
+		otherSceneID
+		singleFileSceneID
+	)
+
+	const (
+		fileID models.FileID = iota + 1
+		otherFileID
+		singleFileID
+		nonPrimaryFileID
+	)
+
+	videoFile := func(id models.FileID) *models.VideoFile {
+		return &models.VideoFile{
+			BaseFile: &models.BaseFile{ID: id},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		sceneID int
+		fileID  models.FileID
+		setup   func(db *mocks.Database)
+		wantErr bool
+	}{
+		{
+			"non-primary file",
+			sceneID,
+			nonPrimaryFileID,
+			func(db *mocks.Database) {
+				db.File.On("IsPrimary", testCtx, nonPrimaryFileID).Return(false, nil).Once()
+				db.Scene.On("AssignFiles", testCtx, sceneID, []models.FileID{nonPrimaryFileID}).Return(nil).Once()
+			},
+			false,
+		},
+		{
+			"primary file of scene with other files",
+			sceneID,
+			fileID,
+			func(db *mocks.Database) {
+				db.File.On("IsPrimary", testCtx, fileID).Return(true, nil).Once()
+				db.Scene.On("FindByPrimaryFileID", testCtx, fileID).Return([]*models.Scene{{ID: otherSceneID}}, nil).Once()
+				db.Scene.On("GetFiles", testCtx, otherSceneID).Return([]*models.VideoFile{videoFile(fileID), videoFile(otherFileID)}, nil).Once()
+				newPrimary := otherFileID
+				db.Scene.On("UpdatePartial", testCtx, otherSceneID, models.ScenePartial{PrimaryFileID: &newPrimary}).Return(&models.Scene{}, nil).Once()
+				db.Scene.On("AssignFiles", testCtx, sceneID, []models.FileID{fileID}).Return(nil).Once()
+			},
+			false,
+		},
+		{
+			"only file of scene",
+			sceneID,
+			singleFileID,
+			func(db *mocks.Database) {
+				db.File.On("IsPrimary", testCtx, singleFileID).Return(true, nil).Once()
+				db.Scene.On("FindByPrimaryFileID", testCtx, singleFileID).Return([]*models.Scene{{ID: singleFileSceneID}}, nil).Once()
+				db.Scene.On("GetFiles", testCtx, singleFileSceneID).Return([]*models.VideoFile{videoFile(singleFileID)}, nil).Once()
+			},
+			true,
+		},
+		{
+			"primary file of same scene",
+			sceneID,
+			fileID,
+			func(db *mocks.Database) {
+				db.File.On("IsPrimary", testCtx, fileID).Return(true, nil).Once()
+				db.Scene.On("FindByPrimaryFileID", testCtx, fileID).Return([]*models.Scene{{ID: sceneID}}, nil).Once()
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+			db.File.On("Find", testCtx, tt.fileID).Return([]models.File{videoFile(tt.fileID)}, nil).Once()
+			tt.setup(db)
+
+			s := &Service{
+				File:       db.File,
+				Repository: db.Scene,
+			}
+
+			err := s.AssignFile(testCtx, tt.sceneID, tt.fileID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.AssignFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			db.AssertExpectations(t)
+		})
+	}
+}
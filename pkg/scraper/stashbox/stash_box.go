@@ -646,6 +646,16 @@ func performerFragmentToScrapedPerformer(p graphql.PerformerFragment) *models.Sc
 		sp.Height = &hs
 	}
 
+	if p.CareerStartYear != nil {
+		cs := strconv.Itoa(*p.CareerStartYear)
+		sp.CareerStart = &cs
+	}
+
+	if p.CareerEndYear != nil {
+		ce := strconv.Itoa(*p.CareerEndYear)
+		sp.CareerEnd = &ce
+	}
+
 	if p.Birthdate != nil {
 		b := p.Birthdate.Date
 		sp.Birthdate = &b
@@ -705,6 +715,10 @@ func studioFragmentToScrapedStudio(s graphql.StudioFragment) *models.ScrapedStud
 		RemoteSiteID: &s.ID,
 	}
 
+	for _, u := range s.Urls {
+		st.URLs = append(st.URLs, u.URL)
+	}
+
 	if len(st.Images) > 0 {
 		st.Image = &st.Images[0]
 	}
@@ -1191,7 +1205,10 @@ func (c Client) SubmitPerformerDraft(ctx context.Context, performer *models.Perf
 		aliases := strings.Join(performer.Aliases.List(), ",")
 		draft.Aliases = &aliases
 	}
-	if performer.CareerLength != "" {
+	if performer.CareerStart != nil || performer.CareerEnd != nil {
+		draft.CareerStartYear = performer.CareerStart
+		draft.CareerEndYear = performer.CareerEnd
+	} else if performer.CareerLength != "" {
 		var career = strings.Split(performer.CareerLength, "-")
 		if i, err := strconv.Atoi(strings.TrimSpace(career[0])); err == nil {
 			draft.CareerStartYear = &i
@@ -33,6 +33,12 @@ func (s *Store) VisitedPluginHandler() func(http.Handler) http.Handler {
 				visitedPlugins, _ := val.([]VisitedPluginHook)
 
 				ctx := setVisitedPluginHooks(r.Context(), visitedPlugins)
+
+				// cookies made for plugins are marked as such
+				if isPlugin, _ := session.Values[pluginKey].(bool); isPlugin {
+					ctx = SetRequestSource(ctx, RequestSourcePlugin)
+				}
+
 				r = r.WithContext(ctx)
 			}
 
@@ -70,6 +76,7 @@ func (s *Store) MakePluginCookie(ctx context.Context) *http.Cookie {
 	}
 
 	session.Values[visitedPluginHooksKey] = visitedPlugins
+	session.Values[pluginKey] = true
 
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values,
 		s.sessionStore.Codecs...)
@@ -14,11 +14,24 @@ type key int
 const (
 	contextUser key = iota
 	contextVisitedPlugins
+	contextRequestSource
 )
 
 const (
 	userIDKey             = "userID"
 	visitedPluginHooksKey = "visitedPluginsHooks"
+	pluginKey             = "plugin"
+)
+
+// RequestSource identifies where a request was made from.
+type RequestSource string
+
+const (
+	// RequestSourceUI is used for requests authenticated with a session
+	// cookie, or without authentication.
+	RequestSourceUI     RequestSource = "UI"
+	RequestSourceAPIKey RequestSource = "API_KEY"
+	RequestSourcePlugin RequestSource = "PLUGIN"
 )
 
 const (
@@ -130,6 +143,39 @@ func (s *Store) GetSessionUserID(w http.ResponseWriter, r *http.Request) (string
 	return "", nil
 }
 
+func getAPIKey(r *http.Request) string {
+	apiKey := r.Header.Get(ApiKeyHeader)
+
+	// try getting the api key as a query parameter
+	if apiKey == "" {
+		apiKey = r.URL.Query().Get(ApiKeyParameter)
+	}
+
+	return apiKey
+}
+
+// GetRequestSourceFromRequest returns RequestSourceAPIKey if the request
+// includes an API key, and RequestSourceUI otherwise. Requests made by
+// plugins are identified by VisitedPluginHandler.
+func GetRequestSourceFromRequest(r *http.Request) RequestSource {
+	if getAPIKey(r) != "" {
+		return RequestSourceAPIKey
+	}
+
+	return RequestSourceUI
+}
+
+func SetRequestSource(ctx context.Context, source RequestSource) context.Context {
+	return context.WithValue(ctx, contextRequestSource, source)
+}
+
+// GetRequestSource gets the source of the request from the provided context.
+// Returns an empty string if the context is not from a request.
+func GetRequestSource(ctx context.Context) RequestSource {
+	ret, _ := ctx.Value(contextRequestSource).(RequestSource)
+	return ret
+}
+
 func SetCurrentUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, contextUser, userID)
 }
@@ -149,12 +195,7 @@ func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID str
 	c := s.config
 
 	// translate api key into current user, if present
-	apiKey := r.Header.Get(ApiKeyHeader)
-
-	// try getting the api key as a query parameter
-	if apiKey == "" {
-		apiKey = r.URL.Query().Get(ApiKeyParameter)
-	}
+	apiKey := getAPIKey(r)
 
 	if apiKey != "" {
 		// match against configured API and set userID to the
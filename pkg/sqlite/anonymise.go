@@ -47,17 +47,30 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 		return utils.Do([]func() error{
 			func() error { return db.deleteBlobs() },
 			func() error { return db.deleteStashIDs() },
+			// generated artifact records contain file hashes
+			func() error { return db.truncateTable(scenesGeneratedTable) },
+			// probe data contains paths and embedded metadata
+			func() error { return db.truncateTable(videoFileProbesTable) },
 			func() error { return db.anonymiseFolders(ctx) },
 			func() error { return db.anonymiseFiles(ctx) },
+			func() error { return db.anonymiseCaptions(ctx) },
 			func() error { return db.anonymiseFingerprints(ctx) },
 			func() error { return db.anonymiseScenes(ctx) },
 			func() error { return db.anonymiseMarkers(ctx) },
 			func() error { return db.anonymiseImages(ctx) },
 			func() error { return db.anonymiseGalleries(ctx) },
+			func() error { return db.anonymiseGalleryChapters(ctx) },
 			func() error { return db.anonymisePerformers(ctx) },
 			func() error { return db.anonymiseStudios(ctx) },
 			func() error { return db.anonymiseTags(ctx) },
 			func() error { return db.anonymiseGroups(ctx) },
+			// saved filters may contain names, paths and search terms
+			func() error { return db.truncateTable(savedFilterTable) },
+			// deleted objects contain the full metadata of the objects
+			func() error { return db.truncateTable(deletedObjectTable) },
+			// audit entries contain the input of changes
+			func() error { return db.truncateTable(auditEntryTable) },
+			func() error { return db.optimiseFTS() },
 			func() error { return db.Optimise(ctx) },
 		})
 	}(); err != nil {
@@ -159,6 +172,23 @@ func (db *Anonymiser) anonymiseFiles(ctx context.Context) error {
 	})
 }
 
+func (db *Anonymiser) anonymiseCaptions(ctx context.Context) error {
+	logger.Infof("Anonymising captions")
+	return txn.WithTxn(ctx, db, func(ctx context.Context) error {
+		table := goqu.T(videoCaptionsTable)
+		// captions filenames are based on the video file name
+		stmt := dialect.Update(table).Set(goqu.Record{
+			"filename": goqu.L("CAST(? AS VARCHAR) || '.' || ? || '.' || ?", table.Col(fileIDColumn), table.Col("language_code"), table.Col("caption_type")),
+		})
+
+		if _, err := exec(ctx, stmt); err != nil {
+			return fmt.Errorf("anonymising %s: %w", table.GetTable(), err)
+		}
+
+		return nil
+	})
+}
+
 func (db *Anonymiser) anonymiseFingerprints(ctx context.Context) error {
 	logger.Infof("Anonymising fingerprints")
 	table := fingerprintTableMgr.table
@@ -479,6 +509,36 @@ func (db *Anonymiser) anonymiseGalleries(ctx context.Context) error {
 		}
 	}
 
+	if err := db.anonymiseURLs(ctx, goqu.T(galleriesURLsTable), galleryIDColumn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *Anonymiser) anonymiseGalleryChapters(ctx context.Context) error {
+	logger.Infof("Anonymising gallery chapters")
+	return txn.WithTxn(ctx, db, func(ctx context.Context) error {
+		table := galleriesChaptersTableMgr.table
+		stmt := dialect.Update(table).Set(goqu.Record{"title": goqu.Cast(table.Col(idColumn), "VARCHAR")})
+
+		if _, err := exec(ctx, stmt); err != nil {
+			return fmt.Errorf("anonymising %s: %w", table.GetTable(), err)
+		}
+
+		return nil
+	})
+}
+
+// optimiseFTS merges the full-text indexes, so that the indexes do not
+// retain deleted entries containing the original text.
+func (db *Anonymiser) optimiseFTS() error {
+	for _, fts := range []ftsIndex{scenesFTS, performersFTS, tagsFTS} {
+		if _, err := db.db.Exec(fmt.Sprintf("INSERT INTO %[1]s(%[1]s) VALUES('optimize')", fts.table)); err != nil {
+			return fmt.Errorf("optimising %s: %w", fts.table, err)
+		}
+	}
+
 	return nil
 }
 
@@ -573,7 +633,6 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 			query := dialect.From(table).Select(
 				table.Col(idColumn),
 				table.Col("name"),
-				table.Col("url"),
 				table.Col("details"),
 			).Where(table.Col(idColumn).Gt(lastID)).Limit(1000)
 
@@ -584,14 +643,12 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 				var (
 					id      int
 					name    sql.NullString
-					url     sql.NullString
 					details sql.NullString
 				)
 
 				if err := rows.Scan(
 					&id,
 					&name,
-					&url,
 					&details,
 				); err != nil {
 					return err
@@ -599,7 +656,6 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 
 				set := goqu.Record{}
 				db.obfuscateNullString(set, "name", name)
-				db.obfuscateNullString(set, "url", url)
 				db.obfuscateNullString(set, "details", details)
 
 				if len(set) > 0 {
@@ -631,6 +687,10 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseURLs(ctx, goqu.T(studioURLsTable), studioIDColumn); err != nil {
+		return err
+	}
+
 	return nil
 }
 
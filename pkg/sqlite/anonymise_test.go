@@ -4,6 +4,7 @@
 package sqlite_test
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"testing"
@@ -35,5 +36,23 @@ func TestAnonymiser_Anonymise(t *testing.T) {
 
 	t.Logf("Anonymised database written to %s", f.Name())
 
-	// TODO - ensure anonymous
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Errorf("Could not read anonymised database: %v", err)
+		return
+	}
+
+	// ensure that text is not retained anywhere in the file, including the
+	// full-text indexes
+	for _, v := range []string{
+		getSceneStringValue(sceneIdxWithPerformer, titleField),
+		getPerformerStringValue(performerIdx1WithScene, "alias"),
+		getTagStringValue(tagIdxWithScene, "Alias"),
+	} {
+		if bytes.Contains(data, []byte(v)) {
+			t.Errorf("anonymised database contains %q", v)
+		}
+	}
+
+	// TODO - ensure other values are anonymous
 }
@@ -0,0 +1,175 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	auditEntryTable = "audit_entries"
+)
+
+type auditEntryRow struct {
+	ID         int         `db:"id" goqu:"skipinsert"`
+	CreatedAt  Timestamp   `db:"created_at"`
+	ObjectType string      `db:"object_type"`
+	ObjectID   int         `db:"object_id"`
+	Action     string      `db:"action"`
+	Source     string      `db:"source"`
+	UserID     zero.String `db:"user_id"`
+	PluginID   zero.String `db:"plugin_id"`
+	Fields     zero.String `db:"fields"`
+	Input      zero.String `db:"input"`
+}
+
+func (r *auditEntryRow) fromAuditEntry(o models.AuditEntry) {
+	r.ID = o.ID
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.ObjectType = o.ObjectType
+	r.ObjectID = o.ObjectID
+	r.Action = o.Action
+	r.Source = o.Source.String()
+	r.UserID = zero.StringFrom(o.UserID)
+	r.PluginID = zero.StringFrom(o.PluginID)
+	if len(o.Fields) > 0 {
+		r.Fields = zero.StringFrom(encodeJSONOrEmpty(o.Fields))
+	}
+	r.Input = zero.StringFrom(o.Input)
+}
+
+func (r *auditEntryRow) resolve() *models.AuditEntry {
+	ret := &models.AuditEntry{
+		ID:         r.ID,
+		CreatedAt:  r.CreatedAt.Timestamp,
+		ObjectType: r.ObjectType,
+		ObjectID:   r.ObjectID,
+		Action:     r.Action,
+		Source:     models.AuditSource(r.Source),
+		UserID:     r.UserID.String,
+		PluginID:   r.PluginID.String,
+		Input:      r.Input.String,
+	}
+
+	decodeJSON(r.Fields.String, &ret.Fields)
+
+	return ret
+}
+
+type AuditEntryStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewAuditEntryStore() *AuditEntryStore {
+	return &AuditEntryStore{
+		repository: repository{
+			tableName: auditEntryTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: auditEntryTableMgr,
+	}
+}
+
+func (qb *AuditEntryStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *AuditEntryStore) Create(ctx context.Context, newObject *models.AuditEntry) error {
+	var r auditEntryRow
+	r.fromAuditEntry(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+func (qb *AuditEntryStore) filterExpressions(filter *models.AuditEntryFilterType) ([]exp.Expression, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	table := qb.table()
+	var ret []exp.Expression
+
+	if filter.ObjectType != nil {
+		ret = append(ret, table.Col("object_type").Eq(*filter.ObjectType))
+	}
+	if filter.ObjectID != nil {
+		id, err := strconv.Atoi(*filter.ObjectID)
+		if err != nil {
+			return nil, fmt.Errorf("converting object id: %w", err)
+		}
+		ret = append(ret, table.Col("object_id").Eq(id))
+	}
+	if filter.Action != nil {
+		ret = append(ret, table.Col("action").Eq(*filter.Action))
+	}
+	if filter.Source != nil {
+		ret = append(ret, table.Col("source").Eq(filter.Source.String()))
+	}
+	if filter.PluginID != nil {
+		ret = append(ret, table.Col("plugin_id").Eq(*filter.PluginID))
+	}
+	if filter.CreatedAfter != nil {
+		ret = append(ret, table.Col("created_at").Gte(Timestamp{Timestamp: *filter.CreatedAfter}))
+	}
+	if filter.CreatedBefore != nil {
+		ret = append(ret, table.Col("created_at").Lt(Timestamp{Timestamp: *filter.CreatedBefore}))
+	}
+
+	return ret, nil
+}
+
+func (qb *AuditEntryStore) Query(ctx context.Context, filter *models.AuditEntryFilterType, findFilter *models.FindFilterType) ([]*models.AuditEntry, int, error) {
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	where, err := qb.filterExpressions(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	table := qb.table()
+
+	countQuery := dialect.From(table).Select(goqu.COUNT("*")).Where(where...)
+	total, err := count(ctx, countQuery)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	q := dialect.From(table).Select(table.All()).Where(where...).Order(table.Col("created_at").Desc(), table.Col(idColumn).Desc())
+
+	if !findFilter.IsGetAll() {
+		pageSize := findFilter.GetPageSize()
+		q = q.Limit(uint(pageSize)).Offset(uint((findFilter.GetPage() - 1) * pageSize))
+	}
+
+	var ret []*models.AuditEntry
+	if err := queryFunc(ctx, q, false, func(r *sqlx.Rows) error {
+		var row auditEntryRow
+		if err := r.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve())
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	return ret, total, nil
+}
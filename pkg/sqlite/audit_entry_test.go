@@ -0,0 +1,109 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEntryStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		assert := assert.New(t)
+		qb := db.AuditEntry
+
+		now := time.Now().Truncate(time.Second)
+		sceneID := sceneIDs[sceneIdxWithPerformer]
+		older := &models.AuditEntry{
+			CreatedAt:  now.AddDate(0, 0, -1),
+			ObjectType: "Scene",
+			ObjectID:   sceneID,
+			Action:     "Update",
+			Source:     models.AuditSourceUI,
+			UserID:     "user",
+			Fields:     []string{"id", "title"},
+			Input:      `{"id":"1","title":"title"}`,
+		}
+		newer := &models.AuditEntry{
+			CreatedAt:  now,
+			ObjectType: "Scene",
+			ObjectID:   sceneID,
+			Action:     "Destroy",
+			Source:     models.AuditSourcePlugin,
+			PluginID:   "plugin",
+		}
+		other := &models.AuditEntry{
+			CreatedAt:  now,
+			ObjectType: "Tag",
+			ObjectID:   tagIDs[tagIdxWithScene],
+			Action:     "Create",
+			Source:     models.AuditSourceTask,
+		}
+
+		for _, e := range []*models.AuditEntry{older, newer, other} {
+			if err := qb.Create(ctx, e); err != nil {
+				t.Errorf("Create() error = %v", err)
+				return nil
+			}
+		}
+
+		objectType := "Scene"
+		objectID := strconv.Itoa(sceneID)
+		found, count, err := qb.Query(ctx, &models.AuditEntryFilterType{
+			ObjectType: &objectType,
+			ObjectID:   &objectID,
+		}, nil)
+		if assert.Nil(err) && assert.Equal(2, count) && assert.Len(found, 2) {
+			// most recent first
+			assert.Equal(newer.ID, found[0].ID)
+			assert.Equal(models.AuditSourcePlugin, found[0].Source)
+			assert.Equal("plugin", found[0].PluginID)
+			assert.Len(found[0].Fields, 0)
+
+			assert.Equal(older.ID, found[1].ID)
+			assert.Equal("user", found[1].UserID)
+			assert.Equal(older.Fields, found[1].Fields)
+			assert.Equal(older.Input, found[1].Input)
+			assert.True(older.CreatedAt.Equal(found[1].CreatedAt))
+		}
+
+		source := models.AuditSourceTask
+		found, count, err = qb.Query(ctx, &models.AuditEntryFilterType{
+			Source: &source,
+		}, nil)
+		if assert.Nil(err) && assert.Equal(1, count) && assert.Len(found, 1) {
+			assert.Equal(other.ID, found[0].ID)
+		}
+
+		before := now.Add(-time.Hour)
+		found, _, err = qb.Query(ctx, &models.AuditEntryFilterType{
+			CreatedBefore: &before,
+		}, nil)
+		if assert.Nil(err) && assert.Len(found, 1) {
+			assert.Equal(older.ID, found[0].ID)
+		}
+
+		page := 2
+		perPage := 1
+		found, count, err = qb.Query(ctx, nil, &models.FindFilterType{
+			Page:    &page,
+			PerPage: &perPage,
+		})
+		if assert.Nil(err) && assert.Equal(3, count) && assert.Len(found, 1) {
+			assert.NotEqual(older.ID, found[0].ID)
+		}
+
+		_, _, err = qb.Query(ctx, &models.AuditEntryFilterType{
+			ObjectID: &objectType,
+		}, nil)
+		assert.NotNil(err)
+
+		return nil
+	})
+}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unsupportedBackends maps the connection string schemes of database servers
+// to their names. Only SQLite database files are supported: the migrations
+// and queries are written in SQLite SQL and rely on functions registered by
+// the custom SQLite driver.
+var unsupportedBackends = map[string]string{
+	"postgres":   "PostgreSQL",
+	"postgresql": "PostgreSQL",
+	"mysql":      "MySQL",
+	"mariadb":    "MariaDB",
+}
+
+// UnsupportedBackendError is returned when opening a database path that is a
+// connection string for a database server rather than a SQLite file.
+type UnsupportedBackendError struct {
+	Backend string
+}
+
+func (e *UnsupportedBackendError) Error() string {
+	return fmt.Sprintf("%s databases are not supported: the database path must be a SQLite database file", e.Backend)
+}
+
+// checkDatabaseBackend returns an UnsupportedBackendError if dbPath is a
+// connection string for an unsupported database server. Without this check
+// the connection string would be used as the name of a new SQLite file.
+func checkDatabaseBackend(dbPath string) error {
+	scheme, _, found := strings.Cut(dbPath, "://")
+	if !found {
+		return nil
+	}
+
+	if name, ok := unsupportedBackends[strings.ToLower(scheme)]; ok {
+		return &UnsupportedBackendError{Backend: name}
+	}
+
+	return nil
+}
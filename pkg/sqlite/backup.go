@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// migrationBackupLabel distinguishes the backups made before migrating the
+// database from other backups.
+const migrationBackupLabel = "pre-migration"
+
+// PruneBackups deletes the oldest backups of the database from dir, so that
+// at most keep backups remain. Backups made before migrating are counted
+// separately from other backups, so that regular backups do not cause the
+// backup needed to restore the database after a migration to be deleted.
+// Backups are identified by the naming scheme of DatabaseBackupPath and
+// DatabaseMigrationBackupPath. Anonymised databases are not considered. Does
+// nothing if keep is zero or less.
+func (db *Database) PruneBackups(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading backup directory %s: %w", dir, err)
+	}
+
+	re := regexp.MustCompile(`^` + regexp.QuoteMeta(filepath.Base(db.dbPath)) + `(\.` + regexp.QuoteMeta(migrationBackupLabel) + `)?\.\d+\.(\d{8}_\d{6})$`)
+
+	type backup struct {
+		name      string
+		timestamp string
+	}
+
+	var backups, migrationBackups []backup
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		m := re.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+
+		b := backup{name: e.Name(), timestamp: m[2]}
+		if m[1] != "" {
+			migrationBackups = append(migrationBackups, b)
+		} else {
+			backups = append(backups, b)
+		}
+	}
+
+	for _, l := range [][]backup{backups, migrationBackups} {
+		if len(l) <= keep {
+			continue
+		}
+
+		// newest first
+		sort.Slice(l, func(i, j int) bool {
+			if l[i].timestamp == l[j].timestamp {
+				return strings.Compare(l[i].name, l[j].name) > 0
+			}
+			return l[i].timestamp > l[j].timestamp
+		})
+
+		for _, b := range l[keep:] {
+			p := filepath.Join(dir, b.name)
+			logger.Infof("Deleting old database backup %s", p)
+			if err := os.Remove(p); err != nil {
+				return fmt.Errorf("deleting old backup %s: %w", p, err)
+			}
+		}
+	}
+
+	return nil
+}
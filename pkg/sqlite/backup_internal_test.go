@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabase_PruneBackups(t *testing.T) {
+	const dbFilename = "stash-go.sqlite"
+
+	files := []string{
+		"stash-go.sqlite",
+		"stash-go.sqlite.9.20230101_120000",
+		"stash-go.sqlite.10.20230102_120000",
+		"stash-go.sqlite.10.20230103_120000",
+		"stash-go.sqlite.10.20230104_120000",
+		"stash-go.sqlite.pre-migration.9.20221201_120000",
+		"stash-go.sqlite.pre-migration.9.20221202_120000",
+		"stash-go.sqlite.pre-migration.10.20230102_130000",
+		"stash-go.sqlite.anonymous.10.20230101_120000",
+		"other.sqlite.10.20230101_120000",
+	}
+
+	tests := []struct {
+		name string
+		keep int
+		want []string
+	}{
+		{
+			"keep all",
+			0,
+			files,
+		},
+		{
+			"keep more than present",
+			10,
+			files,
+		},
+		{
+			"keep two",
+			2,
+			[]string{
+				"stash-go.sqlite",
+				"stash-go.sqlite.10.20230103_120000",
+				"stash-go.sqlite.10.20230104_120000",
+				"stash-go.sqlite.pre-migration.9.20221202_120000",
+				"stash-go.sqlite.pre-migration.10.20230102_130000",
+				"stash-go.sqlite.anonymous.10.20230101_120000",
+				"other.sqlite.10.20230101_120000",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range files {
+				if err := os.WriteFile(filepath.Join(dir, f), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			db := &Database{dbPath: filepath.Join(t.TempDir(), dbFilename)}
+			if err := db.PruneBackups(dir, tt.keep); err != nil {
+				t.Fatalf("Database.PruneBackups() error = %v", err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Name())
+			}
+
+			want := append([]string(nil), tt.want...)
+			sort.Strings(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
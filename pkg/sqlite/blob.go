@@ -15,6 +15,7 @@ import (
 	"github.com/stashapp/stash/pkg/hash/md5"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/sqlite/blob"
+	"github.com/stashapp/stash/pkg/txn"
 	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/guregu/null.v4"
 )
@@ -110,7 +111,8 @@ func (qb *BlobStore) Write(ctx context.Context, data []byte) (string, error) {
 		storedData = data
 	}
 
-	if err := qb.write(ctx, checksum, storedData); err != nil {
+	inserted, err := qb.write(ctx, checksum, storedData)
+	if err != nil {
 		return "", fmt.Errorf("writing to database: %w", err)
 	}
 
@@ -118,24 +120,41 @@ func (qb *BlobStore) Write(ctx context.Context, data []byte) (string, error) {
 		if err := qb.fsStore.Write(ctx, checksum, data); err != nil {
 			return "", fmt.Errorf("writing to filesystem: %w", err)
 		}
+
+		// a new blob is not referenced outside of this transaction, so the
+		// file is removed if the transaction is rolled back
+		if inserted {
+			txn.AddPostRollbackHook(ctx, func(ctx context.Context) {
+				if err := qb.fsStore.Remove(checksum); err != nil {
+					logger.Warnf("error removing blob %s after rollback: %v", checksum, err)
+				}
+			})
+		}
 	}
 
 	return checksum, nil
 }
 
-func (qb *BlobStore) write(ctx context.Context, checksum string, data []byte) error {
+// write inserts the blob if it does not already exist. Returns true if the
+// blob was inserted.
+func (qb *BlobStore) write(ctx context.Context, checksum string, data []byte) (bool, error) {
 	table := qb.table()
 	q := dialect.Insert(table).Prepared(true).Rows(blobRow{
 		Checksum: checksum,
 		Blob:     data,
 	}).OnConflict(goqu.DoNothing())
 
-	_, err := exec(ctx, q)
+	r, err := exec(ctx, q)
 	if err != nil {
-		return fmt.Errorf("inserting into %s: %w", table, err)
+		return false, fmt.Errorf("inserting into %s: %w", table, err)
 	}
 
-	return nil
+	n, err := r.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("inserting into %s: %w", table, err)
+	}
+
+	return n > 0, nil
 }
 
 func (qb *BlobStore) update(ctx context.Context, checksum string, data []byte) error {
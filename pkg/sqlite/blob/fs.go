@@ -3,6 +3,7 @@ package blob
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -107,6 +108,7 @@ func (s *FilesystemStore) Write(ctx context.Context, checksum string, data []byt
 	if err != nil {
 		return fmt.Errorf("creating file %q: %w", fn, err)
 	}
+	defer out.Close()
 
 	r := bytes.NewReader(data)
 
@@ -117,6 +119,26 @@ func (s *FilesystemStore) Write(ctx context.Context, checksum string, data []byt
 	return nil
 }
 
+// Remove removes the file of the checksum immediately, outside of any
+// transaction. It is not an error if the file does not exist.
+func (s *FilesystemStore) Remove(checksum string) error {
+	fs, ok := s.fs.(FS)
+	if !ok {
+		return fmt.Errorf("internal error: fs is not an FS")
+	}
+
+	if s.path == "" {
+		return fmt.Errorf("no path set")
+	}
+
+	fn := s.checksumToPath(checksum)
+	if err := fs.Remove(fn); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing file %q: %w", fn, err)
+	}
+
+	return nil
+}
+
 func (s *FilesystemStore) Delete(ctx context.Context, checksum string) error {
 	if s.path == "" {
 		return fmt.Errorf("no path set")
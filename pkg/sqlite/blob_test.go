@@ -5,9 +5,14 @@ package sqlite_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"testing"
 
+	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +48,77 @@ func testUpdateImage(t *testing.T, ctx context.Context, id int, updateFn updateI
 
 	return nil
 }
+
+func blobFileExists(t *testing.T, dir string, checksum string) bool {
+	t.Helper()
+
+	found := false
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && d.Name() == checksum {
+			found = true
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return found
+}
+
+func TestBlobStoreWriteRollback(t *testing.T) {
+	dir := t.TempDir()
+	db.SetBlobStoreOptions(sqlite.BlobStoreOptions{
+		UseFilesystem: true,
+		Path:          dir,
+	})
+	defer db.SetBlobStoreOptions(sqlite.BlobStoreOptions{
+		UseDatabase: true,
+	})
+
+	errRollback := errors.New("rollback")
+	write := func(data []byte, rollback bool) string {
+		var checksum string
+		err := withTxn(func(ctx context.Context) error {
+			var err error
+			checksum, err = db.Blobs.Write(ctx, data)
+			if err != nil {
+				return err
+			}
+
+			if rollback {
+				return errRollback
+			}
+			return nil
+		})
+
+		if rollback {
+			assert.ErrorIs(t, err, errRollback)
+		} else {
+			assert.Nil(t, err)
+		}
+
+		return checksum
+	}
+
+	// files of new blobs are removed on rollback
+	checksum := write([]byte("rolled back"), true)
+	assert.Equal(t, md5.FromBytes([]byte("rolled back")), checksum)
+	assert.False(t, blobFileExists(t, dir, checksum))
+
+	// files of committed blobs are kept when they are written again in a
+	// transaction that is rolled back
+	checksum = write([]byte("committed"), false)
+	assert.True(t, blobFileExists(t, dir, checksum))
+
+	write([]byte("committed"), true)
+	assert.True(t, blobFileExists(t, dir, checksum))
+
+	if err := withTxn(func(ctx context.Context) error {
+		return db.Blobs.Delete(ctx, checksum)
+	}); err != nil {
+		t.Error(err)
+	}
+}
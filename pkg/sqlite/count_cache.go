@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/hash/md5"
+)
+
+const (
+	// countCacheTTL is the maximum time that a cached count is considered
+	// valid. Writes invalidate the cache immediately, so this only guards
+	// against changes made outside of the transaction manager.
+	countCacheTTL = time.Minute
+
+	// countCacheMaxEntries is the maximum number of cached results held at
+	// any one time.
+	countCacheMaxEntries = 1000
+)
+
+type countCacheEntry struct {
+	value      interface{}
+	generation uint64
+	expires    time.Time
+}
+
+// countCache caches the results of total count and aggregate queries so that
+// paging through a filtered list does not recount the entire result set for
+// each page. Entries are keyed on a hash of the generated SQL and its
+// arguments, which is the normalised form of the filter.
+//
+// Every committed write transaction increments the generation, which
+// invalidates all existing entries.
+type countCache struct {
+	mutex      sync.Mutex
+	generation uint64
+	entries    map[string]countCacheEntry
+}
+
+var queryCountCache = &countCache{
+	entries: make(map[string]countCacheEntry),
+}
+
+func countCacheKey(query string, args []interface{}) string {
+	return md5.FromString(fmt.Sprintf("%s %v", query, args))
+}
+
+func (c *countCache) currentGeneration() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.generation
+}
+
+// invalidate removes all cached entries.
+func (c *countCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	c.entries = make(map[string]countCacheEntry)
+}
+
+func (c *countCache) get(key string, generation uint64) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if e.generation != generation || time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+func (c *countCache) set(key string, generation uint64, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// don't cache results calculated against stale data
+	if generation != c.generation {
+		return
+	}
+
+	if len(c.entries) >= countCacheMaxEntries {
+		c.evictExpired()
+	}
+
+	if len(c.entries) >= countCacheMaxEntries {
+		c.entries = make(map[string]countCacheEntry)
+	}
+
+	c.entries[key] = countCacheEntry{
+		value:      value,
+		generation: generation,
+		expires:    time.Now().Add(countCacheTTL),
+	}
+}
+
+func (c *countCache) evictExpired() {
+	now := time.Now()
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// cacheGeneration returns the generation that results read using ctx should
+// be cached against. Returns false if results should not be cached, either
+// because the context is in a write transaction, which may see its own
+// uncommitted changes, or because a write has been committed since the
+// read transaction began.
+func cacheGeneration(ctx context.Context) (uint64, bool) {
+	current := queryCountCache.currentGeneration()
+
+	if _, err := getTx(ctx); err != nil {
+		// not in a transaction - each statement reads the latest data
+		return current, true
+	}
+
+	if exclusive, _ := ctx.Value(exclusiveKey).(bool); exclusive {
+		return 0, false
+	}
+
+	generation, ok := ctx.Value(countGenerationKey).(uint64)
+	if !ok || generation != current {
+		return 0, false
+	}
+
+	return generation, true
+}
+
+// cachedQuery returns the cached result for the query and args if present,
+// otherwise it calls fn and caches the result.
+func cachedQuery[T any](ctx context.Context, query string, args []interface{}, fn func() (T, error)) (T, error) {
+	generation, ok := cacheGeneration(ctx)
+	if !ok {
+		return fn()
+	}
+
+	key := countCacheKey(query, args)
+	if v, found := queryCountCache.get(key, generation); found {
+		if ret, ok := v.(T); ok {
+			return ret, nil
+		}
+	}
+
+	ret, err := fn()
+	if err != nil {
+		return ret, err
+	}
+
+	queryCountCache.set(key, generation, ret)
+	return ret, nil
+}
@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedQuery(t *testing.T) {
+	const query = "SELECT COUNT(*) as count FROM (SELECT id FROM scenes WHERE title = ?) as temp"
+
+	calls := 0
+	count := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	run := func(ctx context.Context, args ...interface{}) int {
+		ret, err := cachedQuery(ctx, query, args, count)
+		if err != nil {
+			t.Fatalf("cachedQuery: %v", err)
+		}
+		return ret
+	}
+
+	queryCountCache.invalidate()
+	ctx := context.Background()
+
+	assert.Equal(t, 1, run(ctx, "a"))
+	assert.Equal(t, 1, run(ctx, "a"), "result should be cached")
+	assert.Equal(t, 2, run(ctx, "b"), "different args should not share a result")
+
+	queryCountCache.invalidate()
+	assert.Equal(t, 3, run(ctx, "a"), "invalidate should clear cached results")
+
+	txCtx := context.WithValue(ctx, txnKey, &sqlx.Tx{})
+
+	writeCtx := context.WithValue(txCtx, exclusiveKey, true)
+	assert.Equal(t, 4, run(writeCtx, "a"), "write transactions should not use the cache")
+
+	readCtx := context.WithValue(txCtx, exclusiveKey, false)
+	readCtx = context.WithValue(readCtx, countGenerationKey, queryCountCache.currentGeneration())
+	assert.Equal(t, 3, run(readCtx, "a"), "read transactions should use the cache")
+
+	queryCountCache.invalidate()
+	assert.Equal(t, 5, run(readCtx, "a"), "stale read transactions should not use the cache")
+	assert.Equal(t, 6, run(ctx, "a"), "stale read transactions should not populate the cache")
+}
@@ -292,13 +292,13 @@ func resolutionCriterionHandler(resolution *models.ResolutionCriterionInput, hei
 
 			switch resolution.Modifier {
 			case models.CriterionModifierEquals:
-				f.addWhere(fmt.Sprintf("%s BETWEEN %d AND %d", widthHeight, min, max))
+				f.addWhere(widthHeight+" BETWEEN ? AND ?", min, max)
 			case models.CriterionModifierNotEquals:
-				f.addWhere(fmt.Sprintf("%s NOT BETWEEN %d AND %d", widthHeight, min, max))
+				f.addWhere(widthHeight+" NOT BETWEEN ? AND ?", min, max)
 			case models.CriterionModifierLessThan:
-				f.addWhere(fmt.Sprintf("%s < %d", widthHeight, min))
+				f.addWhere(widthHeight+" < ?", min)
 			case models.CriterionModifierGreaterThan:
-				f.addWhere(fmt.Sprintf("%s > %d", widthHeight, max))
+				f.addWhere(widthHeight+" > ?", max)
 			}
 		}
 	}
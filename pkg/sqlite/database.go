@@ -30,7 +30,7 @@ const (
 	dbConnTimeout = 30
 )
 
-var appSchemaVersion uint = 64
+var appSchemaVersion uint = 74
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -61,6 +61,32 @@ func (e *MismatchedSchemaVersionError) Error() string {
 	return fmt.Sprintf("schema version %d is incompatible with required schema version %d", e.CurrentSchemaVersion, e.RequiredSchemaVersion)
 }
 
+// DatabaseOptions are the options used when opening connections to the
+// database.
+type DatabaseOptions struct {
+	// BusyTimeout is the time to wait for a locked database before
+	// returning an error. Defaults to 50ms if zero.
+	BusyTimeout time.Duration
+	// CacheSize is the page cache size of each connection in KiB. Zero uses
+	// the SQLite default.
+	CacheSize int
+	// Synchronous is the value of the synchronous pragma. Defaults to NORMAL
+	// if empty.
+	Synchronous string
+	// SlowQueryThreshold is the duration above which SQL statements are
+	// logged at info level. Zero disables slow query logging.
+	SlowQueryThreshold time.Duration
+	// EncryptionKey is the passphrase used to encrypt the database with
+	// SQLCipher. Requires stash to be built against SQLCipher. Empty
+	// disables encryption.
+	EncryptionKey string
+}
+
+const (
+	defaultBusyTimeout = 50 * time.Millisecond
+	defaultSynchronous = "NORMAL"
+)
+
 type storeRepository struct {
 	Blobs          *BlobStore
 	File           *FileStore
@@ -72,6 +98,8 @@ type storeRepository struct {
 	SceneMarker    *SceneMarkerStore
 	Performer      *PerformerStore
 	SavedFilter    *SavedFilterStore
+	DeletedObject  *DeletedObjectStore
+	AuditEntry     *AuditEntryStore
 	Studio         *StudioStore
 	Tag            *TagStore
 	Group          *GroupStore
@@ -80,8 +108,14 @@ type storeRepository struct {
 type Database struct {
 	*storeRepository
 
-	db     *sqlx.DB
-	dbPath string
+	// db is the single connection used for writes. Writes are serialised by
+	// SQLite, so a single connection avoids contention between writers.
+	db *sqlx.DB
+	// readDB is the pool of read-only connections used outside of write
+	// transactions, so that long-running reads do not block writes.
+	readDB  *sqlx.DB
+	dbPath  string
+	options DatabaseOptions
 
 	schemaVersion uint
 
@@ -112,6 +146,8 @@ func NewDatabase() *Database {
 		Tag:            tagStore,
 		Group:          NewGroupStore(blobStore),
 		SavedFilter:    NewSavedFilterStore(),
+		DeletedObject:  NewDeletedObjectStore(),
+		AuditEntry:     NewAuditEntryStore(),
 	}
 
 	ret := &Database{
@@ -126,6 +162,14 @@ func (db *Database) SetBlobStoreOptions(options BlobStoreOptions) {
 	*db.Blobs = *NewBlobStore(options)
 }
 
+// SetOptions sets the options used when opening the database. The options
+// apply to connections opened by the next call to Open, except for
+// SlowQueryThreshold, which applies immediately.
+func (db *Database) SetOptions(options DatabaseOptions) {
+	db.options = options
+	slowQueryThreshold.Store(int64(options.SlowQueryThreshold))
+}
+
 // Ready returns an error if the database is not ready to begin transactions.
 func (db *Database) Ready() error {
 	if db.db == nil {
@@ -145,6 +189,14 @@ func (db *Database) Open(dbPath string) error {
 
 	db.dbPath = dbPath
 
+	if err := checkDatabaseBackend(dbPath); err != nil {
+		return err
+	}
+
+	if err := checkFTS5(); err != nil {
+		return err
+	}
+
 	databaseSchemaVersion, err := db.getDatabaseSchemaVersion()
 	if err != nil {
 		return fmt.Errorf("getting database schema version: %w", err)
@@ -176,9 +228,7 @@ func (db *Database) Open(dbPath string) error {
 
 	// RunMigrations may have opened a connection already
 	if db.db == nil {
-		const disableForeignKeys = false
-		db.db, err = db.open(disableForeignKeys)
-		if err != nil {
+		if err := db.openConnections(); err != nil {
 			return err
 		}
 	}
@@ -217,31 +267,111 @@ func (db *Database) Close() error {
 	db.lockNoCtx()
 	defer db.unlock()
 
+	if db.readDB != nil {
+		if err := db.readDB.Close(); err != nil {
+			return err
+		}
+
+		db.readDB = nil
+	}
+
 	if db.db != nil {
 		if err := db.db.Close(); err != nil {
 			return err
 		}
 
 		db.db = nil
+
+		// the database may be replaced before it is reopened
+		queryCountCache.invalidate()
 	}
 
 	return nil
 }
 
-func (db *Database) open(disableForeignKeys bool) (*sqlx.DB, error) {
+func (db *Database) busyTimeout() time.Duration {
+	if db.options.BusyTimeout <= 0 {
+		return defaultBusyTimeout
+	}
+	return db.options.BusyTimeout
+}
+
+func (db *Database) synchronous() string {
+	if db.options.Synchronous == "" {
+		return defaultSynchronous
+	}
+	return db.options.Synchronous
+}
+
+func (db *Database) connectionURL(disableForeignKeys bool) string {
 	// https://github.com/mattn/go-sqlite3
-	url := "file:" + db.dbPath + "?_journal=WAL&_sync=NORMAL&_busy_timeout=50"
+	url := fmt.Sprintf("file:%s?_journal=WAL&_sync=%s&_busy_timeout=%d", db.dbPath, db.synchronous(), db.busyTimeout().Milliseconds())
+	if db.options.CacheSize > 0 {
+		// negative values are interpreted by sqlite as KiB rather than pages
+		url += fmt.Sprintf("&_cache_size=-%d", db.options.CacheSize)
+	}
 	if !disableForeignKeys {
 		url += "&_fk=true"
 	}
 
-	conn, err := sqlx.Open(sqlite3Driver, url)
+	return url
+}
+
+// openConnections opens the write connection and the pool of read
+// connections.
+func (db *Database) openConnections() error {
+	const disableForeignKeys = false
+	writeDB, err := db.open(disableForeignKeys)
+	if err != nil {
+		return err
+	}
+
+	writeDB.SetMaxOpenConns(1)
+	writeDB.SetMaxIdleConns(1)
+
+	const queryOnly = true
+	readDB, err := db.openPool(disableForeignKeys, queryOnly)
+	if err != nil {
+		writeDB.Close()
+		return err
+	}
+
+	db.db = writeDB
+	db.readDB = readDB
+	return nil
+}
+
+func (db *Database) open(disableForeignKeys bool) (*sqlx.DB, error) {
+	const queryOnly = false
+	return db.openPool(disableForeignKeys, queryOnly)
+}
+
+// openPool opens a pool of connections to the database. If queryOnly is true,
+// then the connections may not be used to modify the database.
+func (db *Database) openPool(disableForeignKeys bool, queryOnly bool) (*sqlx.DB, error) {
+	var conn *sqlx.DB
+	if db.options.EncryptionKey != "" {
+		c := db.encryptedConnector(disableForeignKeys)
+		if queryOnly {
+			c.pragmas = append(c.pragmas, "PRAGMA query_only = ON")
+		}
+		conn = sqlx.NewDb(sql.OpenDB(c), sqlite3Driver)
+	} else {
+		url := db.connectionURL(disableForeignKeys)
+		if queryOnly {
+			url += "&_query_only=true"
+		}
+
+		var err error
+		conn, err = sqlx.Open(sqlite3Driver, url)
+		if err != nil {
+			return nil, fmt.Errorf("db.Open(): %w", err)
+		}
+	}
+
 	conn.SetMaxOpenConns(dbConns)
 	conn.SetMaxIdleConns(dbConns)
 	conn.SetConnMaxIdleTime(dbConnTimeout * time.Second)
-	if err != nil {
-		return nil, fmt.Errorf("db.Open(): %w", err)
-	}
 
 	return conn, nil
 }
@@ -291,11 +421,18 @@ func (db *Database) Reset() error {
 func (db *Database) Backup(backupPath string) (err error) {
 	thisDB := db.db
 	if thisDB == nil {
-		thisDB, err = sqlx.Connect(sqlite3Driver, "file:"+db.dbPath+"?_fk=true")
+		// open through the same connector as the database itself so that
+		// the encryption key is applied
+		const disableForeignKeys = false
+		thisDB, err = db.open(disableForeignKeys)
 		if err != nil {
 			return fmt.Errorf("open database %s failed: %w", db.dbPath, err)
 		}
 		defer thisDB.Close()
+
+		if err := thisDB.Ping(); err != nil {
+			return fmt.Errorf("open database %s failed: %w", db.dbPath, err)
+		}
 	}
 
 	logger.Infof("Backing up database into: %s", backupPath)
@@ -322,6 +459,185 @@ func (db *Database) RestoreFromBackup(backupPath string) error {
 	return os.Rename(backupPath, db.dbPath)
 }
 
+// Restore replaces the database with a copy of the backup database at
+// backupPath, then reopens it. The backup file is left in place. As with
+// Open, a MigrationNeededError is returned if the restored database requires
+// migration.
+//
+// The backup is checked before the database is replaced. The current
+// database is moved aside with the suffix .pre-restore, and is put back if
+// the restored database cannot be opened.
+func (db *Database) Restore(backupPath string) error {
+	databasePath := db.dbPath
+
+	if exists, _ := fsutil.FileExists(backupPath); !exists {
+		return fmt.Errorf("backup database %s not found", backupPath)
+	}
+
+	if err := db.checkBackup(backupPath); err != nil {
+		return fmt.Errorf("invalid backup database %s: %w", backupPath, err)
+	}
+
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("error closing database: %w", err)
+	}
+
+	logger.Infof("Restoring database %s from backup %s", databasePath, backupPath)
+
+	// copy to a temporary file first so that the database is not left
+	// partially written if the copy fails
+	tmpPath := databasePath + ".restore"
+	_ = os.Remove(tmpPath)
+	if err := fsutil.CopyFile(backupPath, tmpPath); err != nil {
+		return errors.Join(
+			fmt.Errorf("error copying backup database: %w", err),
+			db.Open(databasePath),
+		)
+	}
+
+	// move the database and its -wal, -shm files aside so that the wal is
+	// not applied to the restored database
+	moved, err := moveDatabaseFiles(databasePath, databasePath+preRestoreSuffix)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Join(
+			fmt.Errorf("error moving database aside: %w", err),
+			db.Open(databasePath),
+		)
+	}
+
+	if err := os.Rename(tmpPath, databasePath); err != nil {
+		return db.rollbackRestore(databasePath, moved, fmt.Errorf("error replacing database: %w", err))
+	}
+
+	if err := db.Open(databasePath); err != nil {
+		var migrationNeededErr *MigrationNeededError
+		if errors.As(err, &migrationNeededErr) {
+			return err
+		}
+
+		return db.rollbackRestore(databasePath, moved, fmt.Errorf("error opening restored database: %w", err))
+	}
+
+	logger.Infof("Previous database kept at %s", databasePath+preRestoreSuffix)
+	return nil
+}
+
+const preRestoreSuffix = ".pre-restore"
+
+// checkBackup opens the database at path read-only and checks that it is a
+// stash database that can be opened by this version.
+func (db *Database) checkBackup(path string) error {
+	conn, err := db.openReadOnly(path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var result string
+	if err := conn.Get(&result, "PRAGMA quick_check"); err != nil {
+		return err
+	}
+	if result != "ok" {
+		return fmt.Errorf("integrity check failed: %s", result)
+	}
+
+	var version struct {
+		Version uint `db:"version"`
+		Dirty   bool `db:"dirty"`
+	}
+	if err := conn.Get(&version, "SELECT version, dirty FROM schema_migrations"); err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+
+	if version.Dirty {
+		return fmt.Errorf("schema version %d is dirty", version.Version)
+	}
+
+	if version.Version > appSchemaVersion {
+		return &MismatchedSchemaVersionError{
+			CurrentSchemaVersion:  version.Version,
+			RequiredSchemaVersion: appSchemaVersion,
+		}
+	}
+
+	return nil
+}
+
+// openReadOnly opens a read-only connection to the database at path, using
+// the encryption key if configured.
+func (db *Database) openReadOnly(path string) (*sqlx.DB, error) {
+	dsn := fmt.Sprintf("file:%s?mode=ro", path)
+
+	var conn *sqlx.DB
+	if db.options.EncryptionKey != "" {
+		c := &encryptedConnector{
+			dsn: dsn,
+			key: db.options.EncryptionKey,
+		}
+		conn = sqlx.NewDb(sql.OpenDB(c), sqlite3Driver)
+	} else {
+		var err error
+		conn, err = sqlx.Open(sqlite3Driver, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("db.Open(): %w", err)
+		}
+	}
+
+	conn.SetMaxOpenConns(1)
+	return conn, nil
+}
+
+// moveDatabaseFiles renames the database file at from and its -wal, -shm
+// files to the path to. Any existing files at the destination are replaced.
+// Returns the destination paths of the moved files, keyed on the source path.
+func moveDatabaseFiles(from, to string) (map[string]string, error) {
+	moved := make(map[string]string)
+
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		src := from + suffix
+		dest := to + suffix
+
+		_ = os.Remove(dest)
+
+		if exists, _ := fsutil.FileExists(src); !exists {
+			continue
+		}
+
+		if err := os.Rename(src, dest); err != nil {
+			// put back anything already moved
+			for s, d := range moved {
+				_ = os.Rename(d, s)
+			}
+			return nil, err
+		}
+
+		moved[src] = dest
+	}
+
+	return moved, nil
+}
+
+// rollbackRestore replaces the restored database with the files moved
+// aside by Restore, and reopens it.
+func (db *Database) rollbackRestore(databasePath string, moved map[string]string, err error) error {
+	logger.Errorf("Restore failed, reverting to previous database: %v", err)
+
+	_ = db.Close()
+
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		_ = os.Remove(databasePath + suffix)
+	}
+
+	for src, dest := range moved {
+		if renameErr := os.Rename(dest, src); renameErr != nil {
+			return errors.Join(err, fmt.Errorf("error reverting to previous database %s: %w", dest, renameErr))
+		}
+	}
+
+	return errors.Join(err, db.Open(databasePath))
+}
+
 func (db *Database) AppSchemaVersion() uint {
 	return appSchemaVersion
 }
@@ -340,6 +656,19 @@ func (db *Database) DatabaseBackupPath(backupDirectoryPath string) string {
 	return fn
 }
 
+// DatabaseMigrationBackupPath returns the path of the backup made before
+// migrating the database. The path is labelled so that it can be
+// distinguished from other backups.
+func (db *Database) DatabaseMigrationBackupPath(backupDirectoryPath string) string {
+	fn := fmt.Sprintf("%s.%s.%d.%s", filepath.Base(db.dbPath), migrationBackupLabel, db.schemaVersion, time.Now().Format("20060102_150405"))
+
+	if backupDirectoryPath != "" {
+		return filepath.Join(backupDirectoryPath, fn)
+	}
+
+	return fn
+}
+
 func (db *Database) AnonymousDatabasePath(backupDirectoryPath string) string {
 	fn := fmt.Sprintf("%s.anonymous.%d.%s", filepath.Base(db.dbPath), db.schemaVersion, time.Now().Format("20060102_150405"))
 
@@ -382,6 +711,34 @@ func (db *Database) Analyze(ctx context.Context) error {
 	return err
 }
 
+// IntegrityCheck runs an integrity check on the database. Returns the
+// problems found, or nil if the database is intact.
+func (db *Database) IntegrityCheck(ctx context.Context) ([]string, error) {
+	var results []string
+	if err := db.readDB.SelectContext(ctx, &results, "PRAGMA integrity_check"); err != nil {
+		return nil, err
+	}
+
+	if len(results) == 1 && results[0] == "ok" {
+		return nil, nil
+	}
+
+	return results, nil
+}
+
+// Size returns the size of the database in bytes, excluding the write-ahead log.
+func (db *Database) Size(ctx context.Context) (int64, error) {
+	var pageCount, pageSize int64
+	if err := db.readDB.GetContext(ctx, &pageCount, "PRAGMA page_count"); err != nil {
+		return 0, err
+	}
+	if err := db.readDB.GetContext(ctx, &pageSize, "PRAGMA page_size"); err != nil {
+		return 0, err
+	}
+
+	return pageCount * pageSize, nil
+}
+
 func (db *Database) ExecSQL(ctx context.Context, query string, args []interface{}) (*int64, *int64, error) {
 	wrapper := dbWrapperType{}
 
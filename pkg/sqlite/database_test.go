@@ -0,0 +1,205 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseIntegrityCheck(t *testing.T) {
+	ctx := context.Background()
+
+	problems, err := db.IntegrityCheck(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestDatabaseSize(t *testing.T) {
+	ctx := context.Background()
+
+	size, err := db.Size(ctx)
+	if assert.Nil(t, err) {
+		assert.Greater(t, size, int64(0))
+	}
+}
+
+func TestDatabaseCheckSchema(t *testing.T) {
+	ctx := context.Background()
+
+	status, err := db.CheckSchema(ctx)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Empty(t, status.Differences)
+	assert.NotEmpty(t, status.Objects)
+	assert.Contains(t, status.Dump(), "CREATE INDEX `index_scenes_on_studio_id`")
+}
+
+func TestDatabaseRepairSchema(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		missingIndex    = "index_scenes_on_studio_id"
+		unexpectedIndex = "index_test_unexpected"
+	)
+
+	if err := withTxn(func(ctx context.Context) error {
+		if _, _, err := db.ExecSQL(ctx, "DROP INDEX "+missingIndex, nil); err != nil {
+			return err
+		}
+		_, _, err := db.ExecSQL(ctx, "CREATE INDEX "+unexpectedIndex+" ON scenes (title)", nil)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := db.CheckSchema(ctx)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	kinds := make(map[string]sqlite.SchemaDifferenceKind)
+	for _, d := range status.Differences {
+		kinds[d.Name] = d.Kind
+		assert.True(t, d.Repairable())
+	}
+	assert.Equal(t, map[string]sqlite.SchemaDifferenceKind{
+		missingIndex:    sqlite.SchemaDifferenceMissing,
+		unexpectedIndex: sqlite.SchemaDifferenceUnexpected,
+	}, kinds)
+
+	status, err = db.RepairSchema(ctx)
+	if assert.Nil(t, err) {
+		assert.Empty(t, status.Differences)
+	}
+}
+
+func TestDatabaseEncryptionNotSupported(t *testing.T) {
+	// the test build is not linked against SQLCipher, so opening with a key
+	// must fail rather than silently creating an unencrypted database
+	encryptedDB := sqlite.NewDatabase()
+	encryptedDB.SetOptions(sqlite.DatabaseOptions{
+		EncryptionKey: "passphrase",
+	})
+
+	err := encryptedDB.Open(filepath.Join(t.TempDir(), "encrypted.sqlite"))
+	assert.ErrorIs(t, err, sqlite.ErrEncryptionNotSupported)
+}
+
+func TestDatabaseBackupClosedEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "stash.sqlite")
+
+	plainDB := sqlite.NewDatabase()
+	if err := plainDB.Open(dbPath); err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	if err := plainDB.Close(); err != nil {
+		t.Fatalf("closing database: %v", err)
+	}
+
+	// the database is left closed when it fails to open, as it is when a
+	// migration is needed
+	encryptedDB := sqlite.NewDatabase()
+	encryptedDB.SetOptions(sqlite.DatabaseOptions{
+		EncryptionKey: "passphrase",
+	})
+	_ = encryptedDB.Open(dbPath)
+
+	// the backup connection must apply the key, which fails without
+	// SQLCipher rather than reading the file as a plain database
+	err := encryptedDB.Backup(filepath.Join(dir, "backup.sqlite"))
+	assert.ErrorIs(t, err, sqlite.ErrEncryptionNotSupported)
+}
+
+func TestDatabaseRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	restoreDB := sqlite.NewDatabase()
+	if err := restoreDB.Open(filepath.Join(dir, "stash.sqlite")); err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	defer restoreDB.Close()
+
+	createTag := func(name string) {
+		if err := txn.WithTxn(ctx, restoreDB, func(ctx context.Context) error {
+			return restoreDB.Tag.Create(ctx, &models.Tag{Name: name})
+		}); err != nil {
+			t.Fatalf("creating tag: %v", err)
+		}
+	}
+
+	countTags := func() int {
+		var ret int
+		if err := txn.WithReadTxn(ctx, restoreDB, func(ctx context.Context) error {
+			var err error
+			ret, err = restoreDB.Tag.Count(ctx)
+			return err
+		}); err != nil {
+			t.Fatalf("counting tags: %v", err)
+		}
+		return ret
+	}
+
+	createTag("backed up")
+
+	backupPath := filepath.Join(dir, "backup.sqlite")
+	if err := restoreDB.Backup(backupPath); err != nil {
+		t.Fatalf("backing up database: %v", err)
+	}
+
+	createTag("not backed up")
+	assert.Equal(t, 2, countTags())
+
+	t.Run("invalid backup", func(t *testing.T) {
+		invalidPath := filepath.Join(dir, "invalid.sqlite")
+		if err := os.WriteFile(invalidPath, []byte("not a database"), 0644); err != nil {
+			t.Fatalf("writing invalid backup: %v", err)
+		}
+
+		assert.NotNil(t, restoreDB.Restore(invalidPath))
+		assert.Equal(t, 2, countTags(), "database should not be replaced")
+	})
+
+	t.Run("newer schema version", func(t *testing.T) {
+		newerPath := filepath.Join(dir, "newer.sqlite")
+		if err := restoreDB.Backup(newerPath); err != nil {
+			t.Fatalf("backing up database: %v", err)
+		}
+
+		newerDB := sqlite.NewDatabase()
+		if err := newerDB.Open(newerPath); err != nil {
+			t.Fatalf("opening database: %v", err)
+		}
+		if err := txn.WithTxn(ctx, newerDB, func(ctx context.Context) error {
+			_, _, err := newerDB.ExecSQL(ctx, "UPDATE schema_migrations SET version = version + 1", nil)
+			return err
+		}); err != nil {
+			t.Fatalf("updating schema version: %v", err)
+		}
+		newerDB.Close()
+
+		var mismatchErr *sqlite.MismatchedSchemaVersionError
+		assert.ErrorAs(t, restoreDB.Restore(newerPath), &mismatchErr)
+		assert.Equal(t, 2, countTags(), "database should not be replaced")
+	})
+
+	t.Run("valid backup", func(t *testing.T) {
+		assert.Nil(t, restoreDB.Restore(backupPath))
+		assert.Equal(t, 1, countTags())
+
+		_, err := os.Stat(filepath.Join(dir, "stash.sqlite.pre-restore"))
+		assert.Nil(t, err, "previous database should be kept")
+	})
+}
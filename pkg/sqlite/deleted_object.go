@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const (
+	deletedObjectTable = "deleted_objects"
+)
+
+type deletedObjectRow struct {
+	ID         int       `db:"id" goqu:"skipinsert"`
+	ObjectType string    `db:"object_type"`
+	ObjectID   int       `db:"object_id"`
+	Name       string    `db:"name"`
+	Data       []byte    `db:"data"`
+	DeletedAt  Timestamp `db:"deleted_at"`
+}
+
+func (r *deletedObjectRow) fromDeletedObject(o models.DeletedObject) {
+	r.ID = o.ID
+	r.ObjectType = o.ObjectType.String()
+	r.ObjectID = o.ObjectID
+	r.Name = o.Name
+	r.Data = o.Data
+	r.DeletedAt = Timestamp{Timestamp: o.DeletedAt}
+}
+
+func (r *deletedObjectRow) resolve() *models.DeletedObject {
+	return &models.DeletedObject{
+		ID:         r.ID,
+		ObjectType: models.DeletedObjectType(r.ObjectType),
+		ObjectID:   r.ObjectID,
+		Name:       r.Name,
+		Data:       r.Data,
+		DeletedAt:  r.DeletedAt.Timestamp,
+	}
+}
+
+type DeletedObjectStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewDeletedObjectStore() *DeletedObjectStore {
+	return &DeletedObjectStore{
+		repository: repository{
+			tableName: deletedObjectTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: deletedObjectTableMgr,
+	}
+}
+
+func (qb *DeletedObjectStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *DeletedObjectStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *DeletedObjectStore) Create(ctx context.Context, newObject *models.DeletedObject) error {
+	var r deletedObjectRow
+	r.fromDeletedObject(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *DeletedObjectStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+func (qb *DeletedObjectStore) DestroyBefore(ctx context.Context, t time.Time) (int, error) {
+	q := dialect.Delete(qb.table()).Where(qb.table().Col("deleted_at").Lt(Timestamp{Timestamp: t}))
+
+	result, err := exec(ctx, q)
+	if err != nil {
+		return 0, fmt.Errorf("destroying deleted objects: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
+// returns nil, nil if not found
+func (qb *DeletedObjectStore) Find(ctx context.Context, id int) (*models.DeletedObject, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *DeletedObjectStore) FindMany(ctx context.Context, ids []int) ([]*models.DeletedObject, error) {
+	ret := make([]*models.DeletedObject, len(ids))
+
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(ids))
+	unsorted, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range unsorted {
+		i := sliceutil.Index(ids, s.ID)
+		ret[i] = s
+	}
+
+	for i := range ret {
+		if ret[i] == nil {
+			return nil, fmt.Errorf("deleted object with id %d not found", ids[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *DeletedObjectStore) find(ctx context.Context, id int) (*models.DeletedObject, error) {
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *DeletedObjectStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.DeletedObject, error) {
+	const single = false
+	var ret []*models.DeletedObject
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f deletedObjectRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *DeletedObjectStore) All(ctx context.Context) ([]*models.DeletedObject, error) {
+	table := qb.table()
+	q := qb.selectDataset().Order(table.Col("deleted_at").Desc(), table.Col(idColumn).Desc())
+	return qb.getMany(ctx, q)
+}
@@ -0,0 +1,77 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletedObjectStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		assert := assert.New(t)
+		qb := db.DeletedObject
+
+		now := time.Now().Truncate(time.Second)
+		older := &models.DeletedObject{
+			ObjectType: models.DeletedObjectTypeScene,
+			ObjectID:   sceneIDs[sceneIdxWithPerformer],
+			Name:       "older",
+			Data:       []byte(`{"title":"older"}`),
+			DeletedAt:  now.AddDate(0, 0, -10),
+		}
+		newer := &models.DeletedObject{
+			ObjectType: models.DeletedObjectTypePerformer,
+			ObjectID:   performerIDs[performerIdx1WithScene],
+			Name:       "newer",
+			Data:       []byte(`{"name":"newer"}`),
+			DeletedAt:  now,
+		}
+
+		for _, o := range []*models.DeletedObject{older, newer} {
+			if err := qb.Create(ctx, o); err != nil {
+				t.Errorf("Create() error = %v", err)
+				return nil
+			}
+		}
+
+		found, err := qb.Find(ctx, older.ID)
+		if assert.Nil(err) && assert.NotNil(found) {
+			assert.Equal(models.DeletedObjectTypeScene, found.ObjectType)
+			assert.Equal(older.Data, found.Data)
+			assert.True(older.DeletedAt.Equal(found.DeletedAt))
+		}
+
+		all, err := qb.All(ctx)
+		if assert.Nil(err) && assert.Len(all, 2) {
+			// most recently deleted first
+			assert.Equal(newer.ID, all[0].ID)
+			assert.Equal(older.ID, all[1].ID)
+		}
+
+		_, err = qb.FindMany(ctx, []int{older.ID, older.ID + 100})
+		assert.NotNil(err)
+
+		destroyed, err := qb.DestroyBefore(ctx, now.AddDate(0, 0, -1))
+		assert.Nil(err)
+		assert.Equal(1, destroyed)
+
+		all, err = qb.All(ctx)
+		if assert.Nil(err) && assert.Len(all, 1) {
+			assert.Equal(newer.ID, all[0].ID)
+		}
+
+		assert.Nil(qb.Destroy(ctx, newer.ID))
+
+		found, err = qb.Find(ctx, newer.ID)
+		assert.Nil(err)
+		assert.Nil(found)
+
+		return nil
+	})
+}
@@ -20,6 +20,7 @@ type CustomSQLiteDriver struct{}
 
 type CustomSQLiteConn struct {
 	*sqlite3.SQLiteConn
+	stmts *stmtCache
 }
 
 func (d *CustomSQLiteDriver) Open(dsn string) (driver.Conn, error) {
@@ -60,12 +61,17 @@ func (d *CustomSQLiteDriver) Open(dsn string) (driver.Conn, error) {
 		return nil, err
 	}
 
-	return &CustomSQLiteConn{conn.(*sqlite3.SQLiteConn)}, nil
+	return &CustomSQLiteConn{
+		SQLiteConn: conn.(*sqlite3.SQLiteConn),
+		stmts:      newStmtCache(),
+	}, nil
 }
 
 func (c *CustomSQLiteConn) Close() error {
 	conn := c.SQLiteConn
 
+	c.stmts.close()
+
 	_, _ = conn.Exec("PRAGMA analysis_limit=1000; PRAGMA optimize;", []driver.Value{})
 
 	return conn.Close()
@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// ErrEncryptionNotSupported is returned when an encryption key is configured
+// but stash was not built against SQLCipher.
+var ErrEncryptionNotSupported = errors.New("database encryption requires stash to be built with SQLCipher")
+
+// encryptedConnector opens connections to a database encrypted with SQLCipher.
+// The key must be set before any other statement is executed on the
+// connection, so the connection pragmas are applied here after the key
+// rather than through the connection string.
+type encryptedConnector struct {
+	dsn     string
+	key     string
+	pragmas []string
+}
+
+func (db *Database) encryptedConnector(disableForeignKeys bool) *encryptedConnector {
+	// busy timeout is set through the C API rather than a pragma, so it is
+	// safe to set before the key
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=%d", db.dbPath, db.busyTimeout().Milliseconds())
+
+	pragmas := []string{
+		"PRAGMA journal_mode = WAL",
+		"PRAGMA synchronous = " + db.synchronous(),
+	}
+	if db.options.CacheSize > 0 {
+		// negative values are interpreted by sqlite as KiB rather than pages
+		pragmas = append(pragmas, fmt.Sprintf("PRAGMA cache_size = -%d", db.options.CacheSize))
+	}
+	if !disableForeignKeys {
+		pragmas = append(pragmas, "PRAGMA foreign_keys = ON")
+	}
+
+	return &encryptedConnector{
+		dsn:     dsn,
+		key:     db.options.EncryptionKey,
+		pragmas: pragmas,
+	}
+}
+
+func (c *encryptedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Driver().Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteConn := conn.(*CustomSQLiteConn).SQLiteConn
+	if err := c.initialise(sqliteConn); err != nil {
+		// close the underlying connection directly to avoid running
+		// optimize against a database that cannot be read
+		_ = sqliteConn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *encryptedConnector) Driver() driver.Driver {
+	return &CustomSQLiteDriver{}
+}
+
+func (c *encryptedConnector) initialise(conn *sqlite3.SQLiteConn) error {
+	ctx := context.Background()
+
+	keyStmt := fmt.Sprintf("PRAGMA key = '%s'", strings.ReplaceAll(c.key, "'", "''"))
+	if _, err := conn.ExecContext(ctx, keyStmt, nil); err != nil {
+		return fmt.Errorf("setting database encryption key: %w", err)
+	}
+
+	// PRAGMA key is silently ignored if not built against SQLCipher
+	supported, err := cipherSupported(conn)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return ErrEncryptionNotSupported
+	}
+
+	// the key is only checked when the database is first read
+	if _, err := conn.ExecContext(ctx, "SELECT count(*) FROM sqlite_master", nil); err != nil {
+		return fmt.Errorf("invalid database encryption key: %w", err)
+	}
+
+	for _, p := range c.pragmas {
+		if _, err := conn.ExecContext(ctx, p, nil); err != nil {
+			return fmt.Errorf("executing %q: %w", p, err)
+		}
+	}
+
+	return nil
+}
+
+func cipherSupported(conn *sqlite3.SQLiteConn) (bool, error) {
+	rows, err := conn.QueryContext(context.Background(), "PRAGMA cipher_version", nil)
+	if err != nil {
+		return false, fmt.Errorf("getting cipher version: %w", err)
+	}
+	defer rows.Close()
+
+	dest := make([]driver.Value, len(rows.Columns()))
+	if len(dest) == 0 {
+		return false, nil
+	}
+
+	if err := rows.Next(dest); err != nil {
+		// no rows returned - not SQLCipher
+		return false, nil
+	}
+
+	return dest[0] != nil, nil
+}
@@ -382,6 +382,12 @@ func (qb *FileStore) createVideoFile(ctx context.Context, id models.FileID, f mo
 		return err
 	}
 
+	if f.ProbeData != nil {
+		if err := qb.setProbeData(ctx, id, f.ProbeData); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -402,6 +408,12 @@ func (qb *FileStore) updateOrCreateVideoFile(ctx context.Context, id models.File
 		return err
 	}
 
+	if f.ProbeData != nil {
+		if err := qb.setProbeData(ctx, id, f.ProbeData); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -573,7 +585,7 @@ func (qb *FileStore) Find(ctx context.Context, ids ...models.FileID) ([]models.F
 }
 
 func (qb *FileStore) find(ctx context.Context, id models.FileID) (models.File, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
@@ -713,6 +725,29 @@ func (qb *FileStore) FindByFingerprint(ctx context.Context, fp models.Fingerprin
 	return qb.findBySubquery(ctx, sq)
 }
 
+// FindByFingerprints returns files matching any of the provided fingerprints.
+func (qb *FileStore) FindByFingerprints(ctx context.Context, fp []models.Fingerprint) ([]models.File, error) {
+	if len(fp) == 0 {
+		return nil, nil
+	}
+
+	fingerprintTable := fingerprintTableMgr.table
+
+	fingerprints := fingerprintTable.As("fp")
+
+	var ex []exp.Expression
+	for _, v := range fp {
+		ex = append(ex, goqu.And(
+			fingerprints.Col("type").Eq(v.Type),
+			fingerprints.Col("fingerprint").Eq(v.Fingerprint),
+		))
+	}
+
+	sq := dialect.From(fingerprints).Select(fingerprints.Col(fileIDColumn)).Where(goqu.Or(ex...))
+
+	return qb.findBySubquery(ctx, sq)
+}
+
 func (qb *FileStore) FindByZipFileID(ctx context.Context, zipFileID models.FileID) ([]models.File, error) {
 	table := qb.table()
 
@@ -900,10 +935,16 @@ func (qb *FileStore) queryGroupedFields(ctx context.Context, options models.File
 	const includeSortPagination = false
 	aggregateQuery.from = fmt.Sprintf("(%s) as temp", query.toSQL(includeSortPagination))
 
-	out := struct {
+	type aggregateResult struct {
 		Total int
-	}{}
-	if err := qb.repository.queryStruct(ctx, aggregateQuery.toSQL(includeSortPagination), query.args, &out); err != nil {
+	}
+	aggregateSQL := aggregateQuery.toSQL(includeSortPagination)
+	out, err := cachedQuery(ctx, aggregateSQL, query.args, func() (aggregateResult, error) {
+		var ret aggregateResult
+		err := qb.repository.queryStruct(ctx, aggregateSQL, query.args, &ret)
+		return ret, err
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const videoFileProbesTable = "video_file_probes"
+
+// GetProbeData returns the raw ffprobe output stored for the video file.
+// Returns nil if there is no stored output.
+func (qb *FileStore) GetProbeData(ctx context.Context, fileID models.FileID) ([]byte, error) {
+	table := goqu.T(videoFileProbesTable)
+	q := dialect.From(table).Select(table.Col("data")).Where(table.Col(fileIDColumn).Eq(fileID))
+
+	var ret []byte
+	if err := queryFunc(ctx, q, true, func(rows *sqlx.Rows) error {
+		return rows.Scan(&ret)
+	}); err != nil {
+		return nil, fmt.Errorf("getting probe data for file %d: %w", fileID, err)
+	}
+
+	return ret, nil
+}
+
+func (qb *FileStore) setProbeData(ctx context.Context, fileID models.FileID, data []byte) error {
+	table := goqu.T(videoFileProbesTable)
+	q := dialect.Insert(table).Rows(goqu.Record{
+		fileIDColumn: fileID,
+		"data":       data,
+	}).OnConflict(goqu.DoUpdate(fileIDColumn, goqu.Record{
+		"data": data,
+	}))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting probe data for file %d: %w", fileID, err)
+	}
+
+	return nil
+}
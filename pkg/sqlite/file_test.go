@@ -614,6 +614,77 @@ func TestFileStore_FindByFingerprint(t *testing.T) {
 	}
 }
 
+func TestFileStore_FindByFingerprints(t *testing.T) {
+	tests := []struct {
+		name    string
+		fp      []models.Fingerprint
+		want    []models.FileID
+		wantErr bool
+	}{
+		{
+			"mixed types",
+			[]models.Fingerprint{
+				{
+					Type:        "MD5",
+					Fingerprint: getPrefixedStringValue("file", fileIdxZip, "md5"),
+				},
+				{
+					Type:        "OSHASH",
+					Fingerprint: getPrefixedStringValue("file", fileIdxInZip, "oshash"),
+				},
+			},
+			[]models.FileID{fileIDs[fileIdxZip], fileIDs[fileIdxInZip]},
+			false,
+		},
+		{
+			"same file",
+			[]models.Fingerprint{
+				{
+					Type:        "MD5",
+					Fingerprint: getPrefixedStringValue("file", fileIdxZip, "md5"),
+				},
+				{
+					Type:        "OSHASH",
+					Fingerprint: getPrefixedStringValue("file", fileIdxZip, "oshash"),
+				},
+			},
+			[]models.FileID{fileIDs[fileIdxZip]},
+			false,
+		},
+		{
+			"non-existing",
+			[]models.Fingerprint{
+				{
+					Type:        "OSHASH",
+					Fingerprint: "foo",
+				},
+			},
+			nil,
+			false,
+		},
+	}
+
+	qb := db.File
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			assert := assert.New(t)
+			got, err := qb.FindByFingerprints(ctx, tt.fp)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileStore.FindByFingerprints() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			var gotIDs []models.FileID
+			for _, f := range got {
+				gotIDs = append(gotIDs, f.Base().ID)
+			}
+
+			assert.ElementsMatch(tt.want, gotIDs)
+		})
+	}
+}
+
 func TestFileStore_IsPrimary(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -662,3 +733,72 @@ func TestFileStore_IsPrimary(t *testing.T) {
 		})
 	}
 }
+
+func TestFileStore_ProbeData(t *testing.T) {
+	const basename = "probe.mp4"
+
+	probeData := []byte(`{"streams":[{"codec_name":"h264","field_order":"tt"}],"format":{"format_name":"mp4"}}`)
+	updatedData := []byte(`{"streams":[],"format":{}}`)
+
+	qb := db.File
+
+	runWithRollbackTxn(t, "probe data", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		f := &models.VideoFile{
+			BaseFile: &models.BaseFile{
+				Path:           getFilePath(folderIdxWithFiles, basename),
+				ParentFolderID: folderIDs[folderIdxWithFiles],
+				Basename:       basename,
+			},
+			ProbeData: probeData,
+		}
+
+		if err := qb.Create(ctx, f); err != nil {
+			t.Errorf("FileStore.Create() error = %v", err)
+			return
+		}
+
+		got, err := qb.GetProbeData(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetProbeData() error = %v", err)
+			return
+		}
+		assert.Equal(probeData, got)
+
+		// nil probe data leaves the stored data unchanged
+		f.ProbeData = nil
+		if err := qb.Update(ctx, f); err != nil {
+			t.Errorf("FileStore.Update() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetProbeData(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetProbeData() error = %v", err)
+			return
+		}
+		assert.Equal(probeData, got)
+
+		f.ProbeData = updatedData
+		if err := qb.Update(ctx, f); err != nil {
+			t.Errorf("FileStore.Update() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetProbeData(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetProbeData() error = %v", err)
+			return
+		}
+		assert.Equal(updatedData, got)
+
+		// files without probe data return nil
+		got, err = qb.GetProbeData(ctx, sceneFileIDs[sceneIdx1WithPerformer])
+		if err != nil {
+			t.Errorf("FileStore.GetProbeData() error = %v", err)
+			return
+		}
+		assert.Nil(got)
+	})
+}
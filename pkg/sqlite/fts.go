@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// ErrFTS5NotSupported is returned when opening the database if SQLite was
+// built without the FTS5 extension, which is required by the search indexes.
+var ErrFTS5NotSupported = errors.New("SQLite was built without FTS5 support: build stash with the sqlite_fts5 build tag, or link against a SQLite library built with FTS5")
+
+// checkFTS5 returns ErrFTS5NotSupported if an FTS5 table cannot be created.
+// This is checked before running migrations, which would otherwise fail
+// part way through with a less helpful error.
+func checkFTS5() error {
+	conn, err := (&sqlite3.SQLiteDriver{}).Open(":memory:")
+	if err != nil {
+		return fmt.Errorf("checking FTS5 support: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.(*sqlite3.SQLiteConn).ExecContext(context.Background(), "CREATE VIRTUAL TABLE fts5_check USING fts5(content)", nil); err != nil {
+		if strings.Contains(err.Error(), "no such module") {
+			return ErrFTS5NotSupported
+		}
+		return fmt.Errorf("checking FTS5 support: %w", err)
+	}
+
+	return nil
+}
+
+// ftsMinTermLength is the minimum length of a term that can be matched using
+// the trigram tokenizer. Shorter terms are matched by scanning the index.
+const ftsMinTermLength = 3
+
+// ftsIndex is an FTS5 table indexing text columns of another table. The rowid
+// of the index is the id of the indexed row. Indexes use the trigram
+// tokenizer, so that terms match anywhere in the column, as with LIKE.
+// Indexes are kept up to date by triggers on the indexed tables.
+type ftsIndex struct {
+	table    string
+	idColumn string
+	columns  []string
+}
+
+var (
+	scenesFTS = ftsIndex{
+		table:    "scenes_fts",
+		idColumn: "scenes.id",
+		columns:  []string{"title", "details"},
+	}
+	performersFTS = ftsIndex{
+		table:    "performers_fts",
+		idColumn: "performers.id",
+		columns:  []string{"name", "aliases"},
+	}
+	tagsFTS = ftsIndex{
+		table:    "tags_fts",
+		idColumn: "tags.id",
+		columns:  []string{"name", "aliases"},
+	}
+)
+
+// subquery returns a query selecting the ids of rows where any of the
+// indexed columns contain term.
+func (i ftsIndex) subquery(term string) (string, []interface{}) {
+	if utf8.RuneCountInString(term) < ftsMinTermLength {
+		var clauses []string
+		var args []interface{}
+		for _, column := range i.columns {
+			clauses = append(clauses, i.table+"."+column+" LIKE ?")
+			args = append(args, like(term))
+		}
+
+		return fmt.Sprintf("SELECT rowid FROM %s WHERE %s", i.table, strings.Join(clauses, " OR ")), args
+	}
+
+	return fmt.Sprintf("SELECT rowid FROM %s WHERE %s MATCH ?", i.table, i.table), []interface{}{ftsPhrase(term)}
+}
+
+// matchClause returns a where clause matching rows where any of the indexed
+// columns contain term.
+func (i ftsIndex) matchClause(term string) (string, []interface{}) {
+	query, args := i.subquery(term)
+	return fmt.Sprintf("%s IN (%s)", i.idColumn, query), args
+}
+
+// notMatchClause returns a where clause matching rows where none of the
+// indexed columns contain term.
+func (i ftsIndex) notMatchClause(term string) (string, []interface{}) {
+	query, args := i.subquery(term)
+	return fmt.Sprintf("%s NOT IN (%s)", i.idColumn, query), args
+}
+
+// ftsPhrase returns term quoted as an FTS5 string, so that it is matched
+// literally.
+func ftsPhrase(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
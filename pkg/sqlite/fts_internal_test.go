@@ -0,0 +1,14 @@
+//go:build sqlite_fts5
+// +build sqlite_fts5
+
+package sqlite
+
+import (
+	"testing"
+)
+
+func TestCheckFTS5(t *testing.T) {
+	if err := checkFTS5(); err != nil {
+		t.Errorf("checkFTS5() = %v, want nil when built with sqlite_fts5", err)
+	}
+}
@@ -233,7 +233,7 @@ func (qb *GalleryStore) Create(ctx context.Context, newObject *models.Gallery, f
 	var r galleryRow
 	r.fromGallery(*newObject)
 
-	id, err := qb.tableMgr.insertID(ctx, r)
+	id, err := qb.tableMgr.insertKeepID(ctx, r, newObject.ID)
 	if err != nil {
 		return err
 	}
@@ -432,7 +432,7 @@ func (qb *GalleryStore) FindMany(ctx context.Context, ids []int) ([]*models.Gall
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *GalleryStore) find(ctx context.Context, id int) (*models.Gallery, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
@@ -616,6 +616,42 @@ func (qb *GalleryStore) FindByFolderID(ctx context.Context, folderID models.Fold
 	return ret, nil
 }
 
+// FindByParentFolderID returns galleries with a zip file directly contained in the folder,
+// and folder-based galleries for folders directly contained in the folder.
+func (qb *GalleryStore) FindByParentFolderID(ctx context.Context, parentFolderID models.FolderID) ([]*models.Gallery, error) {
+	table := qb.table()
+	filesTable := fileTableMgr.table
+	foldersTable := folderTableMgr.table
+
+	fileSq := dialect.From(galleriesFilesJoinTable).InnerJoin(
+		filesTable,
+		goqu.On(filesTable.Col(idColumn).Eq(galleriesFilesJoinTable.Col(fileIDColumn))),
+	).Select(galleriesFilesJoinTable.Col(galleryIDColumn)).Where(
+		filesTable.Col("parent_folder_id").Eq(parentFolderID),
+	)
+
+	folderSq := dialect.From(table).InnerJoin(
+		foldersTable,
+		goqu.On(foldersTable.Col(idColumn).Eq(table.Col("folder_id"))),
+	).Select(table.Col(idColumn)).Where(
+		foldersTable.Col("parent_folder_id").Eq(parentFolderID),
+	)
+
+	q := qb.selectDataset().Prepared(true).Where(
+		goqu.Or(
+			table.Col(idColumn).In(fileSq),
+			table.Col(idColumn).In(folderSq),
+		),
+	)
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("getting galleries by parent folder id %d: %w", parentFolderID, err)
+	}
+
+	return ret, nil
+}
+
 func (qb *GalleryStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.Gallery, error) {
 	sq := dialect.From(galleriesScenesJoinTable).Select(galleriesScenesJoinTable.Col(galleryIDColumn)).Where(
 		galleriesScenesJoinTable.Col(sceneIDColumn).Eq(sceneID),
@@ -421,13 +421,13 @@ func (qb *galleryFilterHandler) averageResolutionCriterionHandler(resolution *mo
 
 			switch resolution.Modifier {
 			case models.CriterionModifierEquals:
-				f.addHaving(fmt.Sprintf("%s BETWEEN %d AND %d", widthHeight, min, max))
+				f.addHaving(widthHeight+" BETWEEN ? AND ?", min, max)
 			case models.CriterionModifierNotEquals:
-				f.addHaving(fmt.Sprintf("%s NOT BETWEEN %d AND %d", widthHeight, min, max))
+				f.addHaving(widthHeight+" NOT BETWEEN ? AND ?", min, max)
 			case models.CriterionModifierLessThan:
-				f.addHaving(fmt.Sprintf("%s < %d", widthHeight, min))
+				f.addHaving(widthHeight+" < ?", min)
 			case models.CriterionModifierGreaterThan:
-				f.addHaving(fmt.Sprintf("%s > %d", widthHeight, max))
+				f.addHaving(widthHeight+" > ?", max)
 			}
 		}
 	}
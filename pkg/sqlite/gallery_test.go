@@ -1295,6 +1295,44 @@ func Test_galleryQueryBuilder_CountByImageID(t *testing.T) {
 	}
 }
 
+func Test_galleryQueryBuilder_FindByParentFolderID(t *testing.T) {
+	qb := db.Gallery
+
+	withRollbackTxn(func(ctx context.Context) error {
+		// create a folder-based gallery for a subfolder
+		folderGallery := &models.Gallery{
+			FolderID: &folderIDs[folderIdxWithSceneFiles],
+		}
+		if err := qb.Create(ctx, folderGallery, nil); err != nil {
+			t.Errorf("GalleryStore.Create() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.FindByParentFolderID(ctx, folderIDs[folderIdxWithGalleryFiles])
+		if err != nil {
+			t.Errorf("GalleryStore.FindByParentFolderID() error = %v", err)
+			return nil
+		}
+
+		ids := galleriesToIDs(got)
+		assert.Contains(t, ids, galleryIDs[galleryIdxWithScene])
+		assert.NotContains(t, ids, galleryIDs[galleryIdxWithoutFile])
+		assert.NotContains(t, ids, folderGallery.ID)
+
+		got, err = qb.FindByParentFolderID(ctx, folderIDs[folderIdxForObjectFiles])
+		if err != nil {
+			t.Errorf("GalleryStore.FindByParentFolderID() error = %v", err)
+			return nil
+		}
+
+		ids = galleriesToIDs(got)
+		assert.Contains(t, ids, folderGallery.ID)
+		assert.NotContains(t, ids, galleryIDs[galleryIdxWithScene])
+
+		return nil
+	})
+}
+
 func galleriesToIDs(i []*models.Gallery) []int {
 	var ret []int
 	for _, ii := range i {
@@ -285,7 +285,7 @@ func (qb *GroupStore) FindMany(ctx context.Context, ids []int) ([]*models.Group,
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *GroupStore) find(ctx context.Context, id int) (*models.Group, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
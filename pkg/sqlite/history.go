@@ -3,6 +3,8 @@ package sqlite
 import (
 	"context"
 	"time"
+
+	"github.com/stashapp/stash/pkg/models"
 )
 
 type viewDateManager struct {
@@ -42,6 +44,53 @@ func (qb *viewDateManager) GetManyLastViewed(ctx context.Context, ids []int) ([]
 
 }
 
+func sceneViews(entries []viewHistoryEntry) []*models.SceneView {
+	ret := make([]*models.SceneView, len(entries))
+	for i, e := range entries {
+		ret[i] = &models.SceneView{
+			SceneID:  e.ID,
+			ViewedAt: e.Date,
+		}
+	}
+	return ret
+}
+
+func (qb *viewDateManager) GetViews(ctx context.Context, start *time.Time, end *time.Time) ([]*models.SceneView, error) {
+	entries, err := qb.tableMgr.getEntriesBetween(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return sceneViews(entries), nil
+}
+
+func (qb *viewDateManager) GetViewsOnThisDay(ctx context.Context, date time.Time) ([]*models.SceneView, error) {
+	entries, err := qb.tableMgr.getEntriesOnDay(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return sceneViews(entries), nil
+}
+
+func (qb *viewDateManager) CountViewsByDay(ctx context.Context, start *time.Time, end *time.Time) ([]*models.SceneViewDay, error) {
+	counts, err := qb.tableMgr.getCountsByDay(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*models.SceneViewDay, len(counts))
+	for i, c := range counts {
+		ret[i] = &models.SceneViewDay{
+			Date:       c.Date,
+			PlayCount:  c.Count,
+			SceneCount: c.IDsCount,
+		}
+	}
+
+	return ret, nil
+}
+
 func (qb *viewDateManager) AddViews(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
 	return qb.tableMgr.addDates(ctx, id, dates)
 }
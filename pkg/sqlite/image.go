@@ -418,7 +418,7 @@ func (qb *ImageStore) FindMany(ctx context.Context, ids []int) ([]*models.Image,
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *ImageStore) find(ctx context.Context, id int) (*models.Image, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
@@ -821,12 +821,18 @@ func (qb *ImageStore) queryGroupedFields(ctx context.Context, options models.Ima
 	const includeSortPagination = false
 	aggregateQuery.from = fmt.Sprintf("(%s) as temp", query.toSQL(includeSortPagination))
 
-	out := struct {
+	type aggregateResult struct {
 		Total      int
 		Megapixels null.Float
 		Size       null.Float
-	}{}
-	if err := imageRepository.queryStruct(ctx, aggregateQuery.toSQL(includeSortPagination), query.args, &out); err != nil {
+	}
+	aggregateSQL := aggregateQuery.toSQL(includeSortPagination)
+	out, err := cachedQuery(ctx, aggregateSQL, query.args, func() (aggregateResult, error) {
+		var ret aggregateResult
+		err := imageRepository.queryStruct(ctx, aggregateSQL, query.args, &ret)
+		return ret, err
+	})
+	if err != nil {
 		return nil, err
 	}
 
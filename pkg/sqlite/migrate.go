@@ -3,6 +3,9 @@ package sqlite
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 
 	"github.com/golang-migrate/migrate/v4"
 	sqlite3mig "github.com/golang-migrate/migrate/v4/database/sqlite3"
@@ -14,6 +17,83 @@ func (db *Database) needsMigration() bool {
 	return db.schemaVersion != appSchemaVersion
 }
 
+// MigrationInfo describes a schema migration.
+type MigrationInfo struct {
+	// Version is the schema version of the database after the migration
+	Version uint
+	Name    string
+	// Reversible is true if the migration has a down migration, which
+	// returns the database to the previous schema version.
+	Reversible bool
+}
+
+var migrationFileRE = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migrations returns the schema migrations embedded in the application,
+// ordered by version.
+func Migrations() ([]MigrationInfo, error) {
+	entries, err := migrationsBox.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*MigrationInfo)
+	for _, e := range entries {
+		match := migrationFileRE.FindStringSubmatch(e.Name())
+		if match == nil {
+			continue
+		}
+
+		v, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s: %w", e.Name(), err)
+		}
+
+		version := uint(v)
+		info := byVersion[version]
+		if info == nil {
+			info = &MigrationInfo{
+				Version: version,
+				Name:    match[2],
+			}
+			byVersion[version] = info
+		}
+
+		if match[3] == "down" {
+			info.Reversible = true
+		}
+	}
+
+	ret := make([]MigrationInfo, 0, len(byVersion))
+	for _, info := range byVersion {
+		ret = append(ret, *info)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Version < ret[j].Version
+	})
+
+	return ret, nil
+}
+
+// migrationsBetween returns the migrations with versions greater than from
+// and less than or equal to to.
+func migrationsBetween(from, to uint) ([]MigrationInfo, error) {
+	all, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []MigrationInfo
+	for _, m := range all {
+		if m.Version > from && m.Version <= to {
+			ret = append(ret, m)
+		}
+	}
+
+	return ret, nil
+}
+
 type Migrator struct {
 	db *Database
 	m  *migrate.Migrate
@@ -45,6 +125,40 @@ func (m *Migrator) RequiredSchemaVersion() uint {
 	return appSchemaVersion
 }
 
+// PendingMigrations returns the migrations that must be run to bring the
+// database to the required schema version, in the order they are run.
+func (m *Migrator) PendingMigrations() ([]MigrationInfo, error) {
+	return migrationsBetween(m.CurrentSchemaVersion(), m.RequiredSchemaVersion())
+}
+
+// RevertMigrations returns the migrations that must be reverted to return the
+// database to schemaVersion, in the order they are reverted. Returns an error
+// if any of the migrations are not reversible.
+func (m *Migrator) RevertMigrations(schemaVersion uint) ([]MigrationInfo, error) {
+	current := m.CurrentSchemaVersion()
+	if schemaVersion >= current {
+		return nil, fmt.Errorf("schema version %d is not earlier than the current schema version %d", schemaVersion, current)
+	}
+
+	migrations, err := migrationsBetween(schemaVersion, current)
+	if err != nil {
+		return nil, err
+	}
+
+	// revert the most recent migration first
+	for i, j := 0, len(migrations)-1; i < j; i, j = i+1, j-1 {
+		migrations[i], migrations[j] = migrations[j], migrations[i]
+	}
+
+	for _, mig := range migrations {
+		if !mig.Reversible {
+			return nil, fmt.Errorf("migration for schema version %d (%s) cannot be reverted", mig.Version, mig.Name)
+		}
+	}
+
+	return migrations, nil
+}
+
 func (m *Migrator) getMigrate() (*migrate.Migrate, error) {
 	migrations, err := iofs.New(migrationsBox, "migrations")
 	if err != nil {
@@ -99,6 +213,26 @@ func (m *Migrator) RunMigration(ctx context.Context, newVersion uint) error {
 	return nil
 }
 
+// RevertMigration reverts the migration for the current schema version,
+// returning the database to the previous schema version. Custom migrations
+// are not reverted.
+func (m *Migrator) RevertMigration(ctx context.Context, version uint) error {
+	databaseSchemaVersion, _, _ := m.m.Version()
+
+	if version != databaseSchemaVersion {
+		return fmt.Errorf("invalid migration version %d, expected %d", version, databaseSchemaVersion)
+	}
+
+	if err := m.m.Steps(-1); err != nil {
+		return err
+	}
+
+	// update the schema version
+	m.db.schemaVersion, _, _ = m.m.Version()
+
+	return nil
+}
+
 func (m *Migrator) runCustomMigrations(ctx context.Context, fns []customMigrationFunc) error {
 	for _, fn := range fns {
 		if err := m.runCustomMigration(ctx, fn); err != nil {
@@ -136,10 +270,7 @@ func (db *Database) getDatabaseSchemaVersion() (uint, error) {
 }
 
 func (db *Database) ReInitialise() error {
-	const disableForeignKeys = false
-	var err error
-	db.db, err = db.open(disableForeignKeys)
-	if err != nil {
+	if err := db.openConnections(); err != nil {
 		return fmt.Errorf("re-initializing the database: %w", err)
 	}
 
@@ -172,9 +303,7 @@ func (db *Database) RunAllMigrations() error {
 	}
 
 	// re-initialise the database
-	const disableForeignKeys = false
-	db.db, err = db.open(disableForeignKeys)
-	if err != nil {
+	if err := db.openConnections(); err != nil {
 		return fmt.Errorf("re-initializing the database: %w", err)
 	}
 
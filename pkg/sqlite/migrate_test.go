@@ -0,0 +1,132 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := sqlite.Migrations()
+	if err != nil {
+		t.Errorf("Migrations() error = %v", err)
+		return
+	}
+
+	assert := assert.New(t)
+	appSchemaVersion := db.AppSchemaVersion()
+
+	if !assert.NotEmpty(migrations) {
+		return
+	}
+
+	assert.Equal(appSchemaVersion, migrations[len(migrations)-1].Version)
+
+	for i, m := range migrations {
+		assert.NotEmpty(m.Name)
+		if i > 0 {
+			assert.Greater(m.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestMigratorRevertMigration(t *testing.T) {
+	f, err := os.CreateTemp("", "*.sqlite")
+	if err != nil {
+		t.Errorf("could not create temporary file: %v", err)
+		return
+	}
+	f.Close()
+	databaseFile := f.Name()
+	defer os.Remove(databaseFile)
+
+	testDB := sqlite.NewDatabase()
+	if err := testDB.Open(databaseFile); err != nil {
+		t.Errorf("Open() error = %v", err)
+		return
+	}
+	if err := testDB.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+		return
+	}
+
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	m, err := sqlite.NewMigrator(testDB)
+	if err != nil {
+		t.Errorf("NewMigrator() error = %v", err)
+		return
+	}
+	defer m.Close()
+
+	current := m.CurrentSchemaVersion()
+
+	pending, err := m.PendingMigrations()
+	assert.Nil(err)
+	assert.Len(pending, 0)
+
+	// migrations before schema version 65 are not reversible
+	_, err = m.RevertMigrations(0)
+	assert.NotNil(err)
+	_, err = m.RevertMigrations(63)
+	assert.NotNil(err)
+
+	// revert the latest migration
+	migrations, err := m.RevertMigrations(current - 1)
+	if !assert.Nil(err) || !assert.Len(migrations, 1) {
+		return
+	}
+
+	if err := m.RevertMigration(ctx, migrations[0].Version); err != nil {
+		t.Errorf("RevertMigration() error = %v", err)
+		return
+	}
+
+	assert.Equal(current-1, m.CurrentSchemaVersion())
+	assert.Equal(current-1, testDB.Version())
+
+	pending, err = m.PendingMigrations()
+	assert.Nil(err)
+	assert.Equal(migrations, pending)
+
+	// migrate back up
+	if err := m.RunMigration(ctx, current); err != nil {
+		t.Errorf("RunMigration() error = %v", err)
+		return
+	}
+
+	assert.Equal(current, m.CurrentSchemaVersion())
+
+	// revert all reversible migrations
+	const earliestReversible = 64
+	migrations, err = m.RevertMigrations(earliestReversible)
+	if !assert.Nil(err) {
+		return
+	}
+
+	for _, mig := range migrations {
+		if err := m.RevertMigration(ctx, mig.Version); err != nil {
+			t.Errorf("RevertMigration(%d) error = %v", mig.Version, err)
+			return
+		}
+	}
+
+	assert.Equal(uint(earliestReversible), m.CurrentSchemaVersion())
+
+	// and migrate back up
+	for v := uint(earliestReversible + 1); v <= current; v++ {
+		if err := m.RunMigration(ctx, v); err != nil {
+			t.Errorf("RunMigration(%d) error = %v", v, err)
+			return
+		}
+	}
+
+	assert.Equal(current, m.CurrentSchemaVersion())
+}
@@ -0,0 +1,8 @@
+CREATE TABLE `scenes_generated` (
+  `scene_id` integer not null,
+  `type` varchar(255) not null,
+  `hash` varchar(255) not null,
+  `generated_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `type`)
+);
@@ -0,0 +1,5 @@
+CREATE TABLE `video_file_probes` (
+  `file_id` integer NOT NULL primary key,
+  `data` blob NOT NULL,
+  foreign key(`file_id`) references `video_files`(`file_id`) on delete CASCADE
+);
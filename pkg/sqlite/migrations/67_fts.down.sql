@@ -0,0 +1,20 @@
+DROP TRIGGER `tag_aliases_fts_update`;
+DROP TRIGGER `tag_aliases_fts_delete`;
+DROP TRIGGER `tag_aliases_fts_insert`;
+DROP TRIGGER `tags_fts_delete`;
+DROP TRIGGER `tags_fts_update`;
+DROP TRIGGER `tags_fts_insert`;
+DROP TABLE `tags_fts`;
+
+DROP TRIGGER `performer_aliases_fts_update`;
+DROP TRIGGER `performer_aliases_fts_delete`;
+DROP TRIGGER `performer_aliases_fts_insert`;
+DROP TRIGGER `performers_fts_delete`;
+DROP TRIGGER `performers_fts_update`;
+DROP TRIGGER `performers_fts_insert`;
+DROP TABLE `performers_fts`;
+
+DROP TRIGGER `scenes_fts_delete`;
+DROP TRIGGER `scenes_fts_update`;
+DROP TRIGGER `scenes_fts_insert`;
+DROP TABLE `scenes_fts`;
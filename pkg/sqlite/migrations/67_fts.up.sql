@@ -0,0 +1,93 @@
+-- full-text indexes used for searching scenes, performers and tags
+-- the trigram tokenizer matches terms anywhere in the text, as with LIKE
+-- the indexes are kept up to date by triggers, which must be recreated
+-- if the indexed tables are recreated in later migrations
+
+CREATE VIRTUAL TABLE `scenes_fts` USING fts5(`title`, `details`, tokenize = 'trigram');
+
+INSERT INTO `scenes_fts` (`rowid`, `title`, `details`)
+  SELECT `id`, `title`, `details` FROM `scenes`;
+
+CREATE TRIGGER `scenes_fts_insert` AFTER INSERT ON `scenes` BEGIN
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`) VALUES (NEW.`id`, NEW.`title`, NEW.`details`);
+END;
+
+CREATE TRIGGER `scenes_fts_update` AFTER UPDATE OF `title`, `details` ON `scenes` BEGIN
+  UPDATE `scenes_fts` SET `title` = NEW.`title`, `details` = NEW.`details` WHERE `rowid` = NEW.`id`;
+END;
+
+CREATE TRIGGER `scenes_fts_delete` AFTER DELETE ON `scenes` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+-- aliases are separated by newlines so that terms do not match across aliases
+
+CREATE VIRTUAL TABLE `performers_fts` USING fts5(`name`, `aliases`, tokenize = 'trigram');
+
+INSERT INTO `performers_fts` (`rowid`, `name`, `aliases`)
+  SELECT `id`, `name`, (SELECT group_concat(`alias`, char(10)) FROM `performer_aliases` WHERE `performer_id` = `performers`.`id`)
+  FROM `performers`;
+
+CREATE TRIGGER `performers_fts_insert` AFTER INSERT ON `performers` BEGIN
+  INSERT INTO `performers_fts` (`rowid`, `name`) VALUES (NEW.`id`, NEW.`name`);
+END;
+
+CREATE TRIGGER `performers_fts_update` AFTER UPDATE OF `name` ON `performers` BEGIN
+  UPDATE `performers_fts` SET `name` = NEW.`name` WHERE `rowid` = NEW.`id`;
+END;
+
+CREATE TRIGGER `performers_fts_delete` AFTER DELETE ON `performers` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER `performer_aliases_fts_insert` AFTER INSERT ON `performer_aliases` BEGIN
+  UPDATE `performers_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `performer_aliases` WHERE `performer_id` = NEW.`performer_id`)
+  WHERE `rowid` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER `performer_aliases_fts_delete` AFTER DELETE ON `performer_aliases` BEGIN
+  UPDATE `performers_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `performer_aliases` WHERE `performer_id` = OLD.`performer_id`)
+  WHERE `rowid` = OLD.`performer_id`;
+END;
+
+CREATE TRIGGER `performer_aliases_fts_update` AFTER UPDATE ON `performer_aliases` BEGIN
+  UPDATE `performers_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `performer_aliases` WHERE `performer_id` = OLD.`performer_id`)
+  WHERE `rowid` = OLD.`performer_id`;
+  UPDATE `performers_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `performer_aliases` WHERE `performer_id` = NEW.`performer_id`)
+  WHERE `rowid` = NEW.`performer_id`;
+END;
+
+CREATE VIRTUAL TABLE `tags_fts` USING fts5(`name`, `aliases`, tokenize = 'trigram');
+
+INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`)
+  SELECT `id`, `name`, (SELECT group_concat(`alias`, char(10)) FROM `tag_aliases` WHERE `tag_id` = `tags`.`id`)
+  FROM `tags`;
+
+CREATE TRIGGER `tags_fts_insert` AFTER INSERT ON `tags` BEGIN
+  INSERT INTO `tags_fts` (`rowid`, `name`) VALUES (NEW.`id`, NEW.`name`);
+END;
+
+CREATE TRIGGER `tags_fts_update` AFTER UPDATE OF `name` ON `tags` BEGIN
+  UPDATE `tags_fts` SET `name` = NEW.`name` WHERE `rowid` = NEW.`id`;
+END;
+
+CREATE TRIGGER `tags_fts_delete` AFTER DELETE ON `tags` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER `tag_aliases_fts_insert` AFTER INSERT ON `tag_aliases` BEGIN
+  UPDATE `tags_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `tag_aliases` WHERE `tag_id` = NEW.`tag_id`)
+  WHERE `rowid` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER `tag_aliases_fts_delete` AFTER DELETE ON `tag_aliases` BEGIN
+  UPDATE `tags_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `tag_aliases` WHERE `tag_id` = OLD.`tag_id`)
+  WHERE `rowid` = OLD.`tag_id`;
+END;
+
+CREATE TRIGGER `tag_aliases_fts_update` AFTER UPDATE ON `tag_aliases` BEGIN
+  UPDATE `tags_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `tag_aliases` WHERE `tag_id` = OLD.`tag_id`)
+  WHERE `rowid` = OLD.`tag_id`;
+  UPDATE `tags_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `tag_aliases` WHERE `tag_id` = NEW.`tag_id`)
+  WHERE `rowid` = NEW.`tag_id`;
+END;
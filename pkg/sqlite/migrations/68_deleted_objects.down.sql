@@ -0,0 +1,2 @@
+DROP INDEX `index_deleted_objects_on_deleted_at`;
+DROP TABLE `deleted_objects`;
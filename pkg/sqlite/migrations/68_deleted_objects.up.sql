@@ -0,0 +1,12 @@
+-- scenes, galleries and performers deleted by the user are kept as json, in
+-- the same format as the metadata export, so that they can be restored
+CREATE TABLE `deleted_objects` (
+  `id` integer not null primary key autoincrement,
+  `object_type` varchar(255) not null,
+  `object_id` integer not null,
+  `name` varchar(255) not null,
+  `data` blob not null,
+  `deleted_at` datetime not null
+);
+
+CREATE INDEX `index_deleted_objects_on_deleted_at` ON `deleted_objects` (`deleted_at`);
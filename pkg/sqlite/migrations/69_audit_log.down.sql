@@ -0,0 +1,3 @@
+DROP INDEX `index_audit_entries_on_object`;
+DROP INDEX `index_audit_entries_on_created_at`;
+DROP TABLE `audit_entries`;
@@ -0,0 +1,17 @@
+-- changes made to scenes, images, galleries, performers and other objects
+-- fields and input are json
+CREATE TABLE `audit_entries` (
+  `id` integer not null primary key autoincrement,
+  `created_at` datetime not null,
+  `object_type` varchar(255) not null,
+  `object_id` integer not null,
+  `action` varchar(255) not null,
+  `source` varchar(255) not null,
+  `user_id` varchar(255),
+  `plugin_id` varchar(255),
+  `fields` text,
+  `input` text
+);
+
+CREATE INDEX `index_audit_entries_on_created_at` ON `audit_entries` (`created_at`);
+CREATE INDEX `index_audit_entries_on_object` ON `audit_entries` (`object_type`, `object_id`);
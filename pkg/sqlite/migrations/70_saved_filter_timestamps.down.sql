@@ -0,0 +1,2 @@
+ALTER TABLE `saved_filters` DROP COLUMN `updated_at`;
+ALTER TABLE `saved_filters` DROP COLUMN `created_at`;
@@ -0,0 +1,7 @@
+ALTER TABLE `saved_filters` ADD COLUMN `created_at` datetime not null default '1970-01-01T00:00:00Z';
+ALTER TABLE `saved_filters` ADD COLUMN `updated_at` datetime not null default '1970-01-01T00:00:00Z';
+
+-- the creation time of existing filters is unknown, so use the time of the migration
+UPDATE `saved_filters` SET
+  `created_at` = strftime('%Y-%m-%dT%H:%M:%SZ', 'now'),
+  `updated_at` = strftime('%Y-%m-%dT%H:%M:%SZ', 'now');
@@ -0,0 +1,2 @@
+ALTER TABLE `performers` DROP COLUMN `career_end`;
+ALTER TABLE `performers` DROP COLUMN `career_start`;
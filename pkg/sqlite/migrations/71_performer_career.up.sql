@@ -0,0 +1,10 @@
+ALTER TABLE `performers` ADD COLUMN `career_start` integer;
+ALTER TABLE `performers` ADD COLUMN `career_end` integer;
+
+-- populate from career length values such as "2010 - 2015" and "2010 - present"
+UPDATE `performers` SET `career_start` = CAST(substr(trim(`career_length`), 1, 4) AS INTEGER)
+  WHERE trim(`career_length`) GLOB '[12][0-9][0-9][0-9]'
+  OR trim(`career_length`) GLOB '[12][0-9][0-9][0-9][^0-9]*';
+
+UPDATE `performers` SET `career_end` = CAST(substr(trim(`career_length`), -4) AS INTEGER)
+  WHERE length(trim(`career_length`)) > 4 AND trim(`career_length`) GLOB '[12][0-9][0-9][0-9]*[^0-9][12][0-9][0-9][0-9]';
@@ -0,0 +1,10 @@
+ALTER TABLE `studios` ADD COLUMN `url` varchar(255);
+
+UPDATE `studios` SET `url` = (
+  SELECT `url` FROM `studio_urls`
+  WHERE `studio_urls`.`studio_id` = `studios`.`id`
+  ORDER BY `position` LIMIT 1
+);
+
+DROP INDEX `studio_urls_url`;
+DROP TABLE `studio_urls`;
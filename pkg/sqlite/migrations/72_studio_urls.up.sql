@@ -0,0 +1,72 @@
+PRAGMA foreign_keys=OFF;
+
+CREATE TABLE `studio_urls` (
+  `studio_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE,
+  PRIMARY KEY(`studio_id`, `position`, `url`)
+);
+
+CREATE INDEX `studio_urls_url` on `studio_urls` (`url`);
+
+-- drop url
+CREATE TABLE `studios_new` (
+  `id` INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+  `name` VARCHAR(255) NOT NULL,
+  `parent_id` INTEGER DEFAULT NULL CHECK (`id` IS NOT `parent_id`) REFERENCES `studios`(`id`) ON DELETE SET NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  `details` TEXT,
+  `rating` TINYINT,
+  `ignore_auto_tag` BOOLEAN NOT NULL DEFAULT FALSE,
+  `image_blob` VARCHAR(255) REFERENCES `blobs`(`checksum`),
+  `favorite` boolean not null default '0'
+);
+
+INSERT INTO `studios_new`
+  (
+    `id`,
+    `name`,
+    `parent_id`,
+    `created_at`,
+    `updated_at`,
+    `details`,
+    `rating`,
+    `ignore_auto_tag`,
+    `image_blob`,
+    `favorite`
+  )
+  SELECT 
+    `id`,
+    `name`,
+    `parent_id`,
+    `created_at`,
+    `updated_at`,
+    `details`,
+    `rating`,
+    `ignore_auto_tag`,
+    `image_blob`,
+    `favorite`
+  FROM `studios`;
+
+INSERT INTO `studio_urls`
+  (
+    `studio_id`,
+    `position`,
+    `url`
+  )
+  SELECT 
+    `id`,
+    '0',
+    `url`
+  FROM `studios`
+  WHERE `studios`.`url` IS NOT NULL AND `studios`.`url` != '';
+
+DROP INDEX `index_studios_on_name_unique`;
+DROP TABLE `studios`;
+ALTER TABLE `studios_new` rename to `studios`;
+
+CREATE UNIQUE INDEX `index_studios_on_name_unique` ON `studios`(`name`);
+
+PRAGMA foreign_keys=ON;
@@ -0,0 +1,37 @@
+DROP TRIGGER `performers_scenes_count_delete`;
+DROP TRIGGER `performers_scenes_count_update`;
+DROP TRIGGER `performers_scenes_count_insert`;
+DROP TRIGGER `performers_images_count_delete`;
+DROP TRIGGER `performers_images_count_update`;
+DROP TRIGGER `performers_images_count_insert`;
+DROP TRIGGER `performers_galleries_count_delete`;
+DROP TRIGGER `performers_galleries_count_update`;
+DROP TRIGGER `performers_galleries_count_insert`;
+DROP TRIGGER `scenes_tags_count_delete`;
+DROP TRIGGER `scenes_tags_count_update`;
+DROP TRIGGER `scenes_tags_count_insert`;
+DROP TRIGGER `images_tags_count_delete`;
+DROP TRIGGER `images_tags_count_update`;
+DROP TRIGGER `images_tags_count_insert`;
+DROP TRIGGER `galleries_tags_count_delete`;
+DROP TRIGGER `galleries_tags_count_update`;
+DROP TRIGGER `galleries_tags_count_insert`;
+DROP TRIGGER `scenes_studio_count_delete`;
+DROP TRIGGER `scenes_studio_count_update`;
+DROP TRIGGER `scenes_studio_count_insert`;
+DROP TRIGGER `images_studio_count_delete`;
+DROP TRIGGER `images_studio_count_update`;
+DROP TRIGGER `images_studio_count_insert`;
+DROP TRIGGER `galleries_studio_count_delete`;
+DROP TRIGGER `galleries_studio_count_update`;
+DROP TRIGGER `galleries_studio_count_insert`;
+
+ALTER TABLE `performers` DROP COLUMN `gallery_count`;
+ALTER TABLE `performers` DROP COLUMN `image_count`;
+ALTER TABLE `performers` DROP COLUMN `scene_count`;
+ALTER TABLE `tags` DROP COLUMN `gallery_count`;
+ALTER TABLE `tags` DROP COLUMN `image_count`;
+ALTER TABLE `tags` DROP COLUMN `scene_count`;
+ALTER TABLE `studios` DROP COLUMN `gallery_count`;
+ALTER TABLE `studios` DROP COLUMN `image_count`;
+ALTER TABLE `studios` DROP COLUMN `scene_count`;
@@ -0,0 +1,144 @@
+-- denormalized relationship counts used when sorting by count
+-- the counts are kept up to date by triggers, which must be recreated
+-- if the counted tables are recreated in later migrations
+
+ALTER TABLE `performers` ADD COLUMN `scene_count` integer not null default 0;
+ALTER TABLE `performers` ADD COLUMN `image_count` integer not null default 0;
+ALTER TABLE `performers` ADD COLUMN `gallery_count` integer not null default 0;
+
+ALTER TABLE `tags` ADD COLUMN `scene_count` integer not null default 0;
+ALTER TABLE `tags` ADD COLUMN `image_count` integer not null default 0;
+ALTER TABLE `tags` ADD COLUMN `gallery_count` integer not null default 0;
+
+ALTER TABLE `studios` ADD COLUMN `scene_count` integer not null default 0;
+ALTER TABLE `studios` ADD COLUMN `image_count` integer not null default 0;
+ALTER TABLE `studios` ADD COLUMN `gallery_count` integer not null default 0;
+
+UPDATE `performers` SET `scene_count` = (SELECT COUNT(*) FROM `performers_scenes` WHERE `performers_scenes`.`performer_id` = `performers`.`id`);
+UPDATE `performers` SET `image_count` = (SELECT COUNT(*) FROM `performers_images` WHERE `performers_images`.`performer_id` = `performers`.`id`);
+UPDATE `performers` SET `gallery_count` = (SELECT COUNT(*) FROM `performers_galleries` WHERE `performers_galleries`.`performer_id` = `performers`.`id`);
+UPDATE `tags` SET `scene_count` = (SELECT COUNT(*) FROM `scenes_tags` WHERE `scenes_tags`.`tag_id` = `tags`.`id`);
+UPDATE `tags` SET `image_count` = (SELECT COUNT(*) FROM `images_tags` WHERE `images_tags`.`tag_id` = `tags`.`id`);
+UPDATE `tags` SET `gallery_count` = (SELECT COUNT(*) FROM `galleries_tags` WHERE `galleries_tags`.`tag_id` = `tags`.`id`);
+UPDATE `studios` SET `scene_count` = (SELECT COUNT(*) FROM `scenes` WHERE `scenes`.`studio_id` = `studios`.`id`);
+UPDATE `studios` SET `image_count` = (SELECT COUNT(*) FROM `images` WHERE `images`.`studio_id` = `studios`.`id`);
+UPDATE `studios` SET `gallery_count` = (SELECT COUNT(*) FROM `galleries` WHERE `galleries`.`studio_id` = `studios`.`id`);
+
+CREATE TRIGGER `performers_scenes_count_insert` AFTER INSERT ON `performers_scenes` BEGIN
+  UPDATE `performers` SET `scene_count` = `scene_count` + 1 WHERE `id` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_scenes_count_update` AFTER UPDATE OF `performer_id` ON `performers_scenes` BEGIN
+  UPDATE `performers` SET `scene_count` = `scene_count` - 1 WHERE `id` = OLD.`performer_id`;
+  UPDATE `performers` SET `scene_count` = `scene_count` + 1 WHERE `id` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_scenes_count_delete` AFTER DELETE ON `performers_scenes` BEGIN
+  UPDATE `performers` SET `scene_count` = `scene_count` - 1 WHERE `id` = OLD.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_images_count_insert` AFTER INSERT ON `performers_images` BEGIN
+  UPDATE `performers` SET `image_count` = `image_count` + 1 WHERE `id` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_images_count_update` AFTER UPDATE OF `performer_id` ON `performers_images` BEGIN
+  UPDATE `performers` SET `image_count` = `image_count` - 1 WHERE `id` = OLD.`performer_id`;
+  UPDATE `performers` SET `image_count` = `image_count` + 1 WHERE `id` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_images_count_delete` AFTER DELETE ON `performers_images` BEGIN
+  UPDATE `performers` SET `image_count` = `image_count` - 1 WHERE `id` = OLD.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_galleries_count_insert` AFTER INSERT ON `performers_galleries` BEGIN
+  UPDATE `performers` SET `gallery_count` = `gallery_count` + 1 WHERE `id` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_galleries_count_update` AFTER UPDATE OF `performer_id` ON `performers_galleries` BEGIN
+  UPDATE `performers` SET `gallery_count` = `gallery_count` - 1 WHERE `id` = OLD.`performer_id`;
+  UPDATE `performers` SET `gallery_count` = `gallery_count` + 1 WHERE `id` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_galleries_count_delete` AFTER DELETE ON `performers_galleries` BEGIN
+  UPDATE `performers` SET `gallery_count` = `gallery_count` - 1 WHERE `id` = OLD.`performer_id`;
+END;
+
+CREATE TRIGGER `scenes_tags_count_insert` AFTER INSERT ON `scenes_tags` BEGIN
+  UPDATE `tags` SET `scene_count` = `scene_count` + 1 WHERE `id` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER `scenes_tags_count_update` AFTER UPDATE OF `tag_id` ON `scenes_tags` BEGIN
+  UPDATE `tags` SET `scene_count` = `scene_count` - 1 WHERE `id` = OLD.`tag_id`;
+  UPDATE `tags` SET `scene_count` = `scene_count` + 1 WHERE `id` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER `scenes_tags_count_delete` AFTER DELETE ON `scenes_tags` BEGIN
+  UPDATE `tags` SET `scene_count` = `scene_count` - 1 WHERE `id` = OLD.`tag_id`;
+END;
+
+CREATE TRIGGER `images_tags_count_insert` AFTER INSERT ON `images_tags` BEGIN
+  UPDATE `tags` SET `image_count` = `image_count` + 1 WHERE `id` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER `images_tags_count_update` AFTER UPDATE OF `tag_id` ON `images_tags` BEGIN
+  UPDATE `tags` SET `image_count` = `image_count` - 1 WHERE `id` = OLD.`tag_id`;
+  UPDATE `tags` SET `image_count` = `image_count` + 1 WHERE `id` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER `images_tags_count_delete` AFTER DELETE ON `images_tags` BEGIN
+  UPDATE `tags` SET `image_count` = `image_count` - 1 WHERE `id` = OLD.`tag_id`;
+END;
+
+CREATE TRIGGER `galleries_tags_count_insert` AFTER INSERT ON `galleries_tags` BEGIN
+  UPDATE `tags` SET `gallery_count` = `gallery_count` + 1 WHERE `id` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER `galleries_tags_count_update` AFTER UPDATE OF `tag_id` ON `galleries_tags` BEGIN
+  UPDATE `tags` SET `gallery_count` = `gallery_count` - 1 WHERE `id` = OLD.`tag_id`;
+  UPDATE `tags` SET `gallery_count` = `gallery_count` + 1 WHERE `id` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER `galleries_tags_count_delete` AFTER DELETE ON `galleries_tags` BEGIN
+  UPDATE `tags` SET `gallery_count` = `gallery_count` - 1 WHERE `id` = OLD.`tag_id`;
+END;
+
+-- studios are counted by the studio_id column of the related tables
+
+CREATE TRIGGER `scenes_studio_count_insert` AFTER INSERT ON `scenes` WHEN NEW.`studio_id` IS NOT NULL BEGIN
+  UPDATE `studios` SET `scene_count` = `scene_count` + 1 WHERE `id` = NEW.`studio_id`;
+END;
+
+CREATE TRIGGER `scenes_studio_count_update` AFTER UPDATE OF `studio_id` ON `scenes` WHEN OLD.`studio_id` IS NOT NEW.`studio_id` BEGIN
+  UPDATE `studios` SET `scene_count` = `scene_count` - 1 WHERE `id` = OLD.`studio_id`;
+  UPDATE `studios` SET `scene_count` = `scene_count` + 1 WHERE `id` = NEW.`studio_id`;
+END;
+
+CREATE TRIGGER `scenes_studio_count_delete` AFTER DELETE ON `scenes` WHEN OLD.`studio_id` IS NOT NULL BEGIN
+  UPDATE `studios` SET `scene_count` = `scene_count` - 1 WHERE `id` = OLD.`studio_id`;
+END;
+
+CREATE TRIGGER `images_studio_count_insert` AFTER INSERT ON `images` WHEN NEW.`studio_id` IS NOT NULL BEGIN
+  UPDATE `studios` SET `image_count` = `image_count` + 1 WHERE `id` = NEW.`studio_id`;
+END;
+
+CREATE TRIGGER `images_studio_count_update` AFTER UPDATE OF `studio_id` ON `images` WHEN OLD.`studio_id` IS NOT NEW.`studio_id` BEGIN
+  UPDATE `studios` SET `image_count` = `image_count` - 1 WHERE `id` = OLD.`studio_id`;
+  UPDATE `studios` SET `image_count` = `image_count` + 1 WHERE `id` = NEW.`studio_id`;
+END;
+
+CREATE TRIGGER `images_studio_count_delete` AFTER DELETE ON `images` WHEN OLD.`studio_id` IS NOT NULL BEGIN
+  UPDATE `studios` SET `image_count` = `image_count` - 1 WHERE `id` = OLD.`studio_id`;
+END;
+
+CREATE TRIGGER `galleries_studio_count_insert` AFTER INSERT ON `galleries` WHEN NEW.`studio_id` IS NOT NULL BEGIN
+  UPDATE `studios` SET `gallery_count` = `gallery_count` + 1 WHERE `id` = NEW.`studio_id`;
+END;
+
+CREATE TRIGGER `galleries_studio_count_update` AFTER UPDATE OF `studio_id` ON `galleries` WHEN OLD.`studio_id` IS NOT NEW.`studio_id` BEGIN
+  UPDATE `studios` SET `gallery_count` = `gallery_count` - 1 WHERE `id` = OLD.`studio_id`;
+  UPDATE `studios` SET `gallery_count` = `gallery_count` + 1 WHERE `id` = NEW.`studio_id`;
+END;
+
+CREATE TRIGGER `galleries_studio_count_delete` AFTER DELETE ON `galleries` WHEN OLD.`studio_id` IS NOT NULL BEGIN
+  UPDATE `studios` SET `gallery_count` = `gallery_count` - 1 WHERE `id` = OLD.`studio_id`;
+END;
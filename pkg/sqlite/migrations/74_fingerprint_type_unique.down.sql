@@ -0,0 +1 @@
+DROP INDEX `index_fingerprint_file_id_type`;
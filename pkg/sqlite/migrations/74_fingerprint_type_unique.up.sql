@@ -0,0 +1,7 @@
+-- a file may only have a single fingerprint of each type
+-- keep the most recently inserted fingerprint where there are duplicates
+DELETE FROM `files_fingerprints` WHERE `rowid` NOT IN (
+  SELECT MAX(`rowid`) FROM `files_fingerprints` GROUP BY `file_id`, `type`
+);
+
+CREATE UNIQUE INDEX `index_fingerprint_file_id_type` ON `files_fingerprints` (`file_id`, `type`);
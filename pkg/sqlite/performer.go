@@ -44,6 +44,8 @@ type performerRow struct {
 	PenisLength   null.Float  `db:"penis_length"`
 	Circumcised   zero.String `db:"circumcised"`
 	CareerLength  zero.String `db:"career_length"`
+	CareerStart   null.Int    `db:"career_start"`
+	CareerEnd     null.Int    `db:"career_end"`
 	Tattoos       zero.String `db:"tattoos"`
 	Piercings     zero.String `db:"piercings"`
 	Favorite      bool        `db:"favorite"`
@@ -59,6 +61,11 @@ type performerRow struct {
 
 	// not used in resolution or updates
 	ImageBlob zero.String `db:"image_blob"`
+
+	// maintained by triggers - used for sorting only
+	SceneCount   int `db:"scene_count" goqu:"skipinsert,skipupdate"`
+	ImageCount   int `db:"image_count" goqu:"skipinsert,skipupdate"`
+	GalleryCount int `db:"gallery_count" goqu:"skipinsert,skipupdate"`
 }
 
 func (r *performerRow) fromPerformer(o models.Performer) {
@@ -80,6 +87,8 @@ func (r *performerRow) fromPerformer(o models.Performer) {
 		r.Circumcised = zero.StringFrom(o.Circumcised.String())
 	}
 	r.CareerLength = zero.StringFrom(o.CareerLength)
+	r.CareerStart = intFromPtr(o.CareerStart)
+	r.CareerEnd = intFromPtr(o.CareerEnd)
 	r.Tattoos = zero.StringFrom(o.Tattoos)
 	r.Piercings = zero.StringFrom(o.Piercings)
 	r.Favorite = o.Favorite
@@ -107,6 +116,8 @@ func (r *performerRow) resolve() *models.Performer {
 		FakeTits:       r.FakeTits.String,
 		PenisLength:    nullFloatPtr(r.PenisLength),
 		CareerLength:   r.CareerLength.String,
+		CareerStart:    nullIntPtr(r.CareerStart),
+		CareerEnd:      nullIntPtr(r.CareerEnd),
 		Tattoos:        r.Tattoos.String,
 		Piercings:      r.Piercings.String,
 		Favorite:       r.Favorite,
@@ -152,6 +163,8 @@ func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 	r.setNullFloat64("penis_length", o.PenisLength)
 	r.setNullString("circumcised", o.Circumcised)
 	r.setNullString("career_length", o.CareerLength)
+	r.setNullInt("career_start", o.CareerStart)
+	r.setNullInt("career_end", o.CareerEnd)
 	r.setNullString("tattoos", o.Tattoos)
 	r.setNullString("piercings", o.Piercings)
 	r.setBool("favorite", o.Favorite)
@@ -252,7 +265,7 @@ func (qb *PerformerStore) Create(ctx context.Context, newObject *models.Performe
 	var r performerRow
 	r.fromPerformer(*newObject)
 
-	id, err := qb.tableMgr.insertID(ctx, r)
+	id, err := qb.tableMgr.insertKeepID(ctx, r, newObject.ID)
 	if err != nil {
 		return err
 	}
@@ -418,7 +431,7 @@ func (qb *PerformerStore) FindMany(ctx context.Context, ids []int) ([]*models.Pe
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *PerformerStore) find(ctx context.Context, id int) (*models.Performer, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
@@ -554,23 +567,27 @@ func (qb *PerformerStore) All(ctx context.Context) ([]*models.Performer, error)
 	return qb.getMany(ctx, qb.selectDataset().Order(table.Col("name").Asc()))
 }
 
-func (qb *PerformerStore) QueryForAutoTag(ctx context.Context, words []string) ([]*models.Performer, error) {
+func (qb *PerformerStore) QueryForAutoTag(ctx context.Context, words []string, includeAliases bool) ([]*models.Performer, error) {
 	// TODO - Query needs to be changed to support queries of this type, and
 	// this method should be removed
 	table := qb.table()
 	sq := dialect.From(table).Select(table.Col(idColumn))
-	// TODO - disabled alias matching until we get finer control over it
-	// .LeftJoin(
-	// 	performersAliasesJoinTable,
-	// 	goqu.On(performersAliasesJoinTable.Col(performerIDColumn).Eq(table.Col(idColumn))),
-	// )
+	if includeAliases {
+		// aliases are included in the candidates. Whether they are matched
+		// is determined by the caller.
+		sq = sq.LeftJoin(
+			performersAliasesJoinTable,
+			goqu.On(performersAliasesJoinTable.Col(performerIDColumn).Eq(table.Col(idColumn))),
+		)
+	}
 
 	var whereClauses []exp.Expression
 
 	for _, w := range words {
 		whereClauses = append(whereClauses, table.Col("name").Like(w+"%"))
-		// TODO - see above
-		// whereClauses = append(whereClauses, performersAliasesJoinTable.Col("alias").Like(w+"%"))
+		if includeAliases {
+			whereClauses = append(whereClauses, performersAliasesJoinTable.Col("alias").Like(w+"%"))
+		}
 	}
 
 	sq = sq.Where(
@@ -599,9 +616,8 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 	distinctIDs(&query, performerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(performersAliasesTable, "", "performer_aliases.performer_id = performers.id")
-		searchColumns := []string{"performers.name", "performer_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		// name and aliases are searched using the full-text index
+		query.parseQueryStringFTS(nil, &performersFTS, *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &performerFilterHandler{
@@ -749,11 +765,11 @@ func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (s
 	case "tag_count":
 		sortQuery += getCountSort(performerTable, performersTagsTable, performerIDColumn, direction)
 	case "scenes_count":
-		sortQuery += getCountSort(performerTable, performersScenesTable, performerIDColumn, direction)
+		sortQuery += getCounterSort(performerTable, sceneCountColumn, direction)
 	case "images_count":
-		sortQuery += getCountSort(performerTable, performersImagesTable, performerIDColumn, direction)
+		sortQuery += getCounterSort(performerTable, imageCountColumn, direction)
 	case "galleries_count":
-		sortQuery += getCountSort(performerTable, performersGalleriesTable, performerIDColumn, direction)
+		sortQuery += getCounterSort(performerTable, galleryCountColumn, direction)
 	case "play_count":
 		sortQuery += qb.sortByPlayCount(direction)
 	case "o_counter":
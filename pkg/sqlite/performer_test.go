@@ -61,6 +61,8 @@ func Test_PerformerStore_Create(t *testing.T) {
 		penisLength    = 1.23
 		circumcised    = models.CircumisedEnumCut
 		careerLength   = "careerLength"
+		careerStart    = 2005
+		careerEnd      = 2015
 		tattoos        = "tattoos"
 		piercings      = "piercings"
 		aliases        = []string{"alias1", "alias2"}
@@ -101,6 +103,8 @@ func Test_PerformerStore_Create(t *testing.T) {
 				PenisLength:    &penisLength,
 				Circumcised:    &circumcised,
 				CareerLength:   careerLength,
+				CareerStart:    &careerStart,
+				CareerEnd:      &careerEnd,
 				Tattoos:        tattoos,
 				Piercings:      piercings,
 				Favorite:       favorite,
@@ -208,6 +212,8 @@ func Test_PerformerStore_Update(t *testing.T) {
 		penisLength    = 1.23
 		circumcised    = models.CircumisedEnumCut
 		careerLength   = "careerLength"
+		careerStart    = 2005
+		careerEnd      = 2015
 		tattoos        = "tattoos"
 		piercings      = "piercings"
 		aliases        = []string{"alias1", "alias2"}
@@ -249,6 +255,8 @@ func Test_PerformerStore_Update(t *testing.T) {
 				PenisLength:    &penisLength,
 				Circumcised:    &circumcised,
 				CareerLength:   careerLength,
+				CareerStart:    &careerStart,
+				CareerEnd:      &careerEnd,
 				Tattoos:        tattoos,
 				Piercings:      piercings,
 				Favorite:       favorite,
@@ -356,6 +364,8 @@ func clearPerformerPartial() models.PerformerPartial {
 		PenisLength:    nullFloat,
 		Circumcised:    nullString,
 		CareerLength:   nullString,
+		CareerStart:    nullInt,
+		CareerEnd:      nullInt,
 		Tattoos:        nullString,
 		Piercings:      nullString,
 		Aliases:        &models.UpdateStrings{Mode: models.RelationshipUpdateModeSet},
@@ -389,6 +399,8 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 		penisLength    = 1.23
 		circumcised    = models.CircumisedEnumCut
 		careerLength   = "careerLength"
+		careerStart    = 2005
+		careerEnd      = 2015
 		tattoos        = "tattoos"
 		piercings      = "piercings"
 		aliases        = []string{"alias1", "alias2"}
@@ -435,6 +447,8 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 				PenisLength:  models.NewOptionalFloat64(penisLength),
 				Circumcised:  models.NewOptionalString(circumcised.String()),
 				CareerLength: models.NewOptionalString(careerLength),
+				CareerStart:  models.NewOptionalInt(careerStart),
+				CareerEnd:    models.NewOptionalInt(careerEnd),
 				Tattoos:      models.NewOptionalString(tattoos),
 				Piercings:    models.NewOptionalString(piercings),
 				Aliases: &models.UpdateStrings{
@@ -484,6 +498,8 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 				PenisLength:    &penisLength,
 				Circumcised:    &circumcised,
 				CareerLength:   careerLength,
+				CareerStart:    &careerStart,
+				CareerEnd:      &careerEnd,
 				Tattoos:        tattoos,
 				Piercings:      piercings,
 				Aliases:        models.NewRelatedStrings(aliases),
@@ -925,6 +941,9 @@ func TestPerformerQuery(t *testing.T) {
 	var (
 		endpoint = performerStashID(performerIdxWithGallery).Endpoint
 		stashID  = performerStashID(performerIdxWithGallery).StashID
+
+		aliasQ    = getPerformerStringValue(performerIdx1WithScene, "alias")
+		notAliasQ = "-" + aliasQ
 	)
 
 	tests := []struct {
@@ -1016,6 +1035,26 @@ func TestPerformerQuery(t *testing.T) {
 			[]int{performerIdx1WithScene, performerIdxWithScene},
 			false,
 		},
+		{
+			"q alias",
+			&models.FindFilterType{
+				Q: &aliasQ,
+			},
+			nil,
+			[]int{performerIdx1WithScene},
+			[]int{performerIdx2WithScene},
+			false,
+		},
+		{
+			"q not alias",
+			&models.FindFilterType{
+				Q: &notAliasQ,
+			},
+			nil,
+			[]int{performerIdx2WithScene},
+			[]int{performerIdx1WithScene},
+			false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1042,6 +1081,54 @@ func TestPerformerQuery(t *testing.T) {
 	}
 }
 
+func TestPerformerQueryQUpdated(t *testing.T) {
+	const (
+		newName  = "updated performer name"
+		newAlias = "updated performer alias"
+	)
+
+	runWithRollbackTxn(t, "updated name and alias", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		id := performerIDs[performerIdxWithTwoScenes]
+		_, err := db.Performer.UpdatePartial(ctx, id, models.PerformerPartial{
+			Name: models.NewOptionalString(newName),
+			Aliases: &models.UpdateStrings{
+				Values: []string{newAlias},
+				Mode:   models.RelationshipUpdateModeSet,
+			},
+		})
+		if err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return
+		}
+
+		for _, q := range []string{newName, newAlias} {
+			findFilter := &models.FindFilterType{
+				Q: &q,
+			}
+			performers, _, err := db.Performer.Query(ctx, nil, findFilter)
+			if err != nil {
+				t.Errorf("PerformerStore.Query() error = %v", err)
+				return
+			}
+
+			assert.Equal([]int{id}, performersToIDs(performers))
+		}
+
+		oldName := getPerformerStringValue(performerIdxWithTwoScenes, "Name")
+		performers, _, err := db.Performer.Query(ctx, nil, &models.FindFilterType{
+			Q: &oldName,
+		})
+		if err != nil {
+			t.Errorf("PerformerStore.Query() error = %v", err)
+			return
+		}
+
+		assert.NotContains(performersToIDs(performers), id)
+	})
+}
+
 func TestPerformerQueryPenisLength(t *testing.T) {
 	var upper = 4.0
 
@@ -1149,7 +1236,7 @@ func TestPerformerQueryForAutoTag(t *testing.T) {
 
 		name := performerNames[performerIdx1WithScene] // find a performer by name
 
-		performers, err := tqb.QueryForAutoTag(ctx, []string{name})
+		performers, err := tqb.QueryForAutoTag(ctx, []string{name}, false)
 
 		if err != nil {
 			t.Errorf("Error finding performers: %s", err.Error())
@@ -1163,6 +1250,41 @@ func TestPerformerQueryForAutoTag(t *testing.T) {
 	})
 }
 
+func TestPerformerQueryForAutoTagAliases(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		const alias = "TestPerformerQueryForAutoTagAliases alias"
+		performer := models.Performer{
+			Name:    "TestPerformerQueryForAutoTagAliases",
+			Aliases: models.NewRelatedStrings([]string{alias}),
+		}
+		if err := qb.Create(ctx, &performer); err != nil {
+			t.Errorf("Error creating performer: %s", err.Error())
+			return nil
+		}
+
+		words := []string{strings.ToLower(alias)}
+
+		// aliases are not matched unless requested
+		performers, err := qb.QueryForAutoTag(ctx, words, false)
+		if err != nil {
+			t.Errorf("Error finding performers: %s", err.Error())
+		}
+		assert.Len(t, performers, 0)
+
+		performers, err = qb.QueryForAutoTag(ctx, words, true)
+		if err != nil {
+			t.Errorf("Error finding performers: %s", err.Error())
+		}
+		if assert.Len(t, performers, 1) {
+			assert.Equal(t, performer.ID, performers[0].ID)
+		}
+
+		return nil
+	})
+}
+
 func TestPerformerUpdatePerformerImage(t *testing.T) {
 	if err := withRollbackTxn(func(ctx context.Context) error {
 		qb := db.Performer
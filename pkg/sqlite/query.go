@@ -77,7 +77,7 @@ func (qb queryBuilder) executeCount(ctx context.Context) (int, error) {
 
 	body = qb.repository.buildQueryBody(body, qb.whereClauses, qb.havingClauses)
 	countQuery := withClause + qb.repository.buildCountQuery(body)
-	return qb.repository.runCountQuery(ctx, countQuery, qb.args)
+	return qb.repository.runCachedCountQuery(ctx, countQuery, qb.args)
 }
 
 func (qb *queryBuilder) addWhere(clauses ...string) {
@@ -165,6 +165,12 @@ func (qb *queryBuilder) addFilter(f *filterBuilder) error {
 }
 
 func (qb *queryBuilder) parseQueryString(columns []string, q string) {
+	qb.parseQueryStringFTS(columns, nil, q)
+}
+
+// parseQueryStringFTS is the same as parseQueryString, but additionally
+// searches the columns indexed by fts using the full-text index.
+func (qb *queryBuilder) parseQueryStringFTS(columns []string, fts *ftsIndex, q string) {
 	specs := models.ParseSearchString(q)
 
 	for _, t := range specs.MustHave {
@@ -175,6 +181,12 @@ func (qb *queryBuilder) parseQueryString(columns []string, q string) {
 			qb.addArg(like(t))
 		}
 
+		if fts != nil {
+			clause, args := fts.matchClause(t)
+			clauses = append(clauses, clause)
+			qb.addArg(args...)
+		}
+
 		qb.addWhere("(" + strings.Join(clauses, " OR ") + ")")
 	}
 
@@ -183,6 +195,12 @@ func (qb *queryBuilder) parseQueryString(columns []string, q string) {
 			qb.addWhere(coalesce(column) + " NOT LIKE ?")
 			qb.addArg(like(t))
 		}
+
+		if fts != nil {
+			clause, args := fts.notMatchClause(t)
+			qb.addWhere(clause)
+			qb.addArg(args...)
+		}
 	}
 
 	for _, set := range specs.AnySets {
@@ -195,6 +213,14 @@ func (qb *queryBuilder) parseQueryString(columns []string, q string) {
 			}
 		}
 
+		if fts != nil {
+			for _, v := range set {
+				clause, args := fts.matchClause(v)
+				clauses = append(clauses, clause)
+				qb.addArg(args...)
+			}
+		}
+
 		qb.addWhere("(" + strings.Join(clauses, " OR ") + ")")
 	}
 }
@@ -14,6 +14,13 @@ import (
 
 const idColumn = "id"
 
+// relationship count columns of the performers, tags and studios tables
+const (
+	sceneCountColumn   = "scene_count"
+	imageCountColumn   = "image_count"
+	galleryCountColumn = "gallery_count"
+)
+
 type objectList interface {
 	Append(o interface{})
 	New() interface{}
@@ -84,6 +91,14 @@ func (r *repository) runCountQuery(ctx context.Context, query string, args []int
 	return result.Int, nil
 }
 
+// runCachedCountQuery runs the count query, returning the cached result
+// if the same query has been run since the last write.
+func (r *repository) runCachedCountQuery(ctx context.Context, query string, args []interface{}) (int, error) {
+	return cachedQuery(ctx, query, args, func() (int, error) {
+		return r.runCountQuery(ctx, query, args)
+	})
+}
+
 func (r *repository) runIdsQuery(ctx context.Context, query string, args []interface{}) ([]int, error) {
 	var result []struct {
 		Int int `db:"id"`
@@ -202,7 +217,7 @@ func (r *repository) executeFindQuery(ctx context.Context, body string, args []i
 	var idsResult []int
 	var idsErr error
 
-	countResult, countErr = r.runCountQuery(ctx, countQuery, args)
+	countResult, countErr = r.runCachedCountQuery(ctx, countQuery, args)
 	idsResult, idsErr = r.runIdsQuery(ctx, idsQuery, args)
 
 	if countErr != nil {
@@ -28,6 +28,8 @@ type savedFilterRow struct {
 	FindFilter   string            `db:"find_filter"`
 	ObjectFilter string            `db:"object_filter"`
 	UIOptions    string            `db:"ui_options"`
+	CreatedAt    Timestamp         `db:"created_at" goqu:"skipupdate"`
+	UpdatedAt    Timestamp         `db:"updated_at"`
 }
 
 func encodeJSONOrEmpty(v interface{}) string {
@@ -62,13 +64,18 @@ func (r *savedFilterRow) fromSavedFilter(o models.SavedFilter) {
 	r.FindFilter = encodeJSONOrEmpty(o.FindFilter)
 	r.ObjectFilter = encodeJSONOrEmpty(o.ObjectFilter)
 	r.UIOptions = encodeJSONOrEmpty(o.UIOptions)
+
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
 }
 
 func (r *savedFilterRow) resolve() *models.SavedFilter {
 	ret := &models.SavedFilter{
-		ID:   r.ID,
-		Mode: r.Mode,
-		Name: r.Name,
+		ID:        r.ID,
+		Mode:      r.Mode,
+		Name:      r.Name,
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
 	}
 
 	// decode the filters from json
@@ -138,6 +145,14 @@ func (qb *SavedFilterStore) Update(ctx context.Context, updatedObject *models.Sa
 		return err
 	}
 
+	// created_at is not updated
+	updated, err := qb.find(ctx, updatedObject.ID)
+	if err != nil {
+		return fmt.Errorf("finding after update: %w", err)
+	}
+
+	*updatedObject = *updated
+
 	return nil
 }
 
@@ -182,7 +197,7 @@ func (qb *SavedFilterStore) FindMany(ctx context.Context, ids []int, ignoreNotFo
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *SavedFilterStore) find(ctx context.Context, id int) (*models.SavedFilter, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
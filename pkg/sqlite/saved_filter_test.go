@@ -6,6 +6,7 @@ package sqlite_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -96,7 +97,40 @@ func TestSavedFilterDestroy(t *testing.T) {
 	})
 }
 
-// TODO Update
+func TestSavedFilterUpdateTimestamps(t *testing.T) {
+	createdAt := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2002, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		f := models.SavedFilter{
+			Name:      "filterTimestamps",
+			Mode:      models.FilterModeScenes,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+		if err := db.SavedFilter.Create(ctx, &f); err != nil {
+			t.Errorf("SavedFilterStore.Create() error = %v", err)
+			return nil
+		}
+
+		assert.True(t, f.CreatedAt.Equal(createdAt))
+		assert.True(t, f.UpdatedAt.Equal(createdAt))
+
+		// created at should not be changed
+		f.CreatedAt = updatedAt
+		f.UpdatedAt = updatedAt
+		if err := db.SavedFilter.Update(ctx, &f); err != nil {
+			t.Errorf("SavedFilterStore.Update() error = %v", err)
+			return nil
+		}
+
+		assert.True(t, f.CreatedAt.Equal(createdAt))
+		assert.True(t, f.UpdatedAt.Equal(updatedAt))
+
+		return nil
+	})
+}
+
 // TODO Destroy
 // TODO Find
 // TODO GetMarkerStrings
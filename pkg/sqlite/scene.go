@@ -301,7 +301,7 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 	var r sceneRow
 	r.fromScene(*newObject)
 
-	id, err := qb.tableMgr.insertID(ctx, r)
+	id, err := qb.tableMgr.insertKeepID(ctx, r, newObject.ID)
 	if err != nil {
 		return err
 	}
@@ -524,7 +524,7 @@ func (qb *SceneStore) FindMany(ctx context.Context, ids []int) ([]*models.Scene,
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *SceneStore) find(ctx context.Context, id int) (*models.Scene, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
@@ -641,6 +641,25 @@ func (qb *SceneStore) FindByPrimaryFileID(ctx context.Context, fileID models.Fil
 	return ret, nil
 }
 
+// FindByParentFolderID returns scenes with a file directly contained in the folder.
+func (qb *SceneStore) FindByParentFolderID(ctx context.Context, parentFolderID models.FolderID) ([]*models.Scene, error) {
+	filesTable := fileTableMgr.table
+
+	sq := dialect.From(scenesFilesJoinTable).InnerJoin(
+		filesTable,
+		goqu.On(filesTable.Col(idColumn).Eq(scenesFilesJoinTable.Col(fileIDColumn))),
+	).Select(scenesFilesJoinTable.Col(sceneIDColumn)).Where(
+		filesTable.Col("parent_folder_id").Eq(parentFolderID),
+	)
+
+	ret, err := qb.findBySubquery(ctx, sq)
+	if err != nil {
+		return nil, fmt.Errorf("getting scenes by parent folder id %d: %w", parentFolderID, err)
+	}
+
+	return ret, nil
+}
+
 func (qb *SceneStore) CountByFileID(ctx context.Context, fileID models.FileID) (int, error) {
 	joinTable := scenesFilesJoinTable
 
@@ -955,8 +974,9 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 		)
 
 		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"scenes.title", "scenes.details", filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title"}
-		query.parseQueryString(searchColumns, *q)
+		// title and details are searched using the full-text index
+		searchColumns := []string{filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title"}
+		query.parseQueryStringFTS(searchColumns, &scenesFTS, *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &sceneFilterHandler{
@@ -1040,12 +1060,18 @@ func (qb *SceneStore) queryGroupedFields(ctx context.Context, options models.Sce
 	const includeSortPagination = false
 	aggregateQuery.from = fmt.Sprintf("(%s) as temp", query.toSQL(includeSortPagination))
 
-	out := struct {
+	type aggregateResult struct {
 		Total    int
 		Duration null.Float
 		Size     null.Float
-	}{}
-	if err := sceneRepository.queryStruct(ctx, aggregateQuery.toSQL(includeSortPagination), query.args, &out); err != nil {
+	}
+	aggregateSQL := aggregateQuery.toSQL(includeSortPagination)
+	out, err := cachedQuery(ctx, aggregateSQL, query.args, func() (aggregateResult, error) {
+		var ret aggregateResult
+		err := sceneRepository.queryStruct(ctx, aggregateSQL, query.args, &ret)
+		return ret, err
+	})
+	if err != nil {
 		return nil, err
 	}
 
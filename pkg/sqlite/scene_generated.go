@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const scenesGeneratedTable = "scenes_generated"
+
+type sceneGeneratedRow struct {
+	Type        string    `db:"type"`
+	Hash        string    `db:"hash"`
+	GeneratedAt Timestamp `db:"generated_at"`
+}
+
+func (r *sceneGeneratedRow) resolve() models.GeneratedArtifact {
+	return models.GeneratedArtifact{
+		Type:        models.GeneratedArtifactType(r.Type),
+		Hash:        r.Hash,
+		GeneratedAt: r.GeneratedAt.Timestamp,
+	}
+}
+
+func (qb *SceneStore) GetGeneratedArtifacts(ctx context.Context, sceneID int) ([]models.GeneratedArtifact, error) {
+	table := goqu.T(scenesGeneratedTable)
+	q := dialect.From(table).Select(
+		table.Col("type"),
+		table.Col("hash"),
+		table.Col("generated_at"),
+	).Where(table.Col(sceneIDColumn).Eq(sceneID)).Order(table.Col("type").Asc())
+
+	var ret []models.GeneratedArtifact
+	if err := queryFunc(ctx, q, false, func(rows *sqlx.Rows) error {
+		var row sceneGeneratedRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting generated artifacts for scene %d: %w", sceneID, err)
+	}
+
+	return ret, nil
+}
+
+func (qb *SceneStore) SetGeneratedArtifact(ctx context.Context, sceneID int, artifact models.GeneratedArtifact) error {
+	if err := qb.tableMgr.checkIDExists(ctx, sceneID); err != nil {
+		return err
+	}
+
+	table := goqu.T(scenesGeneratedTable)
+	q := dialect.Insert(table).Rows(goqu.Record{
+		sceneIDColumn:  sceneID,
+		"type":         artifact.Type.String(),
+		"hash":         artifact.Hash,
+		"generated_at": Timestamp{Timestamp: artifact.GeneratedAt},
+	}).OnConflict(goqu.DoUpdate(sceneIDColumn+", type", goqu.Record{
+		"hash":         artifact.Hash,
+		"generated_at": Timestamp{Timestamp: artifact.GeneratedAt},
+	}))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting generated artifact for scene %d: %w", sceneID, err)
+	}
+
+	return nil
+}
+
+func (qb *SceneStore) ClearGeneratedArtifacts(ctx context.Context, sceneID int, types []models.GeneratedArtifactType) error {
+	table := goqu.T(scenesGeneratedTable)
+	q := dialect.Delete(table).Where(table.Col(sceneIDColumn).Eq(sceneID))
+
+	if len(types) > 0 {
+		typeStrs := make([]string, len(types))
+		for i, t := range types {
+			typeStrs[i] = t.String()
+		}
+		q = q.Where(table.Col("type").In(typeStrs))
+	}
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("clearing generated artifacts for scene %d: %w", sceneID, err)
+	}
+
+	return nil
+}
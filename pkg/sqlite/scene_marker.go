@@ -203,7 +203,7 @@ func (qb *SceneMarkerStore) FindMany(ctx context.Context, ids []int) ([]*models.
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *SceneMarkerStore) find(ctx context.Context, id int) (*models.SceneMarker, error) {
-	q := qb.selectDataset().Where(sceneMarkerTableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(sceneMarkerTableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
@@ -304,6 +304,59 @@ func makeSceneFileWithID(i int) *models.VideoFile {
 	return ret
 }
 
+func Test_sceneQueryBuilder_CreateWithID(t *testing.T) {
+	runWithRollbackTxn(t, "create with id", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		qb := db.Scene
+
+		s := &models.Scene{
+			Title: "deleted",
+		}
+		if err := qb.Create(ctx, s, nil); err != nil {
+			t.Errorf("sceneQueryBuilder.Create() error = %v", err)
+			return
+		}
+
+		id := s.ID
+		if err := qb.Destroy(ctx, id); err != nil {
+			t.Errorf("sceneQueryBuilder.Destroy() error = %v", err)
+			return
+		}
+
+		// recreate with the same id, as done when restoring
+		restored := &models.Scene{
+			ID:     id,
+			Title:  "restored",
+			TagIDs: models.NewRelatedIDs([]int{tagIDs[tagIdx1WithScene]}),
+		}
+		if err := qb.Create(ctx, restored, nil); err != nil {
+			t.Errorf("sceneQueryBuilder.Create() error = %v", err)
+			return
+		}
+
+		assert.Equal(id, restored.ID)
+
+		found, err := qb.Find(ctx, id)
+		if !assert.Nil(err) || !assert.NotNil(found) {
+			return
+		}
+
+		assert.Equal("restored", found.Title)
+
+		if err := found.LoadTagIDs(ctx, qb); err != nil {
+			t.Errorf("loading tag ids: %v", err)
+			return
+		}
+		assert.Equal([]int{tagIDs[tagIdx1WithScene]}, found.TagIDs.List())
+
+		// the id of an existing scene cannot be reused
+		duplicate := &models.Scene{
+			ID: sceneIDs[sceneIdxWithGallery],
+		}
+		assert.NotNil(qb.Create(ctx, duplicate, nil))
+	})
+}
+
 func Test_sceneQueryBuilder_Update(t *testing.T) {
 	var (
 		title        = "title"
@@ -1380,6 +1433,85 @@ func Test_sceneQueryBuilder_ResetO(t *testing.T) {
 	}
 }
 
+func Test_sceneQueryBuilder_GeneratedArtifacts(t *testing.T) {
+	qb := db.Scene
+
+	runWithRollbackTxn(t, "set and clear", func(t *testing.T, ctx context.Context) {
+		sceneID := sceneIDs[sceneIdxWithGallery]
+		generatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		sprite := models.GeneratedArtifact{
+			Type:        models.GeneratedArtifactTypeSprite,
+			Hash:        "hash",
+			GeneratedAt: generatedAt,
+		}
+		preview := models.GeneratedArtifact{
+			Type:        models.GeneratedArtifactTypePreview,
+			Hash:        "hash",
+			GeneratedAt: generatedAt,
+		}
+
+		for _, a := range []models.GeneratedArtifact{sprite, preview} {
+			if err := qb.SetGeneratedArtifact(ctx, sceneID, a); err != nil {
+				t.Errorf("SceneStore.SetGeneratedArtifact() error = %v", err)
+				return
+			}
+		}
+
+		// replace the existing sprite record
+		sprite.Hash = "newHash"
+		if err := qb.SetGeneratedArtifact(ctx, sceneID, sprite); err != nil {
+			t.Errorf("SceneStore.SetGeneratedArtifact() error = %v", err)
+			return
+		}
+
+		got, err := qb.GetGeneratedArtifacts(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.GetGeneratedArtifacts() error = %v", err)
+			return
+		}
+
+		assert.Len(t, got, 2)
+		assert.Equal(t, "newHash", models.FindGeneratedArtifact(got, models.GeneratedArtifactTypeSprite, "newHash").Hash)
+		assert.NotNil(t, models.FindGeneratedArtifact(got, models.GeneratedArtifactTypePreview, "hash"))
+
+		if err := qb.ClearGeneratedArtifacts(ctx, sceneID, []models.GeneratedArtifactType{models.GeneratedArtifactTypeSprite}); err != nil {
+			t.Errorf("SceneStore.ClearGeneratedArtifacts() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetGeneratedArtifacts(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.GetGeneratedArtifacts() error = %v", err)
+			return
+		}
+
+		assert.Len(t, got, 1)
+		assert.Equal(t, models.GeneratedArtifactTypePreview, got[0].Type)
+
+		if err := qb.ClearGeneratedArtifacts(ctx, sceneID, nil); err != nil {
+			t.Errorf("SceneStore.ClearGeneratedArtifacts() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetGeneratedArtifacts(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.GetGeneratedArtifacts() error = %v", err)
+			return
+		}
+
+		assert.Len(t, got, 0)
+	})
+
+	runWithRollbackTxn(t, "invalid", func(t *testing.T, ctx context.Context) {
+		err := qb.SetGeneratedArtifact(ctx, invalidID, models.GeneratedArtifact{
+			Type: models.GeneratedArtifactTypeSprite,
+			Hash: "hash",
+		})
+		assert.NotNil(t, err)
+	})
+}
+
 func Test_sceneQueryBuilder_ResetWatchCount(t *testing.T) {
 	return
 }
@@ -1901,6 +2033,52 @@ func Test_sceneStore_FindByFileID(t *testing.T) {
 	}
 }
 
+func Test_sceneStore_FindByParentFolderID(t *testing.T) {
+	tests := []struct {
+		name     string
+		folderID models.FolderID
+		include  []int
+		exclude  []int
+	}{
+		{
+			"scene files folder",
+			folderIDs[folderIdxWithSceneFiles],
+			[]int{sceneIdx1WithPerformer, sceneIdxWithGallery},
+			nil,
+		},
+		{
+			"other folder",
+			folderIDs[folderIdxWithImageFiles],
+			nil,
+			[]int{sceneIdx1WithPerformer, sceneIdxWithGallery},
+		},
+	}
+
+	qb := db.Scene
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			assert := assert.New(t)
+			got, err := qb.FindByParentFolderID(ctx, tt.folderID)
+			if err != nil {
+				t.Errorf("SceneStore.FindByParentFolderID() error = %v", err)
+				return
+			}
+
+			ids := scenesToIDs(got)
+			include := indexesToIDs(sceneIDs, tt.include)
+			exclude := indexesToIDs(sceneIDs, tt.exclude)
+
+			for _, i := range include {
+				assert.Contains(ids, i)
+			}
+			for _, e := range exclude {
+				assert.NotContains(ids, e)
+			}
+		})
+	}
+}
+
 func Test_sceneStore_CountByFileID(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -4762,3 +4940,76 @@ func TestSceneStore_CountUniqueViews(t *testing.T) {
 		return nil
 	})
 }
+
+func TestSceneStore_ViewHistory(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		scene1 := sceneIDs[sceneIdx1WithPerformer]
+		scene2 := sceneIDs[sceneIdx2WithPerformer]
+
+		// use dates outside of the test data, at midday so that they are on
+		// the same local day in UTC
+		day1 := time.Date(2001, 3, 15, 12, 0, 0, 0, time.Local)
+		day2 := time.Date(2001, 3, 16, 12, 0, 0, 0, time.Local)
+		nextYear := time.Date(2002, 3, 15, 12, 0, 0, 0, time.Local)
+
+		if _, err := qb.AddViews(ctx, scene1, []time.Time{day1, day2}); err != nil {
+			t.Errorf("SceneStore.AddViews() error = %v", err)
+			return nil
+		}
+		if _, err := qb.AddViews(ctx, scene2, []time.Time{day1.Add(time.Hour)}); err != nil {
+			t.Errorf("SceneStore.AddViews() error = %v", err)
+			return nil
+		}
+
+		start := day1.AddDate(0, 0, -1)
+		end := day2.AddDate(0, 0, 1)
+
+		views, err := qb.GetViews(ctx, &start, &end)
+		if err != nil {
+			t.Errorf("SceneStore.GetViews() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, views, 3) {
+			assert.Equal(t, scene1, views[0].SceneID)
+			assert.True(t, views[0].ViewedAt.Equal(day2))
+			assert.Equal(t, scene2, views[1].SceneID)
+			assert.Equal(t, scene1, views[2].SceneID)
+		}
+
+		days, err := qb.CountViewsByDay(ctx, &start, &end)
+		if err != nil {
+			t.Errorf("SceneStore.CountViewsByDay() error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, []*models.SceneViewDay{
+			{Date: "2001-03-16", PlayCount: 1, SceneCount: 1},
+			{Date: "2001-03-15", PlayCount: 2, SceneCount: 2},
+		}, days)
+
+		onThisDay, err := qb.GetViewsOnThisDay(ctx, nextYear)
+		if err != nil {
+			t.Errorf("SceneStore.GetViewsOnThisDay() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, onThisDay, 2)
+		for _, v := range onThisDay {
+			assert.Equal(t, "2001-03-15", v.ViewedAt.In(time.Local).Format("2006-01-02"))
+		}
+
+		// views on the same day are not included
+		onThisDay, err = qb.GetViewsOnThisDay(ctx, day1)
+		if err != nil {
+			t.Errorf("SceneStore.GetViewsOnThisDay() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, onThisDay, 0)
+
+		return nil
+	})
+}
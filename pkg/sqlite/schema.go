@@ -0,0 +1,328 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// SchemaObject is a table, index, view or trigger in the database schema.
+type SchemaObject struct {
+	Type      string `db:"type"`
+	Name      string `db:"name"`
+	TableName string `db:"tbl_name"`
+	SQL       string `db:"sql"`
+}
+
+type SchemaDifferenceKind string
+
+const (
+	// SchemaDifferenceMissing means that an expected object is not in the
+	// database.
+	SchemaDifferenceMissing SchemaDifferenceKind = "MISSING"
+	// SchemaDifferenceUnexpected means that an object in the database is not
+	// in the expected schema.
+	SchemaDifferenceUnexpected SchemaDifferenceKind = "UNEXPECTED"
+	// SchemaDifferenceChanged means that the definition of an object differs
+	// from the expected schema.
+	SchemaDifferenceChanged SchemaDifferenceKind = "CHANGED"
+)
+
+// SchemaDifference is a difference between the database schema and the
+// expected schema.
+type SchemaDifference struct {
+	Type string
+	Name string
+	Kind SchemaDifferenceKind
+	// Expected is the expected definition of the object. Empty if the object
+	// is unexpected.
+	Expected string
+	// Actual is the definition of the object in the database. Empty if the
+	// object is missing.
+	Actual string
+}
+
+// Repairable returns true if the difference can be repaired by RepairSchema.
+// Indexes, views and triggers are recreated or dropped, and missing tables
+// are created. Changed and unexpected tables are not repaired, since they
+// may contain data.
+func (d SchemaDifference) Repairable() bool {
+	if d.Type == "table" {
+		return d.Kind == SchemaDifferenceMissing
+	}
+
+	return true
+}
+
+// SchemaStatus is the result of comparing the database schema with the
+// expected schema for its schema version.
+type SchemaStatus struct {
+	SchemaVersion uint
+	// Objects is the current schema of the database.
+	Objects     []SchemaObject
+	Differences []SchemaDifference
+}
+
+// Dump returns the current schema of the database as SQL statements.
+func (s SchemaStatus) Dump() string {
+	var sb strings.Builder
+	for _, o := range s.Objects {
+		sb.WriteString(o.SQL)
+		sb.WriteString(";\n\n")
+	}
+
+	return sb.String()
+}
+
+// schemaTypeOrder is the order in which object types are created
+var schemaTypeOrder = map[string]int{
+	"table":   0,
+	"view":    1,
+	"index":   2,
+	"trigger": 3,
+}
+
+func sortSchemaObjects(objects []SchemaObject) {
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Type != objects[j].Type {
+			return schemaTypeOrder[objects[i].Type] < schemaTypeOrder[objects[j].Type]
+		}
+		return objects[i].Name < objects[j].Name
+	})
+}
+
+func readSchema(ctx context.Context, conn *sqlx.DB) ([]SchemaObject, error) {
+	// internal objects have no sql, and shadow tables are created by their
+	// virtual tables
+	const query = `SELECT type, name, tbl_name, sql FROM sqlite_master
+WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%'
+AND name NOT IN (SELECT name FROM pragma_table_list WHERE type = 'shadow')`
+
+	var ret []SchemaObject
+	if err := conn.SelectContext(ctx, &ret, query); err != nil {
+		return nil, fmt.Errorf("reading schema: %w", err)
+	}
+
+	sortSchemaObjects(ret)
+	return ret, nil
+}
+
+// schemaConn returns a connection to the database, and a function to close it.
+// The database may not be open if a migration is required.
+func (db *Database) schemaConn() (*sqlx.DB, func(), error) {
+	if db.db != nil {
+		return db.db, func() {}, nil
+	}
+
+	const disableForeignKeys = false
+	conn, err := db.open(disableForeignKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, func() { conn.Close() }, nil
+}
+
+// Schema returns the current schema of the database.
+func (db *Database) Schema(ctx context.Context) ([]SchemaObject, error) {
+	conn, closeConn, err := db.schemaConn()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	return readSchema(ctx, conn)
+}
+
+// ExpectedSchema returns the schema of a new database migrated to the
+// provided schema version.
+func ExpectedSchema(ctx context.Context, schemaVersion uint) ([]SchemaObject, error) {
+	if schemaVersion == 0 || schemaVersion > appSchemaVersion {
+		return nil, fmt.Errorf("invalid schema version %d", schemaVersion)
+	}
+
+	dir, err := os.MkdirTemp("", "stash-schema-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	db := NewDatabase()
+	db.dbPath = filepath.Join(dir, "schema.sqlite")
+
+	m, err := NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+
+	for v := uint(1); v <= schemaVersion; v++ {
+		if err := m.RunMigration(ctx, v); err != nil {
+			m.Close()
+			return nil, fmt.Errorf("running migration %d: %w", v, err)
+		}
+	}
+	m.Close()
+
+	return db.Schema(ctx)
+}
+
+var whitespaceRE = regexp.MustCompile(`\s+`)
+
+func normaliseSchemaSQL(sql string) string {
+	return whitespaceRE.ReplaceAllString(strings.TrimSpace(sql), " ")
+}
+
+// CompareSchema returns the differences between the expected and actual
+// schemas, in the order they should be repaired.
+func CompareSchema(expected, actual []SchemaObject) []SchemaDifference {
+	type key struct {
+		typ  string
+		name string
+	}
+
+	actualByKey := make(map[key]SchemaObject)
+	for _, o := range actual {
+		actualByKey[key{o.Type, o.Name}] = o
+	}
+
+	var ret []SchemaDifference
+	expectedKeys := make(map[key]bool)
+	for _, e := range expected {
+		k := key{e.Type, e.Name}
+		expectedKeys[k] = true
+
+		a, found := actualByKey[k]
+		switch {
+		case !found:
+			ret = append(ret, SchemaDifference{
+				Type:     e.Type,
+				Name:     e.Name,
+				Kind:     SchemaDifferenceMissing,
+				Expected: e.SQL,
+			})
+		case normaliseSchemaSQL(e.SQL) != normaliseSchemaSQL(a.SQL):
+			ret = append(ret, SchemaDifference{
+				Type:     e.Type,
+				Name:     e.Name,
+				Kind:     SchemaDifferenceChanged,
+				Expected: e.SQL,
+				Actual:   a.SQL,
+			})
+		}
+	}
+
+	for _, a := range actual {
+		if !expectedKeys[key{a.Type, a.Name}] {
+			ret = append(ret, SchemaDifference{
+				Type:   a.Type,
+				Name:   a.Name,
+				Kind:   SchemaDifferenceUnexpected,
+				Actual: a.SQL,
+			})
+		}
+	}
+
+	// tables must be created before the objects that depend on them
+	sort.SliceStable(ret, func(i, j int) bool {
+		return schemaTypeOrder[ret[i].Type] < schemaTypeOrder[ret[j].Type]
+	})
+
+	return ret
+}
+
+// CheckSchema compares the database schema with the expected schema for the
+// schema version of the database.
+func (db *Database) CheckSchema(ctx context.Context) (*SchemaStatus, error) {
+	if db.schemaVersion == 0 {
+		return nil, ErrDatabaseNotInitialized
+	}
+
+	actual, err := db.Schema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := ExpectedSchema(ctx, db.schemaVersion)
+	if err != nil {
+		return nil, fmt.Errorf("getting expected schema: %w", err)
+	}
+
+	return &SchemaStatus{
+		SchemaVersion: db.schemaVersion,
+		Objects:       actual,
+		Differences:   CompareSchema(expected, actual),
+	}, nil
+}
+
+// RepairSchema repairs the repairable differences between the database
+// schema and the expected schema. Returns the status of the schema after
+// the repair.
+func (db *Database) RepairSchema(ctx context.Context) (*SchemaStatus, error) {
+	status, err := db.CheckSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var repairs []SchemaDifference
+	for _, d := range status.Differences {
+		if d.Repairable() {
+			repairs = append(repairs, d)
+		}
+	}
+
+	if len(repairs) == 0 {
+		return status, nil
+	}
+
+	if err := db.repairSchema(ctx, repairs); err != nil {
+		return nil, err
+	}
+
+	return db.CheckSchema(ctx)
+}
+
+func (db *Database) repairSchema(ctx context.Context, repairs []SchemaDifference) error {
+	if err := db.lock(ctx); err != nil {
+		return err
+	}
+	defer db.unlock()
+
+	conn, closeConn, err := db.schemaConn()
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range repairs {
+		logger.Infof("Repairing schema: %s %s %s", strings.ToLower(string(d.Kind)), d.Type, d.Name)
+
+		if d.Kind != SchemaDifferenceMissing {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP %s IF EXISTS %q", strings.ToUpper(d.Type), d.Name)); err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("dropping %s %s: %w", d.Type, d.Name, err)
+			}
+		}
+
+		if d.Kind != SchemaDifferenceUnexpected {
+			if _, err := tx.ExecContext(ctx, d.Expected); err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("creating %s %s: %w", d.Type, d.Name, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
@@ -137,6 +137,12 @@ func getCountSort(primaryTable, joinTable, primaryFK, direction string) string {
 	return fmt.Sprintf(" ORDER BY (SELECT COUNT(*) FROM %s AS sort WHERE sort.%s = %s.id) %s", joinTable, primaryFK, primaryTable, getSortDirection(direction))
 }
 
+// getCounterSort returns a sort clause for a relationship count column that
+// is maintained by triggers on the related tables.
+func getCounterSort(primaryTable, column, direction string) string {
+	return fmt.Sprintf(" ORDER BY %s.%s %s", primaryTable, column, getSortDirection(direction))
+}
+
 func getStringSearchClause(columns []string, q string, not bool) sqlClause {
 	var likeClauses []string
 	var args []interface{}
@@ -286,9 +292,48 @@ func getTimestampCriterionWhereClause(column string, input models.TimestampCrite
 	return getTimestampWhereClause(column, input.Modifier, input.Value, input.Value2)
 }
 
+var relativeTimestampRE = regexp.MustCompile(`^-(\d+)([hdwmy])$`)
+
+// resolveTimestamp converts a relative timestamp value, such as "-7d" for
+// seven days before now, to an absolute timestamp. Supported units are
+// h (hours), d (days), w (weeks), m (months) and y (years). Other values are
+// returned unchanged.
+func resolveTimestamp(value string, now time.Time) string {
+	m := relativeTimestampRE.FindStringSubmatch(value)
+	if m == nil {
+		return value
+	}
+
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return value
+	}
+
+	var t time.Time
+	switch m[2] {
+	case "h":
+		t = now.Add(-time.Duration(n) * time.Hour)
+	case "d":
+		t = now.AddDate(0, 0, -n)
+	case "w":
+		t = now.AddDate(0, 0, -7*n)
+	case "m":
+		t = now.AddDate(0, -n, 0)
+	case "y":
+		t = now.AddDate(-n, 0, 0)
+	}
+
+	return t.Format(time.RFC3339)
+}
+
 func getTimestampWhereClause(column string, modifier models.CriterionModifier, value string, upper *string) (string, []interface{}) {
+	now := time.Now()
+	value = resolveTimestamp(value, now)
 	if upper == nil {
-		u := time.Now().AddDate(0, 0, 1).Format(time.RFC3339)
+		u := now.AddDate(0, 0, 1).Format(time.RFC3339)
+		upper = &u
+	} else {
+		u := resolveTimestamp(*upper, now)
 		upper = &u
 	}
 
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveTimestamp(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"2024-01-01", "2024-01-01"},
+		{"2024-01-01T00:00:00Z", "2024-01-01T00:00:00Z"},
+		{"-12h", "2024-03-15T00:00:00Z"},
+		{"-7d", "2024-03-08T12:00:00Z"},
+		{"-2w", "2024-03-01T12:00:00Z"},
+		{"-1m", "2024-02-15T12:00:00Z"},
+		{"-1y", "2023-03-15T12:00:00Z"},
+		{"-7x", "-7x"},
+		{"7d", "7d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveTimestamp(tt.value, now))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"sync"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// stmtCacheMaxEntries is the maximum number of prepared statements cached for
+// each connection.
+const stmtCacheMaxEntries = 200
+
+type cachedStmt struct {
+	stmt  *sqlite3.SQLiteStmt
+	inUse bool
+}
+
+// stmtCache caches prepared statements for a single connection, keyed on the
+// query string. Preparing a statement requires SQLite to parse and plan the
+// query, which is a significant part of the cost of the simple queries used
+// to find objects by id. Because the cache is held by the connection,
+// statements are reused across transactions and requests for as long as the
+// connection remains open.
+//
+// Only parameterised queries are cached. Queries with values embedded in the
+// SQL are unlikely to be executed again and would only evict useful entries.
+type stmtCache struct {
+	mutex   sync.Mutex
+	entries map[string]*cachedStmt
+}
+
+func newStmtCache() *stmtCache {
+	return &stmtCache{
+		entries: make(map[string]*cachedStmt),
+	}
+}
+
+func cacheableQuery(query string, args []driver.NamedValue) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	for _, a := range args {
+		if a.Name != "" {
+			return false
+		}
+	}
+
+	// queries containing multiple statements cannot be executed with a
+	// single prepared statement
+	return !strings.Contains(query, ";")
+}
+
+// acquire returns the cached statement for query, preparing it if necessary.
+// Returns nil if the statement is already in use, which occurs when the same
+// query is executed while iterating over the results of an earlier
+// execution. The statement must be released once its rows are closed.
+func (c *stmtCache) acquire(ctx context.Context, conn *sqlite3.SQLiteConn, query string) (*cachedStmt, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, found := c.entries[query]; found {
+		if e.inUse {
+			return nil, nil
+		}
+
+		e.inUse = true
+		return e, nil
+	}
+
+	s, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.entries) >= stmtCacheMaxEntries {
+		c.evictOne()
+	}
+
+	e := &cachedStmt{
+		stmt:  s.(*sqlite3.SQLiteStmt),
+		inUse: true,
+	}
+	c.entries[query] = e
+	return e, nil
+}
+
+func (c *stmtCache) release(e *cachedStmt) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e.inUse = false
+}
+
+// remove closes and removes the cached statement for query.
+func (c *stmtCache) remove(query string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, found := c.entries[query]; found {
+		delete(c.entries, query)
+		_ = e.stmt.Close()
+	}
+}
+
+// evictOne closes and removes a statement that is not in use.
+// Must be called with the mutex held.
+func (c *stmtCache) evictOne() {
+	for q, e := range c.entries {
+		if !e.inUse {
+			delete(c.entries, q)
+			_ = e.stmt.Close()
+			return
+		}
+	}
+}
+
+// close closes all cached statements.
+func (c *stmtCache) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, e := range c.entries {
+		_ = e.stmt.Close()
+	}
+	c.entries = make(map[string]*cachedStmt)
+}
+
+func (c *stmtCache) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.entries)
+}
+
+// cachedRows releases the cached statement when the rows are closed.
+type cachedRows struct {
+	*sqlite3.SQLiteRows
+	release  func()
+	released bool
+}
+
+func (r *cachedRows) Close() error {
+	// closing the rows resets the statement so that it can be reused
+	err := r.SQLiteRows.Close()
+	if !r.released {
+		r.released = true
+		r.release()
+	}
+	return err
+}
+
+// QueryContext executes parameterised queries using a cached prepared
+// statement. Other queries are prepared and finalised on each execution.
+func (c *CustomSQLiteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !cacheableQuery(query, args) {
+		return c.SQLiteConn.QueryContext(ctx, query, args)
+	}
+
+	e, err := c.stmts.acquire(ctx, c.SQLiteConn, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if e == nil || e.stmt.NumInput() != len(args) {
+		if e != nil {
+			c.stmts.release(e)
+		}
+		return c.SQLiteConn.QueryContext(ctx, query, args)
+	}
+
+	rows, err := e.stmt.QueryContext(ctx, args)
+	if err != nil {
+		// don't reuse a statement in an unknown state
+		c.stmts.release(e)
+		c.stmts.remove(query)
+		return nil, err
+	}
+
+	return &cachedRows{
+		SQLiteRows: rows.(*sqlite3.SQLiteRows),
+		release: func() {
+			c.stmts.release(e)
+		},
+	}, nil
+}
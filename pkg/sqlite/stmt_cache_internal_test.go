@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStmtCache(t *testing.T) {
+	ctx := context.Background()
+
+	dc, err := (&CustomSQLiteDriver{}).Open(":memory:")
+	if err != nil {
+		t.Fatalf("opening connection: %v", err)
+	}
+	conn := dc.(*CustomSQLiteConn)
+	defer conn.Close()
+
+	for _, s := range []string{
+		"CREATE TABLE things (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO things (id, name) VALUES (1, 'a'), (2, 'b'), (3, 'c')",
+	} {
+		if _, err := conn.ExecContext(ctx, s, nil); err != nil {
+			t.Fatalf("executing %q: %v", s, err)
+		}
+	}
+
+	const query = "SELECT name FROM things WHERE id >= ? ORDER BY id"
+
+	run := func(id int64) driver.Rows {
+		rows, err := conn.QueryContext(ctx, query, []driver.NamedValue{{Ordinal: 1, Value: id}})
+		if err != nil {
+			t.Fatalf("querying: %v", err)
+		}
+		return rows
+	}
+
+	readAll := func(rows driver.Rows) []string {
+		defer rows.Close()
+
+		var ret []string
+		dest := make([]driver.Value, 1)
+		for {
+			err := rows.Next(dest)
+			if err == io.EOF {
+				return ret
+			}
+			if err != nil {
+				t.Fatalf("reading rows: %v", err)
+			}
+			ret = append(ret, dest[0].(string))
+		}
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, readAll(run(1)))
+	assert.Equal(t, []string{"b", "c"}, readAll(run(2)))
+	assert.Equal(t, 1, conn.stmts.len(), "statement should be reused")
+
+	// executing the same query while the first is still open must not reset
+	// the open rows
+	outer := run(1)
+	dest := make([]driver.Value, 1)
+	assert.Nil(t, outer.Next(dest))
+	assert.Equal(t, []string{"c"}, readAll(run(3)))
+	assert.Nil(t, outer.Next(dest))
+	assert.Equal(t, "b", dest[0].(string))
+	assert.Nil(t, outer.Close())
+	assert.Equal(t, 1, conn.stmts.len())
+
+	// queries without arguments are not cached
+	assert.Len(t, readAll(mustQuery(t, conn, "SELECT name FROM things")), 3)
+	assert.Equal(t, 1, conn.stmts.len())
+}
+
+func mustQuery(t *testing.T, conn *CustomSQLiteConn, query string) driver.Rows {
+	rows, err := conn.QueryContext(context.Background(), query, nil)
+	if err != nil {
+		t.Fatalf("querying: %v", err)
+	}
+	return rows
+}
@@ -26,12 +26,13 @@ const (
 	studioNameColumn      = "name"
 	studioImageBlobColumn = "image_blob"
 	studiosTagsTable      = "studios_tags"
+	studioURLsTable       = "studio_urls"
+	studioURLColumn       = "url"
 )
 
 type studioRow struct {
 	ID        int         `db:"id" goqu:"skipinsert"`
 	Name      zero.String `db:"name"`
-	URL       zero.String `db:"url"`
 	ParentID  null.Int    `db:"parent_id,omitempty"`
 	CreatedAt Timestamp   `db:"created_at"`
 	UpdatedAt Timestamp   `db:"updated_at"`
@@ -43,12 +44,16 @@ type studioRow struct {
 
 	// not used in resolutions or updates
 	ImageBlob zero.String `db:"image_blob"`
+
+	// maintained by triggers - used for sorting only
+	SceneCount   int `db:"scene_count" goqu:"skipinsert,skipupdate"`
+	ImageCount   int `db:"image_count" goqu:"skipinsert,skipupdate"`
+	GalleryCount int `db:"gallery_count" goqu:"skipinsert,skipupdate"`
 }
 
 func (r *studioRow) fromStudio(o models.Studio) {
 	r.ID = o.ID
 	r.Name = zero.StringFrom(o.Name)
-	r.URL = zero.StringFrom(o.URL)
 	r.ParentID = intFromPtr(o.ParentID)
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
@@ -62,7 +67,6 @@ func (r *studioRow) resolve() *models.Studio {
 	ret := &models.Studio{
 		ID:            r.ID,
 		Name:          r.Name.String,
-		URL:           r.URL.String,
 		ParentID:      nullIntPtr(r.ParentID),
 		CreatedAt:     r.CreatedAt.Timestamp,
 		UpdatedAt:     r.UpdatedAt.Timestamp,
@@ -81,7 +85,6 @@ type studioRowRecord struct {
 
 func (r *studioRowRecord) fromPartial(o models.StudioPartial) {
 	r.setNullString("name", o.Name)
-	r.setNullString("url", o.URL)
 	r.setNullInt("parent_id", o.ParentID)
 	r.setTimestamp("created_at", o.CreatedAt)
 	r.setTimestamp("updated_at", o.UpdatedAt)
@@ -180,6 +183,13 @@ func (qb *StudioStore) Create(ctx context.Context, newObject *models.Studio) err
 		return err
 	}
 
+	if newObject.URLs.Loaded() {
+		const startPos = 0
+		if err := studiosURLsTableMgr.insertJoins(ctx, id, startPos, newObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if newObject.Aliases.Loaded() {
 		if err := studio.EnsureAliasesUnique(ctx, id, newObject.Aliases.List(), qb); err != nil {
 			return err
@@ -224,6 +234,12 @@ func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPar
 		}
 	}
 
+	if input.URLs != nil {
+		if err := studiosURLsTableMgr.modifyJoins(ctx, input.ID, input.URLs.Values, input.URLs.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	if input.Aliases != nil {
 		if err := studio.EnsureAliasesUnique(ctx, input.ID, input.Aliases.Values, qb); err != nil {
 			return nil, err
@@ -256,6 +272,12 @@ func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio)
 		return err
 	}
 
+	if updatedObject.URLs.Loaded() {
+		if err := studiosURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if updatedObject.Aliases.Loaded() {
 		if err := studiosAliasesTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.Aliases.List()); err != nil {
 			return err
@@ -325,7 +347,7 @@ func (qb *StudioStore) FindMany(ctx context.Context, ids []int) ([]*models.Studi
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *StudioStore) find(ctx context.Context, id int) (*models.Studio, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
@@ -610,11 +632,11 @@ func (qb *StudioStore) getStudioSort(findFilter *models.FindFilterType) (string,
 	case "tag_count":
 		sortQuery += getCountSort(studioTable, studiosTagsTable, studioIDColumn, direction)
 	case "scenes_count":
-		sortQuery += getCountSort(studioTable, sceneTable, studioIDColumn, direction)
+		sortQuery += getCounterSort(studioTable, sceneCountColumn, direction)
 	case "images_count":
-		sortQuery += getCountSort(studioTable, imageTable, studioIDColumn, direction)
+		sortQuery += getCounterSort(studioTable, imageCountColumn, direction)
 	case "galleries_count":
-		sortQuery += getCountSort(studioTable, galleryTable, studioIDColumn, direction)
+		sortQuery += getCounterSort(studioTable, galleryCountColumn, direction)
 	case "child_count":
 		sortQuery += getCountSort(studioTable, studioTable, studioParentIDColumn, direction)
 	default:
@@ -646,6 +668,96 @@ func (qb *StudioStore) GetStashIDs(ctx context.Context, studioID int) ([]models.
 	return studiosStashIDsTableMgr.get(ctx, studioID)
 }
 
+func (qb *StudioStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	studioTables := []string{
+		sceneTable,
+		imageTable,
+		galleryTable,
+		groupTable,
+	}
+
+	for _, table := range studioTables {
+		if _, err := dbWrapper.Exec(ctx, "UPDATE "+table+" SET studio_id = ? WHERE studio_id IN "+inBinding, args...); err != nil {
+			return err
+		}
+	}
+
+	// the destination may itself be a child of a source studio. Its parent
+	// is cleared when the source is destroyed.
+	parentArgs := append(append([]interface{}{}, args...), destination)
+	if _, err := dbWrapper.Exec(ctx, "UPDATE "+studioTable+" SET parent_id = ? WHERE parent_id IN "+inBinding+" AND id != ?", parentArgs...); err != nil {
+		return err
+	}
+
+	// tags and stash ids that the destination already has are removed with
+	// the source studios
+	if _, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+studiosTagsTable+`
+SET studio_id = ?
+WHERE studio_id IN `+inBinding, args...); err != nil {
+		return err
+	}
+
+	stashIDArgs := append(append([]interface{}{}, args...), destination)
+	if _, err := dbWrapper.Exec(ctx, `UPDATE studio_stash_ids
+SET studio_id = ?
+WHERE studio_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM studio_stash_ids o WHERE o.endpoint = studio_stash_ids.endpoint AND o.studio_id = ?)`,
+		stashIDArgs...,
+	); err != nil {
+		return err
+	}
+
+	if _, err := dbWrapper.Exec(ctx, "INSERT OR IGNORE INTO "+studioAliasesTable+" (studio_id, alias) SELECT ?, name FROM "+studioTable+" WHERE id IN "+inBinding, args...); err != nil {
+		return err
+	}
+
+	if _, err := dbWrapper.Exec(ctx, "UPDATE OR IGNORE "+studioAliasesTable+" SET studio_id = ? WHERE studio_id IN "+inBinding, args...); err != nil {
+		return err
+	}
+
+	// append the source urls that the destination does not already have
+	urlArgs := append(append([]interface{}{destination, destination}, srcArgs...), destination)
+	if _, err := dbWrapper.Exec(ctx, `INSERT OR IGNORE INTO `+studioURLsTable+` (studio_id, position, url)
+SELECT ?, (SELECT COALESCE(MAX(position) + 1, 0) FROM `+studioURLsTable+` WHERE studio_id = ?) + ROW_NUMBER() OVER (ORDER BY MIN(s.rowid)) - 1, s.url
+FROM `+studioURLsTable+` s
+WHERE s.studio_id IN `+inBinding+`
+AND s.url NOT IN (SELECT url FROM `+studioURLsTable+` WHERE studio_id = ?)
+GROUP BY s.url`,
+		urlArgs...,
+	); err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qb *StudioStore) GetURLs(ctx context.Context, studioID int) ([]string, error) {
+	return studiosURLsTableMgr.get(ctx, studioID)
+}
+
 func (qb *StudioStore) GetAliases(ctx context.Context, studioID int) ([]string, error) {
 	return studiosAliasesTableMgr.get(ctx, studioID)
 }
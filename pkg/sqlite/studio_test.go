@@ -109,7 +109,11 @@ func TestStudioQueryNameAndUrl(t *testing.T) {
 
 		assert.Len(t, studios, 1)
 		assert.Equal(t, studioName, studios[0].Name)
-		assert.Equal(t, studioUrl, studios[0].URL)
+
+		if err := studios[0].LoadURLs(ctx, sqb); err != nil {
+			t.Errorf("Error loading studio URLs: %v", err)
+		}
+		assert.Equal(t, []string{studioUrl}, studios[0].URLs.List())
 
 		return nil
 	})
@@ -145,9 +149,15 @@ func TestStudioQueryNameNotUrl(t *testing.T) {
 		studios := queryStudio(ctx, t, sqb, &studioFilter, nil)
 
 		for _, studio := range studios {
+			if err := studio.LoadURLs(ctx, sqb); err != nil {
+				t.Errorf("Error loading studio URLs: %v", err)
+			}
+
 			verifyString(t, studio.Name, nameCriterion)
 			urlCriterion.Modifier = models.CriterionModifierNotEquals
-			verifyString(t, studio.URL, urlCriterion)
+			for _, url := range studio.URLs.List() {
+				verifyString(t, url, urlCriterion)
+			}
 		}
 
 		return nil
@@ -656,7 +666,18 @@ func TestStudioQueryURL(t *testing.T) {
 
 	verifyFn := func(ctx context.Context, g *models.Studio) {
 		t.Helper()
-		verifyString(t, g.URL, urlCriterion)
+
+		if err := g.LoadURLs(ctx, db.Studio); err != nil {
+			t.Errorf("Error loading studio URLs: %v", err)
+		}
+
+		urls := g.URLs.List()
+		var url string
+		if len(urls) > 0 {
+			url = urls[0]
+		}
+
+		verifyString(t, url, urlCriterion)
 	}
 
 	verifyStudioQuery(t, filter, verifyFn)
@@ -679,6 +700,56 @@ func TestStudioQueryURL(t *testing.T) {
 	verifyStudioQuery(t, filter, verifyFn)
 }
 
+func TestStudioUpdateURLs(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		s := models.Studio{
+			Name: "TestStudioUpdateURLs",
+			URLs: models.NewRelatedStrings([]string{"aaa", "bbb"}),
+		}
+
+		if err := qb.Create(ctx, &s); err != nil {
+			return fmt.Errorf("Error creating studio: %w", err)
+		}
+
+		partial := models.NewStudioPartial()
+		partial.ID = s.ID
+
+		partial.URLs = &models.UpdateStrings{
+			Values: []string{"ccc"},
+			Mode:   models.RelationshipUpdateModeAdd,
+		}
+		if _, err := qb.UpdatePartial(ctx, partial); err != nil {
+			return fmt.Errorf("Error adding studio URLs: %w", err)
+		}
+
+		urls, err := qb.GetURLs(ctx, s.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, []string{"aaa", "bbb", "ccc"}, urls)
+
+		partial.URLs = &models.UpdateStrings{
+			Values: []string{"aaa"},
+			Mode:   models.RelationshipUpdateModeRemove,
+		}
+		if _, err := qb.UpdatePartial(ctx, partial); err != nil {
+			return fmt.Errorf("Error removing studio URLs: %w", err)
+		}
+
+		urls, err = qb.GetURLs(ctx, s.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, []string{"bbb", "ccc"}, urls)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestStudioQueryRating(t *testing.T) {
 	const rating = 60
 	ratingCriterion := models.IntCriterionInput{
@@ -1039,6 +1110,130 @@ func testStudioAlias(ctx context.Context, t *testing.T, s *models.Studio) {
 	assert.Len(t, s.Aliases.List(), 0)
 }
 
+func TestStudioMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		// try merging into same studio
+		err := qb.Merge(ctx, []int{studioIDs[studioIdxWithScene]}, studioIDs[studioIdxWithScene])
+		assert.NotNil(err)
+
+		dest, err := createStudio(ctx, qb, "merge destination", nil)
+		if err != nil {
+			return err
+		}
+		destID := dest.ID
+
+		// the destination url is kept, and a source url it already has is
+		// not duplicated
+		const destURL = "merge destination url"
+		sharedURL := getStudioStringValue(studioIdxWithImage, urlField)
+		destPartial := models.NewStudioPartial()
+		destPartial.ID = destID
+		destPartial.URLs = &models.UpdateStrings{
+			Values: []string{destURL, sharedURL},
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+		if _, err := qb.UpdatePartial(ctx, destPartial); err != nil {
+			return err
+		}
+
+		srcIdxs := []int{
+			studioIdxWithScene,
+			studioIdxWithImage,
+			studioIdxWithGallery,
+			studioIdxWithGroup,
+			studioIdxWithChildStudio,
+			studioIdxWithTag,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, studioIDs[idx])
+		}
+
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure other studios are deleted
+		for _, id := range srcIDs {
+			s, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			assert.Nil(s)
+		}
+
+		// ensure source names and aliases are set on the destination
+		destAliases, err := qb.GetAliases(ctx, destID)
+		if err != nil {
+			return err
+		}
+		for _, idx := range srcIdxs {
+			assert.Contains(destAliases, getStudioStringValue(idx, "Name"))
+		}
+		assert.Contains(destAliases, getStudioStringValue(studioIdxWithGroup, "Alias"))
+
+		// ensure source urls are moved to the destination
+		destURLs, err := qb.GetURLs(ctx, destID)
+		if err != nil {
+			return err
+		}
+		wantURLs := []string{destURL, sharedURL}
+		for _, idx := range srcIdxs {
+			if u := getStudioStringValue(idx, urlField); u != sharedURL {
+				wantURLs = append(wantURLs, u)
+			}
+		}
+		assert.ElementsMatch(wantURLs, destURLs)
+		assert.Equal([]string{destURL, sharedURL}, destURLs[:2])
+
+		s, err := db.Scene.Find(ctx, sceneIDs[sceneIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, s.StudioID)
+
+		i, err := db.Image.Find(ctx, imageIDs[imageIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, i.StudioID)
+
+		g, err := db.Gallery.Find(ctx, galleryIDs[galleryIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, g.StudioID)
+
+		group, err := db.Group.Find(ctx, groupIDs[groupIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, group.StudioID)
+
+		child, err := qb.Find(ctx, studioIDs[studioIdxWithParentStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, child.ParentID)
+
+		destTagIDs, err := qb.GetTagIDs(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Contains(destTagIDs, tagIDs[tagIdxWithStudio])
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 // TestStudioQueryFast does a quick test for major errors, no result verification
 func TestStudioQueryFast(t *testing.T) {
 
@@ -55,6 +55,23 @@ func (t *table) insertID(ctx context.Context, o interface{}) (int, error) {
 	return int(ret), nil
 }
 
+// insertKeepID inserts o as insertID does. If id is non-zero, the inserted
+// row is given that id instead of the generated one. It is used to restore
+// deleted objects with their original id.
+func (t *table) insertKeepID(ctx context.Context, o interface{}, id int) (int, error) {
+	ret, err := t.insertID(ctx, o)
+	if err != nil || id == 0 || ret == id {
+		return ret, err
+	}
+
+	q := dialect.Update(t.table).Prepared(true).Set(goqu.Record{idColumn: id}).Where(t.byID(ret))
+	if _, err := exec(ctx, q); err != nil {
+		return 0, fmt.Errorf("setting id of %s: %w", t.table.GetTable(), err)
+	}
+
+	return id, nil
+}
+
 func (t *table) updateByID(ctx context.Context, id interface{}, o interface{}) error {
 	q := dialect.Update(t.table).Prepared(true).Set(o).Where(t.byID(id))
 
@@ -983,6 +1000,102 @@ func (t *viewHistoryTable) getUniqueCount(ctx context.Context) (int, error) {
 	return ret, nil
 }
 
+// dateRange returns the where clauses for dates in the range. Nil values
+// are not bounded.
+func (t *viewHistoryTable) dateRange(start *time.Time, end *time.Time) []exp.Expression {
+	var ret []exp.Expression
+	if start != nil {
+		ret = append(ret, t.dateColumn.Gte(UTCTimestamp{Timestamp{*start}}))
+	}
+	if end != nil {
+		ret = append(ret, t.dateColumn.Lt(UTCTimestamp{Timestamp{*end}}))
+	}
+	return ret
+}
+
+type viewHistoryEntry struct {
+	ID   int
+	Date time.Time
+}
+
+func (t *viewHistoryTable) getEntries(ctx context.Context, where ...exp.Expression) ([]viewHistoryEntry, error) {
+	table := t.table.table
+
+	q := dialect.Select(
+		t.idColumn,
+		t.dateColumn,
+	).From(table).Where(where...).Order(t.dateColumn.Desc())
+
+	const single = false
+	var ret []viewHistoryEntry
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var e viewHistoryEntry
+		var date Timestamp
+		if err := rows.Scan(&e.ID, &date); err != nil {
+			return err
+		}
+		e.Date = date.Timestamp
+		ret = append(ret, e)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// getEntriesBetween returns the entries in the date range, most recent first.
+func (t *viewHistoryTable) getEntriesBetween(ctx context.Context, start *time.Time, end *time.Time) ([]viewHistoryEntry, error) {
+	return t.getEntries(ctx, t.dateRange(start, end)...)
+}
+
+// getEntriesOnDay returns the entries on the same month and day as date in
+// earlier years, most recent first. Days are in local time.
+func (t *viewHistoryTable) getEntriesOnDay(ctx context.Context, date time.Time) ([]viewHistoryEntry, error) {
+	col := t.dateColumn.GetCol().(string)
+	localDate := goqu.L(fmt.Sprintf("date(%q, 'localtime')", col))
+	localMonthDay := goqu.L(fmt.Sprintf("strftime('%%m-%%d', %q, 'localtime')", col))
+
+	return t.getEntries(ctx,
+		localMonthDay.Eq(date.Format("01-02")),
+		localDate.Lt(date.Format("2006-01-02")),
+	)
+}
+
+type viewHistoryDayCount struct {
+	Date     string
+	Count    int
+	IDsCount int
+}
+
+// getCountsByDay returns the number of entries and distinct ids for each
+// day in the date range, most recent first. Days are in local time.
+func (t *viewHistoryTable) getCountsByDay(ctx context.Context, start *time.Time, end *time.Time) ([]viewHistoryDayCount, error) {
+	table := t.table.table
+	localDate := goqu.L(fmt.Sprintf("date(%q, 'localtime')", t.dateColumn.GetCol().(string)))
+
+	q := dialect.Select(
+		localDate.As("day"),
+		goqu.COUNT("*"),
+		goqu.COUNT(goqu.DISTINCT(t.idColumn)),
+	).From(table).Where(t.dateRange(start, end)...).GroupBy(goqu.C("day")).Order(goqu.C("day").Desc())
+
+	const single = false
+	var ret []viewHistoryDayCount
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var c viewHistoryDayCount
+		if err := rows.Scan(&c.Date, &c.Count, &c.IDsCount); err != nil {
+			return err
+		}
+		ret = append(ret, c)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (t *viewHistoryTable) addDates(ctx context.Context, id int, dates []time.Time) ([]time.Time, error) {
 	table := t.table.table
 
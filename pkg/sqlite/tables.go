@@ -36,6 +36,7 @@ var (
 	studiosAliasesJoinTable  = goqu.T(studioAliasesTable)
 	studiosTagsJoinTable     = goqu.T(studiosTagsTable)
 	studiosStashIDsJoinTable = goqu.T("studio_stash_ids")
+	studiosURLsJoinTable     = goqu.T(studioURLsTable)
 
 	groupsURLsJoinTable = goqu.T(groupURLsTable)
 	groupsTagsJoinTable = goqu.T(groupsTagsTable)
@@ -295,6 +296,14 @@ var (
 		stringColumn: studiosAliasesJoinTable.Col(studioAliasColumn),
 	}
 
+	studiosURLsTableMgr = &orderedValueTable[string]{
+		table: table{
+			table:    studiosURLsJoinTable,
+			idColumn: studiosURLsJoinTable.Col(studioIDColumn),
+		},
+		valueColumn: studiosURLsJoinTable.Col(studioURLColumn),
+	}
+
 	studiosTagsTableMgr = &joinTable{
 		table: table{
 			table:    studiosTagsJoinTable,
@@ -374,3 +383,17 @@ var (
 		idColumn: goqu.T(savedFilterTable).Col(idColumn),
 	}
 )
+
+var (
+	deletedObjectTableMgr = &table{
+		table:    goqu.T(deletedObjectTable),
+		idColumn: goqu.T(deletedObjectTable).Col(idColumn),
+	}
+)
+
+var (
+	auditEntryTableMgr = &table{
+		table:    goqu.T(auditEntryTable),
+		idColumn: goqu.T(auditEntryTable).Col(idColumn),
+	}
+)
@@ -41,6 +41,11 @@ type tagRow struct {
 
 	// not used in resolutions or updates
 	ImageBlob zero.String `db:"image_blob"`
+
+	// maintained by triggers - used for sorting only
+	SceneCount   int `db:"scene_count" goqu:"skipinsert,skipupdate"`
+	ImageCount   int `db:"image_count" goqu:"skipinsert,skipupdate"`
+	GalleryCount int `db:"gallery_count" goqu:"skipinsert,skipupdate"`
 }
 
 func (r *tagRow) fromTag(o models.Tag) {
@@ -332,7 +337,7 @@ func (qb *TagStore) FindMany(ctx context.Context, ids []int) ([]*models.Tag, err
 
 // returns nil, sql.ErrNoRows if not found
 func (qb *TagStore) find(ctx context.Context, id int) (*models.Tag, error) {
-	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+	q := qb.selectDataset().Prepared(true).Where(qb.tableMgr.byID(id))
 
 	ret, err := qb.get(ctx, q)
 	if err != nil {
@@ -602,9 +607,8 @@ func (qb *TagStore) Query(ctx context.Context, tagFilter *models.TagFilterType,
 	distinctIDs(&query, tagTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(tagAliasesTable, "", "tag_aliases.tag_id = tags.id")
-		searchColumns := []string{"tags.name", "tag_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		// name and aliases are searched using the full-text index
+		query.parseQueryStringFTS(nil, &tagsFTS, *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &tagFilterHandler{
@@ -673,13 +677,13 @@ func (qb *TagStore) getTagSort(query *queryBuilder, findFilter *models.FindFilte
 	sortQuery := ""
 	switch sort {
 	case "scenes_count":
-		sortQuery += getCountSort(tagTable, scenesTagsTable, tagIDColumn, direction)
+		sortQuery += getCounterSort(tagTable, sceneCountColumn, direction)
 	case "scene_markers_count":
 		sortQuery += fmt.Sprintf(" ORDER BY (SELECT COUNT(*) FROM scene_markers_tags WHERE tags.id = scene_markers_tags.tag_id)+(SELECT COUNT(*) FROM scene_markers WHERE tags.id = scene_markers.primary_tag_id) %s", getSortDirection(direction))
 	case "images_count":
-		sortQuery += getCountSort(tagTable, imagesTagsTable, tagIDColumn, direction)
+		sortQuery += getCounterSort(tagTable, imageCountColumn, direction)
 	case "galleries_count":
-		sortQuery += getCountSort(tagTable, galleriesTagsTable, tagIDColumn, direction)
+		sortQuery += getCounterSort(tagTable, galleryCountColumn, direction)
 	case "performers_count":
 		sortQuery += getCountSort(tagTable, performersTagsTable, tagIDColumn, direction)
 	case "studios_count":
@@ -921,6 +925,49 @@ SELECT t.*, c.path FROM tags t INNER JOIN children c ON t.id = c.child_id
 	return qb.queryTagPaths(ctx, query, args)
 }
 
+// FindCoOccurring returns the tags most frequently applied to the same scenes
+// as the provided tags, ordered by the number of shared scenes. The provided
+// tags are excluded from the results.
+func (qb *TagStore) FindCoOccurring(ctx context.Context, tagIDs []int, limit int) ([]*models.TagCoOccurrence, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	source := goqu.T(scenesTagsTable).As("source")
+	other := goqu.T(scenesTagsTable).As("other")
+	countCol := goqu.COUNT(goqu.DISTINCT(source.Col(sceneIDColumn)))
+
+	q := dialect.Select(other.Col(tagIDColumn), countCol).
+		From(source).
+		InnerJoin(other, goqu.On(other.Col(sceneIDColumn).Eq(source.Col(sceneIDColumn)))).
+		Where(
+			source.Col(tagIDColumn).In(tagIDs),
+			other.Col(tagIDColumn).NotIn(tagIDs),
+		).
+		GroupBy(other.Col(tagIDColumn)).
+		Order(countCol.Desc(), other.Col(tagIDColumn).Asc())
+
+	if limit > 0 {
+		q = q.Limit(uint(limit))
+	}
+
+	var ret []*models.TagCoOccurrence
+	const single = false
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var v models.TagCoOccurrence
+		if err := rows.Scan(&v.TagID, &v.Count); err != nil {
+			return err
+		}
+
+		ret = append(ret, &v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 type tagRelationshipStore struct {
 	idRelationshipStore
 }
@@ -152,6 +152,31 @@ func TestTagQueryForAutoTag(t *testing.T) {
 	})
 }
 
+func TestTagFindCoOccurring(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		tqb := db.Tag
+
+		got, err := tqb.FindCoOccurring(ctx, []int{tagIDs[tagIdx1WithScene]}, 0)
+		if err != nil {
+			t.Errorf("Error finding co-occurring tags: %s", err.Error())
+		}
+
+		assert.Equal(t, []*models.TagCoOccurrence{
+			{TagID: tagIDs[tagIdx2WithScene], Count: 2},
+			{TagID: tagIDs[tagIdx3WithScene], Count: 1},
+		}, got)
+
+		got, err = tqb.FindCoOccurring(ctx, []int{tagIDs[tagIdx1WithScene]}, 1)
+		if err != nil {
+			t.Errorf("Error finding co-occurring tags: %s", err.Error())
+		}
+
+		assert.Len(t, got, 1)
+
+		return nil
+	})
+}
+
 func TestTagFindByNames(t *testing.T) {
 	var names []string
 
@@ -242,6 +267,71 @@ func TestTagQuerySort(t *testing.T) {
 	})
 }
 
+func TestTagQuerySortScenesCountUpdated(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		sqb := db.Tag
+		assert := assert.New(t)
+
+		tag := models.Tag{
+			Name: "TestTagQuerySortScenesCountUpdated",
+		}
+		if err := sqb.Create(ctx, &tag); err != nil {
+			return fmt.Errorf("Error creating tag: %w", err)
+		}
+
+		sortBy := "scenes_count"
+		dir := models.SortDirectionEnumDesc
+		findFilter := &models.FindFilterType{
+			Sort:      &sortBy,
+			Direction: &dir,
+		}
+
+		// add the tag to more scenes than any other tag
+		const numScenes = 10
+		for _, sceneID := range sceneIDs[:numScenes] {
+			if _, err := db.Scene.UpdatePartial(ctx, sceneID, models.ScenePartial{
+				TagIDs: &models.UpdateIDs{
+					IDs:  []int{tag.ID},
+					Mode: models.RelationshipUpdateModeAdd,
+				},
+			}); err != nil {
+				return fmt.Errorf("Error updating scene: %w", err)
+			}
+		}
+
+		tags := queryTags(ctx, t, sqb, nil, findFilter)
+		assert.Equal(tag.ID, tags[0].ID)
+
+		// updating the tag must not reset the count
+		tag.Description = "description"
+		if err := sqb.Update(ctx, &tag); err != nil {
+			return fmt.Errorf("Error updating tag: %w", err)
+		}
+
+		tags = queryTags(ctx, t, sqb, nil, findFilter)
+		assert.Equal(tag.ID, tags[0].ID)
+
+		// removing the tag from the scenes decrements the count
+		for _, sceneID := range sceneIDs[:numScenes] {
+			if _, err := db.Scene.UpdatePartial(ctx, sceneID, models.ScenePartial{
+				TagIDs: &models.UpdateIDs{
+					IDs:  []int{tag.ID},
+					Mode: models.RelationshipUpdateModeRemove,
+				},
+			}); err != nil {
+				return fmt.Errorf("Error updating scene: %w", err)
+			}
+		}
+
+		tags = queryTags(ctx, t, sqb, nil, findFilter)
+		assert.Equal(tagIDs[tagIdx2WithScene], tags[0].ID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestTagQueryName(t *testing.T) {
 	const tagIdx = 1
 	tagName := getSceneStringValue(tagIdx, "Name")
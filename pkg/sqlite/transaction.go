@@ -18,6 +18,7 @@ const (
 	txnKey key = iota + 1
 	dbKey
 	exclusiveKey
+	countGenerationKey
 )
 
 func (db *Database) WithDatabase(ctx context.Context) (context.Context, error) {
@@ -26,7 +27,7 @@ func (db *Database) WithDatabase(ctx context.Context) (context.Context, error) {
 		return ctx, nil
 	}
 
-	return context.WithValue(ctx, dbKey, db.db), nil
+	return context.WithValue(ctx, dbKey, db.readDB), nil
 }
 
 func (db *Database) Begin(ctx context.Context, exclusive bool) (context.Context, error) {
@@ -43,7 +44,18 @@ func (db *Database) Begin(ctx context.Context, exclusive bool) (context.Context,
 		}
 	}
 
-	tx, err := db.db.BeginTxx(ctx, nil)
+	// capture the generation before the transaction sees any data, so that
+	// counts are not cached against a stale snapshot
+	generation := queryCountCache.currentGeneration()
+
+	// write transactions use the write connection, read transactions use
+	// the read-only pool
+	conn := db.readDB
+	if exclusive {
+		conn = db.db
+	}
+
+	tx, err := conn.BeginTxx(ctx, nil)
 	if err != nil {
 		// begin failed, unlock
 		if exclusive {
@@ -53,6 +65,7 @@ func (db *Database) Begin(ctx context.Context, exclusive bool) (context.Context,
 	}
 
 	ctx = context.WithValue(ctx, exclusiveKey, exclusive)
+	ctx = context.WithValue(ctx, countGenerationKey, generation)
 
 	return context.WithValue(ctx, txnKey, tx), nil
 }
@@ -89,6 +102,8 @@ func (db *Database) Rollback(ctx context.Context) error {
 
 func (db *Database) txnComplete(ctx context.Context) {
 	if exclusive := ctx.Value(exclusiveKey).(bool); exclusive {
+		// writes may have been made - invalidate cached counts
+		queryCountCache.invalidate()
 		db.unlock()
 	}
 }
@@ -139,5 +154,7 @@ func (db *Database) Repository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    db.SavedFilter,
+		DeletedObject:  db.DeletedObject,
+		AuditEntry:     db.AuditEntry,
 	}
 }
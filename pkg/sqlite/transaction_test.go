@@ -77,37 +77,41 @@ func waitForOtherThread(c chan struct{}) error {
 	}
 }
 
-func TestConcurrentReadTxn(t *testing.T) {
+func TestReadTxnReadOnly(t *testing.T) {
+	ctx := context.Background()
+
+	// read transactions use the read-only connection pool
+	err := txn.WithReadTxn(ctx, db, func(ctx context.Context) error {
+		scene := &models.Scene{
+			Title: "test",
+		}
+
+		return db.Scene.Create(ctx, scene, nil)
+	})
+
+	if err == nil {
+		t.Error("expected error creating scene in read transaction")
+	}
+}
+
+func TestReadTxnDoesNotBlockWrite(t *testing.T) {
 	var wg sync.WaitGroup
 	ctx := context.Background()
 	c := make(chan struct{})
 
-	// first thread
+	// first thread holds a read transaction open while the second writes
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
 		if err := txn.WithReadTxn(ctx, db, func(ctx context.Context) error {
-			scene := &models.Scene{
-				Title: "test",
-			}
-
-			if err := db.Scene.Create(ctx, scene, nil); err != nil {
+			if _, err := db.Scene.Find(ctx, sceneIDs[sceneIdx1WithPerformer]); err != nil {
 				return err
 			}
 
-			// wait for other thread to start
 			if err := signalOtherThread(c); err != nil {
 				return err
 			}
-			if err := waitForOtherThread(c); err != nil {
-				return err
-			}
-
-			if err := db.Scene.Destroy(ctx, scene.ID); err != nil {
-				return err
-			}
-
-			return nil
+			return waitForOtherThread(c)
 		}); err != nil {
 			t.Errorf("unexpected error in first thread: %v", err)
 		}
@@ -116,42 +120,122 @@ func TestConcurrentReadTxn(t *testing.T) {
 	// second thread
 	go func() {
 		defer wg.Done()
-		_ = txn.WithReadTxn(ctx, db, func(ctx context.Context) error {
-			// wait for first thread
-			if err := waitForOtherThread(c); err != nil {
+
+		if err := waitForOtherThread(c); err != nil {
+			t.Errorf(err.Error())
+			return
+		}
+
+		defer func() {
+			if err := signalOtherThread(c); err != nil {
 				t.Errorf(err.Error())
-				return err
 			}
+		}()
 
-			defer func() {
-				if err := signalOtherThread(c); err != nil {
-					t.Errorf(err.Error())
-				}
-			}()
-
+		if err := txn.WithTxn(ctx, db, func(ctx context.Context) error {
 			scene := &models.Scene{
 				Title: "test",
 			}
 
-			// expect error when we try to do this, as the other thread has already
-			// modified this table
-			// this takes time to fail, so we need to wait for it
 			if err := db.Scene.Create(ctx, scene, nil); err != nil {
-				if !db.IsLocked(err) {
-					t.Errorf("unexpected error: %v", err)
-				}
 				return err
-			} else {
-				t.Errorf("expected locked error in second thread")
 			}
 
-			return nil
-		})
+			return db.Scene.Destroy(ctx, scene.ID)
+		}); err != nil {
+			t.Errorf("unexpected error in second thread: %v", err)
+		}
 	}()
 
 	wg.Wait()
 }
 
+func TestSharedTxn(t *testing.T) {
+	const (
+		firstName  = "shared txn first"
+		secondName = "shared txn second"
+	)
+	ctx := context.Background()
+
+	createTag := func(ctx context.Context, name string) error {
+		return txn.WithTxn(ctx, db, func(ctx context.Context) error {
+			return db.Tag.Create(ctx, &models.Tag{Name: name})
+		})
+	}
+
+	findTag := func(name string) *models.Tag {
+		var ret *models.Tag
+		if err := txn.WithReadTxn(ctx, db, func(ctx context.Context) error {
+			var err error
+			ret, err = db.Tag.FindByName(ctx, name, false)
+			return err
+		}); err != nil {
+			t.Fatalf("finding tag %s: %v", name, err)
+		}
+		return ret
+	}
+
+	errTest := errors.New("test error")
+	postCommit := false
+	err := txn.WithSharedTxn(ctx, db, func(ctx context.Context) error {
+		if err := createTag(ctx, firstName); err != nil {
+			return err
+		}
+
+		txn.AddPostCommitHook(ctx, func(ctx context.Context) {
+			postCommit = true
+		})
+
+		if err := createTag(ctx, secondName); err != nil {
+			return err
+		}
+
+		return errTest
+	})
+
+	if !errors.Is(err, errTest) {
+		t.Errorf("WithSharedTxn() error = %v, want %v", err, errTest)
+	}
+	if postCommit {
+		t.Error("post-commit hook was executed after rollback")
+	}
+	if findTag(firstName) != nil || findTag(secondName) != nil {
+		t.Error("changes were not rolled back")
+	}
+
+	if err := txn.WithSharedTxn(ctx, db, func(ctx context.Context) error {
+		if err := createTag(ctx, firstName); err != nil {
+			return err
+		}
+
+		txn.AddPostCommitHook(ctx, func(ctx context.Context) {
+			postCommit = true
+		})
+
+		return createTag(ctx, secondName)
+	}); err != nil {
+		t.Fatalf("WithSharedTxn() error = %v", err)
+	}
+
+	if !postCommit {
+		t.Error("post-commit hook was not executed")
+	}
+
+	for _, name := range []string{firstName, secondName} {
+		tag := findTag(name)
+		if tag == nil {
+			t.Errorf("tag %s was not created", name)
+			continue
+		}
+
+		if err := txn.WithTxn(ctx, db, func(ctx context.Context) error {
+			return db.Tag.Destroy(ctx, tag.ID)
+		}); err != nil {
+			t.Errorf("destroying tag %s: %v", name, err)
+		}
+	}
+}
+
 func TestConcurrentExclusiveAndReadTxn(t *testing.T) {
 	var wg sync.WaitGroup
 	ctx := context.Background()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -26,8 +27,38 @@ type stmt struct {
 	query string
 }
 
-func logSQL(start time.Time, query string, args ...interface{}) {
+// slowQueryThreshold is the duration in nanoseconds above which statements
+// are logged at info level. Zero disables slow query logging.
+var slowQueryThreshold atomic.Int64
+
+type contextKey int
+
+const operationKey contextKey = iota
+
+// WithOperation returns a context with the name of the operation that is
+// executing statements, such as a GraphQL operation. The operation is
+// included in slow query logs.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey, operation)
+}
+
+func getOperation(ctx context.Context) string {
+	ret, _ := ctx.Value(operationKey).(string)
+	return ret
+}
+
+func logSQL(ctx context.Context, start time.Time, query string, args ...interface{}) {
 	since := time.Since(start)
+
+	if threshold := time.Duration(slowQueryThreshold.Load()); threshold > 0 && since >= threshold {
+		if operation := getOperation(ctx); operation != "" {
+			logger.Infof("SLOW SQL [%v] in %s: %s, args: %v", since, operation, query, args)
+		} else {
+			logger.Infof("SLOW SQL [%v]: %s, args: %v", since, query, args)
+		}
+		return
+	}
+
 	if since >= slowLogTime {
 		logger.Debugf("SLOW SQL [%v]: %s, args: %v", since, query, args)
 	} else {
@@ -55,7 +86,7 @@ func (*dbWrapperType) Get(ctx context.Context, dest interface{}, query string, a
 
 	start := time.Now()
 	err = tx.Get(dest, query, args...)
-	logSQL(start, query, args...)
+	logSQL(ctx, start, query, args...)
 
 	return sqlError(err, query, args...)
 }
@@ -68,7 +99,7 @@ func (*dbWrapperType) Select(ctx context.Context, dest interface{}, query string
 
 	start := time.Now()
 	err = tx.Select(dest, query, args...)
-	logSQL(start, query, args...)
+	logSQL(ctx, start, query, args...)
 
 	return sqlError(err, query, args...)
 }
@@ -81,7 +112,7 @@ func (*dbWrapperType) Queryx(ctx context.Context, query string, args ...interfac
 
 	start := time.Now()
 	ret, err := tx.Queryx(query, args...)
-	logSQL(start, query, args...)
+	logSQL(ctx, start, query, args...)
 
 	return ret, sqlError(err, query, args...)
 }
@@ -94,7 +125,7 @@ func (*dbWrapperType) QueryxContext(ctx context.Context, query string, args ...i
 
 	start := time.Now()
 	ret, err := tx.QueryxContext(ctx, query, args...)
-	logSQL(start, query, args...)
+	logSQL(ctx, start, query, args...)
 
 	return ret, sqlError(err, query, args...)
 }
@@ -107,7 +138,7 @@ func (*dbWrapperType) NamedExec(ctx context.Context, query string, arg interface
 
 	start := time.Now()
 	ret, err := tx.NamedExec(query, arg)
-	logSQL(start, query, arg)
+	logSQL(ctx, start, query, arg)
 
 	return ret, sqlError(err, query, arg)
 }
@@ -120,7 +151,7 @@ func (*dbWrapperType) Exec(ctx context.Context, query string, args ...interface{
 
 	start := time.Now()
 	ret, err := tx.Exec(query, args...)
-	logSQL(start, query, args...)
+	logSQL(ctx, start, query, args...)
 
 	return ret, sqlError(err, query, args...)
 }
@@ -152,7 +183,7 @@ func (*dbWrapperType) ExecStmt(ctx context.Context, stmt *stmt, args ...interfac
 
 	start := time.Now()
 	ret, err := stmt.ExecContext(ctx, args...)
-	logSQL(start, stmt.query, args...)
+	logSQL(ctx, start, stmt.query, args...)
 
 	return ret, sqlError(err, stmt.query, args...)
 }
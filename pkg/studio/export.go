@@ -15,6 +15,7 @@ type FinderImageStashIDGetter interface {
 	models.StudioGetter
 	models.AliasLoader
 	models.StashIDLoader
+	models.URLLoader
 	GetImage(ctx context.Context, studioID int) ([]byte, error)
 }
 
@@ -22,7 +23,6 @@ type FinderImageStashIDGetter interface {
 func ToJSON(ctx context.Context, reader FinderImageStashIDGetter, studio *models.Studio) (*jsonschema.Studio, error) {
 	newStudioJSON := jsonschema.Studio{
 		Name:          studio.Name,
-		URL:           studio.URL,
 		Details:       studio.Details,
 		Favorite:      studio.Favorite,
 		IgnoreAutoTag: studio.IgnoreAutoTag,
@@ -45,6 +45,11 @@ func ToJSON(ctx context.Context, reader FinderImageStashIDGetter, studio *models
 		newStudioJSON.Rating = *studio.Rating
 	}
 
+	if err := studio.LoadURLs(ctx, reader); err != nil {
+		return nil, fmt.Errorf("loading studio urls: %w", err)
+	}
+	newStudioJSON.URLs = studio.URLs.List()
+
 	if err := studio.LoadAliases(ctx, reader); err != nil {
 		return nil, fmt.Errorf("loading studio aliases: %w", err)
 	}
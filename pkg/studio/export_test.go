@@ -60,7 +60,7 @@ func createFullStudio(id int, parentID int) models.Studio {
 	ret := models.Studio{
 		ID:            id,
 		Name:          studioName,
-		URL:           url,
+		URLs:          models.NewRelatedStrings([]string{url}),
 		Details:       details,
 		Favorite:      true,
 		CreatedAt:     createTime,
@@ -85,6 +85,7 @@ func createEmptyStudio(id int) models.Studio {
 		CreatedAt: createTime,
 		UpdatedAt: updateTime,
 		Aliases:   models.NewRelatedStrings([]string{}),
+		URLs:      models.NewRelatedStrings([]string{}),
 		TagIDs:    models.NewRelatedIDs([]int{}),
 		StashIDs:  models.NewRelatedStashIDs([]models.StashID{}),
 	}
@@ -93,7 +94,7 @@ func createEmptyStudio(id int) models.Studio {
 func createFullJSONStudio(parentStudio, image string, aliases []string) *jsonschema.Studio {
 	return &jsonschema.Studio{
 		Name:     studioName,
-		URL:      url,
+		URLs:     []string{url},
 		Details:  details,
 		Favorite: true,
 		CreatedAt: json.JSONTime{
@@ -119,6 +120,7 @@ func createEmptyJSONStudio() *jsonschema.Studio {
 		UpdatedAt: json.JSONTime{
 			Time: updateTime,
 		},
+		URLs:     []string{},
 		Aliases:  []string{},
 		StashIDs: []models.StashID{},
 	}
@@ -216,7 +216,6 @@ func (i *Importer) Update(ctx context.Context, id int) error {
 func studioJSONtoStudio(studioJSON jsonschema.Studio) models.Studio {
 	newStudio := models.Studio{
 		Name:          studioJSON.Name,
-		URL:           studioJSON.URL,
 		Aliases:       models.NewRelatedStrings(studioJSON.Aliases),
 		Details:       studioJSON.Details,
 		Favorite:      studioJSON.Favorite,
@@ -228,6 +227,12 @@ func studioJSONtoStudio(studioJSON jsonschema.Studio) models.Studio {
 		StashIDs: models.NewRelatedStashIDs(studioJSON.StashIDs),
 	}
 
+	if len(studioJSON.URLs) > 0 {
+		newStudio.URLs = models.NewRelatedStrings(studioJSON.URLs)
+	} else if studioJSON.URL != "" {
+		newStudio.URLs = models.NewRelatedStrings([]string{studioJSON.URL})
+	}
+
 	if studioJSON.Rating != 0 {
 		newStudio.Rating = &studioJSON.Rating
 	}
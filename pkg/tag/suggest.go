@@ -0,0 +1,106 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// DefaultSuggestionCacheTTL is the default duration that tag suggestions are
+// cached for.
+const DefaultSuggestionCacheTTL = 5 * time.Minute
+
+type CoOccurrenceFinder interface {
+	FindCoOccurring(ctx context.Context, tagIDs []int, limit int) ([]*models.TagCoOccurrence, error)
+}
+
+type suggestionCacheEntry struct {
+	suggestions []*models.TagCoOccurrence
+	expires     time.Time
+}
+
+// Suggester suggests additional tags for a set of tags, based on how often
+// tags are applied to the same scenes across the library. Results are cached
+// since the statistics are expensive to compute for large libraries.
+type Suggester struct {
+	TTL time.Duration
+
+	mutex sync.Mutex
+	cache map[string]suggestionCacheEntry
+}
+
+func NewSuggester() *Suggester {
+	return &Suggester{
+		TTL:   DefaultSuggestionCacheTTL,
+		cache: make(map[string]suggestionCacheEntry),
+	}
+}
+
+func suggestionCacheKey(tagIDs []int, limit int) string {
+	sorted := make([]int, len(tagIDs))
+	copy(sorted, tagIDs)
+	sort.Ints(sorted)
+
+	var sb strings.Builder
+	for _, id := range sorted {
+		sb.WriteString(strconv.Itoa(id))
+		sb.WriteString(",")
+	}
+	sb.WriteString(fmt.Sprintf("limit=%d", limit))
+	return sb.String()
+}
+
+// Suggest returns the tags that most frequently co-occur with the provided
+// tags, ordered by frequency.
+func (s *Suggester) Suggest(ctx context.Context, qb CoOccurrenceFinder, tagIDs []int, limit int) ([]*models.TagCoOccurrence, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	key := suggestionCacheKey(tagIDs, limit)
+	now := time.Now()
+
+	s.mutex.Lock()
+	entry, found := s.cache[key]
+	s.mutex.Unlock()
+
+	if found && now.Before(entry.expires) {
+		return entry.suggestions, nil
+	}
+
+	suggestions, err := qb.FindCoOccurring(ctx, tagIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// remove expired entries so that the cache does not grow indefinitely
+	for k, v := range s.cache {
+		if now.After(v.expires) {
+			delete(s.cache, k)
+		}
+	}
+
+	s.cache[key] = suggestionCacheEntry{
+		suggestions: suggestions,
+		expires:     now.Add(s.TTL),
+	}
+
+	return suggestions, nil
+}
+
+// Invalidate clears all cached suggestions.
+func (s *Suggester) Invalidate() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.cache = make(map[string]suggestionCacheEntry)
+}
@@ -0,0 +1,40 @@
+package tag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingFinder struct {
+	calls int
+}
+
+func (f *countingFinder) FindCoOccurring(ctx context.Context, tagIDs []int, limit int) ([]*models.TagCoOccurrence, error) {
+	f.calls++
+	return []*models.TagCoOccurrence{{TagID: 3, Count: 2}}, nil
+}
+
+func TestSuggesterCache(t *testing.T) {
+	ctx := context.Background()
+	finder := &countingFinder{}
+	s := NewSuggester()
+
+	got, err := s.Suggest(ctx, finder, []int{1, 2}, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.TagCoOccurrence{{TagID: 3, Count: 2}}, got)
+
+	// same tags in a different order should hit the cache
+	_, _ = s.Suggest(ctx, finder, []int{2, 1}, 10)
+	assert.Equal(t, 1, finder.calls)
+
+	// different limit is a different entry
+	_, _ = s.Suggest(ctx, finder, []int{1, 2}, 5)
+	assert.Equal(t, 2, finder.calls)
+
+	s.Invalidate()
+	_, _ = s.Suggest(ctx, finder, []int{1, 2}, 10)
+	assert.Equal(t, 3, finder.calls)
+}
@@ -8,6 +8,7 @@ type key int
 
 const (
 	hookManagerKey key = iota + 1
+	sharedTxnKey
 )
 
 type hookManager struct {
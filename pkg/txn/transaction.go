@@ -41,9 +41,10 @@ func WithTxn(ctx context.Context, m Manager, fn TxnFunc) error {
 
 // WithReadTxn executes fn in a transaction. If fn returns an error then
 // the transaction is rolled back. Otherwise it is committed.
-// Transaction is not exclusive and does not enforce read-only restrictions.
+// Transaction is not exclusive and is read-only: any attempt to modify the
+// database within fn fails. Use WithTxn for changes to the database.
 // Multiple threads can run transactions using this function concurrently,
-// but concurrent writes may result in locked database error.
+// and they do not block, or get blocked by, concurrent write transactions.
 func WithReadTxn(ctx context.Context, m Manager, fn TxnFunc) error {
 	const (
 		execComplete = true
@@ -52,7 +53,30 @@ func WithReadTxn(ctx context.Context, m Manager, fn TxnFunc) error {
 	return withTxn(ctx, m, fn, exclusive, execComplete)
 }
 
+// WithSharedTxn executes fn in an exclusive transaction that is shared by
+// any transactions started within fn. Nested calls to WithTxn and
+// WithReadTxn join the shared transaction instead of starting a new one,
+// so that all changes made by fn are committed or rolled back together.
+// Hooks added within fn are executed when the shared transaction completes.
+func WithSharedTxn(ctx context.Context, m Manager, fn TxnFunc) error {
+	return WithTxn(ctx, m, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, sharedTxnKey, true))
+	})
+}
+
+// InSharedTxn returns true if ctx is within a transaction started by
+// WithSharedTxn.
+func InSharedTxn(ctx context.Context) bool {
+	shared, _ := ctx.Value(sharedTxnKey).(bool)
+	return shared
+}
+
 func withTxn(ctx context.Context, m Manager, fn TxnFunc, exclusive bool, execCompleteOnLocked bool) error {
+	if InSharedTxn(ctx) {
+		// join the shared transaction
+		return fn(ctx)
+	}
+
 	// post-hooks should be executed with the outside context
 	txnCtx, err := begin(ctx, m, exclusive)
 	if err != nil {
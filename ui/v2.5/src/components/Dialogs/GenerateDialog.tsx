@@ -82,6 +82,8 @@ export const GenerateDialog: React.FC<ISceneGenerateDialog> = ({
             existing.previewOptions?.previewExcludeEnd,
           previewPreset:
             general.previewPreset ?? existing.previewOptions?.previewPreset,
+          previewWidth:
+            general.previewWidth ?? existing.previewOptions?.previewWidth,
         },
       }));
       setConfigRead(true);
@@ -64,6 +64,7 @@ export interface IParserInput {
   pageSize: number;
   findClicked: boolean;
   ignoreOrganized: boolean;
+  regex: boolean;
 }
 
 interface IParserRecipe {
@@ -99,6 +100,7 @@ export const ParserInput: React.FC<IParserInputProps> = (
   const [ignoreOrganized, setIgnoreOrganized] = useState<boolean>(
     props.input.ignoreOrganized
   );
+  const [regex, setRegex] = useState<boolean>(props.input.regex);
 
   function onFind() {
     props.onFind({
@@ -110,6 +112,7 @@ export const ParserInput: React.FC<IParserInputProps> = (
       pageSize: props.input.pageSize,
       findClicked: props.input.findClicked,
       ignoreOrganized,
+      regex,
     });
   }
 
@@ -118,6 +121,7 @@ export const ParserInput: React.FC<IParserInputProps> = (
     setIgnoreWords(recipe.ignoreWords.join(" "));
     setWhitespaceCharacters(recipe.whitespaceCharacters);
     setCapitalizeTitle(recipe.capitalizeTitle);
+    setRegex(false);
   }
 
   const validFields = [new ParserField("", "Wildcard")].concat(
@@ -168,10 +172,26 @@ export const ParserInput: React.FC<IParserInputProps> = (
         </InputGroup>
         <Form.Text className="text-muted row col-10 offset-2">
           {intl.formatMessage({
-            id: "config.tools.scene_filename_parser.escape_chars",
+            id: regex
+              ? "config.tools.scene_filename_parser.regex_desc"
+              : "config.tools.scene_filename_parser.escape_chars",
           })}
         </Form.Text>
       </Form.Group>
+      <Form.Group>
+        <Form.Check
+          inline
+          className="m-0"
+          id="pattern-regex"
+          checked={regex}
+          onChange={() => setRegex(!regex)}
+        />
+        <Form.Label htmlFor="pattern-regex">
+          {intl.formatMessage({
+            id: "config.tools.scene_filename_parser.regex",
+          })}
+        </Form.Label>
+      </Form.Group>
 
       <Form.Group className="row" controlId="ignored-words">
         <Form.Label className="col-2">
@@ -25,6 +25,7 @@ const initialParserInput = {
   pageSize: 20,
   findClicked: false,
   ignoreOrganized: true,
+  regex: false,
 };
 
 const initialShowFieldsState = new Map<string, boolean>([
@@ -128,6 +129,7 @@ export const SceneFilenameParser: React.FC = () => {
       whitespaceCharacters: parserInput.whitespaceCharacters,
       capitalizeTitle: parserInput.capitalizeTitle,
       ignoreOrganized: parserInput.ignoreOrganized,
+      regex: parserInput.regex,
     };
 
     queryParseSceneFilenames(parserFilter, parserInputData)
@@ -569,7 +569,8 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
       return (
         src.pathname.endsWith("/stream") ||
         src.pathname.endsWith("/stream.mpd") ||
-        src.pathname.endsWith("/stream.m3u8")
+        src.pathname.endsWith("/stream.m3u8") ||
+        src.pathname.endsWith("/stream_master.m3u8")
       );
     }
 
@@ -39,6 +39,8 @@ interface IScenePreviewProps {
   isPortrait: boolean;
   image?: string;
   video?: string;
+  // if set, the animated image is shown on hover instead of the video
+  animation?: string;
   soundActive: boolean;
   vttPath?: string;
   onScrubberClick?: (timestamp: number) => void;
@@ -47,12 +49,15 @@ interface IScenePreviewProps {
 export const ScenePreview: React.FC<IScenePreviewProps> = ({
   image,
   video,
+  animation,
   isPortrait,
   soundActive,
   vttPath,
   onScrubberClick,
 }) => {
   const videoEl = useRef<HTMLVideoElement>(null);
+  // only load the animated image once hovered
+  const [animationActive, setAnimationActive] = useState(false);
 
   useEffect(() => {
     const observer = new IntersectionObserver((entries) => {
@@ -73,23 +78,34 @@ export const ScenePreview: React.FC<IScenePreviewProps> = ({
   }, [soundActive]);
 
   return (
-    <div className={cx("scene-card-preview", { portrait: isPortrait })}>
+    <div
+      className={cx("scene-card-preview", { portrait: isPortrait })}
+      onMouseEnter={() => setAnimationActive(true)}
+    >
       <img
         className="scene-card-preview-image"
         loading="lazy"
         src={image}
         alt=""
       />
-      <video
-        disableRemotePlayback
-        playsInline
-        muted={!soundActive}
-        className="scene-card-preview-video"
-        loop
-        preload="none"
-        ref={videoEl}
-        src={video}
-      />
+      {animation ? (
+        <img
+          className="scene-card-preview-video"
+          src={animationActive ? animation : undefined}
+          alt=""
+        />
+      ) : (
+        <video
+          disableRemotePlayback
+          playsInline
+          muted={!soundActive}
+          className="scene-card-preview-video"
+          loop
+          preload="none"
+          ref={videoEl}
+          src={video}
+        />
+      )}
       <PreviewScrubber vttPath={vttPath} onClick={onScrubberClick} />
     </div>
   );
@@ -411,6 +427,11 @@ const SceneCardImage = PatchComponent(
         <ScenePreview
           image={props.scene.paths.screenshot ?? undefined}
           video={props.scene.paths.preview ?? undefined}
+          animation={
+            configuration?.interface?.cardPlayback === "animation"
+              ? props.scene.paths.webp ?? undefined
+              : undefined
+          }
           isPortrait={isPortrait()}
           soundActive={configuration?.interface?.soundOnPreview ?? false}
           vttPath={props.scene.paths.vtt ?? undefined}
@@ -138,15 +138,17 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
           truncate
         />
       </dl>
-      {props.ofMany && props.onSetPrimaryFile && !props.primary && (
+      {props.ofMany && props.onSetPrimaryFile && (
         <div>
-          <Button
-            className="edit-button"
-            disabled={props.loading}
-            onClick={props.onSetPrimaryFile}
-          >
-            <FormattedMessage id="actions.make_primary" />
-          </Button>
+          {!props.primary && (
+            <Button
+              className="edit-button"
+              disabled={props.loading}
+              onClick={props.onSetPrimaryFile}
+            >
+              <FormattedMessage id="actions.make_primary" />
+            </Button>
+          )}
           <Button
             className="edit-button"
             disabled={props.loading}
@@ -157,13 +159,15 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
           <Button className="edit-button" onClick={onSplit}>
             <FormattedMessage id="actions.split" />
           </Button>
-          <Button
-            variant="danger"
-            disabled={props.loading}
-            onClick={props.onDeleteFile}
-          >
-            <FormattedMessage id="actions.delete_file" />
-          </Button>
+          {!props.primary && (
+            <Button
+              variant="danger"
+              disabled={props.loading}
+              onClick={props.onDeleteFile}
+            >
+              <FormattedMessage id="actions.delete_file" />
+            </Button>
+          )}
         </div>
       )}
     </div>
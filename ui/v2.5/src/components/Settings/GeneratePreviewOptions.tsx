@@ -9,6 +9,7 @@ export type VideoPreviewSettingsInput = Pick<
   | "previewSegmentDuration"
   | "previewExcludeStart"
   | "previewExcludeEnd"
+  | "previewWidth"
 >;
 
 interface IVideoPreviewInput {
@@ -34,6 +35,7 @@ export const VideoPreviewInput: React.FC<IVideoPreviewInput> = ({
     previewSegmentDuration,
     previewExcludeStart,
     previewExcludeEnd,
+    previewWidth,
   } = value;
 
   return (
@@ -129,6 +131,32 @@ export const VideoPreviewInput: React.FC<IVideoPreviewInput> = ({
           })}
         </Form.Text>
       </Form.Group>
+
+      <Form.Group id="preview-width">
+        <h6>
+          {intl.formatMessage({
+            id: "dialogs.scene_gen.preview_width_head",
+          })}
+        </h6>
+        <Form.Control
+          className="text-input"
+          type="number"
+          value={previewWidth?.toString() ?? ""}
+          min={2}
+          onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+            set({
+              previewWidth: e.currentTarget.value
+                ? Number.parseInt(e.currentTarget.value, 10)
+                : undefined,
+            })
+          }
+        />
+        <Form.Text className="text-muted">
+          {intl.formatMessage({
+            id: "dialogs.scene_gen.preview_width_desc",
+          })}
+        </Form.Text>
+      </Form.Group>
     </div>
   );
 };
@@ -323,6 +323,22 @@ export const SettingsInterfacePanel: React.FC = () => {
           checked={iface.showStudioAsText ?? undefined}
           onChange={(v) => saveInterface({ showStudioAsText: v })}
         />
+        <SelectSetting
+          id="card-preview"
+          headingID="config.ui.scene_list.options.card_preview_type"
+          subHeadingID="config.ui.scene_list.options.card_preview_type_desc"
+          value={iface.cardPlayback ?? undefined}
+          onChange={(v) => saveInterface({ cardPlayback: v })}
+        >
+          <option value="video">
+            {intl.formatMessage({ id: "config.ui.preview_type.options.video" })}
+          </option>
+          <option value="animation">
+            {intl.formatMessage({
+              id: "config.ui.preview_type.options.animated",
+            })}
+          </option>
+        </SelectSetting>
       </SettingSection>
 
       <SettingSection headingID="config.ui.scene_player.heading">
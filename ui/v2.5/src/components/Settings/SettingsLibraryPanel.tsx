@@ -3,7 +3,12 @@ import { Icon } from "../Shared/Icon";
 import { LoadingIndicator } from "../Shared/LoadingIndicator";
 import { StashSetting } from "./StashConfiguration";
 import { SettingSection } from "./SettingSection";
-import { BooleanSetting, StringListSetting, StringSetting } from "./Inputs";
+import {
+  BooleanSetting,
+  NumberSetting,
+  StringListSetting,
+  StringSetting,
+} from "./Inputs";
 import { useSettings } from "./context";
 import { useIntl } from "react-intl";
 import { faQuestionCircle } from "@fortawesome/free-solid-svg-icons";
@@ -36,6 +41,23 @@ export const SettingsLibraryPanel: React.FC = () => {
         onChange={(v) => saveGeneral({ stashes: v })}
       />
 
+      <SettingSection headingID="config.library.watch_library">
+        <BooleanSetting
+          id="watch-library"
+          headingID="config.library.watch_library_head"
+          subHeadingID="config.library.watch_library_desc"
+          checked={general.watchLibrary ?? false}
+          onChange={(v) => saveGeneral({ watchLibrary: v })}
+        />
+        <NumberSetting
+          id="watch-library-debounce"
+          headingID="config.library.watch_library_debounce_head"
+          subHeadingID="config.library.watch_library_debounce_desc"
+          value={general.watchLibraryDebounce ?? undefined}
+          onChange={(v) => saveGeneral({ watchLibraryDebounce: v })}
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.library.media_content_extensions">
         <StringSetting
           id="video-extensions"
@@ -123,6 +145,14 @@ export const SettingsLibraryPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ writeImageThumbnails: v })}
         />
 
+        <NumberSetting
+          id="image-thumbnail-size"
+          headingID="config.ui.images.options.image_thumbnail_size.heading"
+          subHeadingID="config.ui.images.options.image_thumbnail_size.description"
+          value={general.imageThumbnailSize ?? undefined}
+          onChange={(v) => saveGeneral({ imageThumbnailSize: v })}
+        />
+
         <BooleanSetting
           id="create-image-clips-from-videos"
           headingID="config.ui.images.options.create_image_clips_from_videos.heading"
@@ -140,6 +170,16 @@ export const SettingsLibraryPanel: React.FC = () => {
         />
       </SettingSection>
 
+      <SettingSection headingID="config.tasks.auto_tagging">
+        <BooleanSetting
+          id="auto-tag-performer-aliases"
+          headingID="config.library.auto_tag_performer_aliases_head"
+          subHeadingID="config.library.auto_tag_performer_aliases_desc"
+          checked={general.autoTagPerformerAliases ?? false}
+          onChange={(v) => saveGeneral({ autoTagPerformerAliases: v })}
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.ui.delete_options.heading">
         <BooleanSetting
           id="delete-file-default"
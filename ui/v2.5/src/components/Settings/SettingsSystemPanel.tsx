@@ -222,6 +222,46 @@ export const SettingsConfigurationPanel: React.FC = () => {
           value={general.backupDirectoryPath ?? undefined}
           onChange={(v) => saveGeneral({ backupDirectoryPath: v })}
         />
+
+        <NumberSetting
+          id="backup-retention"
+          headingID="config.general.backup_retention.heading"
+          subHeadingID="config.general.backup_retention.description"
+          value={general.backupRetention ?? undefined}
+          onChange={(v) => saveGeneral({ backupRetention: v })}
+        />
+
+        <StringSetting
+          id="trash-path"
+          headingID="config.general.trash_path.heading"
+          subHeadingID="config.general.trash_path.description"
+          value={general.trashPath ?? undefined}
+          onChange={(v) => saveGeneral({ trashPath: v })}
+        />
+
+        <NumberSetting
+          id="trash-retention"
+          headingID="config.general.trash_retention.heading"
+          subHeadingID="config.general.trash_retention.description"
+          value={general.trashRetention ?? undefined}
+          onChange={(v) => saveGeneral({ trashRetention: v })}
+        />
+
+        <NumberSetting
+          id="deleted-object-retention"
+          headingID="config.general.deleted_object_retention.heading"
+          subHeadingID="config.general.deleted_object_retention.description"
+          value={general.deletedObjectRetention ?? undefined}
+          onChange={(v) => saveGeneral({ deletedObjectRetention: v })}
+        />
+
+        <BooleanSetting
+          id="audit-log"
+          headingID="config.general.audit_log.heading"
+          subHeadingID="config.general.audit_log.description"
+          checked={general.auditLog ?? false}
+          onChange={(v) => saveGeneral({ auditLog: v })}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.general.database">
@@ -302,6 +342,32 @@ export const SettingsConfigurationPanel: React.FC = () => {
           ))}
         </SelectSetting>
 
+        <SelectSetting
+          advanced
+          id="generated-transcode-codec"
+          headingID="config.general.ffmpeg.generate.transcode.codec.heading"
+          subHeadingID="config.general.ffmpeg.generate.transcode.codec.desc"
+          value={general.generatedTranscodeCodec ?? GQL.TranscodeCodec.H264}
+          onChange={(v) =>
+            saveGeneral({ generatedTranscodeCodec: v as GQL.TranscodeCodec })
+          }
+        >
+          {Object.values(GQL.TranscodeCodec).map((c) => (
+            <option key={c} value={c}>
+              {c}
+            </option>
+          ))}
+        </SelectSetting>
+
+        <NumberSetting
+          advanced
+          id="generated-transcode-crf"
+          headingID="config.general.ffmpeg.generate.transcode.crf.heading"
+          subHeadingID="config.general.ffmpeg.generate.transcode.crf.desc"
+          value={general.generatedTranscodeCrf ?? 0}
+          onChange={(v) => saveGeneral({ generatedTranscodeCrf: v })}
+        />
+
         <SelectSetting
           id="streaming-transcode-size"
           headingID="config.general.maximum_streaming_transcode_size_head"
@@ -320,6 +386,58 @@ export const SettingsConfigurationPanel: React.FC = () => {
           ))}
         </SelectSetting>
 
+        <SelectSetting
+          advanced
+          id="live-transcode-preset"
+          headingID="config.general.ffmpeg.live_transcode.preset.heading"
+          subHeadingID="config.general.ffmpeg.live_transcode.preset.desc"
+          value={general.liveTranscodePreset ?? undefined}
+          onChange={(v) =>
+            saveGeneral({
+              liveTranscodePreset: (v as GQL.PreviewPreset) ?? undefined,
+            })
+          }
+        >
+          {Object.keys(GQL.PreviewPreset).map((p) => (
+            <option value={p.toLowerCase()} key={p}>
+              {p}
+            </option>
+          ))}
+        </SelectSetting>
+
+        <NumberSetting
+          advanced
+          id="live-transcode-crf"
+          headingID="config.general.ffmpeg.live_transcode.crf.heading"
+          subHeadingID="config.general.ffmpeg.live_transcode.crf.desc"
+          value={general.liveTranscodeCrf ?? 0}
+          onChange={(v) => saveGeneral({ liveTranscodeCrf: v })}
+        />
+
+        <NumberSetting
+          id="live-transcode-max-bitrate"
+          headingID="config.general.ffmpeg.live_transcode.max_bitrate.heading"
+          subHeadingID="config.general.ffmpeg.live_transcode.max_bitrate.desc"
+          value={general.liveTranscodeMaxBitrate ?? 0}
+          onChange={(v) => saveGeneral({ liveTranscodeMaxBitrate: v })}
+        />
+
+        <NumberSetting
+          id="live-transcode-cache-size"
+          headingID="config.general.ffmpeg.live_transcode.cache_size.heading"
+          subHeadingID="config.general.ffmpeg.live_transcode.cache_size.desc"
+          value={general.liveTranscodeCacheSize ?? 0}
+          onChange={(v) => saveGeneral({ liveTranscodeCacheSize: v })}
+        />
+
+        <BooleanSetting
+          id="tone-mapping"
+          headingID="config.general.ffmpeg.tone_mapping.heading"
+          subHeadingID="config.general.ffmpeg.tone_mapping.desc"
+          checked={general.transcodeToneMapping ?? false}
+          onChange={(v) => saveGeneral({ transcodeToneMapping: v })}
+        />
+
         <BooleanSetting
           id="hardware-encoding"
           headingID="config.general.ffmpeg.hardware_acceleration.heading"
@@ -328,6 +446,26 @@ export const SettingsConfigurationPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ transcodeHardwareAcceleration: v })}
         />
 
+        <SelectSetting
+          id="hardware-encoder"
+          headingID="config.general.ffmpeg.hardware_encoder.heading"
+          subHeadingID="config.general.ffmpeg.hardware_encoder.desc"
+          value={general.transcodeHardwareEncoder ?? GQL.HardwareEncoder.Auto}
+          onChange={(v) =>
+            saveGeneral({ transcodeHardwareEncoder: v as GQL.HardwareEncoder })
+          }
+        >
+          {Object.values(GQL.HardwareEncoder).map((e) => (
+            <option key={e} value={e}>
+              {e === GQL.HardwareEncoder.Auto
+                ? intl.formatMessage({
+                    id: "config.general.ffmpeg.hardware_encoder.auto",
+                  })
+                : e}
+            </option>
+          ))}
+        </SelectSetting>
+
         <StringListSetting
           advanced
           id="transcode-input-args"
@@ -344,6 +482,54 @@ export const SettingsConfigurationPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ transcodeOutputArgs: v })}
           value={general.transcodeOutputArgs ?? []}
         />
+        <StringListSetting
+          advanced
+          id="generate-preview-input-args"
+          headingID="config.general.ffmpeg.generate.preview.input_args.heading"
+          subHeadingID="config.general.ffmpeg.generate.preview.input_args.desc"
+          onChange={(v) => saveGeneral({ previewInputArgs: v })}
+          value={general.previewInputArgs ?? []}
+        />
+        <StringListSetting
+          advanced
+          id="generate-preview-output-args"
+          headingID="config.general.ffmpeg.generate.preview.output_args.heading"
+          subHeadingID="config.general.ffmpeg.generate.preview.output_args.desc"
+          onChange={(v) => saveGeneral({ previewOutputArgs: v })}
+          value={general.previewOutputArgs ?? []}
+        />
+        <StringListSetting
+          advanced
+          id="generate-screenshot-input-args"
+          headingID="config.general.ffmpeg.generate.screenshot.input_args.heading"
+          subHeadingID="config.general.ffmpeg.generate.screenshot.input_args.desc"
+          onChange={(v) => saveGeneral({ screenshotInputArgs: v })}
+          value={general.screenshotInputArgs ?? []}
+        />
+        <StringListSetting
+          advanced
+          id="generate-screenshot-output-args"
+          headingID="config.general.ffmpeg.generate.screenshot.output_args.heading"
+          subHeadingID="config.general.ffmpeg.generate.screenshot.output_args.desc"
+          onChange={(v) => saveGeneral({ screenshotOutputArgs: v })}
+          value={general.screenshotOutputArgs ?? []}
+        />
+        <StringListSetting
+          advanced
+          id="generate-transcode-input-args"
+          headingID="config.general.ffmpeg.generate.transcode.input_args.heading"
+          subHeadingID="config.general.ffmpeg.generate.transcode.input_args.desc"
+          onChange={(v) => saveGeneral({ generatedTranscodeInputArgs: v })}
+          value={general.generatedTranscodeInputArgs ?? []}
+        />
+        <StringListSetting
+          advanced
+          id="generate-transcode-output-args"
+          headingID="config.general.ffmpeg.generate.transcode.output_args.heading"
+          subHeadingID="config.general.ffmpeg.generate.transcode.output_args.desc"
+          onChange={(v) => saveGeneral({ generatedTranscodeOutputArgs: v })}
+          value={general.generatedTranscodeOutputArgs ?? []}
+        />
 
         <StringListSetting
           advanced
@@ -361,6 +547,22 @@ export const SettingsConfigurationPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ liveTranscodeOutputArgs: v })}
           value={general.liveTranscodeOutputArgs ?? []}
         />
+        <StringListSetting
+          advanced
+          id="live-transcode-h264-encoders"
+          headingID="config.general.ffmpeg.live_transcode.h264_encoders.heading"
+          subHeadingID="config.general.ffmpeg.live_transcode.h264_encoders.desc"
+          onChange={(v) => saveGeneral({ liveTranscodeH264Encoders: v })}
+          value={general.liveTranscodeH264Encoders ?? []}
+        />
+        <StringListSetting
+          advanced
+          id="live-transcode-vp9-encoders"
+          headingID="config.general.ffmpeg.live_transcode.vp9_encoders.heading"
+          subHeadingID="config.general.ffmpeg.live_transcode.vp9_encoders.desc"
+          onChange={(v) => saveGeneral({ liveTranscodeVp9Encoders: v })}
+          value={general.liveTranscodeVp9Encoders ?? []}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.general.parallel_scan_head">
@@ -371,6 +573,41 @@ export const SettingsConfigurationPanel: React.FC = () => {
           value={general.parallelTasks ?? undefined}
           onChange={(v) => saveGeneral({ parallelTasks: v })}
         />
+        <NumberSetting
+          id="scan-parallel-tasks"
+          headingID="config.general.number_of_parallel_files_for_scan_head"
+          subHeadingID="config.general.number_of_parallel_files_for_scan_desc"
+          value={general.scanParallelTasks ?? undefined}
+          onChange={(v) => saveGeneral({ scanParallelTasks: v })}
+        />
+        <NumberSetting
+          id="ffmpeg-generate-max-processes"
+          headingID="config.general.ffmpeg.generate.max_processes.heading"
+          subHeadingID="config.general.ffmpeg.generate.max_processes.desc"
+          value={general.ffmpegGenerateMaxProcesses ?? undefined}
+          onChange={(v) => saveGeneral({ ffmpegGenerateMaxProcesses: v })}
+        />
+        <NumberSetting
+          id="ffmpeg-max-processes"
+          headingID="config.general.ffmpeg.max_processes.heading"
+          subHeadingID="config.general.ffmpeg.max_processes.desc"
+          value={general.ffmpegMaxProcesses ?? undefined}
+          onChange={(v) => saveGeneral({ ffmpegMaxProcesses: v })}
+        />
+        <NumberSetting
+          id="ffmpeg-generate-threads"
+          headingID="config.general.ffmpeg.generate.threads.heading"
+          subHeadingID="config.general.ffmpeg.generate.threads.desc"
+          value={general.ffmpegGenerateThreads ?? undefined}
+          onChange={(v) => saveGeneral({ ffmpegGenerateThreads: v })}
+        />
+        <NumberSetting
+          id="ffmpeg-generate-niceness"
+          headingID="config.general.ffmpeg.generate.niceness.heading"
+          subHeadingID="config.general.ffmpeg.generate.niceness.desc"
+          value={general.ffmpegGenerateNiceness ?? undefined}
+          onChange={(v) => saveGeneral({ ffmpegGenerateNiceness: v })}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.general.preview_generation">
@@ -408,6 +645,7 @@ export const SettingsConfigurationPanel: React.FC = () => {
             previewExcludeStart: general.previewExcludeStart,
             previewSegmentDuration: general.previewSegmentDuration,
             previewSegments: general.previewSegments,
+            previewWidth: general.previewWidth,
           }}
           onChange={(v) => saveGeneral(v)}
           renderField={(value, setValue) => (
@@ -445,6 +683,30 @@ export const SettingsConfigurationPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ logOut: v })}
         />
 
+        <NumberSetting
+          id="log-max-size"
+          headingID="config.general.auth.log_max_size"
+          subHeadingID="config.general.auth.log_max_size_desc"
+          value={general.logMaxSize ?? undefined}
+          onChange={(v) => saveGeneral({ logMaxSize: v })}
+        />
+
+        <NumberSetting
+          id="log-max-age"
+          headingID="config.general.auth.log_max_age"
+          subHeadingID="config.general.auth.log_max_age_desc"
+          value={general.logMaxAge ?? undefined}
+          onChange={(v) => saveGeneral({ logMaxAge: v })}
+        />
+
+        <NumberSetting
+          id="log-max-backups"
+          headingID="config.general.auth.log_max_backups"
+          subHeadingID="config.general.auth.log_max_backups_desc"
+          value={general.logMaxBackups ?? undefined}
+          onChange={(v) => saveGeneral({ logMaxBackups: v })}
+        />
+
         <SelectSetting
           id="log-level"
           headingID="config.logs.log_level"
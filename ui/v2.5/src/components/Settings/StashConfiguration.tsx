@@ -5,7 +5,8 @@ import { FormattedMessage } from "react-intl";
 import { Icon } from "src/components/Shared/Icon";
 import * as GQL from "src/core/generated-graphql";
 import { FolderSelectDialog } from "../Shared/FolderSelect/FolderSelectDialog";
-import { BooleanSetting } from "./Inputs";
+import { StringListInput } from "../Shared/StringListInput";
+import { BooleanSetting, SettingModal } from "./Inputs";
 import { SettingSection } from "./SettingSection";
 
 interface IStashProps {
@@ -32,10 +33,41 @@ const Stash: React.FC<IStashProps> = ({
     onSave(newObj);
   };
 
+  const [editingExcludes, setEditingExcludes] = useState<
+    "excludes" | "imageExcludes" | undefined
+  >();
+
   const classAdd = index % 2 === 1 ? "bg-dark" : "";
 
+  function maybeRenderExcludesModal() {
+    if (!editingExcludes) return;
+
+    const headingID =
+      editingExcludes === "excludes"
+        ? "config.general.excluded_video_patterns_head"
+        : "config.general.excluded_image_gallery_patterns_head";
+
+    return (
+      <SettingModal<string[]>
+        headingID={headingID}
+        subHeadingID="config.general.stash_excluded_patterns_desc"
+        value={stash[editingExcludes] ?? []}
+        renderField={(value, setValue) => (
+          <StringListInput value={value ?? []} setValue={setValue} />
+        )}
+        close={(v) => {
+          if (v !== undefined) {
+            handleInput(editingExcludes, v);
+          }
+          setEditingExcludes(undefined);
+        }}
+      />
+    );
+  }
+
   return (
     <Row className={`stash-row align-items-center ${classAdd}`}>
+      {maybeRenderExcludesModal()}
       <Form.Label column md={7}>
         {stash.path}
       </Form.Label>
@@ -79,6 +111,12 @@ const Stash: React.FC<IStashProps> = ({
             <Dropdown.Item onClick={() => onEdit()}>
               <FormattedMessage id="actions.edit" />
             </Dropdown.Item>
+            <Dropdown.Item onClick={() => setEditingExcludes("excludes")}>
+              <FormattedMessage id="config.general.excluded_video_patterns_head" />
+            </Dropdown.Item>
+            <Dropdown.Item onClick={() => setEditingExcludes("imageExcludes")}>
+              <FormattedMessage id="config.general.excluded_image_gallery_patterns_head" />
+            </Dropdown.Item>
             <Dropdown.Item onClick={() => onDelete()}>
               <FormattedMessage id="actions.delete" />
             </Dropdown.Item>
@@ -11,6 +11,8 @@ import {
   mutateMigrateSceneScreenshots,
   mutateMigrateBlobs,
   mutateOptimiseDatabase,
+  mutateEmptyTrash,
+  mutatePurgeDeletedObjects,
   mutateCleanGenerated,
 } from "src/core/StashService";
 import { useToast } from "src/hooks/Toast";
@@ -381,6 +383,42 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
+  async function onEmptyTrash() {
+    try {
+      await mutateEmptyTrash({ all: true });
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "actions.empty_trash",
+            }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function onPurgeDeletedObjects() {
+    try {
+      await mutatePurgeDeletedObjects({ all: true });
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "actions.purge_deleted_objects",
+            }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   async function onAnonymise(download?: boolean) {
     try {
       setIsAnonymiseRunning(true);
@@ -507,6 +545,32 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
             <FormattedMessage id="actions.optimise_database" />
           </Button>
         </Setting>
+
+        <Setting
+          headingID="actions.empty_trash"
+          subHeadingID="config.tasks.empty_trash_desc"
+        >
+          <Button
+            id="emptyTrash"
+            variant="danger"
+            onClick={() => onEmptyTrash()}
+          >
+            <FormattedMessage id="actions.empty_trash" />
+          </Button>
+        </Setting>
+
+        <Setting
+          headingID="actions.purge_deleted_objects"
+          subHeadingID="config.tasks.purge_deleted_objects_desc"
+        >
+          <Button
+            id="purgeDeletedObjects"
+            variant="danger"
+            onClick={() => onPurgeDeletedObjects()}
+          >
+            <FormattedMessage id="actions.purge_deleted_objects" />
+          </Button>
+        </Setting>
       </SettingSection>
 
       <SettingSection headingID="metadata">
@@ -70,6 +70,7 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
                 previewExcludeStart: previewOptions.previewExcludeStart,
                 previewSegmentDuration: previewOptions.previewSegmentDuration,
                 previewSegments: previewOptions.previewSegments,
+                previewWidth: previewOptions.previewWidth,
               }}
               onChange={(v) => setOptions({ previewOptions: v })}
               renderField={(value, setValue) => (
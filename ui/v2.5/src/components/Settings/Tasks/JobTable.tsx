@@ -1,9 +1,13 @@
 import React, { useState, useEffect } from "react";
 import { Button, Card, ProgressBar } from "react-bootstrap";
 import {
+  mutatePauseJob,
+  mutatePrioritiseJob,
+  mutateResumeJob,
   mutateStopJob,
   useJobQueue,
   useJobsSubscribe,
+  usePausedJobs,
 } from "src/core/StashService";
 import * as GQL from "src/core/generated-graphql";
 import { Icon } from "src/components/Shared/Icon";
@@ -14,20 +18,33 @@ import {
   faCircle,
   faCircleExclamation,
   faCog,
+  faAngleDoubleUp,
   faHourglassStart,
+  faPause,
+  faPlay,
   faTimes,
 } from "@fortawesome/free-solid-svg-icons";
 
 type JobFragment = Pick<
   GQL.Job,
-  "id" | "status" | "subTasks" | "description" | "progress" | "error"
+  | "id"
+  | "status"
+  | "subTasks"
+  | "subTaskProgress"
+  | "description"
+  | "progress"
+  | "error"
+  | "pausable"
 >;
 
 interface IJob {
   job: JobFragment;
+  onPrioritise: () => void;
+  onPause: () => void;
 }
 
-const Task: React.FC<IJob> = ({ job }) => {
+const Task: React.FC<IJob> = ({ job, onPrioritise, onPause }) => {
+  const intl = useIntl();
   const [stopping, setStopping] = useState(false);
   const [className, setClassName] = useState("");
 
@@ -132,11 +149,17 @@ const Task: React.FC<IJob> = ({ job }) => {
       return (
         <div>
           {/* eslint-disable react/no-array-index-key */}
-          {(job.subTasks ?? []).map((t, i) => (
-            <div className="job-subtask" key={i}>
-              {t}
-            </div>
-          ))}
+          {(job.subTasks ?? []).map((t, i) => {
+            const subTaskProgress = job.subTaskProgress?.[i];
+            return (
+              <div className="job-subtask" key={i}>
+                {t}
+                {subTaskProgress !== undefined && subTaskProgress !== null
+                  ? ` (${(subTaskProgress * 100).toFixed(0)}%)`
+                  : undefined}
+              </div>
+            );
+          })}
           {/* eslint-enable react/no-array-index-key */}
         </div>
       );
@@ -158,6 +181,29 @@ const Task: React.FC<IJob> = ({ job }) => {
         >
           <Icon icon={faTimes} />
         </Button>
+        {job.pausable && canStop() ? (
+          <Button
+            className="minimal pause"
+            size="sm"
+            title={intl.formatMessage({ id: "actions.pause" })}
+            onClick={() => {
+              setStopping(true);
+              onPause();
+            }}
+          >
+            <Icon icon={faPause} />
+          </Button>
+        ) : undefined}
+        {job.status === GQL.JobStatus.Ready ? (
+          <Button
+            className="minimal prioritise"
+            size="sm"
+            title={intl.formatMessage({ id: "actions.prioritise" })}
+            onClick={() => onPrioritise()}
+          >
+            <Icon icon={faAngleDoubleUp} />
+          </Button>
+        ) : undefined}
         <div className={`job-status ${getStatusClass()}`}>
           <div>
             {getStatusIcon()}
@@ -171,10 +217,51 @@ const Task: React.FC<IJob> = ({ job }) => {
   );
 };
 
+interface IPausedJob {
+  job: GQL.PausedJob;
+  onResume: () => void;
+}
+
+const PausedTask: React.FC<IPausedJob> = ({ job, onResume }) => {
+  const intl = useIntl();
+  const [resuming, setResuming] = useState(false);
+
+  return (
+    <li className="job fade-in">
+      <div>
+        <Button
+          className="minimal resume"
+          size="sm"
+          title={intl.formatMessage({ id: "actions.resume" })}
+          disabled={resuming}
+          onClick={() => {
+            setResuming(true);
+            onResume();
+          }}
+        >
+          <Icon icon={faPlay} />
+        </Button>
+        <div className="job-status paused">
+          <div>
+            <Icon icon={faPause} className="fa-fw" />
+            <span>{job.description}</span>
+          </div>
+          <div className="text-muted">
+            <small>
+              {intl.formatMessage({ id: "config.tasks.paused_job_restarts" })}
+            </small>
+          </div>
+        </div>
+      </div>
+    </li>
+  );
+};
+
 export const JobTable: React.FC = () => {
   const intl = useIntl();
   const jobStatus = useJobQueue();
   const jobsSubscribe = useJobsSubscribe();
+  const pausedJobs = usePausedJobs();
 
   const [queue, setQueue] = useState<JobFragment[]>([]);
 
@@ -219,16 +306,58 @@ export const JobTable: React.FC = () => {
     }
   }, [jobsSubscribe.data]);
 
+  async function prioritiseJob(job: JobFragment) {
+    const result = await mutatePrioritiseJob(job.id);
+    if (!result.data?.prioritiseJob) {
+      return;
+    }
+
+    // move the job ahead of the other jobs that have not started
+    setQueue((q) => {
+      const others = q.filter((j) => j.id !== job.id);
+      let index = others.findIndex((j) => j.status === GQL.JobStatus.Ready);
+      if (index === -1) {
+        index = others.length;
+      }
+
+      return [...others.slice(0, index), job, ...others.slice(index)];
+    });
+  }
+
+  async function pauseJob(job: JobFragment) {
+    await mutatePauseJob(job.id);
+    pausedJobs.refetch();
+  }
+
+  async function resumeJob(job: GQL.PausedJob) {
+    await mutateResumeJob(job.id);
+    pausedJobs.refetch();
+  }
+
+  const paused = pausedJobs.data?.pausedJobs ?? [];
+
   return (
     <Card className="job-table">
       <ul>
-        {!queue?.length ? (
+        {!queue?.length && !paused.length ? (
           <span className="empty-queue-message">
             {intl.formatMessage({ id: "config.tasks.empty_queue" })}
           </span>
         ) : undefined}
         {(queue ?? []).map((j) => (
-          <Task job={j} key={j.id} />
+          <Task
+            job={j}
+            key={j.id}
+            onPrioritise={() => prioritiseJob(j)}
+            onPause={() => pauseJob(j)}
+          />
+        ))}
+        {paused.map((j) => (
+          <PausedTask
+            job={j}
+            key={`paused-${j.id}`}
+            onResume={() => resumeJob(j)}
+          />
         ))}
       </ul>
     </Card>
@@ -5,6 +5,8 @@ import {
   mutateMetadataScan,
   mutateMetadataAutoTag,
   mutateMetadataGenerate,
+  mutateMetadataImportNFO,
+  mutateMetadataExportNFO,
 } from "src/core/StashService";
 import { withoutTypename } from "src/utils/data";
 import { ConfigurationContext } from "src/hooks/Config";
@@ -180,6 +182,8 @@ export const LibraryTasks: React.FC = () => {
               existing.previewOptions?.previewExcludeEnd,
             previewPreset:
               general.previewPreset ?? existing.previewOptions?.previewPreset,
+            previewWidth:
+              general.previewWidth ?? existing.previewOptions?.previewWidth,
           },
         }));
       }
@@ -281,6 +285,36 @@ export const LibraryTasks: React.FC = () => {
     }
   }
 
+  async function runImportNFO() {
+    try {
+      await mutateMetadataImportNFO({});
+
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          { operation_name: intl.formatMessage({ id: "actions.import_nfo" }) }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function runExportNFO() {
+    try {
+      await mutateMetadataExportNFO({});
+
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          { operation_name: intl.formatMessage({ id: "actions.export_nfo" }) }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   function maybeRenderIdentifyDialog() {
     if (!dialogOpen.identify) return;
 
@@ -412,6 +446,47 @@ export const LibraryTasks: React.FC = () => {
         </SettingGroup>
       </SettingSection>
 
+      <SettingSection advanced>
+        <Setting
+          heading={
+            <>
+              <FormattedMessage id="actions.import_nfo" />
+              <ManualLink tab="Tasks">
+                <Icon icon={faQuestionCircle} />
+              </ManualLink>
+            </>
+          }
+          subHeadingID="config.tasks.import_nfo_desc"
+        >
+          <Button
+            variant="secondary"
+            type="submit"
+            onClick={() => runImportNFO()}
+          >
+            <FormattedMessage id="actions.import_nfo" />
+          </Button>
+        </Setting>
+        <Setting
+          heading={
+            <>
+              <FormattedMessage id="actions.export_nfo" />
+              <ManualLink tab="Tasks">
+                <Icon icon={faQuestionCircle} />
+              </ManualLink>
+            </>
+          }
+          subHeadingID="config.tasks.export_nfo_desc"
+        >
+          <Button
+            variant="secondary"
+            type="submit"
+            onClick={() => runExportNFO()}
+          >
+            <FormattedMessage id="actions.export_nfo" />
+          </Button>
+        </Setting>
+      </SettingSection>
+
       <SettingSection headingID="config.tasks.generated_content">
         <SettingGroup
           settingProps={{
@@ -5,6 +5,7 @@ import { useHistory } from "react-router-dom";
 import * as GQL from "src/core/generated-graphql";
 import {
   useSystemStatus,
+  useMigrationStatus,
   mutateMigrate,
   postMigrate,
   refetchSystemStatus,
@@ -20,6 +21,7 @@ export const Migrate: React.FC = () => {
   const history = useHistory();
 
   const { data: systemStatus, loading } = useSystemStatus();
+  const { data: migrationStatus } = useMigrationStatus();
 
   const [backupPath, setBackupPath] = useState<string | undefined>();
   const [migrateLoading, setMigrateLoading] = useState(false);
@@ -56,7 +58,7 @@ export const Migrate: React.FC = () => {
     .replace(/:/g, "")
     .replace(/\..*/, "");
   const defaultBackupPath = systemStatus
-    ? `${databasePath}.${systemStatus.systemStatus.databaseSchema}.${now}`
+    ? `${databasePath}.pre-migration.${systemStatus.systemStatus.databaseSchema}.${now}`
     : "";
 
   const discordLink = (
@@ -112,6 +114,32 @@ export const Migrate: React.FC = () => {
     );
   }, [status]);
 
+  const pendingMigrations = migrationStatus?.migrationStatus.pendingMigrations;
+
+  function maybeRenderPendingMigrations() {
+    if (!pendingMigrations?.length) return;
+
+    return (
+      <section>
+        <h4>
+          <FormattedMessage id="setup.migrate.pending_migrations" />
+        </h4>
+        <ul>
+          {pendingMigrations.map((m) => (
+            <li key={m.version}>
+              <strong>{m.version}</strong>: <code>{m.name}</code>
+              {!m.reversible && (
+                <span className="text-muted ml-2">
+                  (<FormattedMessage id="setup.migrate.irreversible" />)
+                </span>
+              )}
+            </li>
+          ))}
+        </ul>
+      </section>
+    );
+  }
+
   // only display setup wizard if system is not setup
   if (loading || !systemStatus || !status) {
     return <LoadingIndicator />;
@@ -235,6 +263,8 @@ export const Migrate: React.FC = () => {
           </p>
         </section>
 
+        {maybeRenderPendingMigrations()}
+
         {maybeMigrationNotes}
 
         <section>
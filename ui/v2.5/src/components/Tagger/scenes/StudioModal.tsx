@@ -84,6 +84,44 @@ const StudioDetails: React.FC<IStudioDetailsProps> = ({
     );
   }
 
+  function maybeRenderURLListField(
+    id: string,
+    text: string[] | null | undefined,
+    isSelectable: boolean = true
+  ) {
+    if (!text?.length) return;
+
+    return (
+      <div className="row no-gutters">
+        <div className="col-5 studio-create-modal-field" key={id}>
+          {isSelectable && (
+            <Button
+              onClick={() => toggleField(id)}
+              variant="secondary"
+              className={excluded[id] ? "text-muted" : "text-success"}
+            >
+              <Icon icon={excluded[id] ? faTimes : faCheck} />
+            </Button>
+          )}
+          <strong>
+            <FormattedMessage id={id} />:
+          </strong>
+        </div>
+        <div className="col-7">
+          <ul>
+            {text.map((t, i) => (
+              <li key={i}>
+                <ExternalLink href={t}>
+                  <TruncatedText text={t} />
+                </ExternalLink>
+              </li>
+            ))}
+          </ul>
+        </div>
+      </div>
+    );
+  }
+
   function maybeRenderStashBoxLink() {
     if (!link) return;
 
@@ -103,7 +141,7 @@ const StudioDetails: React.FC<IStudioDetailsProps> = ({
       <div className="row">
         <div className="col-12">
           {maybeRenderField("name", studio.name, !isNew)}
-          {maybeRenderField("url", studio.url)}
+          {maybeRenderURLListField("urls", studio.urls)}
           {maybeRenderField("parent_studio", studio.parent?.name, false)}
           {maybeRenderStashBoxLink()}
         </div>
@@ -191,7 +229,7 @@ const StudioModal: React.FC<IStudioModalProps> = ({
 
     const studioData: GQL.StudioCreateInput = {
       name: studio.name,
-      url: studio.url,
+      urls: studio.urls,
       image: studio.image,
       parent_id: studio.parent?.stored_id,
     };
@@ -219,7 +257,7 @@ const StudioModal: React.FC<IStudioModalProps> = ({
 
       parentData = {
         name: studio.parent?.name,
-        url: studio.parent?.url,
+        urls: studio.parent?.urls,
         image: studio.parent?.image,
       };
 
@@ -275,6 +275,9 @@ export const useFindGallery = (id: string) => {
   return GQL.useFindGalleryQuery({ variables: { id }, skip });
 };
 
+export const useBrowseFolder = (id?: string) =>
+  GQL.useBrowseFolderQuery({ variables: { id } });
+
 export const useFindGalleries = (filter?: ListFilterModel) =>
   GQL.useFindGalleriesQuery({
     skip: filter === undefined,
@@ -1817,6 +1820,31 @@ export const useStudiosDestroy = (input: GQL.StudiosDestroyMutationVariables) =>
     },
   });
 
+export const useStudioMerge = () =>
+  GQL.useStudioMergeMutation({
+    update(cache, result, { variables }) {
+      if (!result.data?.studioMerge || !variables) return;
+
+      const { source, destination } = variables;
+
+      for (const id of source) {
+        const obj = { __typename: "Studio", id };
+        deleteObject(cache, obj, GQL.FindStudioDocument);
+      }
+
+      updateStats(cache, "studio_count", -source.length);
+
+      const obj = { __typename: "Studio", id: destination };
+      evictTypeFields(
+        cache,
+        studioMutationImpactedTypeFields,
+        cache.identify(obj) // don't evict destination studio
+      );
+
+      evictQueries(cache, studioMutationImpactedQueries);
+    },
+  });
+
 const tagMutationImpactedTypeFields = {
   Tag: ["parents", "children"],
 };
@@ -2237,6 +2265,11 @@ export const useJobQueue = () =>
     fetchPolicy: "no-cache",
   });
 
+export const usePausedJobs = () =>
+  GQL.usePausedJobsQuery({
+    fetchPolicy: "no-cache",
+  });
+
 export const useLogs = () =>
   GQL.useLogsQuery({
     fetchPolicy: "no-cache",
@@ -2249,6 +2282,7 @@ export const queryLogs = () =>
   });
 
 export const useSystemStatus = () => GQL.useSystemStatusQuery();
+export const useMigrationStatus = () => GQL.useMigrationStatusQuery();
 export const refetchSystemStatus = () => {
   client.refetchQueries({
     include: [GQL.SystemStatusDocument],
@@ -2413,6 +2447,24 @@ export const mutateStopJob = (jobID: string) =>
     variables: { job_id: jobID },
   });
 
+export const mutatePrioritiseJob = (jobID: string) =>
+  client.mutate<GQL.PrioritiseJobMutation>({
+    mutation: GQL.PrioritiseJobDocument,
+    variables: { job_id: jobID },
+  });
+
+export const mutatePauseJob = (jobID: string) =>
+  client.mutate<GQL.PauseJobMutation>({
+    mutation: GQL.PauseJobDocument,
+    variables: { job_id: jobID },
+  });
+
+export const mutateResumeJob = (id: string) =>
+  client.mutate<GQL.ResumeJobMutation>({
+    mutation: GQL.ResumeJobDocument,
+    variables: { id },
+  });
+
 const setupMutationImpactedQueries = [
   GQL.ConfigurationDocument,
   GQL.SystemStatusDocument,
@@ -2435,6 +2487,12 @@ export const mutateMigrate = (input: GQL.MigrateInput) =>
     variables: { input },
   });
 
+export const mutateMigrateDown = (input: GQL.MigrateDownInput) =>
+  client.mutate<GQL.MigrateDownMutation>({
+    mutation: GQL.MigrateDownDocument,
+    variables: { input },
+  });
+
 // migrate now runs asynchronously, so we need to evict queries
 // once it successfully completes
 export function postMigrate() {
@@ -2573,6 +2631,18 @@ export const mutateMetadataAutoTag = (input: GQL.AutoTagMetadataInput) =>
     variables: { input },
   });
 
+export const mutateMetadataImportNFO = (input: GQL.ImportNfoInput) =>
+  client.mutate<GQL.MetadataImportNfoMutation>({
+    mutation: GQL.MetadataImportNfoDocument,
+    variables: { input },
+  });
+
+export const mutateMetadataExportNFO = (input: GQL.ExportNfoInput) =>
+  client.mutate<GQL.MetadataExportNfoMutation>({
+    mutation: GQL.MetadataExportNfoDocument,
+    variables: { input },
+  });
+
 export const mutateMetadataGenerate = (input: GQL.GenerateMetadataInput) =>
   client.mutate<GQL.MetadataGenerateMutation>({
     mutation: GQL.MetadataGenerateDocument,
@@ -2635,9 +2705,24 @@ export const mutateAnonymiseDatabase = (input: GQL.AnonymiseDatabaseInput) =>
     variables: { input },
   });
 
-export const mutateOptimiseDatabase = () =>
+export const mutateEmptyTrash = (input: GQL.EmptyTrashInput) =>
+  client.mutate<GQL.EmptyTrashMutation>({
+    mutation: GQL.EmptyTrashDocument,
+    variables: { input },
+  });
+
+export const mutatePurgeDeletedObjects = (
+  input: GQL.PurgeDeletedObjectsInput
+) =>
+  client.mutate<GQL.PurgeDeletedObjectsMutation>({
+    mutation: GQL.PurgeDeletedObjectsDocument,
+    variables: { input },
+  });
+
+export const mutateOptimiseDatabase = (input?: GQL.OptimiseDatabaseInput) =>
   client.mutate<GQL.OptimiseDatabaseMutation>({
     mutation: GQL.OptimiseDatabaseDocument,
+    variables: { input },
   });
 
 export const mutateMigrateHashNaming = () =>
@@ -958,6 +958,11 @@ export class TimestampCriterion extends Criterion<ITimestampValue> {
       return value.replace(" ", "T");
     }
 
+    // relative to now, such as -7d
+    if (/^-\d+[hdwmy]$/.test(value)) {
+      return value;
+    }
+
     return "";
   }
 
@@ -63,6 +63,8 @@ const numberCriteria: CriterionType[] = [
   "age",
   "weight",
   "penis_length",
+  "career_start",
+  "career_end",
 ];
 
 const stringCriteria: CriterionType[] = [